@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -61,7 +62,11 @@ type App struct {
 	monitor     *service.MonitorService
 	healthCheck *service.HealthCheckService
 	cost        *service.CostService
-	routing     *service.RoutingService // 智能路由服务
+	routing     *service.RoutingService        // 智能路由服务
+	sla         *service.SLAService            // 响应时间 SLA 监控
+	tokenRate   *service.TokenRateAlertService // token 消耗速率告警
+	autoOptimize *service.AutoOptimizeService  // 定时检测并优化端点
+	keyExpiry    *service.KeyExpiryService      // 端点 API Key 到期提醒与自动禁用
 
 	// Interaction storage
 	interactionStorage *interaction.Storage
@@ -135,6 +140,14 @@ func (a *App) startup(ctx context.Context) {
 		logger.GetLogger().SetMinLevel(logger.LogLevel(cfg.GetLogLevel()))
 	}
 
+	// 按端点分文件记录请求日志
+	logger.GetLogger().SetKeyedDir(filepath.Join(configDir, "logs", "endpoints"))
+	if cfg.GetPerEndpointLogging() {
+		if err := logger.GetLogger().SetKeyedEnabled(true); err != nil {
+			logger.Warn("Failed to enable per-endpoint logging: %v", err)
+		}
+	}
+
 	deviceID, err := sqliteStorage.GetOrCreateDeviceID()
 	if err != nil {
 		logger.Warn("Failed to get device ID: %v, using default", err)
@@ -172,7 +185,9 @@ func (a *App) startup(ctx context.Context) {
 	version := a.GetVersion()
 	a.stats = service.NewStatsService(a.proxy, a.config)
 	a.stats.SetStorage(sqliteStorage)
+	a.stats.SetDeviceID(deviceID)
 	a.endpoint = service.NewEndpointService(a.config, a.proxy, a.storage)
+	a.endpoint.SetDeviceID(deviceID)
 	a.settings = service.NewSettingsService(a.config, a.storage)
 	a.webdav = service.NewWebDAVService(a.config, a.storage, version)
 	a.backup = service.NewBackupService(a.config, a.storage, version, a.webdav)
@@ -182,45 +197,20 @@ func (a *App) startup(ctx context.Context) {
 	a.healthCheck = service.NewHealthCheckService(a.config, a.proxy.GetMonitor())
 	a.healthCheck.SetStorage(sqliteStorage)
 	a.healthCheck.SetDeviceID(deviceID)
+	a.healthCheck.SetProxy(a.proxy)
 	a.cost = service.NewCostService(a.proxy, a.config)
 	a.routing = service.NewRoutingService(a.config, a.storage, a.proxy)
+	a.sla = service.NewSLAService(a.config, a.storage)
+	a.tokenRate = service.NewTokenRateAlertService(a.config, a.proxy, a.storage)
+	a.autoOptimize = service.NewAutoOptimizeService(a.config, a.endpoint, a.healthCheck)
+	a.keyExpiry = service.NewKeyExpiryService(a.config, a.proxy, a.storage)
 
 	// 设置告警回调
-	a.healthCheck.SetAlertCallback(func(event service.AlertEvent) {
-		// 发送前端事件
-		a.ctxMutex.RLock()
-		ctx := a.ctx
-		a.ctxMutex.RUnlock()
-		if ctx != nil {
-			// 发送告警事件
-			runtime.EventsEmit(ctx, "endpoint:alert", map[string]interface{}{
-				"endpointName": event.EndpointName,
-				"clientType":   event.ClientType,
-				"alertType":    event.AlertType,
-				"message":      event.Message,
-				"timestamp":    event.Timestamp.Unix(),
-			})
-
-			// 如果是健康检查完成事件，额外发送专门的事件
-			if event.AlertType == "health_check_completed" {
-				runtime.EventsEmit(ctx, "health:check:completed", map[string]interface{}{
-					"endpointName": event.EndpointName,
-					"clientType":   event.ClientType,
-				})
-			}
-		}
-
-		// 发送系统通知（仅针对故障和恢复）
-		alertConfig := a.config.GetAlert()
-		if alertConfig != nil && alertConfig.SystemNotification {
-			title := "ccNexus"
-			if event.AlertType == "failure" {
-				notify.SendAlert(title, event.Message)
-			} else if event.AlertType == "recovery" {
-				notify.SendRecovery(title, event.Message)
-			}
-		}
-	})
+	notify.SetAggregationWindow(a.config.GetAlert().AggregationWindowSeconds)
+	a.healthCheck.SetAlertCallback(a.handleAlertEvent)
+	a.sla.SetAlertCallback(a.handleAlertEvent)
+	a.tokenRate.SetAlertCallback(a.handleAlertEvent)
+	a.keyExpiry.SetAlertCallback(a.handleAlertEvent)
 
 	// Initialize interaction storage and service
 	exePath, err := os.Executable()
@@ -232,16 +222,10 @@ func (a *App) startup(ctx context.Context) {
 	a.interactionStorage = interaction.NewStorage(interactionsDir)
 	a.interaction = service.NewInteractionService(a.interactionStorage, a.storage)
 	a.proxy.SetInteractionStorage(a.interactionStorage)
+	a.endpoint.SetInteractionStorage(a.interactionStorage)
 
-	// Cleanup old interactions (30 days retention)
-	go func() {
-		deleted, err := a.interactionStorage.Cleanup(30)
-		if err != nil {
-			logger.Warn("Failed to cleanup old interactions: %v", err)
-		} else if deleted > 0 {
-			logger.Info("Cleaned up %d old interaction folders", deleted)
-		}
-	}()
+	// Periodically cleanup old interactions based on retention days
+	a.interaction.StartAutoCleanup(a.config)
 
 	// Cleanup old health history records
 	go func() {
@@ -260,6 +244,10 @@ func (a *App) startup(ctx context.Context) {
 
 		// Start health check service after proxy
 		a.healthCheck.Start()
+		a.sla.Start()
+		a.tokenRate.Start()
+		a.autoOptimize.Start()
+		a.keyExpiry.Start()
 	} else {
 		logger.Info("Proxy server disabled (CCNEXUS_NO_PROXY is set)")
 	}
@@ -270,11 +258,69 @@ func (a *App) startup(ctx context.Context) {
 	logger.Info("Application started successfully")
 }
 
+// handleAlertEvent 处理健康检查和 SLA 监控的告警：发送前端事件和系统通知
+func (a *App) handleAlertEvent(event service.AlertEvent) {
+	// 发送前端事件
+	a.ctxMutex.RLock()
+	ctx := a.ctx
+	a.ctxMutex.RUnlock()
+	if ctx != nil {
+		// 发送告警事件
+		runtime.EventsEmit(ctx, "endpoint:alert", map[string]interface{}{
+			"endpointName": event.EndpointName,
+			"clientType":   event.ClientType,
+			"alertType":    event.AlertType,
+			"message":      event.Message,
+			"timestamp":    event.Timestamp.Unix(),
+		})
+
+		// 如果是健康检查完成事件，额外发送专门的事件
+		if event.AlertType == "health_check_completed" {
+			runtime.EventsEmit(ctx, "health:check:completed", map[string]interface{}{
+				"endpointName": event.EndpointName,
+				"clientType":   event.ClientType,
+			})
+		}
+	}
+
+	// 发送系统通知（仅针对故障、恢复、SLA 违约、token 消耗速率超限、主备切换和 API Key 到期）
+	alertConfig := a.config.GetAlert()
+	if alertConfig != nil && alertConfig.SystemNotification {
+		title := "ccNexus"
+		if event.AlertType == "failure" {
+			notify.SendAlert(title, event.Message)
+		} else if event.AlertType == "recovery" || event.AlertType == "failback" {
+			notify.SendRecovery(title, event.Message)
+		} else if event.AlertType == "sla" || event.AlertType == "token_rate" || event.AlertType == "failover" ||
+			event.AlertType == "key_expiring" || event.AlertType == "key_expired" {
+			notify.SendWarning(title, event.Message)
+		}
+	}
+
+	// 推送到告警 webhook
+	service.SendAlertWebhook(alertConfig, event)
+}
+
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
 	if a.healthCheck != nil {
 		a.healthCheck.Stop()
 	}
+	if a.sla != nil {
+		a.sla.Stop()
+	}
+	if a.tokenRate != nil {
+		a.tokenRate.Stop()
+	}
+	if a.autoOptimize != nil {
+		a.autoOptimize.Stop()
+	}
+	if a.keyExpiry != nil {
+		a.keyExpiry.Stop()
+	}
+	if a.interaction != nil {
+		a.interaction.StopAutoCleanup()
+	}
 	if a.proxy != nil {
 		a.proxy.Stop()
 	}
@@ -471,55 +517,120 @@ func (a *App) GetStatsYesterday() string { return a.stats.GetStatsYesterday() }
 func (a *App) GetStatsWeekly() string    { return a.stats.GetStatsWeekly() }
 func (a *App) GetStatsMonthly() string   { return a.stats.GetStatsMonthly() }
 func (a *App) GetStatsTrend() string     { return a.stats.GetStatsTrend() }
+func (a *App) GetStatsByPeriod(period string, excludeTest bool) string {
+	return a.stats.GetStatsByPeriod(period, excludeTest)
+}
 func (a *App) GetStatsTrendByPeriod(period string) string {
 	return a.stats.GetStatsTrendByPeriod(period)
 }
+func (a *App) GetStatsTrendByRange(startDate, endDate string) string {
+	return a.stats.GetStatsTrendByRange(startDate, endDate)
+}
 
 func (a *App) GetDailyRequestDetails(limit, offset int) string {
 	return a.stats.GetDailyRequestDetails(limit, offset)
 }
 
+func (a *App) SearchRequestStats(keyword, clientType, startDate, endDate string, limit, offset int) string {
+	return a.stats.SearchRequestStats(keyword, clientType, startDate, endDate, limit, offset)
+}
+
 func (a *App) GetPerformanceStats(period string) string {
 	return a.stats.GetPerformanceStats(period)
 }
 
+// GetEndpointReliabilityRanking 获取端点成功率排名
+func (a *App) GetEndpointReliabilityRanking(period string) string {
+	return a.stats.GetEndpointReliabilityRanking(period)
+}
+
+func (a *App) ExportStatsCSV(period string) string {
+	return a.stats.ExportStatsCSV(period)
+}
+
+// ImportStatsFromURL imports daily stats from another ccNexus instance
+// strategy: "keep_local" 保留本地已有记录, "overwrite_local" 用远程数据覆盖
+func (a *App) ImportStatsFromURL(url string, strategy string) error {
+	mergeStrategy := storage.MergeStrategy(strategy)
+	if mergeStrategy != storage.MergeStrategyKeepLocal && mergeStrategy != storage.MergeStrategyOverwriteLocal {
+		return fmt.Errorf("invalid merge strategy: %s", strategy)
+	}
+	return a.stats.ImportStatsFromURL(url, mergeStrategy)
+}
+
+func (a *App) GetModelStats(period string) string {
+	return a.stats.GetModelStats(period)
+}
+
+// GetErrorBreakdown 获取统计周期内失败请求的错误分类占比
+func (a *App) GetErrorBreakdown(period string) string {
+	return a.stats.GetErrorBreakdown(period)
+}
+
+func (a *App) GetDeviceStats(startDate, endDate string, merged bool) string {
+	return a.stats.GetDeviceStats(startDate, endDate, merged)
+}
+
 func (a *App) GetTokenTrendData(granularity, period, startTime, endTime string) string {
 	return a.stats.GetTokenTrendData(granularity, period, startTime, endTime)
 }
 
 // ========== Endpoint Bindings ==========
 
-func (a *App) AddEndpoint(clientType, name, apiUrl, apiKey, transformer, model, remark, tags string,
-	modelPatterns string, costPerInputToken, costPerOutputToken float64, quotaLimit int64, quotaResetCycle string, priority int) error {
-	return a.endpoint.AddEndpoint(clientType, name, apiUrl, apiKey, transformer, model, remark, tags,
-		modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority)
+func (a *App) AddEndpoint(clientType string, input service.EndpointInput) error {
+	return a.endpoint.AddEndpoint(clientType, input)
 }
 func (a *App) RemoveEndpoint(clientType string, index int) error {
 	return a.endpoint.RemoveEndpoint(clientType, index)
 }
-func (a *App) UpdateEndpoint(clientType string, index int, name, apiUrl, apiKey, transformer, model, remark, tags string,
-	modelPatterns string, costPerInputToken, costPerOutputToken float64, quotaLimit int64, quotaResetCycle string, priority int) error {
-	return a.endpoint.UpdateEndpoint(clientType, index, name, apiUrl, apiKey, transformer, model, remark, tags,
-		modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority)
+func (a *App) CloneEndpoint(clientType string, index int, newName string) error {
+	return a.endpoint.CloneEndpoint(clientType, index, newName)
+}
+func (a *App) UpdateEndpoint(clientType string, index int, input service.EndpointInput) error {
+	return a.endpoint.UpdateEndpoint(clientType, index, input)
 }
 func (a *App) ToggleEndpoint(clientType string, index int, enabled bool) error {
 	return a.endpoint.ToggleEndpoint(clientType, index, enabled)
 }
+func (a *App) ToggleEndpointsByTag(clientType, tag string, enabled bool) (int, error) {
+	return a.endpoint.ToggleEndpointsByTag(clientType, tag, enabled)
+}
+func (a *App) SetEndpointMaintenance(clientType string, index int, maintenance bool) error {
+	return a.endpoint.SetEndpointMaintenance(clientType, index, maintenance)
+}
 func (a *App) ReorderEndpoints(clientType string, names []string) error {
 	return a.endpoint.ReorderEndpoints(clientType, names)
 }
+func (a *App) AutoSortEndpointsByLatency(clientType string) error {
+	return a.endpoint.AutoSortEndpointsByLatency(clientType)
+}
 func (a *App) GetCurrentEndpoint(clientType string) string {
 	return a.endpoint.GetCurrentEndpoint(clientType)
 }
 func (a *App) SwitchToEndpoint(clientType, endpointName string) error {
 	return a.endpoint.SwitchToEndpoint(clientType, endpointName)
 }
-func (a *App) TestEndpoint(clientType string, index int) string {
-	return a.endpoint.TestEndpoint(clientType, index)
+func (a *App) PinEndpoint(clientType, endpointName string, durationMinutes int) error {
+	return a.endpoint.PinEndpoint(clientType, endpointName, durationMinutes)
+}
+func (a *App) UnpinEndpoint(clientType string) error {
+	return a.endpoint.UnpinEndpoint(clientType)
+}
+func (a *App) GetPinnedEndpoints() string {
+	return a.endpoint.GetPinnedEndpoints()
+}
+func (a *App) TestEndpoint(clientType string, index int, prompt string, maxTokens int) string {
+	return a.endpoint.TestEndpoint(clientType, index, prompt, maxTokens)
+}
+func (a *App) GetLastTestResult(clientType string, index int) string {
+	return a.endpoint.GetLastTestResult(clientType, index)
 }
 func (a *App) TestEndpointLight(clientType string, index int) string {
 	return a.endpoint.TestEndpointLight(clientType, index)
 }
+func (a *App) PreflightCheck(clientType string, index int) string {
+	return a.endpoint.PreflightCheck(clientType, index)
+}
 func (a *App) TestAllEndpointsZeroCost(clientType string) string {
 	return a.endpoint.TestAllEndpointsZeroCost(clientType)
 }
@@ -532,12 +643,26 @@ func (a *App) ExportEndpoints(clientType string, includeKeys bool) string {
 func (a *App) ExportAllEndpoints(includeKeys bool) string {
 	return a.endpoint.ExportAllEndpoints(includeKeys)
 }
-func (a *App) ImportEndpoints(jsonData string, mode string) string {
-	return a.endpoint.ImportEndpoints(jsonData, mode)
+func (a *App) ImportEndpoints(jsonData string, mode string, validate bool) string {
+	return a.endpoint.ImportEndpoints(jsonData, mode, validate)
+}
+func (a *App) PreviewImport(jsonData string, mode string, validate bool) string {
+	return a.endpoint.PreviewImport(jsonData, mode, validate)
+}
+func (a *App) ExportFullConfig(includeKeys bool) string {
+	return a.endpoint.ExportFullConfig(includeKeys)
+}
+func (a *App) ImportFullConfig(jsonData string, mode string) string {
+	return a.endpoint.ImportFullConfig(jsonData, mode)
 }
 func (a *App) GetAllEndpointTags() ([]string, error) {
 	return a.endpoint.GetAllEndpointTags()
 }
+
+// GetConfigAuditLog 获取端点配置变更审计记录（按时间倒序分页）
+func (a *App) GetConfigAuditLog(limit, offset int) string {
+	return a.endpoint.GetConfigAuditLog(limit, offset)
+}
 func (a *App) GetHealthHistory(endpointName, clientType string, hours int) ([]map[string]interface{}, error) {
 	records, err := a.endpoint.GetHealthHistory(endpointName, clientType, hours)
 	if err != nil {
@@ -559,6 +684,23 @@ func (a *App) GetHealthHistory(endpointName, clientType string, hours int) ([]ma
 	}
 	return result, nil
 }
+func (a *App) GetHealthHistorySummary(endpointName, clientType string, hours, bucketMinutes int) ([]map[string]interface{}, error) {
+	buckets, err := a.endpoint.GetHealthHistorySummary(endpointName, clientType, hours, bucketMinutes)
+	if err != nil {
+		return nil, err
+	}
+	// 空桶的 successRate/avgLatencyMs 为 null
+	result := make([]map[string]interface{}, len(buckets))
+	for i, b := range buckets {
+		result[i] = map[string]interface{}{
+			"timestamp":    b.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+			"total":        b.Total,
+			"successRate":  b.SuccessRate,
+			"avgLatencyMs": b.AvgLatencyMs,
+		}
+	}
+	return result, nil
+}
 func (a *App) GetHealthHistoryRetentionDays() int {
 	return a.endpoint.GetHealthHistoryRetentionDays()
 }
@@ -573,6 +715,9 @@ func (a *App) UpdateConfig(configJSON string) error {
 	return a.settings.UpdateConfig(configJSON, a.proxy)
 }
 func (a *App) UpdatePort(port int) error            { return a.settings.UpdatePort(port) }
+func (a *App) UpdateClientPorts(ports map[string]int) error {
+	return a.settings.UpdateClientPorts(ports)
+}
 func (a *App) GetSystemLanguage() string            { return a.settings.GetSystemLanguage() }
 func (a *App) GetLanguage() string                  { return a.settings.GetLanguage() }
 func (a *App) SetLanguage(language string) error    { return a.settings.SetLanguage(language) }
@@ -596,6 +741,7 @@ func (a *App) SetCloseWindowBehavior(behavior string) error {
 }
 func (a *App) GetProxyURL() string               { return a.settings.GetProxyURL() }
 func (a *App) SetProxyURL(proxyURL string) error { return a.settings.SetProxyURL(proxyURL) }
+func (a *App) TestProxyURL(proxyURL string) string { return a.settings.TestProxyURL(proxyURL) }
 func (a *App) GetHealthCheckInterval() int       { return a.config.GetHealthCheckInterval() }
 func (a *App) SetHealthCheckInterval(interval int) error {
 	a.config.UpdateHealthCheckInterval(interval)
@@ -610,6 +756,31 @@ func (a *App) SetHealthCheckInterval(interval int) error {
 	}
 	return nil
 }
+
+func (a *App) GetAutoOptimizeInterval() int { return a.config.GetAutoOptimizeInterval() }
+func (a *App) SetAutoOptimizeInterval(interval int) error {
+	a.config.UpdateAutoOptimizeInterval(interval)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	if err := a.config.SaveToStorage(configAdapter); err != nil {
+		return err
+	}
+	// 按新的间隔重启定时优化
+	if a.autoOptimize != nil {
+		a.autoOptimize.Restart()
+	}
+	return nil
+}
+
+// GetFailoverStatus 获取主备降级状态
+func (a *App) GetFailoverStatus() string {
+	return a.healthCheck.GetFailoverStatus()
+}
+func (a *App) GetStatsTimezone() string         { return a.settings.GetStatsTimezone() }
+func (a *App) SetStatsTimezone(tz string) error { return a.settings.SetStatsTimezone(tz) }
+func (a *App) GetDashboardConfig() string { return a.settings.GetDashboardConfig() }
+func (a *App) UpdateDashboardConfig(cards []string, timeRange string) error {
+	return a.settings.UpdateDashboardConfig(cards, timeRange)
+}
 func (a *App) GetRequestTimeout() int { return a.config.GetRequestTimeout() }
 func (a *App) SetRequestTimeout(timeout int) error {
 	a.config.UpdateRequestTimeout(timeout)
@@ -617,6 +788,52 @@ func (a *App) SetRequestTimeout(timeout int) error {
 	configAdapter := storage.NewConfigStorageAdapter(a.storage)
 	return a.config.SaveToStorage(configAdapter)
 }
+func (a *App) GetEnableHTTP2() bool { return a.config.GetEnableHTTP2() }
+func (a *App) SetEnableHTTP2(enabled bool) error {
+	a.config.UpdateEnableHTTP2(enabled)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+func (a *App) GetPerEndpointLogging() bool { return a.config.GetPerEndpointLogging() }
+func (a *App) SetPerEndpointLogging(enabled bool) error {
+	return a.settings.SetPerEndpointLogging(enabled)
+}
+func (a *App) GetHealthCheckMethod() string { return a.config.GetHealthCheckMethod() }
+func (a *App) SetHealthCheckMethod(method string) error {
+	if !config.IsValidHealthCheckMethod(method) {
+		return fmt.Errorf("invalid health check method: %s", method)
+	}
+	a.config.UpdateHealthCheckMethod(method)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+func (a *App) GetStreamHeartbeatInterval() int { return a.config.GetStreamHeartbeatInterval() }
+func (a *App) SetStreamHeartbeatInterval(interval int) error {
+	if interval < 0 {
+		interval = 0
+	}
+	a.config.UpdateStreamHeartbeatInterval(interval)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+func (a *App) GetStreamFirstByteTimeout() int { return a.config.GetStreamFirstByteTimeout() }
+func (a *App) SetStreamFirstByteTimeout(timeout int) error {
+	if timeout < 0 {
+		timeout = 0
+	}
+	a.config.UpdateStreamFirstByteTimeout(timeout)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+func (a *App) GetMaxRequestBodyBytes() int64 { return a.config.GetMaxRequestBodyBytes() }
+func (a *App) SetMaxRequestBodyBytes(size int64) error {
+	if size < 0 {
+		size = 0
+	}
+	a.config.UpdateMaxRequestBodyBytes(size)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
 
 // ========== Alert Bindings ==========
 
@@ -628,7 +845,11 @@ func (a *App) GetAlertConfig() string {
 }
 
 // SetAlertConfig 设置告警配置
-func (a *App) SetAlertConfig(enabled bool, consecutiveFailures int, notifyOnRecovery bool, systemNotification bool, cooldownMinutes int, performanceAlertEnabled bool, latencyThresholdMs int, latencyIncreasePercent int, autoEnableOnRecovery bool, autoEnableSuccessThreshold int) error {
+func (a *App) SetAlertConfig(enabled bool, consecutiveFailures int, notifyOnRecovery bool, systemNotification bool, cooldownMinutes int, performanceAlertEnabled bool, latencyThresholdMs int, latencyIncreasePercent int, autoEnableOnRecovery bool, autoEnableSuccessThreshold int, aggregationWindowSeconds int, webhookURL string, webhookTemplate string) error {
+	webhookURL = strings.TrimSpace(webhookURL)
+	if err := service.ValidateAlertWebhook(webhookURL, webhookTemplate); err != nil {
+		return err
+	}
 	alertConfig := &config.AlertConfig{
 		Enabled:                   enabled,
 		ConsecutiveFailures:       consecutiveFailures,
@@ -640,13 +861,67 @@ func (a *App) SetAlertConfig(enabled bool, consecutiveFailures int, notifyOnReco
 		LatencyIncreasePercent:    latencyIncreasePercent,
 		AutoEnableOnRecovery:      autoEnableOnRecovery,
 		AutoEnableSuccessThreshold: autoEnableSuccessThreshold,
+		AggregationWindowSeconds:   aggregationWindowSeconds,
+		WebhookURL:                 webhookURL,
+		WebhookTemplate:            webhookTemplate,
 	}
 	a.config.UpdateAlert(alertConfig)
+	notify.SetAggregationWindow(aggregationWindowSeconds)
 	// Save to storage
 	configAdapter := storage.NewConfigStorageAdapter(a.storage)
 	return a.config.SaveToStorage(configAdapter)
 }
 
+// TestAlertWebhook 向 webhook 发送一条测试告警（使用尚未保存的地址和模板）
+func (a *App) TestAlertWebhook(webhookURL, webhookTemplate string) string {
+	return service.TestAlertWebhook(strings.TrimSpace(webhookURL), webhookTemplate)
+}
+
+// ========== SLA Bindings ==========
+
+// GetSLAConfig 获取 SLA 监控配置
+func (a *App) GetSLAConfig() string {
+	return a.sla.GetSLAConfig()
+}
+
+// UpdateSLAConfig 更新 SLA 监控配置
+func (a *App) UpdateSLAConfig(enabled bool, defaultP95Ms, windowMinutes, checkIntervalMinutes, minSamples int) error {
+	return a.sla.UpdateSLAConfig(enabled, defaultP95Ms, windowMinutes, checkIntervalMinutes, minSamples)
+}
+
+// GetSLAStatus 获取各端点的 SLA 达成情况
+func (a *App) GetSLAStatus() string {
+	return a.sla.GetSLAStatus()
+}
+
+// GetSLAViolations 获取最近的 SLA 违约记录
+func (a *App) GetSLAViolations(limit int) string {
+	return a.sla.GetSLAViolations(limit)
+}
+
+// ========== Token Rate Alert Bindings ==========
+
+// GetTokenRateAlertConfig 获取 token 消耗速率告警配置
+func (a *App) GetTokenRateAlertConfig() string {
+	return a.tokenRate.GetTokenRateAlertConfig()
+}
+
+// UpdateTokenRateAlertConfig 更新 token 消耗速率告警配置
+func (a *App) UpdateTokenRateAlertConfig(enabled bool, thresholdPerMinute, windowSeconds, cooldownMinutes int) error {
+	return a.tokenRate.UpdateTokenRateAlertConfig(enabled, thresholdPerMinute, windowSeconds, cooldownMinutes)
+}
+
+// GetTokenRateStatus 获取当前 token 消耗速率
+func (a *App) GetTokenRateStatus() string {
+	return a.tokenRate.GetTokenRateStatus()
+}
+
+// CheckSLANow 立即执行一次 SLA 检查并返回最新状态
+func (a *App) CheckSLANow() string {
+	a.sla.CheckAll()
+	return a.sla.GetSLAStatus()
+}
+
 // ========== Cache Bindings ==========
 
 // GetCacheConfig 获取缓存配置
@@ -690,6 +965,90 @@ func (a *App) ClearCache() {
 	}
 }
 
+// ========== Idempotency Bindings ==========
+
+// GetIdempotencyConfig 获取幂等键去重配置
+func (a *App) GetIdempotencyConfig() string {
+	idempotencyConfig := a.config.GetIdempotency()
+	data, _ := json.Marshal(idempotencyConfig)
+	return string(data)
+}
+
+// SetIdempotencyConfig 设置幂等键去重配置
+func (a *App) SetIdempotencyConfig(enabled bool, ttlSeconds int) error {
+	idempotencyConfig := &config.IdempotencyConfig{
+		Enabled:    enabled,
+		TTLSeconds: ttlSeconds,
+	}
+	a.config.UpdateIdempotency(idempotencyConfig)
+	// 更新代理幂等配置
+	if a.proxy != nil {
+		a.proxy.UpdateIdempotencyConfig(enabled, ttlSeconds)
+	}
+	// Save to storage
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
+// GetIdempotencyStats 获取幂等键去重统计
+func (a *App) GetIdempotencyStats() string {
+	if a.proxy == nil {
+		return "{}"
+	}
+	stats := a.proxy.GetIdempotencyStats()
+	data, _ := json.Marshal(stats)
+	return string(data)
+}
+
+// ClearIdempotencyCache 清空已保存的幂等结果
+func (a *App) ClearIdempotencyCache() {
+	if a.proxy != nil {
+		a.proxy.ClearIdempotencyCache()
+	}
+}
+
+// ========== Dedup Bindings ==========
+
+// GetDedupConfig 获取请求去重配置
+func (a *App) GetDedupConfig() string {
+	dedupConfig := a.config.GetDedup()
+	data, _ := json.Marshal(dedupConfig)
+	return string(data)
+}
+
+// SetDedupConfig 设置请求去重配置
+func (a *App) SetDedupConfig(enabled bool, windowSeconds int) error {
+	dedupConfig := &config.DedupConfig{
+		Enabled:       enabled,
+		WindowSeconds: windowSeconds,
+	}
+	a.config.UpdateDedup(dedupConfig)
+	// 更新代理去重配置
+	if a.proxy != nil {
+		a.proxy.UpdateDedupConfig(enabled, windowSeconds)
+	}
+	// Save to storage
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
+// GetDedupStats 获取请求去重统计
+func (a *App) GetDedupStats() string {
+	if a.proxy == nil {
+		return "{}"
+	}
+	stats := a.proxy.GetDedupStats()
+	data, _ := json.Marshal(stats)
+	return string(data)
+}
+
+// ClearDedupCache 清空已保存的去重响应
+func (a *App) ClearDedupCache() {
+	if a.proxy != nil {
+		a.proxy.ClearDedupCache()
+	}
+}
+
 // ========== Rate Limit Bindings ==========
 
 // GetRateLimitConfig 获取速率限制配置
@@ -700,11 +1059,15 @@ func (a *App) GetRateLimitConfig() string {
 }
 
 // SetRateLimitConfig 设置速率限制配置
-func (a *App) SetRateLimitConfig(enabled bool, globalLimit, perEndpointLimit int) error {
+func (a *App) SetRateLimitConfig(enabled bool, globalLimit, perEndpointLimit, perClientIPLimit int) error {
+	if perClientIPLimit < 0 {
+		perClientIPLimit = 0
+	}
 	rateLimitConfig := &config.RateLimitConfig{
 		Enabled:          enabled,
 		GlobalLimit:      globalLimit,
 		PerEndpointLimit: perEndpointLimit,
+		PerClientIPLimit: perClientIPLimit,
 	}
 	a.config.UpdateRateLimit(rateLimitConfig)
 	// 更新代理速率限制配置
@@ -733,6 +1096,121 @@ func (a *App) ResetRateLimitStats() {
 	}
 }
 
+// ========== Retry Budget Bindings ==========
+
+// GetRetryBudgetConfig 获取全局重试预算配置
+func (a *App) GetRetryBudgetConfig() string {
+	data, _ := json.Marshal(a.config.GetRetryBudget())
+	return string(data)
+}
+
+// SetRetryBudgetConfig 设置全局重试预算配置
+func (a *App) SetRetryBudgetConfig(enabled bool, ratio float64, minRetries, windowSeconds int) error {
+	if ratio < 0 {
+		return fmt.Errorf("retry ratio must not be negative")
+	}
+	if minRetries < 0 {
+		minRetries = 0
+	}
+	if windowSeconds <= 0 {
+		windowSeconds = 60
+	}
+	a.config.UpdateRetryBudget(&config.RetryBudgetConfig{
+		Enabled:       enabled,
+		Ratio:         ratio,
+		MinRetries:    minRetries,
+		WindowSeconds: windowSeconds,
+	})
+	// Save to storage
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
+// GetRetryBudgetStats 获取重试预算统计
+func (a *App) GetRetryBudgetStats() string {
+	if a.proxy == nil {
+		return "{}"
+	}
+	data, _ := json.Marshal(a.proxy.GetRetryBudgetStats())
+	return string(data)
+}
+
+// ResetRetryBudgetStats 重置重试预算统计
+func (a *App) ResetRetryBudgetStats() {
+	if a.proxy != nil {
+		a.proxy.ResetRetryBudgetStats()
+	}
+}
+
+// ========== Auto Continue Bindings ==========
+
+// GetAutoContinueConfig 获取 max_tokens 截断自动续写和断线续传配置
+func (a *App) GetAutoContinueConfig() string {
+	data, _ := json.Marshal(a.config.GetAutoContinue())
+	return string(data)
+}
+
+// SetAutoContinueConfig 设置 max_tokens 截断自动续写和断线续传配置
+func (a *App) SetAutoContinueConfig(enabled bool, maxContinuations int, resumeOnDisconnect bool) error {
+	if maxContinuations <= 0 {
+		return fmt.Errorf("max continuations must be positive")
+	}
+	a.config.UpdateAutoContinue(&config.AutoContinueConfig{
+		Enabled:            enabled,
+		MaxContinuations:   maxContinuations,
+		ResumeOnDisconnect: resumeOnDisconnect,
+	})
+	// Save to storage
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
+// ========== Circuit Breaker Bindings ==========
+
+// GetCircuitBreakerConfig 获取端点熔断配置
+func (a *App) GetCircuitBreakerConfig() string {
+	data, _ := json.Marshal(a.config.GetCircuitBreaker())
+	return string(data)
+}
+
+// SetCircuitBreakerConfig 设置端点熔断配置
+func (a *App) SetCircuitBreakerConfig(enabled bool, failureThreshold, cooldownSeconds, halfOpenProbes int) error {
+	if failureThreshold <= 0 {
+		return fmt.Errorf("failure threshold must be positive")
+	}
+	if cooldownSeconds <= 0 {
+		return fmt.Errorf("cooldown seconds must be positive")
+	}
+	if halfOpenProbes <= 0 {
+		halfOpenProbes = 1
+	}
+	a.config.UpdateCircuitBreaker(&config.CircuitBreakerConfig{
+		Enabled:          enabled,
+		FailureThreshold: failureThreshold,
+		CooldownSeconds:  cooldownSeconds,
+		HalfOpenProbes:   halfOpenProbes,
+	})
+	// Save to storage
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
+// GetCircuitBreakerStatus 获取各端点的熔断状态
+func (a *App) GetCircuitBreakerStatus() string {
+	if a.proxy == nil {
+		return "[]"
+	}
+	data, _ := json.Marshal(a.proxy.GetCircuitBreakerStatus())
+	return string(data)
+}
+
+// ResetCircuitBreaker 重置所有端点的熔断状态
+func (a *App) ResetCircuitBreaker() {
+	if a.proxy != nil {
+		a.proxy.ResetCircuitBreaker()
+	}
+}
+
 // ========== WebDAV Bindings ==========
 
 func (a *App) UpdateWebDAVConfig(url, username, password string) error {
@@ -761,6 +1239,9 @@ func (a *App) UpdateBackupProvider(provider string) error {
 	return a.backup.UpdateBackupProvider(provider)
 }
 func (a *App) UpdateLocalBackupDir(dir string) error { return a.backup.UpdateLocalBackupDir(dir) }
+func (a *App) UpdateLocalBackupKeepVersions(keepVersions int) error {
+	return a.backup.UpdateLocalBackupKeepVersions(keepVersions)
+}
 func (a *App) UpdateS3BackupConfig(endpoint, region, bucket, prefix, accessKey, secretKey, sessionToken string, useSSL, forcePathStyle bool) error {
 	return a.backup.UpdateS3BackupConfig(endpoint, region, bucket, prefix, accessKey, secretKey, sessionToken, useSSL, forcePathStyle)
 }
@@ -809,6 +1290,14 @@ func (a *App) SetInteractionEnabled(enabled bool) string {
 	return a.interaction.SetEnabled(enabled)
 }
 
+func (a *App) GetInteractionRedactPatterns() string {
+	return a.interaction.GetRedactPatterns()
+}
+
+func (a *App) SetInteractionRedactPatterns(patterns []string) string {
+	return a.interaction.SetRedactPatterns(patterns)
+}
+
 func (a *App) GetInteractionDates() string {
 	return a.interaction.GetDates()
 }
@@ -821,6 +1310,11 @@ func (a *App) GetInteractionDetail(date, requestID string) string {
 	return a.interaction.GetInteractionDetail(date, requestID)
 }
 
+// ReplayRequest re-sends an archived request to the given endpoint; this consumes tokens
+func (a *App) ReplayRequest(requestID, endpointName string) string {
+	return a.endpoint.ReplayRequest(requestID, endpointName)
+}
+
 func (a *App) ExportInteractions(date string) string {
 	return a.interaction.ExportInteractions(date)
 }
@@ -829,6 +1323,19 @@ func (a *App) CleanupInteractions(daysToKeep int) string {
 	return a.interaction.Cleanup(daysToKeep)
 }
 
+func (a *App) GetInteractionRetentionDays() int {
+	return a.config.GetInteractionRetentionDays()
+}
+
+func (a *App) SetInteractionRetentionDays(days int) error {
+	if days < 1 || days > 365 {
+		return fmt.Errorf("retention days must be between 1 and 365")
+	}
+	a.config.UpdateInteractionRetentionDays(days)
+	configAdapter := storage.NewConfigStorageAdapter(a.storage)
+	return a.config.SaveToStorage(configAdapter)
+}
+
 func (a *App) GetInteractionStoragePath() string {
 	return a.interaction.GetStoragePath()
 }
@@ -849,6 +1356,7 @@ func (a *App) GetEndpointMetrics() string {
 
 func (a *App) ResetMonitorMetrics() {
 	a.monitor.ResetMetrics()
+	a.proxy.ResetConcurrencyPeaks()
 }
 
 func (a *App) GetEndpointHealth() string {
@@ -866,6 +1374,12 @@ func (a *App) GetEndpointCheckResults() string {
 	return string(jsonData)
 }
 
+// GetConcurrencyStats 获取各端点的并发统计（在途请求数、排队数、历史峰值）
+func (a *App) GetConcurrencyStats() string {
+	jsonData, _ := json.Marshal(a.proxy.GetConcurrencyStats())
+	return string(jsonData)
+}
+
 // TestAllEndpointsAndOptimize 一键检测并优化端点配置
 func (a *App) TestAllEndpointsAndOptimize(clientType string) string {
 	return a.endpoint.TestAllEndpointsAndOptimize(clientType)
@@ -906,6 +1420,41 @@ func (a *App) UpdateRoutingConfig(enableModelRouting, enableLoadBalance, enableC
 	return a.routing.UpdateRoutingConfig(cfg)
 }
 
+// GetClientRoutingConfigs 获取各 client type 的独立路由配置
+func (a *App) GetClientRoutingConfigs() string {
+	data, _ := json.Marshal(a.routing.GetClientRoutingConfigs())
+	return string(data)
+}
+
+// UpdateClientRoutingConfig 设置 client type 的独立路由配置
+func (a *App) UpdateClientRoutingConfig(clientType string, enableModelRouting, enableLoadBalance, enableCostPriority, enableQuotaRouting bool, loadBalanceAlgorithm string) error {
+	cfg := &config.RoutingConfig{
+		EnableModelRouting:   enableModelRouting,
+		EnableLoadBalance:    enableLoadBalance,
+		EnableCostPriority:   enableCostPriority,
+		EnableQuotaRouting:   enableQuotaRouting,
+		LoadBalanceAlgorithm: loadBalanceAlgorithm,
+	}
+	return a.routing.UpdateClientRoutingConfig(clientType, cfg)
+}
+
+// ClearClientRoutingConfig 删除 client type 的独立路由配置，恢复使用全局配置
+func (a *App) ClearClientRoutingConfig(clientType string) error {
+	return a.routing.UpdateClientRoutingConfig(clientType, nil)
+}
+
+// GetCrossClientFallbackConfig 获取跨 client type 回退配置
+func (a *App) GetCrossClientFallbackConfig() string {
+	cfg := a.routing.GetCrossClientFallback()
+	data, _ := json.Marshal(cfg)
+	return string(data)
+}
+
+// UpdateCrossClientFallbackConfig 更新跨 client type 回退配置
+func (a *App) UpdateCrossClientFallbackConfig(enabled bool, clientTypes []string) error {
+	return a.routing.UpdateCrossClientFallback(enabled, clientTypes)
+}
+
 // GetQuotaStatuses 获取所有端点的配额状态
 func (a *App) GetQuotaStatuses(clientType string) string {
 	statuses := a.routing.GetQuotaStatuses(clientType)
@@ -928,6 +1477,11 @@ func (a *App) ResetQuota(endpointName, clientType string) error {
 	return a.routing.ResetQuota(endpointName, clientType)
 }
 
+// ExplainRouting 解释一次假设请求的路由决策（选中的端点及原因）
+func (a *App) ExplainRouting(clientType, model, sessionID string) string {
+	return a.routing.ExplainRouting(clientType, model, sessionID)
+}
+
 // ========== Session Affinity Bindings ==========
 
 // GetSessionStats 获取会话统计信息
@@ -957,11 +1511,23 @@ func (a *App) GetSessionAffinityConfig() string {
 }
 
 // UpdateSessionAffinityConfig 更新会话亲和性配置
-func (a *App) UpdateSessionAffinityConfig(enabled bool, sessionTimeoutHours, maxConcurrentPerEndpoint int) error {
+func (a *App) UpdateSessionAffinityConfig(enabled bool, sessionTimeoutHours, maxConcurrentPerEndpoint int, sessionKeySource, sessionKeyName string) error {
+	switch sessionKeySource {
+	case config.SessionKeySourceDefault, config.SessionKeySourceHeader, config.SessionKeySourceBody:
+	default:
+		return fmt.Errorf("invalid session key source: %s", sessionKeySource)
+	}
+	sessionKeyName = strings.TrimSpace(sessionKeyName)
+	if sessionKeySource != config.SessionKeySourceDefault && sessionKeyName == "" {
+		return fmt.Errorf("session key name is required when session key source is %s", sessionKeySource)
+	}
+
 	cfg := &config.SessionAffinityConfig{
 		Enabled:              enabled,
 		SessionTimeoutHours:  sessionTimeoutHours,
 		MaxConcurrentPerEndpoint: maxConcurrentPerEndpoint,
+		SessionKeySource:     sessionKeySource,
+		SessionKeyName:       sessionKeyName,
 	}
 	a.config.UpdateSessionAffinity(cfg)
 
@@ -33,7 +33,19 @@ export default {
         current: '当前使用',
         switchTo: '切换',
         switchFailed: '切换失败',
+        pin: '钉选',
+        unpin: '取消钉选',
+        pinTip: '接下来 {minutes} 分钟内所有请求都走该端点',
+        unpinTip: '解除钉选，恢复正常路由',
+        pinnedTip: '已钉选：{time} 前所有请求都走该端点',
+        keyExpired: '已过期',
+        keyExpiringTip: 'API Key 将于 {date} 到期（剩余 {days} 天）',
+        keyExpiredTip: 'API Key 已于 {date} 到期',
+        pinFailed: '钉选失败',
         reorderFailed: '排序失败',
+        sortByLatency: '按延迟排序',
+        sortByLatencyTitle: '按健康检查延迟从快到慢重新排序端点，无延迟数据的端点排在末尾',
+        sortByLatencySuccess: '端点已按延迟排序',
         collapse: '收起',
         expand: '展开',
         testTipSuccess: '已测试连接成功',
@@ -50,19 +62,35 @@ export default {
         statusWarning: '警告',
         statusUnavailable: '不可用',
         statusUntested: '未检测',
+        statusDegraded: '降级',
+        degradedTip: '健康检查延迟超过阈值，仅在没有可用端点时使用',
+        slaTip: 'p95 {p95}ms / 阈值 {threshold}ms',
+        slaNoData: '请求数不足（{samples}）',
         statusDisabled: '禁用',
+        statusMaintenance: '维护中',
+        enterMaintenance: '维护',
+        exitMaintenance: '结束维护',
+        maintenanceTip: '维护期间不再向该端点路由新请求，健康检查和统计照常进行',
+        maintenanceFailed: '设置维护模式失败',
+        clone: '复制',
+        cloneTip: '复制全部配置为一个禁用的新端点，再修改名称和 key',
+        cloneSuccess: '已复制为 {name}（默认禁用，修改后手动启用）',
+        cloneFailed: '复制端点失败',
         clientType: '客户端',
         export: '导出',
         import: '导入',
         exportEndpoints: '导出端点',
         importEndpoints: '导入端点',
         exportAll: '导出全部',
+        exportFullConfig: '导出完整配置',
+        exportFullConfigHelp: '全部端点及路由、缓存、限流、告警、备份设置，用于迁移到新机器，不包含设备特定的设置',
         exportCurrent: '导出当前',
         includeApiKeys: '包含 API 密钥',
         includeApiKeysHelp: '警告：导出的文件将包含明文 API 密钥，请妥善保管',
         exportSuccess: '导出成功',
         exportFailed: '导出失败',
         importSuccess: '导入成功：{imported} 个端点已导入，{skipped} 个已跳过',
+        importSettingsSuccess: '{count} 项设置已导入（仅覆盖模式会覆盖本地设置）',
         importFailed: '导入失败',
         importMode: '导入模式',
         importModeSkip: '跳过已存在',
@@ -71,6 +99,18 @@ export default {
         importModeSkipHelp: '如果端点名称已存在，则跳过导入',
         importModeOverwriteHelp: '如果端点名称已存在，则覆盖现有配置',
         importModeRenameHelp: '如果端点名称已存在，则自动添加后缀',
+        importPreview: '预览',
+        importPreviewSummary: '预览：新增 {added} 个，覆盖 {overwrite} 个，重命名 {renamed} 个，跳过 {skipped} 个，无效 {invalid} 个',
+        importAction_add: '新增',
+        importAction_overwrite: '覆盖',
+        importAction_rename: '重命名',
+        importAction_skip: '跳过',
+        importAction_invalid: '无效',
+        importValidate: '导入前检查可达性',
+        importValidateHelp: '并发探测每个端点的 models 接口；不可达的端点会被标记，但仍会导入',
+        importPreviewUnreachable: '{count} 个不可达',
+        importUnreachable: '不可达',
+        importWarnings: '{count} 条警告',
         selectFile: '选择文件',
         dropFileHere: '拖拽文件到此处或点击选择',
         invalidFileFormat: '无效的文件格式，请选择 JSON 文件',
@@ -81,6 +121,13 @@ export default {
         tags: '标签',
         filterByTag: '按标签筛选',
         allTags: '全部标签',
+        enableByTag: '全部启用',
+        disableByTag: '全部禁用',
+        enableByTagTip: '启用带有该标签的所有端点',
+        disableByTagTip: '禁用带有该标签的所有端点',
+        enabledByTag: '已启用 {count} 个标签为「{tag}」的端点',
+        disabledByTag: '已禁用 {count} 个标签为「{tag}」的端点',
+        toggleByTagFailed: '按标签批量操作失败',
         noTags: '无标签'
     },
     modal: {
@@ -107,12 +154,23 @@ export default {
         tags: '标签',
         tagsPlaceholder: '例如：生产, 备用',
         tagsHelp: '可选：添加标签用于分组/筛选（逗号分隔）',
+        group: '分组',
+        groupPlaceholder: '例如：provider-a',
+        groupHelp: '可选：发往 /{client}/group/{分组名}/... 的请求只在该组内的端点之间轮询和故障转移',
         routingSettings: '路由设置',
         modelPatterns: '模型匹配模式',
         modelPatternsPlaceholder: '例如：claude-*,gpt-4*',
         modelPatternsHelp: '支持通配符，多个模式用逗号分隔',
         costPerInputToken: '输入成本（$/百万token）',
         costPerOutputToken: '输出成本（$/百万token）',
+        costPerCacheReadToken: '缓存读取成本（$/百万token）',
+        costPerCacheReadTokenHelp: '用于统计中的预估花费，留空时按输入成本计算',
+        hideThinking: '不转发推理内容（thinking）',
+        hideThinkingHelp: '默认会把上游返回的 reasoning_content / reasoning 字段（DeepSeek、o 系列等）转换为 Claude 的 thinking 块，勾选后丢弃',
+        disableStreamUsage: '流式请求不获取 usage',
+        disableStreamUsageHelp: '流式请求默认注入 stream_options.include_usage，让上游返回准确的 token 用量；端点不支持该字段报错时勾选',
+        pinStatus: '锁定状态',
+        pinStatusHelp: '请求结果和健康检查不再自动修改该端点的状态，只能通过手动启用、禁用或维护来修改',
         quotaLimit: '配额限制（tokens）',
         quotaLimitHelp: '0 表示无限制',
         quotaResetCycle: '配额重置周期',
@@ -120,8 +178,64 @@ export default {
         quotaDaily: '每日',
         quotaWeekly: '每周',
         quotaMonthly: '每月',
+        quotaMode: '配额模式',
+        quotaModeToken: '按 Token 数',
+        quotaModeCost: '按成本（美元）',
+        quotaModeHelp: '按成本时配额限制表示美元上限，用量按该端点的单价换算成美元累计',
         priority: '优先级',
         priorityHelp: '数值越小优先级越高，默认 100',
+        userAgent: 'User-Agent',
+        userAgentPlaceholder: '留空则透传客户端的 User-Agent',
+        userAgentHelp: '发往该端点的 User-Agent，适用于按 UA 区分对待的中转站',
+        slaP95: 'SLA p95 响应时间 (毫秒)',
+        slaP95Placeholder: '0 = 使用全局默认值',
+        slaP95Help: '该端点 p95 响应时间超过此值时告警（需启用 SLA 监控）',
+        maxConcurrency: '最大并发请求数',
+        maxConcurrencyPlaceholder: '0 = 不限制',
+        maxConcurrencyHelp: '超过上限的请求会排队等待空闲名额，超时仍未轮到则切换到其他端点',
+        timeoutSeconds: '请求超时（秒）',
+        timeoutSecondsPlaceholder: '0 = 使用全局超时',
+        timeoutSecondsHelp: '覆盖全局请求超时，例如为较慢的端点设置更长的超时',
+        weight: '权重',
+        weightHelp: '用于加权轮询负载均衡，权重越高分配的请求越多，默认1',
+        headerMode: '请求头透传',
+        headerModeAll: '全部透传（默认）',
+        headerModeWhitelist: '白名单',
+        headerModeMinimal: '最小化',
+        headerModeHelp: '控制转发给该端点的客户端请求头。全部透传会保留 x-stainless-* 等客户端指纹；最小化仅保留协议必需的请求头（Content-Type、Accept、anthropic-version、anthropic-beta）',
+        headerWhitelist: '请求头白名单',
+        headerWhitelistHelp: '逗号分隔，在协议必需请求头之外额外透传的请求头，支持 x-stainless-* 形式的前缀通配',
+        healthFields: '健康检查必需字段',
+        healthFieldsHelp: '逗号分隔，健康检查响应中必须包含的字段，支持 choices.0.message 形式的点号路径。留空时仅使用内置判定',
+        healthErrorWords: '健康检查错误关键词',
+        healthErrorWordsPlaceholder: '如：余额不足, quota exceeded',
+        healthErrorWordsHelp: '逗号分隔，不区分大小写。HTTP 200 的响应中包含任一关键词即判定为不健康，用于识别用 200 包装的错误',
+        healthCheckPath: '自定义健康检查路径',
+        activeHours: '活跃时段',
+        activeHoursPlaceholder: '如：22:00-06:00 或 09:00-12:00,14:00-18:00 Asia/Shanghai',
+        activeHoursHelp: '端点只在这些时段内参与路由。多个 HH:MM-HH:MM 区间用逗号分隔，结束早于开始表示跨午夜；末尾可加时区（如 Asia/Shanghai、UTC+8），默认本机时区。留空则一直可用',
+        keyExpiryDate: '密钥到期日',
+        keyExpiryDateHelp: '到期前 7 天内发出告警提醒，到期后自动禁用端点（到期日当天仍可用）。密钥不过期时留空',
+        healthCheckPathHelp: '配置后健康检查只请求该路径（如 /health），返回 2xx 即视为健康，不消耗 token；留空则使用全局健康检查方式',
+        extraApiKeys: '额外 API Key',
+        extraApiKeysPlaceholder: '每行一个 key',
+        extraApiKeysHelp: '与上方 API Key 组成 key 池轮询使用；某把 key 返回 401/429 时暂停一段时间并换下一把重试',
+        refreshToken: 'OAuth Refresh Token',
+        refreshTokenPlaceholder: '使用长期 API Key 时留空',
+        refreshTokenHelp: '填写后 API Key 视为 Anthropic OAuth access token，临近过期时自动刷新；刷新失败时继续使用旧 token 并记录告警',
+        tokenExpiry: 'Access Token 过期时间',
+        tokenExpiryHelp: '到期前 5 分钟自动刷新，刷新成功后自动更新',
+        models: '支持的模型',
+        modelsHelp: '声明该端点支持的多个模型及各自单价、配额（支持 claude-* 等通配符）。请求列表中的模型时会路由到此端点并按该模型转发；单价/配额留空时使用端点设置',
+        modelRewrite: '模型名重写规则',
+        modelRewritePlaceholder: 'claude-3-5-sonnet=anthropic/claude-3.5-sonnet',
+        modelRewriteHelp: '每行一条，格式为 原模型名=上游模型名；转换前按精确匹配替换请求体中的模型名，未匹配的模型保持原样',
+        addModel: '添加模型',
+        removeModel: '移除',
+        modelName: '模型名称',
+        modelCostInput: '输入 $/M',
+        modelCostOutput: '输出 $/M',
+        modelQuota: 'Token 配额',
         cancel: '取消',
         save: '保存',
         close: '关闭',
@@ -130,6 +244,9 @@ export default {
         portLabel: '端口号 (1-65535)：',
         portNote: '注意：修改端口号需要重启应用',
         portInvalid: '请输入有效的端口号（1-65535）',
+        clientPortsLabel: '客户端独立端口（可选）：',
+        clientPortsHelp: '为各客户端单独监听一个端口，工具无需在 base URL 中加 /claude、/gemini、/codex 路径前缀；留空表示不启用',
+        clientPortsConflict: '端口 {port} 与其他端口重复',
         portUpdateSuccess: '端口修改成功！请重启应用以使更改生效。',
         portUpdateFailed: '端口修改失败：{error}',
         requiredFields: '请填写所有必填项',
@@ -169,7 +286,22 @@ export default {
         connectionSuccess: '连接成功！',
         connectionFailed: '连接失败',
         testError: '测试出错',
-        notSupportedMessage: '当前端点不支持测试接口，可尝试直接到客户端中使用'
+        notSupportedMessage: '当前端点不支持测试接口，可尝试直接到客户端中使用',
+        customTitle: '自定义测试',
+        customPrompt: '测试语句',
+        customPromptPlaceholder: '留空使用默认测试语句',
+        customMaxTokens: 'max_tokens',
+        customMaxTokensHelp: '留空或 0 使用默认值（16）',
+        retest: '重新测试',
+        lastResultAt: '上次测试于 {time}',
+        retestNow: '立即重测',
+        preflightTitle: '网络预检（DNS + TCP）',
+        preflightHelp: '仅做 DNS 解析并对解析到的每个 IP 建立 TCP 连接，不发送 HTTP 请求，可快速区分网络问题与鉴权问题。',
+        preflightRun: '开始预检',
+        preflightReachable: '✅ 网络可达',
+        preflightUnreachable: '❌ 所有地址均不可达',
+        preflightDnsFailed: '❌ DNS 解析失败',
+        preflightProxyNote: '已配置代理，实际请求经由代理发出，此直连结果仅供参考'
     },
     shortcuts: {
         title: '快捷键',
@@ -253,9 +385,13 @@ export default {
         proxy: '代理设置',
         proxyUrl: '代理地址',
         proxyUrlPlaceholder: '例如：http://127.0.0.1:7890 或 socks5://127.0.0.1:1080',
-        proxyHelp: '配置 HTTP/SOCKS5 代理，留空则直连',
+        proxyHelp: '配置 HTTP/SOCKS5 代理，留空则直连；需要认证时使用 socks5://用户名:密码@主机:端口',
+        proxyTest: '测试',
+        proxyTestEmpty: '请先填写代理地址',
+        proxyTestSuccess: '代理可用（{latency}ms）',
+        proxyTestFailed: '代理测试失败',
         healthCheck: '健康检测',
-        healthCheckHelp: '定期检测所有端点的可用性和延时（每次检测约消耗1-2个token）',
+        healthCheckHelp: '定期检测所有端点的可用性和延时（token 消耗取决于健康检查方式）',
         healthCheckOptions: {
             disabled: '禁用',
             sec30: '30秒',
@@ -263,8 +399,28 @@ export default {
             min5: '5分钟',
             min10: '10分钟'
         },
+        autoOptimize: '定时优化端点',
+        autoOptimizeHelp: '定期检测所有端点：启用已恢复的端点、将失效的端点标记为不可用，并把最快的端点设为当前端点。每次检测对每个端点发送一个最小请求（约消耗1-2个token），不会与健康检测同时进行',
+        autoOptimizeOptions: {
+            disabled: '禁用',
+            hour1: '每小时',
+            hour6: '每6小时',
+            hour12: '每12小时',
+            day1: '每天'
+        },
+        healthCheckMethod: '健康检查方式',
+        healthCheckMethodHelp: 'Models API 和 token 计数接口不消耗 token，端点不支持时自动回退到最小请求（约消耗1-2个token）；配置了自定义健康规则的端点始终使用最小请求',
+        healthCheckMethodOptions: {
+            models: 'Models API（免费）',
+            tokenCount: 'Token 计数（免费，仅 Claude）',
+            minimal: '最小请求（约1-2个token）'
+        },
         requestTimeout: '请求超时',
         requestTimeoutHelp: '设置 API 请求的最大等待时间，超时后将自动切换到下一个端点',
+        enableHTTP2: '上游 HTTP/2',
+        enableHTTP2Help: '上游请求复用共享连接池，端点支持时协商 HTTP/2，减少建连延迟。兼容 HTTP 和 SOCKS5 代理，不支持 HTTP/2 的端点自动回退 HTTP/1.1',
+        perEndpointLogging: '按端点分文件记录日志',
+        perEndpointLoggingHelp: '将各端点的请求日志额外写入数据目录 logs/endpoints 下以端点名命名的文件，单个文件超过 10MB 时轮转，保留 3 个历史文件',
         requestTimeoutOptions: {
             default: '默认 (5分钟)',
             min1: '1分钟',
@@ -275,9 +431,25 @@ export default {
             min15: '15分钟',
             min30: '30分钟'
         },
+        streamHeartbeat: '流式心跳保活',
+        streamHeartbeatHelp: '等待上游首字节期间（如长时间思考），定期向客户端发送 SSE 注释行，避免连接被中间代理/负载均衡器断开',
+        streamHeartbeatOptions: {
+            disabled: '关闭',
+            seconds: '秒'
+        },
+        streamFirstByteTimeout: '流式首字节超时',
+        streamFirstByteTimeoutHelp: '流式请求发出后超过该时间仍未收到数据则切换到下一个端点，非流式请求不受影响。心跳发出后无法再重试，建议心跳间隔大于该超时',
+        maxRequestBody: '请求体大小上限',
+        maxRequestBodyUnlimited: '不限制',
+        maxRequestBodyHelp: '请求体超过该大小时返回 HTTP 413，并在请求统计中记录为失败',
         healthHistoryRetention: '健康历史保留',
         healthHistoryRetentionHelp: '健康检测历史记录的保留天数',
         healthHistoryRetentionDays: '天',
+        interactionRetention: '交互记录保留',
+        interactionRetentionHelp: '超过保留天数的请求/响应交互记录将被自动清理',
+        statsTimezone: '统计时区',
+        statsTimezonePlaceholder: '留空使用本机时区，如 UTC、Asia/Shanghai、UTC+8',
+        statsTimezoneHelp: '统计中「今天」和按天汇总所使用的时区。修改后请求明细会按新时区重新计算日期，已有的按天汇总保留原日期',
         languageHelp: '选择界面显示语言',
         alertConfig: '端点故障告警',
         alertEnabled: '启用告警',
@@ -285,11 +457,28 @@ export default {
         alertTimes: '次',
         alertCooldown: '告警冷却时间',
         alertMinutes: '分钟',
+        alertAggregationWindow: '告警聚合窗口',
+        slaConfig: '响应时间 SLA',
+        slaEnabled: '启用 SLA 监控',
+        slaDefaultP95: '默认 p95 阈值',
+        slaWindow: '统计窗口',
+        slaHelp: '周期性计算各端点 p95 响应时间，超过 SLA 时告警；阈值可在端点设置中单独配置',
+        tokenRateAlertConfig: 'Token 消耗速率告警',
+        tokenRateAlertEnabled: '启用速率告警',
+        tokenRateAlertThreshold: '告警阈值',
+        tokenRateAlertPerMin: 'tokens/分钟',
+        tokenRateAlertWindow: '滑动窗口',
+        tokenRateAlertCooldown: '告警冷却时间',
+        tokenRateAlertCurrent: '当前速率',
+        tokenRateAlertHelp: '滑动窗口内的实时 token 消耗速率（含缓存 token）超过阈值时告警，便于及时发现死循环或异常高频调用，避免意外产生高额账单',
+        alertSeconds: '秒',
+        alertNoAggregation: '不聚合',
         alertNotifyOnRecovery: '恢复时通知',
         alertSystemNotification: '发送系统通知',
         alertConfigHelp: '当端点连续健康检测失败时发送告警通知',
         performanceAlertEnabled: '启用性能告警',
         performanceLatencyThreshold: '延迟阈值',
+        performanceLatencyThresholdHelp: '健康检查延迟超过该阈值的端点会被标记为降级，路由时排在可用端点之后',
         performanceLatencyMs: '毫秒',
         performanceLatencyIncrease: '延迟增加比例',
         performanceLatencyPercent: '%',
@@ -311,6 +500,9 @@ export default {
         rateLimitEnabled: '启用限制',
         rateLimitGlobal: '全局限制',
         rateLimitPerEndpoint: '端点限制',
+        rateLimitPerClientIp: '每客户端 IP 限制',
+        rateLimitUnlimited: '不限制',
+        rateLimitRejectedIps: '被限流的 IP',
         rateLimitRequestsPerMin: '请求/分钟',
         rateLimitConfigHelp: '限制每分钟的请求数量，防止 API 过载',
         rateLimitStats: '限制统计',
@@ -318,6 +510,31 @@ export default {
         rateLimitAllowed: '已允许',
         rateLimitRejected: '已拒绝',
         rateLimitReset: '重置统计',
+        retryBudgetConfig: '重试预算',
+        retryBudgetEnabled: '启用重试预算',
+        retryBudgetRatio: '最大重试比例',
+        retryBudgetMinRetries: '窗口内最少允许重试次数',
+        retryBudgetWindow: '统计窗口',
+        retryBudgetConfigHelp: '将窗口内的重试次数限制为正常请求数的一定比例，预算耗尽后失败请求直接返回而不再重试，避免上游故障时重试放大。测试请求不计入',
+        retryBudgetStats: '预算统计',
+        retryBudgetRequests: '窗口内请求数',
+        retryBudgetRetries: '重试数 / 预算',
+        retryBudgetRejected: '被拒绝的重试',
+        autoContinueConfig: '自动续写',
+        autoContinueEnabled: '响应因 max_tokens 截断时自动续写',
+        autoContinueMax: '单个请求最多续写次数',
+        autoContinueResume: '上游流中途断开时换端点续传',
+        autoContinueConfigHelp: '响应以 stop_reason max_tokens 结束时，自动向同一端点发起续写请求并拼接结果，流式与非流式均支持。每次续写都会重新发送完整上下文并消耗额度。仅对 Claude 格式、未开启 thinking 的纯文本响应生效。开启断线续传后，上游流式响应中途断开时以已发出的文本为上下文换其他端点续写（最多 2 次），声明了 tools 的请求不续传',
+        circuitBreakerConfig: '端点熔断',
+        circuitBreakerEnabled: '端点连续失败后暂停使用一段时间',
+        circuitBreakerThreshold: '触发熔断的连续失败次数',
+        circuitBreakerCooldown: '冷却时间',
+        circuitBreakerProbes: '冷却结束后的试探请求数',
+        circuitBreakerStatus: '端点状态',
+        circuitBreakerNone: '当前没有冷却中的端点',
+        circuitBreakerOpen: '冷却中（剩余 {seconds} 秒）',
+        circuitBreakerHalfOpen: '试探中',
+        circuitBreakerConfigHelp: '端点连续失败达到次数后，在冷却时间内不再被路由选中；冷却结束后放行试探请求，全部成功则恢复，否则重新冷却。所有端点都在冷却中时仍会照常发送请求',
         routingConfig: '智能路由',
         routingEnabled: '启用智能路由',
         routingConfigHelp: '启用智能路由策略，根据模型、负载、成本和配额自动选择最佳端点',
@@ -327,7 +544,9 @@ export default {
         loadBalanceAlgorithms: {
             fastest: '最快响应',
             weighted: '加权随机',
-            roundRobin: '轮询'
+            roundRobin: '轮询',
+            weightedRoundRobin: '加权轮询',
+            leastConnections: '最少连接'
         },
         costPriority: '成本优先',
         quotaRouting: '配额路由',
@@ -341,6 +560,8 @@ export default {
         modelPatternsHelp: '支持通配符，多个模式用逗号分隔',
         costPerInputToken: '输入成本（$/百万token）',
         costPerOutputToken: '输出成本（$/百万token）',
+        costPerCacheReadToken: '缓存读取成本（$/百万token）',
+        costPerCacheReadTokenHelp: '用于统计中的预估花费，留空时按输入成本计算',
         quotaLimit: '配额限制（tokens）',
         quotaLimitHelp: '0 表示无限制',
         quotaResetCycle: '配额重置周期',
@@ -360,12 +581,24 @@ export default {
         autoEnableOnRecovery: '自动启用恢复的端点',
         autoEnableSuccessThreshold: '连续成功阈值',
         autoEnableHelp: '当端点健康检查连续成功达到阈值后，自动启用该端点',
+        alertWebhookUrl: 'Webhook 地址',
+        alertWebhookTemplate: 'Webhook 请求体模板（可选）',
+        alertWebhookHelp: '模板为空时以 JSON 形式 POST {endpointName, clientType, alertType, timestamp, message}。模板使用 Go template 语法且需渲染为 JSON，变量 {{.EndpointName}} {{.ClientType}} {{.AlertType}} {{.Timestamp}} {{.Message}} 已做 JSON 转义，放在引号内使用即可。请求失败最多重试 3 次',
+        alertWebhookEmpty: '请输入 Webhook 地址',
+        alertWebhookTestSuccess: '测试告警已发送',
+        alertWebhookTestFailed: 'Webhook 测试失败',
         sessionAffinityConfig: '会话亲和性',
         sessionAffinityEnabled: '启用会话亲和性',
         sessionAffinityTimeout: '会话超时',
         sessionAffinityTimeoutHours: '小时',
         sessionAffinityMaxConcurrent: '每端点最大并发会话',
         sessionAffinityMaxConcurrentHelp: '0 表示无限制',
+        sessionKeySource: '会话 ID 来源',
+        sessionKeySourceDefault: '默认（会话请求头，或客户端 IP + User-Agent）',
+        sessionKeySourceHeader: '请求头',
+        sessionKeySourceBody: '请求体字段',
+        sessionKeyName: '请求头名 / 字段路径',
+        sessionKeyNameHelp: '请求头如 X-Session-Id，请求体字段如 metadata.user_id；取不到值时回退到默认规则',
         sessionAffinityHelp: '同一会话使用相同端点，新会话优先使用高优先级端点'
     },
     statistics: {
@@ -394,8 +627,24 @@ export default {
         out: '输出',
         details: '详情',
         viewDetails: '查看今日使用详情',
+        exportCSV: '导出',
+        exportCSVTitle: '将所选周期的每日统计导出为 CSV',
+        exportCSVFailed: '导出统计失败',
+        importStats: '导入',
+        importStatsTitle: '从其他 ccNexus 实例导入统计',
+        importStatsUrl: '实例地址',
+        importStatsStrategy: '冲突处理',
+        importStatsKeepLocal: '保留本地记录',
+        importStatsOverwrite: '用远程记录覆盖',
+        importStatsNote: '从对方实例的 /stats/export 接口拉取每日统计。统计按设备分别保存，重复导入同一实例不会重复计数。',
+        importStatsUrlRequired: '请输入实例地址',
+        importStatsSuccess: '统计导入成功',
+        importStatsFailed: '导入统计失败',
         dailyDetails: '今日使用详情',
         totalRecords: '总记录数',
+        search: '搜索',
+        clearSearch: '清除',
+        searchPlaceholder: '搜索错误信息、模型或端点',
         pageSize: '每页显示',
         time: '时间',
         endpoint: '端点',
@@ -412,6 +661,8 @@ export default {
         avgDuration: '平均时长',
         requestLatency: '延迟',
         avgOutputSpeed: '输出速度',
+        percentileInsufficient: '仅 {count} 个样本，数据不足',
+        upstreamModelTip: '客户端请求的模型 → 实际发往上游的模型',
         generationSpeed: '生成',
         duration: '时长',
         outputTokensPerSec: 'tok/s',
@@ -433,7 +684,29 @@ export default {
         sessionStatsDisabled: '会话亲和性未启用',
         sessionStatsEmpty: '暂无活跃会话',
         unbindSuccess: '解除绑定成功',
-        unbindFailed: '解除绑定失败'
+        unbindFailed: '解除绑定失败',
+        requestTypeTitle: '流式 / 非流式性能',
+        requestTypeAll: '全部端点',
+        reliabilityTitle: '端点成功率排名',
+        reliabilityEmpty: '该周期内暂无请求',
+        reliabilityRank: '排名',
+        reliabilityEndpoint: '端点',
+        reliabilitySuccessTotal: '成功 / 总数',
+        reliabilityInsufficientShort: '样本不足',
+        reliabilityInsufficient: '该周期内请求少于 {count} 次，不参与排名',
+        errorBreakdownTitle: '失败分类',
+        errorBreakdownEmpty: '该周期内没有失败的请求',
+        errorBreakdownSummary: '失败 {errors} / 共 {total} 次请求（{rate}）',
+        errorCategories: {
+            network: '网络错误',
+            timeout: '超时',
+            auth: '认证失败 (401/403)',
+            rate_limit: '限流 (429)',
+            server_error: '服务端错误 (5xx)',
+            upstream_error: '上游业务错误',
+            other: '其他',
+            client_gone: '客户端断开'
+        }
     },
     cost: {
         title: '成本统计',
@@ -449,7 +722,9 @@ export default {
         noData: '暂无成本数据',
         trend: '趋势',
         viewCost: '查看成本',
-        costBreakdown: '成本明细'
+        costBreakdown: '成本明细',
+        estimatedCost: '预估花费',
+        estimatedCostHelp: '按各端点配置的单价估算（输入 / 输出 / 缓存），未配置单价的端点记为 $0'
     },
     chart: {
         minutes: '分钟',
@@ -605,7 +880,9 @@ export default {
             dirPlaceholder: '请选择用于存放备份的目录',
             chooseDir: '选择',
             dirRequired: '请选择备份目录',
-            saveFirst: '请先保存配置'
+            saveFirst: '请先保存配置',
+            keepVersions: '保留版本数',
+            keepVersionsHelp: '大于 0 时备份文件名带时间戳，只保留最近 N 份；为 0 时每次覆盖同名文件'
         },
         s3: {
             title: 'S3备份',
@@ -628,6 +905,7 @@ export default {
         errors: {
             backup_provider_invalid: '备份类型不合法',
             backup_local_dir_not_set: '未设置本地备份目录',
+            backup_keep_versions_invalid: '保留版本数不能为负数',
             backup_local_not_configured: '本地备份未配置',
             backup_s3_not_configured: 'S3 未配置',
             backup_s3_endpoint_invalid: 'S3 Endpoint 无效（请填写 host:port 或 https://host:port）',
@@ -736,6 +1014,12 @@ export default {
         enabled: '启用记录',
         enableRecording: '启用记录',
         enabledHelp: '记录所有请求/响应交互以供分析',
+        redactPatterns: '脱敏规则',
+        redactPatternsPlaceholder: '每行一个正则表达式，例如 sk-[A-Za-z0-9_-]{20,}',
+        redactPatternsHelp: '保存记录前将请求/响应文本中匹配的内容替换为 ***，不影响已保存的记录',
+        redactPatternsSave: '保存规则',
+        redactPatternsSaved: '脱敏规则已保存',
+        redactPatternsFailed: '保存脱敏规则失败',
         selectDate: '选择日期',
         export: '导出',
         noData: '暂无交互记录',
@@ -757,12 +1041,18 @@ export default {
         detailTitle: '交互详情',
         transformer: '转换器',
         actions: '操作',
-        retention: '交互记录保留30天，超过30天的记录将自动清理',
+        retention: '超过保留天数的交互记录将自动清理，保留天数可在设置中修改',
         close: '关闭',
         requestRaw: '原始请求',
         requestTransformed: '转换后请求',
         responseRaw: '原始响应',
         responseTransformed: '转换后响应',
+        replay: '重放',
+        replayResult: '重放结果',
+        replayEndpointPlaceholder: '端点（默认原端点）',
+        replayConfirm: '重放会把归档的请求再次发送到端点，并消耗 token。是否继续？',
+        replayFailed: '重放失败',
+        replayEmpty: '尚未重放',
         messagePreview: '消息摘要',
         toolCalls: '工具调用',
         intentType: '意图',
@@ -864,6 +1154,7 @@ export default {
         // 一键检测相关
         testAllEndpoints: '一键检测',
         testing: '检测中...',
+        testProgress: '检测中 {completed}/{total}',
         testComplete: '检测完成',
         testFailed: '检测失败',
         bestEndpoint: '最佳端点',
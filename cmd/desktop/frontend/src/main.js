@@ -4,13 +4,13 @@ import '../wailsjs/runtime/runtime.js'
 import { setLanguage } from './i18n/index.js'
 import { initUI, changeLanguage } from './modules/ui.js'
 import { loadConfig } from './modules/config.js'
-import { loadStats, switchStatsPeriod, loadStatsByPeriod, getCurrentPeriod, refreshSessionStats } from './modules/stats.js'
+import { loadStats, switchStatsPeriod, loadStatsByPeriod, getCurrentPeriod, refreshSessionStats, exportStatsCSV, showImportStatsModal, closeImportStatsModal, importStatsFromURL } from './modules/stats.js'
 import { initTokenChart } from './modules/chart.js'
-import { renderEndpoints, toggleEndpointPanel, initEndpointSuccessListener, checkAllEndpointsOnStartup, switchEndpointViewMode, initEndpointViewMode, isDropdownOpen, initEndpoints, renderClientTypeSelector, renderTagFilter } from './modules/endpoints.js'
+import { renderEndpoints, toggleEndpointPanel, initEndpointSuccessListener, checkAllEndpointsOnStartup, switchEndpointViewMode, initEndpointViewMode, isDropdownOpen, initEndpoints, renderClientTypeSelector, renderTagFilter, autoSortEndpointsByLatency } from './modules/endpoints.js'
 import { loadLogs, toggleLogPanel, changeLogLevel, copyLogs, clearLogs } from './modules/logs.js'
 import { showDataSyncDialog } from './modules/webdav.js'
 import { initTips } from './modules/tips.js'
-import { showDailyDetailsModal, closeDailyDetailsModal, changeDetailsPageSize, loadPreviousDetailsPage, loadNextDetailsPage } from './modules/details.js'
+import { showDailyDetailsModal, closeDailyDetailsModal, changeDetailsPageSize, loadPreviousDetailsPage, loadNextDetailsPage, searchDetails, clearDetailsSearch } from './modules/details.js'
 import { showSettingsModal, closeSettingsModal, saveSettings, applyTheme, initTheme, showAutoThemeConfigModal, closeAutoThemeConfigModal, saveAutoThemeConfig, updateAutoThemeModeHelp } from './modules/settings.js'
 import { initBroadcast } from './modules/broadcast.js'
 import {
@@ -21,7 +21,9 @@ import {
     showInteractionDetail,
     closeInteractionDetailModal,
     switchDetailTab,
-    exportInteractions
+    replayInteraction,
+    exportInteractions,
+    saveInteractionRedactPatterns
 } from './modules/interactions.js'
 import {
     showAddEndpointModal,
@@ -34,6 +36,8 @@ import {
     initModelInputEvents,
     toggleModelDropdown,
     toggleRoutingSettings,
+    handleHeaderModeChange,
+    addEndpointModelRow,
     showEditPortModal,
     savePort,
     closePortModal,
@@ -44,7 +48,10 @@ import {
     closeChangelogModal,
     showChangelogIfNewVersion,
     testEndpointHandler,
+    showTestResultHandler,
     closeTestResultModal,
+    retestEndpoint,
+    runPreflightCheck,
     openGitHub,
     openArticle,
     togglePasswordVisibility,
@@ -240,6 +247,8 @@ window.handleTransformerChange = handleTransformerChange;
 window.fetchModels = fetchModels;
 window.toggleModelDropdown = toggleModelDropdown;
 window.toggleRoutingSettings = toggleRoutingSettings;
+window.handleHeaderModeChange = handleHeaderModeChange;
+window.addEndpointModelRow = () => addEndpointModelRow();
 window.showEditPortModal = showEditPortModal;
 window.savePort = savePort;
 window.closePortModal = closePortModal;
@@ -248,7 +257,10 @@ window.closeWelcomeModal = closeWelcomeModal;
 window.showChangelogModal = showChangelogModal;
 window.closeChangelogModal = closeChangelogModal;
 window.testEndpoint = testEndpointHandler;
+window.showTestResult = showTestResultHandler;
 window.closeTestResultModal = closeTestResultModal;
+window.retestEndpoint = retestEndpoint;
+window.runPreflightCheck = runPreflightCheck;
 window.openGitHub = openGitHub;
 window.openArticle = openArticle;
 window.toggleLogPanel = toggleLogPanel;
@@ -268,8 +280,13 @@ window.refreshConnectedClients = refreshConnectedClients;
 window.changeClientsHoursFilter = changeClientsHoursFilter;
 window.showDataSyncDialog = showDataSyncDialog;
 window.switchStatsPeriod = switchStatsPeriod;
+window.exportStatsCSV = exportStatsCSV;
+window.showImportStatsModal = showImportStatsModal;
+window.closeImportStatsModal = closeImportStatsModal;
+window.importStatsFromURL = importStatsFromURL;
 window.toggleEndpointPanel = toggleEndpointPanel;
 window.switchEndpointViewMode = switchEndpointViewMode;
+window.autoSortEndpointsByLatency = autoSortEndpointsByLatency;
 window.showSettingsModal = showSettingsModal;
 window.closeSettingsModal = closeSettingsModal;
 window.saveSettings = saveSettings;
@@ -294,6 +311,8 @@ window.deleteHistoryArchive = async () => {
 window.showDailyDetailsModal = showDailyDetailsModal;
 window.closeDailyDetailsModal = closeDailyDetailsModal;
 window.changeDetailsPageSize = changeDetailsPageSize;
+window.searchDetails = searchDetails;
+window.clearDetailsSearch = clearDetailsSearch;
 window.loadPreviousDetailsPage = loadPreviousDetailsPage;
 window.loadNextDetailsPage = loadNextDetailsPage;
 
@@ -305,4 +324,6 @@ window.changeInteractionDate = changeInteractionDate;
 window.showInteractionDetail = showInteractionDetail;
 window.closeInteractionDetailModal = closeInteractionDetailModal;
 window.switchDetailTab = switchDetailTab;
+window.replayInteraction = replayInteraction;
 window.exportInteractions = exportInteractions;
+window.saveInteractionRedactPatterns = saveInteractionRedactPatterns;
@@ -34,28 +34,41 @@ export async function updatePort(port) {
     await window.go.main.App.UpdatePort(port);
 }
 
-export async function addEndpoint(clientType, name, url, key, transformer, model, remark, tags,
-    modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority) {
-    await window.go.main.App.AddEndpoint(clientType, name, url, key, transformer, model, remark || '', tags || '',
-        modelPatterns || '', costPerInputToken || 0, costPerOutputToken || 0, quotaLimit || 0, quotaResetCycle || '', priority || 100);
+export async function addEndpoint(clientType, input) {
+    await window.go.main.App.AddEndpoint(clientType, input);
 }
 
-export async function updateEndpoint(clientType, index, name, url, key, transformer, model, remark, tags,
-    modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority) {
-    await window.go.main.App.UpdateEndpoint(clientType, index, name, url, key, transformer, model, remark || '', tags || '',
-        modelPatterns || '', costPerInputToken || 0, costPerOutputToken || 0, quotaLimit || 0, quotaResetCycle || '', priority || 100);
+export async function updateEndpoint(clientType, index, input) {
+    await window.go.main.App.UpdateEndpoint(clientType, index, input);
 }
 
 export async function removeEndpoint(clientType, index) {
     await window.go.main.App.RemoveEndpoint(clientType, index);
 }
 
+export async function cloneEndpoint(clientType, index, newName = '') {
+    await window.go.main.App.CloneEndpoint(clientType, index, newName);
+}
+
 export async function toggleEndpoint(clientType, index, enabled) {
     await window.go.main.App.ToggleEndpoint(clientType, index, enabled);
 }
 
-export async function testEndpoint(clientType, index) {
-    const resultStr = await window.go.main.App.TestEndpoint(clientType, index);
+export async function toggleEndpointsByTag(clientType, tag, enabled) {
+    return await window.go.main.App.ToggleEndpointsByTag(clientType, tag, enabled);
+}
+
+export async function setEndpointMaintenance(clientType, index, maintenance) {
+    await window.go.main.App.SetEndpointMaintenance(clientType, index, maintenance);
+}
+
+export async function testEndpoint(clientType, index, prompt = '', maxTokens = 0) {
+    const resultStr = await window.go.main.App.TestEndpoint(clientType, index, prompt, maxTokens);
+    return JSON.parse(resultStr);
+}
+
+export async function getLastTestResult(clientType, index) {
+    const resultStr = await window.go.main.App.GetLastTestResult(clientType, index);
     return JSON.parse(resultStr);
 }
 
@@ -64,6 +77,11 @@ export async function testEndpointLight(clientType, index) {
     return JSON.parse(resultStr);
 }
 
+export async function preflightCheck(clientType, index) {
+    const resultStr = await window.go.main.App.PreflightCheck(clientType, index);
+    return JSON.parse(resultStr);
+}
+
 export async function testAllEndpointsZeroCost(clientType) {
     const resultStr = await window.go.main.App.TestAllEndpointsZeroCost(clientType);
     return JSON.parse(resultStr);
@@ -5,6 +5,7 @@ let currentPage = 1;
 let pageSize = 20;
 let totalRecords = 0;
 let totalPages = 1;
+let searchKeyword = '';
 
 // Show daily details modal
 export async function showDailyDetailsModal() {
@@ -13,6 +14,9 @@ export async function showDailyDetailsModal() {
 
     // Reset to first page
     currentPage = 1;
+    searchKeyword = '';
+    const searchInput = document.getElementById('detailsSearchKeyword');
+    if (searchInput) searchInput.value = '';
 
     // Show modal
     modal.style.display = 'flex';
@@ -33,11 +37,13 @@ export function closeDailyDetailsModal() {
 async function loadDetailsPage(page) {
     try {
         const offset = (page - 1) * pageSize;
-        const result = await window.go.main.App.GetDailyRequestDetails(pageSize, offset);
+        const result = searchKeyword
+            ? await window.go.main.App.SearchRequestStats(searchKeyword, '', '', '', pageSize, offset)
+            : await window.go.main.App.GetDailyRequestDetails(pageSize, offset);
         const data = JSON.parse(result);
 
         if (!data.success) {
-            showError(data.message || t('statistics.loadFailed'));
+            showError(data.message || data.error || t('statistics.loadFailed'));
             return;
         }
 
@@ -99,7 +105,7 @@ function renderDetailsTable(requests) {
         row.innerHTML = `
             <td>${time}</td>
             <td>${escapeHtml(req.endpointName || '-')}</td>
-            <td>${escapeHtml(req.model || '-')}</td>
+            <td>${formatModel(req)}</td>
             <td>${formatTokens(inputTotal)}</td>
             <td>${formatTokens(outputTotal)}</td>
             <td>${durationDisplay}</td>
@@ -111,6 +117,15 @@ function renderDetailsTable(requests) {
     });
 }
 
+// 客户端请求模型与实际上游模型不同时显示映射关系
+function formatModel(req) {
+    const model = req.model || '-';
+    if (!req.upstreamModel || req.upstreamModel === req.model) {
+        return escapeHtml(model);
+    }
+    return `<span title="${escapeHtml(t('statistics.upstreamModelTip'))}">${escapeHtml(model)} → ${escapeHtml(req.upstreamModel)}</span>`;
+}
+
 // Format duration in milliseconds to readable format
 function formatDuration(ms) {
     if (ms < 1000) {
@@ -186,6 +201,21 @@ export async function changeDetailsPageSize() {
     await loadDetailsPage(currentPage);
 }
 
+// Search today's requests by error message, model or endpoint name
+export async function searchDetails() {
+    const input = document.getElementById('detailsSearchKeyword');
+    searchKeyword = input ? input.value.trim() : '';
+    await loadDetailsPage(1);
+}
+
+// Clear search and show all requests of today
+export async function clearDetailsSearch() {
+    const input = document.getElementById('detailsSearchKeyword');
+    if (input) input.value = '';
+    searchKeyword = '';
+    await loadDetailsPage(1);
+}
+
 // Load previous page
 export async function loadPreviousDetailsPage() {
     if (currentPage > 1) {
@@ -10,7 +10,7 @@ let refreshPromise = null; // 用于防止并发刷新
 
 // StatusInfo 结构
 // {
-//   status: 'available' | 'unavailable' | 'disabled' | 'unknown',
+//   status: 'available' | 'degraded' | 'unavailable' | 'disabled' | 'unknown',
 //   source: 'health_check' | 'manual_test' | 'config',
 //   lastCheckAt: Date,
 //   latencyMs: number,
@@ -86,6 +86,20 @@ async function calculateEndpointStatus(endpoint, checkResults) {
         };
     }
 
+    // 健康检查延迟超过阈值被标记为降级（后端判定），优先于请求记录显示
+    if (endpoint.status === 'degraded') {
+        const checkResult = checkResults[endpoint.name];
+        return {
+            status: 'degraded',
+            source: 'config',
+            latencyMs: checkResult ? checkResult.latencyMs : undefined,
+            testIcon: '🐢',
+            testTip: checkResult && checkResult.latencyMs
+                ? `${t('endpoints.degradedTip')} (${Math.round(checkResult.latencyMs)}ms)`
+                : t('endpoints.degradedTip')
+        };
+    }
+
     // 2. 查询最近3次请求记录（新增：基于实际请求成功率）
     try {
         const recentRequestsStr = await window.go.main.App.GetRecentRequestsByEndpoint(
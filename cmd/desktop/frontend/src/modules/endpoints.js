@@ -1,7 +1,8 @@
 import { t } from '../i18n/index.js';
 import { formatTokens, maskApiKey } from '../utils/format.js';
 import { getEndpointStats } from './stats.js';
-import { toggleEndpoint, testAllEndpointsZeroCost } from './config.js';
+import { toggleEndpoint, toggleEndpointsByTag, setEndpointMaintenance, testAllEndpointsZeroCost, cloneEndpoint } from './config.js';
+import { showNotification } from './modal.js';
 import {
     initEndpointStatus,
     refreshEndpointStatus,
@@ -70,9 +71,17 @@ export async function renderTagFilter(containerId = 'tagFilterContainer') {
                     </option>
                 `).join('')}
             </select>
+            ${currentTagFilter ? `
+                <button class="btn btn-secondary btn-sm" data-action="tag-enable" title="${t('endpoints.enableByTagTip')}">${t('endpoints.enableByTag')}</button>
+                <button class="btn btn-secondary btn-sm" data-action="tag-disable" title="${t('endpoints.disableByTagTip')}">${t('endpoints.disableByTag')}</button>
+            ` : ''}
         </div>
     `;
 
+    container.querySelectorAll('[data-action="tag-enable"], [data-action="tag-disable"]').forEach(btn => {
+        btn.addEventListener('click', () => toggleByTag(btn, btn.dataset.action === 'tag-enable'));
+    });
+
     const select = document.getElementById('tagFilterSelect');
     if (select) {
         select.addEventListener('change', async (e) => {
@@ -85,6 +94,24 @@ export async function renderTagFilter(containerId = 'tagFilterContainer') {
     }
 }
 
+// 按当前筛选的标签批量启用/禁用端点
+async function toggleByTag(btn, enabled) {
+    const tag = currentTagFilter;
+    if (!tag) return;
+    try {
+        btn.disabled = true;
+        const affected = await toggleEndpointsByTag(currentClientType, tag, enabled);
+        const message = enabled ? t('endpoints.enabledByTag') : t('endpoints.disabledByTag');
+        showNotification(message.replace('{count}', affected).replace('{tag}', tag), 'success');
+        window.loadConfig();
+    } catch (error) {
+        console.error('Failed to toggle endpoints by tag:', error);
+        alert(t('endpoints.toggleByTagFailed') + ': ' + error);
+    } finally {
+        btn.disabled = false;
+    }
+}
+
 // 刷新端点列表
 export async function refreshEndpoints() {
     if (window.loadConfig) {
@@ -266,6 +293,118 @@ export function setTestState(button, index) {
     currentTestIndex = index;
 }
 
+// Load SLA status of the given client type, keyed by endpoint name (empty when SLA monitoring is disabled)
+async function loadSLAStatuses(clientType) {
+    try {
+        const result = JSON.parse(await window.go.main.App.GetSLAStatus());
+        const statuses = {};
+        if (!result.enabled) {
+            return statuses;
+        }
+        (result.statuses || [])
+            .filter(s => s.clientType === clientType)
+            .forEach(s => { statuses[s.endpointName] = s; });
+        return statuses;
+    } catch (error) {
+        console.error('Failed to load SLA status:', error);
+        return {};
+    }
+}
+
+// Render SLA traffic light badge
+function renderSLABadge(sla) {
+    if (!sla) return '';
+    const tip = sla.status === 'no_data'
+        ? t('endpoints.slaNoData').replace('{samples}', sla.samples)
+        : t('endpoints.slaTip').replace('{p95}', sla.p95Ms).replace('{threshold}', sla.thresholdMs);
+    return `<span class="sla-badge sla-${sla.status}" title="SLA: ${tip}">●</span>`;
+}
+
+// Default pin duration (minutes) when pinning from the endpoint list
+const PIN_DURATION_MINUTES = 30;
+
+// Load the active endpoint pin of a client type
+async function loadPinnedEndpoint(clientType) {
+    try {
+        const result = JSON.parse(await window.go.main.App.GetPinnedEndpoints());
+        return (result.pins || []).find(p => p.clientType === clientType) || null;
+    } catch (error) {
+        console.error('Failed to load pinned endpoint:', error);
+        return null;
+    }
+}
+
+// Render pin badge with remaining time
+function renderPinBadge(pin) {
+    if (!pin) return '';
+    const minutes = Math.max(1, Math.ceil(pin.remainingSeconds / 60));
+    const remaining = minutes >= 60 ? `${Math.floor(minutes / 60)}h${minutes % 60}m` : `${minutes}m`;
+    const tip = t('endpoints.pinnedTip').replace('{time}', new Date(pin.expiresAt).toLocaleTimeString());
+    return `<span class="pin-badge" title="${tip}">📌 ${remaining}</span>`;
+}
+
+// Days before the key expiry date to show the expiry badge (matches the backend warning window)
+const KEY_EXPIRY_WARN_DAYS = 7;
+
+// Render key expiry badge when the API key expires within KEY_EXPIRY_WARN_DAYS or has expired
+function renderKeyExpiryBadge(ep) {
+    if (!ep.keyExpiryDate) return '';
+    const [year, month, day] = ep.keyExpiryDate.split('-').map(Number);
+    const today = new Date();
+    today.setHours(0, 0, 0, 0);
+    const daysLeft = Math.round((new Date(year, month - 1, day) - today) / 86400000);
+    if (daysLeft > KEY_EXPIRY_WARN_DAYS) return '';
+    const tip = daysLeft < 0
+        ? t('endpoints.keyExpiredTip').replace('{date}', ep.keyExpiryDate)
+        : t('endpoints.keyExpiringTip').replace('{date}', ep.keyExpiryDate).replace('{days}', daysLeft);
+    return `<span class="key-expiry-badge${daysLeft < 0 ? ' expired' : ''}" title="${tip}">🔑 ${daysLeft < 0 ? t('endpoints.keyExpired') : daysLeft + 'd'}</span>`;
+}
+
+// Pin or unpin an endpoint
+async function togglePin(btn, name, pinned) {
+    try {
+        btn.disabled = true;
+        if (pinned) {
+            await window.go.main.App.UnpinEndpoint(currentClientType);
+        } else {
+            await window.go.main.App.PinEndpoint(currentClientType, name, PIN_DURATION_MINUTES);
+        }
+        window.loadConfig(); // Refresh display
+    } catch (error) {
+        console.error('Failed to pin endpoint:', error);
+        alert(t('endpoints.pinFailed') + ': ' + error);
+        btn.disabled = false;
+    }
+}
+
+// 进入/退出维护模式
+// 复制端点配置为一个禁用的新端点，并打开编辑窗口修改名称和 key
+async function cloneEndpointAt(index) {
+    try {
+        await cloneEndpoint(currentClientType, index);
+        await window.loadConfig();
+        const config = JSON.parse(await window.go.main.App.GetConfig());
+        const endpoints = config.endpoints.filter(ep => (ep.clientType || 'claude') === currentClientType);
+        showNotification(t('endpoints.cloneSuccess').replace('{name}', endpoints[endpoints.length - 1].name), 'success');
+        window.editEndpoint(endpoints.length - 1);
+    } catch (error) {
+        console.error('Failed to clone endpoint:', error);
+        alert(t('endpoints.cloneFailed') + ': ' + error);
+    }
+}
+
+async function toggleMaintenance(btn, index, isMaintenance) {
+    try {
+        btn.disabled = true;
+        await setEndpointMaintenance(currentClientType, index, !isMaintenance);
+        window.loadConfig();
+    } catch (error) {
+        console.error('Failed to set maintenance:', error);
+        alert(t('endpoints.maintenanceFailed') + ': ' + error);
+        btn.disabled = false;
+    }
+}
+
 export async function renderEndpoints(endpoints) {
     const container = document.getElementById('endpointList');
     if (!container) return; // 添加空值检查
@@ -292,6 +431,9 @@ export async function renderEndpoints(endpoints) {
         console.error('Failed to get current endpoint:', error);
     }
 
+    const slaStatuses = await loadSLAStatuses(currentClientType);
+    const pin = await loadPinnedEndpoint(currentClientType);
+
     if (filteredEndpoints.length === 0) {
         container.innerHTML = `
             <div class="empty-state">
@@ -315,7 +457,7 @@ export async function renderEndpoints(endpoints) {
     const viewMode = getEndpointViewMode();
     if (viewMode === 'compact') {
         container.classList.add('compact-view');
-        renderCompactView(sortedEndpoints, container, currentEndpointName);
+        renderCompactView(sortedEndpoints, container, currentEndpointName, slaStatuses, pin);
         return;
     } else {
         container.classList.remove('compact-view');
@@ -329,6 +471,8 @@ export async function renderEndpoints(endpoints) {
         const transformer = ep.transformer || 'claude';
         const model = ep.model || '';
         const isCurrentEndpoint = ep.name === currentEndpointName;
+        const isPinned = pin !== null && pin.endpointName === ep.name;
+        const isMaintenance = ep.status === 'maintenance';
 
         const item = document.createElement('div');
         item.className = 'endpoint-item';
@@ -364,6 +508,8 @@ export async function renderEndpoints(endpoints) {
             statusBadge = '<span class="status-badge status-available" title="' + t('endpoints.statusAvailable') + '">●</span>';
         } else if (status === 'warning') {
             statusBadge = '<span class="status-badge status-warning" title="' + t('endpoints.statusWarning') + '">●</span>';
+        } else if (status === 'degraded') {
+            statusBadge = '<span class="status-badge status-degraded" title="' + t('endpoints.statusDegraded') + '">●</span>';
         } else if (status === 'unavailable') {
             statusBadge = '<span class="status-badge status-unavailable" title="' + t('endpoints.statusUnavailable') + '">●</span>';
         } else if (status === 'untested') {
@@ -371,6 +517,10 @@ export async function renderEndpoints(endpoints) {
         } else if (status === 'disabled') {
             statusBadge = '<span class="status-badge status-disabled" title="' + t('endpoints.statusDisabled') + '">●</span>';
         }
+        // 维护状态由用户手动设置，优先于检测结果显示
+        if (isMaintenance) {
+            statusBadge = '<span class="status-badge status-maintenance" title="' + t('endpoints.statusMaintenance') + '">●</span>';
+        }
 
         item.innerHTML = `
             <div class="endpoint-info">
@@ -378,8 +528,12 @@ export async function renderEndpoints(endpoints) {
                     <span title="${testStatusTip}" style="cursor: help">${testStatusIcon}</span>
                     ${ep.name}
                     ${statusBadge}
+                    ${renderSLABadge(slaStatuses[ep.name])}
                     ${isCurrentEndpoint ? '<span class="current-badge">' + t('endpoints.current') + '</span>' : ''}
-                    ${enabled && !isCurrentEndpoint ? '<button class="btn btn-switch" data-action="switch" data-name="' + ep.name + '">' + t('endpoints.switchTo') + '</button>' : ''}
+                    ${isPinned ? renderPinBadge(pin) : ''}
+                    ${renderKeyExpiryBadge(ep)}
+                    ${enabled && !isMaintenance && !isCurrentEndpoint ? '<button class="btn btn-switch" data-action="switch" data-name="' + ep.name + '">' + t('endpoints.switchTo') + '</button>' : ''}
+                    ${isPinned ? '<button class="btn btn-switch" data-action="pin" title="' + t('endpoints.unpinTip') + '">' + t('endpoints.unpin') + '</button>' : (enabled && !isMaintenance ? '<button class="btn btn-switch" data-action="pin" title="' + t('endpoints.pinTip').replace('{minutes}', PIN_DURATION_MINUTES) + '">📌 ' + t('endpoints.pin') + '</button>' : '')}
                 </h3>
                 <p style="display: flex; align-items: center; gap: 8px; min-width: 0;"><span style="white-space: nowrap; overflow: hidden; text-overflow: ellipsis;">🌐 ${ep.apiUrl}</span> <button class="copy-btn" data-copy="${ep.apiUrl}" aria-label="${t('endpoints.copy')}" title="${t('endpoints.copy')}"><svg viewBox="0 0 24 24" fill="none" xmlns="http://www.w3.org/2000/svg" width="1em" height="1em"><path d="M7 4c0-1.1.9-2 2-2h11a2 2 0 0 1 2 2v11a2 2 0 0 1-2 2h-1V8c0-2-1-3-3-3H7V4Z" fill="currentColor"></path><path d="M5 7a2 2 0 0 0-2 2v10c0 1.1.9 2 2 2h10a2 2 0 0 0 2-2V9a2 2 0 0 0-2-2H5Z" fill="currentColor"></path></svg></button></p>
                 <p style="display: flex; align-items: center; gap: 8px; min-width: 0;"><span style="white-space: nowrap; overflow: hidden; text-overflow: ellipsis;">🔑 ${maskApiKey(ep.apiKey)}</span> <button class="copy-btn" data-copy="${ep.apiKey}" aria-label="${t('endpoints.copy')}" title="${t('endpoints.copy')}"><svg viewBox="0 0 24 24" fill="none" xmlns="http://www.w3.org/2000/svg" width="1em" height="1em"><path d="M7 4c0-1.1.9-2 2-2h11a2 2 0 0 1 2 2v11a2 2 0 0 1-2 2h-1V8c0-2-1-3-3-3H7V4Z" fill="currentColor"></path><path d="M5 7a2 2 0 0 0-2 2v10c0 1.1.9 2 2 2h10a2 2 0 0 0 2-2V9a2 2 0 0 0-2-2H5Z" fill="currentColor"></path></svg></button></p>
@@ -405,7 +559,9 @@ export async function renderEndpoints(endpoints) {
                     <span class="toggle-slider"></span>
                 </label>
                 <button class="btn-card btn-secondary" data-action="test" data-index="${index}">${t('endpoints.test')}</button>
+                ${enabled ? `<button class="btn-card btn-secondary" data-action="maintenance" data-index="${index}" title="${t('endpoints.maintenanceTip')}">${isMaintenance ? t('endpoints.exitMaintenance') : t('endpoints.enterMaintenance')}</button>` : ''}
                 <button class="btn-card btn-secondary" data-action="edit" data-index="${index}">${t('endpoints.edit')}</button>
+                <button class="btn-card btn-secondary" data-action="clone" data-index="${index}" title="${t('endpoints.cloneTip')}">${t('endpoints.clone')}</button>
                 <button class="btn-card btn-danger" data-action="delete" data-index="${index}">${t('endpoints.delete')}</button>
             </div>
         `;
@@ -424,7 +580,7 @@ export async function renderEndpoints(endpoints) {
 
         testBtn.addEventListener('click', () => {
             const idx = parseInt(testBtn.getAttribute('data-index'));
-            window.testEndpoint(idx, testBtn);
+            window.showTestResult(idx, testBtn);
         });
         editBtn.addEventListener('click', () => {
             const idx = parseInt(editBtn.getAttribute('data-index'));
@@ -434,6 +590,7 @@ export async function renderEndpoints(endpoints) {
             const idx = parseInt(deleteBtn.getAttribute('data-index'));
             window.deleteEndpoint(idx);
         });
+        item.querySelector('[data-action="clone"]').addEventListener('click', () => cloneEndpointAt(index));
         toggleSwitch.addEventListener('change', async (e) => {
             const idx = parseInt(e.target.getAttribute('data-index'));
             const newEnabled = e.target.checked;
@@ -475,6 +632,17 @@ export async function renderEndpoints(endpoints) {
             });
         }
 
+        // Add pin button event listener
+        const pinBtn = item.querySelector('[data-action="pin"]');
+        if (pinBtn) {
+            pinBtn.addEventListener('click', () => togglePin(pinBtn, ep.name, isPinned));
+        }
+
+        const maintenanceBtn = item.querySelector('[data-action="maintenance"]');
+        if (maintenanceBtn) {
+            maintenanceBtn.addEventListener('click', () => toggleMaintenance(maintenanceBtn, index, isMaintenance));
+        }
+
         // Add drag and drop event listeners
         setupDragAndDrop(item, container);
 
@@ -647,6 +815,13 @@ export function initEndpointSuccessListener() {
             }
         });
 
+        // 监听 API Key 过期事件，端点已被自动禁用，刷新列表
+        window.runtime.EventsOn('endpoint:alert', (data) => {
+            if (data.alertType === 'key_expired' && window.loadConfig) {
+                window.loadConfig();
+            }
+        });
+
         // 监听健康检查完成事件
         window.runtime.EventsOn('health:check:completed', async (data) => {
             // 健康检查完成后刷新统一状态
@@ -693,12 +868,14 @@ export async function checkAllEndpointsOnStartup() {
 }
 
 // 渲染简洁视图
-function renderCompactView(sortedEndpoints, container, currentEndpointName) {
+function renderCompactView(sortedEndpoints, container, currentEndpointName, slaStatuses = {}, pin = null) {
     sortedEndpoints.forEach(({ endpoint: ep, originalIndex: index, stats }) => {
         const enabled = ep.enabled !== undefined ? ep.enabled : true;
         const transformer = ep.transformer || 'claude';
         const model = ep.model || '';
         const isCurrentEndpoint = ep.name === currentEndpointName;
+        const isPinned = pin !== null && pin.endpointName === ep.name;
+        const isMaintenance = ep.status === 'maintenance';
 
         // 计算成功率
         const successRate = stats.requests > 0
@@ -728,6 +905,8 @@ function renderCompactView(sortedEndpoints, container, currentEndpointName) {
             statusBadge = '<span class="status-badge status-available" title="' + t('endpoints.statusAvailable') + '">●</span>';
         } else if (status === 'warning') {
             statusBadge = '<span class="status-badge status-warning" title="' + t('endpoints.statusWarning') + '">●</span>';
+        } else if (status === 'degraded') {
+            statusBadge = '<span class="status-badge status-degraded" title="' + t('endpoints.statusDegraded') + '">●</span>';
         } else if (status === 'unavailable') {
             statusBadge = '<span class="status-badge status-unavailable" title="' + t('endpoints.statusUnavailable') + '">●</span>';
         } else if (status === 'untested') {
@@ -735,6 +914,10 @@ function renderCompactView(sortedEndpoints, container, currentEndpointName) {
         } else if (status === 'disabled') {
             statusBadge = '<span class="status-badge status-disabled" title="' + t('endpoints.statusDisabled') + '">●</span>';
         }
+        // 维护状态由用户手动设置，优先于检测结果显示
+        if (isMaintenance) {
+            statusBadge = '<span class="status-badge status-maintenance" title="' + t('endpoints.statusMaintenance') + '">●</span>';
+        }
 
         const item = document.createElement('div');
         item.className = 'endpoint-item-compact';
@@ -776,8 +959,11 @@ function renderCompactView(sortedEndpoints, container, currentEndpointName) {
             <span class="compact-status" title="${testStatusTip}" style="cursor: help">${testStatusIcon}</span>
             <span class="compact-name" title="${ep.name}">${ep.name}</span>
             ${statusBadge}
+            ${renderSLABadge(slaStatuses[ep.name])}
+            ${isPinned ? renderPinBadge(pin) : ''}
+            ${renderKeyExpiryBadge(ep)}
             ${tagsHtml ? `<span class="compact-tags">${tagsHtml}</span>` : ''}
-            ${isCurrentEndpoint ? '<span class="btn btn-primary compact-badge-btn">' + t('endpoints.current') + '</span>' : (status === 'available' ? '<button class="btn btn-primary compact-badge-btn" data-action="switch" data-name="' + ep.name + '">' + t('endpoints.switchTo') + '</button>' : '')}
+            ${isCurrentEndpoint ? '<span class="btn btn-primary compact-badge-btn">' + t('endpoints.current') + '</span>' : (status === 'available' && !isMaintenance ? '<button class="btn btn-primary compact-badge-btn" data-action="switch" data-name="' + ep.name + '">' + t('endpoints.switchTo') + '</button>' : '')}
             <span class="compact-url" title="${ep.apiUrl}"><span class="compact-url-icon">🌐</span>${displayUrl}</span>
             <span class="compact-transformer">🔄 ${transformer}</span>
             <span class="compact-stats" title="${statsTooltip}">📊 ${stats.requests} | ✅ ${successRate}% | ⚡ ${latencyDisplay}</span>
@@ -792,6 +978,9 @@ function renderCompactView(sortedEndpoints, container, currentEndpointName) {
                     <div class="compact-more-menu">
                         <button data-action="test" data-index="${index}">🧪 ${t('endpoints.test')}</button>
                         <button data-action="edit" data-index="${index}">✏️ ${t('endpoints.edit')}</button>
+                        <button data-action="clone" data-index="${index}" title="${t('endpoints.cloneTip')}">📄 ${t('endpoints.clone')}</button>
+                        ${isPinned || (enabled && !isMaintenance) ? `<button data-action="pin">📌 ${isPinned ? t('endpoints.unpin') : t('endpoints.pin')}</button>` : ''}
+                        ${enabled ? `<button data-action="maintenance" title="${t('endpoints.maintenanceTip')}">🛠️ ${isMaintenance ? t('endpoints.exitMaintenance') : t('endpoints.enterMaintenance')}</button>` : ''}
                         <button data-action="delete" data-index="${index}" class="danger">🗑️ ${t('endpoints.delete')}</button>
                     </div>
                 </div>
@@ -800,6 +989,20 @@ function renderCompactView(sortedEndpoints, container, currentEndpointName) {
 
         // 绑定事件
         bindCompactItemEvents(item, index, enabled);
+        const pinBtn = item.querySelector('[data-action="pin"]');
+        if (pinBtn) {
+            pinBtn.addEventListener('click', () => {
+                closeAllDropdowns();
+                togglePin(pinBtn, ep.name, isPinned);
+            });
+        }
+        const maintenanceBtn = item.querySelector('[data-action="maintenance"]');
+        if (maintenanceBtn) {
+            maintenanceBtn.addEventListener('click', () => {
+                closeAllDropdowns();
+                toggleMaintenance(maintenanceBtn, index, isMaintenance);
+            });
+        }
 
         // 设置拖拽
         setupCompactDragAndDrop(item, container);
@@ -878,7 +1081,7 @@ function bindCompactItemEvents(item, index, enabled) {
     testBtn.addEventListener('click', () => {
         closeAllDropdowns();
         const idx = parseInt(testBtn.getAttribute('data-index'));
-        window.testEndpoint(idx, testBtn);
+        window.showTestResult(idx, testBtn);
     });
 
     // 编辑按钮
@@ -888,6 +1091,12 @@ function bindCompactItemEvents(item, index, enabled) {
         window.editEndpoint(idx);
     });
 
+    // 复制按钮
+    item.querySelector('[data-action="clone"]').addEventListener('click', () => {
+        closeAllDropdowns();
+        cloneEndpointAt(index);
+    });
+
     // 删除按钮
     deleteBtn.addEventListener('click', () => {
         closeAllDropdowns();
@@ -1133,6 +1342,18 @@ async function handleContainerDrop(e) {
     }
 }
 
+// 按健康检查延迟从快到慢重新排序端点（无延迟数据的端点排在末尾）
+export async function autoSortEndpointsByLatency() {
+    try {
+        await window.go.main.App.AutoSortEndpointsByLatency(currentClientType);
+        window.loadConfig();
+        showNotification(t('endpoints.sortByLatencySuccess'), 'success');
+    } catch (error) {
+        console.error('Failed to sort endpoints by latency:', error);
+        alert(t('endpoints.reorderFailed') + ': ' + error);
+    }
+}
+
 // 格式化延时显示
 function formatLatency(ms) {
     if (!ms || ms <= 0) return '-';
@@ -1,5 +1,6 @@
 import { t } from '../i18n/index.js';
 import { showNotification } from './modal.js';
+import { escapeHtml } from '../utils/format.js';
 import { getCurrentClientType, refreshEndpoints } from './endpoints.js';
 
 let importExportModal = null;
@@ -43,6 +44,9 @@ function createImportExportModal() {
                             <button class="btn btn-secondary" onclick="window.exportAllEndpoints()">
                                 <span id="exportAllLabel"></span>
                             </button>
+                            <button class="btn btn-secondary" onclick="window.exportFullConfig()" id="exportFullConfigBtn">
+                                <span id="exportFullConfigLabel"></span>
+                            </button>
                         </div>
                     </div>
                     <div id="exportResult" style="display: none; margin-top: 15px;">
@@ -72,6 +76,13 @@ function createImportExportModal() {
                         </select>
                         <small class="form-help" id="importModeHelp"></small>
                     </div>
+                    <div class="form-group" style="margin-top: 10px;">
+                        <label style="display: flex; align-items: center; gap: 8px; cursor: pointer;">
+                            <input type="checkbox" id="importValidate" onchange="window.clearImportPreview()">
+                            <span id="importValidateLabel"></span>
+                        </label>
+                        <small class="form-help" id="importValidateHelp"></small>
+                    </div>
                     <div class="form-group" style="margin-top: 15px;">
                         <label id="selectFileLabel"></label>
                         <div id="dropZone" class="drop-zone" onclick="document.getElementById('importFileInput').click()">
@@ -83,11 +94,15 @@ function createImportExportModal() {
                         <label id="importDataLabel"></label>
                         <textarea id="importData" class="form-control" rows="8" placeholder='{"endpoints": [...]}' style="font-family: monospace; font-size: 12px;"></textarea>
                     </div>
-                    <div style="margin-top: 15px;">
+                    <div style="margin-top: 15px; display: flex; gap: 10px;">
+                        <button class="btn btn-secondary" onclick="window.previewImportEndpoints()">
+                            <span id="previewImportBtnLabel"></span>
+                        </button>
                         <button class="btn btn-primary" onclick="window.importEndpoints()">
                             <span id="importBtnLabel"></span>
                         </button>
                     </div>
+                    <div id="importPreviewResult" class="import-preview" style="display: none; margin-top: 15px;"></div>
                 </div>
             </div>
         </div>
@@ -138,6 +153,33 @@ function createImportExportModal() {
         .tab-content {
             padding-top: 15px;
         }
+        .import-preview {
+            max-height: 220px;
+            overflow-y: auto;
+            border: 1px solid var(--border-color);
+            border-radius: 6px;
+            padding: 10px;
+            font-size: 13px;
+        }
+        .import-preview-summary {
+            font-weight: 600;
+            margin-bottom: 8px;
+        }
+        .import-preview-item {
+            display: flex;
+            gap: 8px;
+            padding: 3px 0;
+        }
+        .import-preview-action {
+            min-width: 70px;
+            font-weight: 600;
+        }
+        .import-preview-action.add { color: var(--success-color, #28a745); }
+        .import-preview-action.overwrite { color: var(--warning-color, #ffc107); }
+        .import-preview-action.rename { color: var(--primary-color); }
+        .import-preview-action.skip,
+        .import-preview-action.invalid { color: var(--text-secondary); }
+        .import-preview-unreachable { color: var(--danger-color, #dc3545); }
     `;
     document.head.appendChild(style);
 
@@ -162,7 +204,9 @@ function createImportExportModal() {
     // Setup import mode change
     document.getElementById('importMode').addEventListener('change', (e) => {
         updateImportModeHelp(e.target.value);
+        clearImportPreview();
     });
+    document.getElementById('importData').addEventListener('input', clearImportPreview);
 
     return modal;
 }
@@ -204,6 +248,16 @@ function updateLabels() {
         exportAllLabel.textContent = '📤 ' + t('endpoints.exportAll');
     }
 
+    const exportFullConfigLabel = document.getElementById('exportFullConfigLabel');
+    if (exportFullConfigLabel) {
+        exportFullConfigLabel.textContent = '🗂️ ' + t('endpoints.exportFullConfig');
+    }
+
+    const exportFullConfigBtn = document.getElementById('exportFullConfigBtn');
+    if (exportFullConfigBtn) {
+        exportFullConfigBtn.title = t('endpoints.exportFullConfigHelp');
+    }
+
     const exportDataLabel = document.getElementById('exportDataLabel');
     if (exportDataLabel) {
         exportDataLabel.textContent = t('endpoints.exportEndpoints');
@@ -259,6 +313,21 @@ function updateLabels() {
         importBtnLabel.textContent = '📥 ' + t('endpoints.import');
     }
 
+    const previewImportBtnLabel = document.getElementById('previewImportBtnLabel');
+    if (previewImportBtnLabel) {
+        previewImportBtnLabel.textContent = '🔍 ' + t('endpoints.importPreview');
+    }
+
+    const importValidateLabel = document.getElementById('importValidateLabel');
+    if (importValidateLabel) {
+        importValidateLabel.textContent = t('endpoints.importValidate');
+    }
+
+    const importValidateHelp = document.getElementById('importValidateHelp');
+    if (importValidateHelp) {
+        importValidateHelp.textContent = t('endpoints.importValidateHelp');
+    }
+
     // Update import mode help
     const importMode = document.getElementById('importMode');
     if (importMode) {
@@ -289,6 +358,7 @@ function handleFile(file) {
     const reader = new FileReader();
     reader.onload = (e) => {
         document.getElementById('importData').value = e.target.result;
+        clearImportPreview();
     };
     reader.readAsText(file);
 }
@@ -363,6 +433,26 @@ export async function exportAllEndpoints() {
     }
 }
 
+// Export all endpoints and portable app settings (routing, cache, rate limit, alert, backup)
+export async function exportFullConfig() {
+    try {
+        const includeKeys = document.getElementById('exportIncludeKeys').checked;
+        const result = await window.go.main.App.ExportFullConfig(includeKeys);
+
+        if (result.includes('"error"')) {
+            const data = JSON.parse(result);
+            showNotification(data.error || t('endpoints.exportFailed'), 'error');
+            return;
+        }
+
+        document.getElementById('exportData').value = result;
+        document.getElementById('exportResult').style.display = 'block';
+        showNotification(t('endpoints.exportSuccess'), 'success');
+    } catch (err) {
+        showNotification(t('endpoints.exportFailed') + ': ' + err.message, 'error');
+    }
+}
+
 // Copy export data to clipboard
 export function copyExportData() {
     const data = document.getElementById('exportData').value;
@@ -382,7 +472,9 @@ export function downloadExportData() {
         const parsed = JSON.parse(data);
         const clientType = parsed.clientType || 'all';
         const timestamp = new Date().toISOString().slice(0, 10);
-        const filename = `ccnexus-endpoints-${clientType}-${timestamp}.json`;
+        const filename = isFullConfig(parsed)
+            ? `ccnexus-config-${timestamp}.json`
+            : `ccnexus-endpoints-${clientType}-${timestamp}.json`;
 
         const blob = new Blob([data], { type: 'application/json' });
         const url = URL.createObjectURL(blob);
@@ -398,6 +490,11 @@ export function downloadExportData() {
     }
 }
 
+// 完整配置导出带有 settings 字段，导入时同时恢复应用设置
+function isFullConfig(data) {
+    return data && typeof data.settings === 'object' && data.settings !== null;
+}
+
 // Handle import file input
 export function handleImportFile(event) {
     const file = event.target.files[0];
@@ -406,6 +503,83 @@ export function handleImportFile(event) {
     }
 }
 
+// Hide the dry-run preview (data, mode or validation option changed)
+export function clearImportPreview() {
+    const el = document.getElementById('importPreviewResult');
+    if (el) {
+        el.style.display = 'none';
+        el.innerHTML = '';
+    }
+}
+
+// Render the dry-run preview returned by PreviewImport
+function renderImportPreview(data) {
+    const el = document.getElementById('importPreviewResult');
+    if (!el) return;
+
+    let summary = t('endpoints.importPreviewSummary')
+        .replace('{added}', data.added)
+        .replace('{overwrite}', data.overwrite)
+        .replace('{renamed}', data.renamed)
+        .replace('{skipped}', data.skipped)
+        .replace('{invalid}', data.invalid);
+    if (data.unreachable > 0) {
+        summary += ` <span class="import-preview-unreachable">${t('endpoints.importPreviewUnreachable').replace('{count}', data.unreachable)}</span>`;
+    }
+
+    const items = (data.items || []).map(item => {
+        let name = escapeHtml(item.originalName || '-');
+        if (item.action === 'rename') {
+            name += ' → ' + escapeHtml(item.name);
+        }
+        let reason = item.reason ? ` <span style="color: var(--text-secondary);">(${escapeHtml(item.reason)})</span>` : '';
+        if (item.unreachable) {
+            reason += ` <span class="import-preview-unreachable">⚠️ ${t('endpoints.importUnreachable')}: ${escapeHtml(item.unreachable)}</span>`;
+        }
+        return `
+            <div class="import-preview-item">
+                <span class="import-preview-action ${escapeHtml(item.action)}">${t('endpoints.importAction_' + item.action)}</span>
+                <span>[${escapeHtml(item.clientType)}] ${name}${reason}</span>
+            </div>
+        `;
+    }).join('');
+
+    el.innerHTML = `<div class="import-preview-summary">${summary}</div>${items}`;
+    el.style.display = 'block';
+}
+
+// Preview import (dry-run, nothing is changed)
+export async function previewImportEndpoints() {
+    try {
+        const jsonData = document.getElementById('importData').value.trim();
+        if (!jsonData) {
+            showNotification(t('endpoints.invalidFileFormat'), 'error');
+            return;
+        }
+
+        try {
+            JSON.parse(jsonData);
+        } catch {
+            showNotification(t('endpoints.invalidFileFormat'), 'error');
+            return;
+        }
+
+        const mode = document.getElementById('importMode').value;
+        const validate = document.getElementById('importValidate').checked;
+        const result = await window.go.main.App.PreviewImport(jsonData, mode, validate);
+        const data = JSON.parse(result);
+
+        if (data.success) {
+            renderImportPreview(data);
+        } else {
+            clearImportPreview();
+            showNotification(data.message || t('endpoints.importFailed'), 'error');
+        }
+    } catch (err) {
+        showNotification(t('endpoints.importFailed') + ': ' + err.message, 'error');
+    }
+}
+
 // Import endpoints
 export async function importEndpoints() {
     try {
@@ -416,22 +590,36 @@ export async function importEndpoints() {
         }
 
         // Validate JSON
+        let parsed;
         try {
-            JSON.parse(jsonData);
+            parsed = JSON.parse(jsonData);
         } catch {
             showNotification(t('endpoints.invalidFileFormat'), 'error');
             return;
         }
 
         const mode = document.getElementById('importMode').value;
-        const result = await window.go.main.App.ImportEndpoints(jsonData, mode);
+        const validate = document.getElementById('importValidate').checked;
+        const fullConfig = isFullConfig(parsed);
+        const result = fullConfig
+            ? await window.go.main.App.ImportFullConfig(jsonData, mode)
+            : await window.go.main.App.ImportEndpoints(jsonData, mode, validate);
         const data = JSON.parse(result);
 
         if (data.success) {
-            const message = t('endpoints.importSuccess')
+            let message = t('endpoints.importSuccess')
                 .replace('{imported}', data.imported)
                 .replace('{skipped}', data.skipped);
-            showNotification(message, 'success');
+            if (fullConfig) {
+                message += ', ' + t('endpoints.importSettingsSuccess').replace('{count}', data.settingsImported);
+            }
+            if (data.errors && data.errors.length > 0) {
+                // 可达性检查发现的问题不阻止导入，只提示
+                console.warn('Import warnings:', data.errors);
+                showNotification(message + ' (' + t('endpoints.importWarnings').replace('{count}', data.errors.length) + ')', 'warning');
+            } else {
+                showNotification(message, 'success');
+            }
 
             // Refresh endpoints list
             await refreshEndpoints();
@@ -457,7 +645,10 @@ window.hideImportExportModal = hideImportExportModal;
 window.switchImportExportTab = switchImportExportTab;
 window.exportCurrentEndpoints = exportCurrentEndpoints;
 window.exportAllEndpoints = exportAllEndpoints;
+window.exportFullConfig = exportFullConfig;
 window.copyExportData = copyExportData;
 window.downloadExportData = downloadExportData;
 window.handleImportFile = handleImportFile;
 window.importEndpoints = importEndpoints;
+window.previewImportEndpoints = previewImportEndpoints;
+window.clearImportPreview = clearImportPreview;
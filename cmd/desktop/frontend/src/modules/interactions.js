@@ -1,6 +1,7 @@
 // Interaction recording module
 import { t } from '../i18n/index.js'
 import { formatTokens } from '../utils/format.js'
+import { showNotification, showConfirm } from './modal.js'
 
 let currentDate = ''
 let interactionEnabled = false
@@ -12,6 +13,7 @@ export async function showInteractionsModal() {
 
     modal.classList.add('active')
     await loadEnabled()
+    await loadRedactPatterns()
     await loadDates()
 }
 
@@ -59,6 +61,40 @@ export async function toggleInteractionEnabled() {
     }
 }
 
+// Load redact patterns
+async function loadRedactPatterns() {
+    try {
+        const result = await window.go.main.App.GetInteractionRedactPatterns()
+        const data = JSON.parse(result)
+        const textarea = document.getElementById('interactionRedactPatterns')
+        if (data.success && textarea) {
+            textarea.value = (data.patterns || []).join('\n')
+        }
+    } catch (err) {
+        console.error('Failed to load interaction redact patterns:', err)
+    }
+}
+
+// Save redact patterns (one regex per line)
+export async function saveInteractionRedactPatterns() {
+    const textarea = document.getElementById('interactionRedactPatterns')
+    if (!textarea) return
+
+    const patterns = textarea.value.split('\n').map(p => p.trim()).filter(p => p)
+    try {
+        const result = await window.go.main.App.SetInteractionRedactPatterns(patterns)
+        const data = JSON.parse(result)
+        if (data.success) {
+            textarea.value = (data.patterns || []).join('\n')
+            showNotification(t('interactions.redactPatternsSaved'), 'success')
+        } else {
+            showNotification(t('interactions.redactPatternsFailed') + ': ' + data.error, 'error')
+        }
+    } catch (err) {
+        showNotification(t('interactions.redactPatternsFailed') + ': ' + err, 'error')
+    }
+}
+
 // Load available dates
 async function loadDates() {
     try {
@@ -277,6 +313,13 @@ function renderDetailModal(interaction) {
 
     // Store interaction data for tab switching
     window._currentInteraction = interaction
+    window._currentReplay = null
+
+    const replayEndpoint = document.getElementById('interactionReplayEndpoint')
+    if (replayEndpoint) {
+        replayEndpoint.value = ''
+        replayEndpoint.placeholder = interaction.endpoint?.name || t('interactions.replayEndpointPlaceholder')
+    }
 
     // Show first tab by default
     switchDetailTab('request-raw')
@@ -313,11 +356,47 @@ export function switchDetailTab(tabName) {
         case 'response-transformed':
             data = interaction.response?.transformed
             break
+        case 'replay':
+            data = window._currentReplay || t('interactions.replayEmpty')
+            break
     }
 
     content.innerHTML = `<pre class="json-viewer">${formatJson(data)}</pre>`
 }
 
+// Replay the current interaction's raw request (sends a real request and consumes tokens)
+export async function replayInteraction() {
+    const interaction = window._currentInteraction
+    if (!interaction) return
+
+    const confirmed = await showConfirm(t('interactions.replayConfirm'))
+    if (!confirmed) return
+
+    const endpointName = document.getElementById('interactionReplayEndpoint')?.value.trim() || ''
+    const btn = document.getElementById('interactionReplayBtn')
+    if (btn) btn.disabled = true
+
+    try {
+        const result = await window.go.main.App.ReplayRequest(interaction.requestId, endpointName)
+        const data = JSON.parse(result)
+        if (!data.success) {
+            showNotification(t('interactions.replayFailed') + ': ' + data.error, 'error')
+            return
+        }
+
+        window._currentReplay = {
+            original: data.original,
+            replay: data.replay
+        }
+        switchDetailTab('replay')
+    } catch (err) {
+        console.error('Failed to replay interaction:', err)
+        showNotification(t('interactions.replayFailed'), 'error')
+    } finally {
+        if (btn) btn.disabled = false
+    }
+}
+
 // Export interactions
 export async function exportInteractions() {
     if (!currentDate) return
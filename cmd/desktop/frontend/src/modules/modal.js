@@ -1,6 +1,6 @@
 import { t } from '../i18n/index.js';
 import { escapeHtml } from '../utils/format.js';
-import { addEndpoint, updateEndpoint, removeEndpoint, testEndpoint, testEndpointLight, updatePort } from './config.js';
+import { addEndpoint, updateEndpoint, removeEndpoint, testEndpoint, getLastTestResult, testEndpointLight, preflightCheck, updatePort } from './config.js';
 import { setTestState, clearTestState, saveEndpointTestStatus, getCurrentClientType } from './endpoints.js';
 import { updateEndpointStatus } from './endpoint-status.js';
 
@@ -103,18 +103,43 @@ export function showAddEndpointModal() {
     document.getElementById('endpointUrl').value = '';
     document.getElementById('endpointKey').value = '';
     document.getElementById('endpointKey').type = 'password';
+    document.getElementById('endpointExtraKeys').value = '';
     document.getElementById('eyeIcon').innerHTML = '<path d="M1 12s4-8 11-8 11 8 11 8-4 8-11 8-11-8-11-8z"></path><circle cx="12" cy="12" r="3"></circle>';
     document.getElementById('endpointTransformer').value = 'claude';
     document.getElementById('endpointModel').value = '';
     document.getElementById('endpointRemark').value = '';
     document.getElementById('endpointTags').value = '';
+    document.getElementById('endpointGroup').value = '';
     // 重置智能路由字段
     document.getElementById('endpointModelPatterns').value = '';
     document.getElementById('endpointCostInput').value = '';
     document.getElementById('endpointCostOutput').value = '';
+    document.getElementById('endpointCostCacheRead').value = '';
     document.getElementById('endpointQuotaLimit').value = '';
     document.getElementById('endpointQuotaResetCycle').value = '';
+    document.getElementById('endpointQuotaMode').value = '';
     document.getElementById('endpointPriority').value = '';
+    document.getElementById('endpointUserAgent').value = '';
+    document.getElementById('endpointSlaP95').value = '';
+    document.getElementById('endpointMaxConcurrency').value = '';
+    document.getElementById('endpointTimeoutSeconds').value = '';
+    document.getElementById('endpointWeight').value = '';
+    document.getElementById('endpointHeaderMode').value = '';
+    document.getElementById('endpointHeaderWhitelist').value = '';
+    document.getElementById('endpointHealthFields').value = '';
+    document.getElementById('endpointHealthErrorWords').value = '';
+    document.getElementById('endpointHealthCheckPath').value = '';
+    document.getElementById('endpointHealthCheckMethod').value = 'GET';
+    document.getElementById('endpointActiveHours').value = '';
+    document.getElementById('endpointKeyExpiryDate').value = '';
+    document.getElementById('endpointRefreshToken').value = '';
+    document.getElementById('endpointTokenExpiry').value = '';
+    document.getElementById('endpointHideThinking').checked = false;
+    document.getElementById('endpointDisableStreamUsage').checked = false;
+    document.getElementById('endpointPinStatus').checked = false;
+    handleHeaderModeChange();
+    renderEndpointModels([]);
+    document.getElementById('endpointModelRewrite').value = '';
     // 折叠路由设置面板
     document.getElementById('routingSettingsPanel').style.display = 'none';
     document.getElementById('routingSettingsIcon').textContent = '▶';
@@ -138,21 +163,52 @@ export async function editEndpoint(index) {
     document.getElementById('endpointUrl').value = ep.apiUrl;
     document.getElementById('endpointKey').value = ep.apiKey;
     document.getElementById('endpointKey').type = 'password';
+    document.getElementById('endpointExtraKeys').value = (ep.apiKeys || []).join('\n');
     document.getElementById('eyeIcon').innerHTML = '<path d="M1 12s4-8 11-8 11 8 11 8-4 8-11 8-11-8-11-8z"></path><circle cx="12" cy="12" r="3"></circle>';
     document.getElementById('endpointTransformer').value = ep.transformer || 'claude';
     document.getElementById('endpointModel').value = ep.model || '';
     document.getElementById('endpointRemark').value = ep.remark || '';
     document.getElementById('endpointTags').value = ep.tags || '';
+    document.getElementById('endpointGroup').value = ep.group || '';
     // 填充智能路由字段
     document.getElementById('endpointModelPatterns').value = ep.modelPatterns || '';
     document.getElementById('endpointCostInput').value = ep.costPerInputToken || '';
     document.getElementById('endpointCostOutput').value = ep.costPerOutputToken || '';
+    document.getElementById('endpointCostCacheRead').value = ep.costPerCacheReadToken || '';
     document.getElementById('endpointQuotaLimit').value = ep.quotaLimit || '';
     document.getElementById('endpointQuotaResetCycle').value = ep.quotaResetCycle || '';
+    document.getElementById('endpointQuotaMode').value = ep.quotaMode || '';
     document.getElementById('endpointPriority').value = ep.priority || '';
+    document.getElementById('endpointUserAgent').value = ep.userAgent || '';
+    document.getElementById('endpointSlaP95').value = ep.slaP95Ms || '';
+    document.getElementById('endpointMaxConcurrency').value = ep.maxConcurrency || '';
+    document.getElementById('endpointTimeoutSeconds').value = ep.timeoutSeconds || '';
+    document.getElementById('endpointWeight').value = ep.weight || '';
+    document.getElementById('endpointHeaderMode').value = ep.headerMode === 'all' ? '' : (ep.headerMode || '');
+    document.getElementById('endpointHeaderWhitelist').value = ep.headerWhitelist || '';
+    document.getElementById('endpointHealthFields').value = ep.healthFields || '';
+    document.getElementById('endpointHealthErrorWords').value = ep.healthErrorWords || '';
+    document.getElementById('endpointHealthCheckPath').value = ep.healthCheckPath || '';
+    document.getElementById('endpointHealthCheckMethod').value = ep.healthCheckMethod || 'GET';
+    document.getElementById('endpointActiveHours').value = ep.activeHours || '';
+    document.getElementById('endpointKeyExpiryDate').value = ep.keyExpiryDate || '';
+    document.getElementById('endpointRefreshToken').value = ep.refreshToken || '';
+    document.getElementById('endpointTokenExpiry').value = formatTokenExpiryInput(ep.tokenExpiry);
+    document.getElementById('endpointHideThinking').checked = !!ep.hideThinking;
+    document.getElementById('endpointDisableStreamUsage').checked = !!ep.disableStreamUsage;
+    document.getElementById('endpointPinStatus').checked = !!ep.pinStatus;
+    handleHeaderModeChange();
+    renderEndpointModels(ep.models || []);
+    document.getElementById('endpointModelRewrite').value = formatModelRewrite(ep.modelRewrite);
     // 如果有路由字段值，展开面板
-    const hasRoutingSettings = ep.modelPatterns || ep.costPerInputToken || ep.costPerOutputToken ||
-                               ep.quotaLimit || ep.quotaResetCycle || (ep.priority && ep.priority !== 100);
+    const hasRoutingSettings = ep.modelPatterns || ep.costPerInputToken || ep.costPerOutputToken || ep.costPerCacheReadToken ||
+                               ep.quotaLimit || ep.quotaResetCycle || ep.quotaMode || (ep.priority && ep.priority !== 100) ||
+                               ep.userAgent || ep.slaP95Ms || ep.maxConcurrency || ep.timeoutSeconds || (ep.weight && ep.weight !== 1) ||
+                               (ep.models && ep.models.length > 0) ||
+                               (ep.modelRewrite && Object.keys(ep.modelRewrite).length > 0) ||
+                               (ep.headerMode && ep.headerMode !== 'all') ||
+                               ep.healthFields || ep.healthErrorWords || ep.healthCheckPath || ep.activeHours || ep.keyExpiryDate || ep.refreshToken ||
+                               (ep.apiKeys && ep.apiKeys.length > 0);
     if (hasRoutingSettings) {
         document.getElementById('routingSettingsPanel').style.display = 'block';
         document.getElementById('routingSettingsIcon').textContent = '▼';
@@ -167,22 +223,60 @@ export async function editEndpoint(index) {
     document.getElementById('endpointModal').classList.add('active');
 }
 
+// OAuth token 过期时间：存储为 Unix 秒，表单使用 datetime-local（本地时间）
+function formatTokenExpiryInput(expiry) {
+    if (!expiry) return '';
+    const d = new Date(expiry * 1000);
+    const pad = (n) => String(n).padStart(2, '0');
+    return `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}T${pad(d.getHours())}:${pad(d.getMinutes())}`;
+}
+
+function parseTokenExpiryInput(value) {
+    if (!value) return 0;
+    const ms = new Date(value).getTime();
+    return isNaN(ms) ? 0 : Math.floor(ms / 1000);
+}
+
 export async function saveEndpoint() {
     const name = document.getElementById('endpointName').value.trim();
     const url = document.getElementById('endpointUrl').value.trim();
     const key = document.getElementById('endpointKey').value.trim();
+    const apiKeys = document.getElementById('endpointExtraKeys').value.trim();
     const transformer = document.getElementById('endpointTransformer').value;
     const model = document.getElementById('endpointModel').value.trim();
     const remark = document.getElementById('endpointRemark').value.trim();
     const tags = document.getElementById('endpointTags').value.trim();
+    const group = document.getElementById('endpointGroup').value.trim();
 
     // 收集智能路由字段
     const modelPatterns = document.getElementById('endpointModelPatterns').value.trim();
     const costPerInputToken = parseFloat(document.getElementById('endpointCostInput').value) || 0;
     const costPerOutputToken = parseFloat(document.getElementById('endpointCostOutput').value) || 0;
+    const costPerCacheReadToken = parseFloat(document.getElementById('endpointCostCacheRead').value) || 0;
     const quotaLimit = parseInt(document.getElementById('endpointQuotaLimit').value) || 0;
     const quotaResetCycle = document.getElementById('endpointQuotaResetCycle').value;
+    const quotaMode = document.getElementById('endpointQuotaMode').value;
     const priority = parseInt(document.getElementById('endpointPriority').value) || 100;
+    const userAgent = document.getElementById('endpointUserAgent').value.trim();
+    const slaP95Ms = parseInt(document.getElementById('endpointSlaP95').value) || 0;
+    const maxConcurrency = Math.max(0, parseInt(document.getElementById('endpointMaxConcurrency').value) || 0);
+    const timeoutSeconds = Math.max(0, parseInt(document.getElementById('endpointTimeoutSeconds').value) || 0);
+    const weight = parseInt(document.getElementById('endpointWeight').value) || 1;
+    const headerMode = document.getElementById('endpointHeaderMode').value;
+    const headerWhitelist = document.getElementById('endpointHeaderWhitelist').value.trim();
+    const healthFields = document.getElementById('endpointHealthFields').value.trim();
+    const healthErrorWords = document.getElementById('endpointHealthErrorWords').value.trim();
+    const healthCheckPath = document.getElementById('endpointHealthCheckPath').value.trim();
+    const healthCheckMethod = document.getElementById('endpointHealthCheckMethod').value;
+    const activeHours = document.getElementById('endpointActiveHours').value.trim();
+    const keyExpiryDate = document.getElementById('endpointKeyExpiryDate').value.trim();
+    const refreshToken = document.getElementById('endpointRefreshToken').value.trim();
+    const tokenExpiry = parseTokenExpiryInput(document.getElementById('endpointTokenExpiry').value);
+    const hideThinking = document.getElementById('endpointHideThinking').checked;
+    const disableStreamUsage = document.getElementById('endpointDisableStreamUsage').checked;
+    const pinStatus = document.getElementById('endpointPinStatus').checked;
+    const models = collectEndpointModels();
+    const modelRewrite = collectModelRewrite();
 
     if (!name || !url || !key) {
         showError(t('modal.requiredFields'));
@@ -210,13 +304,19 @@ export async function saveEndpoint() {
         return;
     }
 
+    const input = {
+        name, apiUrl: url, apiKey: key, transformer, model, remark, tags,
+        modelPatterns, costPerInputToken, costPerOutputToken, costPerCacheReadToken, quotaLimit, quotaResetCycle, quotaMode,
+        priority, userAgent, slaP95Ms, weight, models, group, headerMode, headerWhitelist, healthFields, healthErrorWords,
+        healthCheckPath, healthCheckMethod, activeHours, keyExpiryDate, refreshToken, tokenExpiry, apiKeys,
+        hideThinking, disableStreamUsage, pinStatus, maxConcurrency, timeoutSeconds, modelRewrite
+    };
+
     try {
         if (currentEditIndex === -1) {
-            await addEndpoint(clientType, name, url, key, transformer, model, remark, tags,
-                modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority);
+            await addEndpoint(clientType, input);
         } else {
-            await updateEndpoint(clientType, currentEditIndex, name, url, key, transformer, model, remark, tags,
-                modelPatterns, costPerInputToken, costPerOutputToken, quotaLimit, quotaResetCycle, priority);
+            await updateEndpoint(clientType, currentEditIndex, input);
         }
 
         closeModal();
@@ -253,6 +353,70 @@ export function closeModal() {
     document.getElementById('endpointModal').classList.remove('active');
 }
 
+// 渲染多模型配置行
+function renderEndpointModels(models) {
+    const list = document.getElementById('endpointModelsList');
+    list.innerHTML = '';
+    models.forEach(m => addEndpointModelRow(m));
+}
+
+// 添加一行模型配置
+export function addEndpointModelRow(model = {}) {
+    const list = document.getElementById('endpointModelsList');
+    const row = document.createElement('div');
+    row.className = 'endpoint-model-row';
+    row.innerHTML = `
+        <input type="text" class="model-name" placeholder="${t('modal.modelName')}" value="${escapeHtml(model.name || '')}">
+        <input type="number" class="model-cost model-cost-input" step="0.01" min="0" placeholder="${t('modal.modelCostInput')}" value="${model.costPerInputToken || ''}">
+        <input type="number" class="model-cost model-cost-output" step="0.01" min="0" placeholder="${t('modal.modelCostOutput')}" value="${model.costPerOutputToken || ''}">
+        <input type="number" class="model-quota" min="0" placeholder="${t('modal.modelQuota')}" value="${model.quotaLimit || ''}">
+        <button type="button" class="btn btn-secondary btn-remove-model" title="${t('modal.removeModel')}">✕</button>
+    `;
+    row.querySelector('.btn-remove-model').addEventListener('click', () => row.remove());
+    list.appendChild(row);
+}
+
+// 收集多模型配置，返回 JSON 字符串（无模型时返回空字符串）
+function collectEndpointModels() {
+    const rows = document.querySelectorAll('#endpointModelsList .endpoint-model-row');
+    const models = [];
+    rows.forEach(row => {
+        const name = row.querySelector('.model-name').value.trim();
+        if (!name) return;
+        models.push({
+            name,
+            costPerInputToken: parseFloat(row.querySelector('.model-cost-input').value) || 0,
+            costPerOutputToken: parseFloat(row.querySelector('.model-cost-output').value) || 0,
+            quotaLimit: parseInt(row.querySelector('.model-quota').value) || 0
+        });
+    });
+    return models.length > 0 ? JSON.stringify(models) : '';
+}
+
+// 将模型名重写规则格式化为每行一条 原模型名=上游模型名
+function formatModelRewrite(rules) {
+    return Object.entries(rules || {}).map(([from, to]) => `${from}=${to}`).join('\n');
+}
+
+// 收集模型名重写规则，返回 JSON 字符串（无规则时返回空字符串）
+function collectModelRewrite() {
+    const rules = {};
+    document.getElementById('endpointModelRewrite').value.split('\n').forEach(line => {
+        const idx = line.indexOf('=');
+        if (idx <= 0) return;
+        const from = line.slice(0, idx).trim();
+        const to = line.slice(idx + 1).trim();
+        if (from && to) rules[from] = to;
+    });
+    return Object.keys(rules).length > 0 ? JSON.stringify(rules) : '';
+}
+
+// 仅在白名单模式下显示请求头白名单输入框
+export function handleHeaderModeChange() {
+    const mode = document.getElementById('endpointHeaderMode').value;
+    document.getElementById('endpointHeaderWhitelistGroup').style.display = mode === 'whitelist' ? 'block' : 'none';
+}
+
 // 切换路由设置面板的展开/折叠状态
 export function toggleRoutingSettings() {
     const panel = document.getElementById('routingSettingsPanel');
@@ -279,6 +443,10 @@ export function handleTransformerChange() {
     // Clear fetched models when transformer changes
     clearFetchedModels();
 
+    // 推理内容转换只对 OpenAI Chat 格式的上游生效
+    document.getElementById('hideThinkingGroup').style.display = transformer === 'openai' ? 'block' : 'none';
+    document.getElementById('streamUsageGroup').style.display = transformer === 'openai' ? 'block' : 'none';
+
     if (transformer === 'claude') {
         modelRequired.style.display = 'none';
         modelInput.placeholder = 'e.g., claude-3-5-sonnet-20241022';
@@ -418,9 +586,19 @@ export async function showEditPortModal() {
     const config = JSON.parse(configStr);
 
     document.getElementById('portInput').value = config.port;
+    const clientPorts = config.clientPorts || {};
+    for (const clientType of CLIENT_PORT_TYPES) {
+        document.getElementById(clientPortInputId(clientType)).value = clientPorts[clientType] || '';
+    }
     document.getElementById('portModal').classList.add('active');
 }
 
+const CLIENT_PORT_TYPES = ['claude', 'gemini', 'codex'];
+
+function clientPortInputId(clientType) {
+    return 'clientPort' + clientType.charAt(0).toUpperCase() + clientType.slice(1);
+}
+
 export async function savePort() {
     const port = parseInt(document.getElementById('portInput').value);
 
@@ -429,8 +607,28 @@ export async function savePort() {
         return;
     }
 
+    // 独立端口留空表示不启用
+    const clientPorts = {};
+    const usedPorts = new Set([port]);
+    for (const clientType of CLIENT_PORT_TYPES) {
+        const value = document.getElementById(clientPortInputId(clientType)).value.trim();
+        if (!value) continue;
+        const clientPort = parseInt(value);
+        if (!clientPort || clientPort < 1 || clientPort > 65535) {
+            showNotification(t('modal.portInvalid'), 'error');
+            return;
+        }
+        if (usedPorts.has(clientPort)) {
+            showNotification(t('modal.clientPortsConflict').replace('{port}', clientPort), 'error');
+            return;
+        }
+        usedPorts.add(clientPort);
+        clientPorts[clientType] = clientPort;
+    }
+
     try {
         await updatePort(port);
+        await window.go.main.App.UpdateClientPorts(clientPorts);
         closePortModal();
         window.loadConfig();
         showNotification(t('modal.portUpdateSuccess'), 'success');
@@ -544,8 +742,11 @@ function isTestNotSupported(statusCode, message) {
 }
 
 // Test Result Modal
-export async function testEndpointHandler(index, buttonElement) {
+let lastTestIndex = null;
+
+export async function testEndpointHandler(index, buttonElement, prompt = '', maxTokens = 0) {
     setTestState(buttonElement, index);
+    lastTestIndex = index;
 
     const clientType = getCurrentClientType();
 
@@ -560,12 +761,15 @@ export async function testEndpointHandler(index, buttonElement) {
         moreBtn.innerHTML = '⏳';
     }
 
+    const preflightResult = document.getElementById('preflightResult');
+    if (preflightResult) preflightResult.innerHTML = '';
+
     try {
         buttonElement.disabled = true;
         buttonElement.innerHTML = '⏳';
 
         // 使用真实API请求测试
-        const result = await testEndpoint(clientType, index);
+        const result = await testEndpoint(clientType, index, prompt, maxTokens);
 
         const resultContent = document.getElementById('testResultContent');
         const resultTitle = document.getElementById('testResultTitle');
@@ -649,11 +853,111 @@ export async function testEndpointHandler(index, buttonElement) {
     }
 }
 
+// Show the cached result of the last test first; endpoints not tested since startup are tested right away
+export async function showTestResultHandler(index, buttonElement) {
+    let last = null;
+    try {
+        const data = await getLastTestResult(getCurrentClientType(), index);
+        last = data.success ? data.result : null;
+    } catch (error) {
+        console.error('Failed to load last test result:', error);
+    }
+    if (!last) {
+        return testEndpointHandler(index, buttonElement);
+    }
+
+    lastTestIndex = index;
+    const preflightResult = document.getElementById('preflightResult');
+    if (preflightResult) preflightResult.innerHTML = '';
+
+    const resultContent = document.getElementById('testResultContent');
+    const resultTitle = document.getElementById('testResultTitle');
+    const testedAt = t('test.lastResultAt').replace('{time}', new Date(last.testedAt).toLocaleString());
+    const latency = `${Math.round(last.latencyMs)}ms`;
+
+    if (last.success) {
+        resultTitle.innerHTML = t('test.successTitle');
+        resultContent.innerHTML = `
+            <div style="padding: 15px; background: #d4edda; border: 1px solid #c3e6cb; border-radius: 5px; margin-bottom: 15px;">
+                <strong style="color: #155724;">${t('test.connectionSuccess')}</strong> (${latency})
+            </div>
+            <div style="padding: 15px; background: #f8f9fa; border-radius: 5px; font-family: monospace; white-space: pre-line; word-break: break-all;">${escapeHtml(last.message)}</div>
+        `;
+    } else {
+        resultTitle.innerHTML = t('test.failedTitle');
+        resultContent.innerHTML = `
+            <div style="padding: 15px; background: #f8d7da; border: 1px solid #f5c6cb; border-radius: 5px; margin-bottom: 15px;">
+                <strong style="color: #721c24;">${t('test.connectionFailed')}</strong>
+            </div>
+            <div style="padding: 15px; background: #f8f9fa; border-radius: 5px; font-family: monospace; white-space: pre-line; word-break: break-all;"><strong>Error:</strong><br>${escapeHtml(last.message)}</div>
+        `;
+    }
+    resultContent.innerHTML += `
+        <div style="display: flex; align-items: center; justify-content: space-between; margin-top: 10px; color: #666; font-size: 12px;">
+            <span>${escapeHtml(testedAt)}</span>
+            <button class="btn btn-secondary btn-sm" onclick="window.retestEndpoint()">${t('test.retestNow')}</button>
+        </div>
+    `;
+
+    document.getElementById('testResultModal').classList.add('active');
+}
+
 export function closeTestResultModal() {
     document.getElementById('testResultModal').classList.remove('active');
     clearTestState();
 }
 
+// Retest the last tested endpoint with the custom prompt / max_tokens
+export function retestEndpoint() {
+    if (lastTestIndex === null) return;
+    const prompt = document.getElementById('testPromptInput').value.trim();
+    const maxTokens = parseInt(document.getElementById('testMaxTokensInput').value) || 0;
+    const button = document.querySelector(`#endpointList [data-action="test"][data-index="${lastTestIndex}"]`);
+    if (!button) return;
+    closeTestResultModal();
+    testEndpointHandler(lastTestIndex, button, prompt, maxTokens);
+}
+
+// DNS + TCP preflight for the last tested endpoint (no HTTP request)
+export async function runPreflightCheck() {
+    if (lastTestIndex === null) return;
+    const button = document.getElementById('preflightBtn');
+    const container = document.getElementById('preflightResult');
+    button.disabled = true;
+    container.innerHTML = t('test.testing');
+
+    try {
+        const result = await preflightCheck(getCurrentClientType(), lastTestIndex);
+        if (result.error) {
+            container.innerHTML = `<span style="color: #721c24;">${escapeHtml(result.error)}</span>`;
+            return;
+        }
+
+        const color = result.success ? '#155724' : '#721c24';
+        const summary = result.stage === 'dns'
+            ? t('test.preflightDnsFailed')
+            : (result.success ? t('test.preflightReachable') : t('test.preflightUnreachable'));
+        let html = `<div style="color: ${color}; font-weight: 600; margin-bottom: 6px;">${summary}</div>`;
+        html += `<div>${escapeHtml(result.host)}:${escapeHtml(result.port)} · DNS ${result.dnsMs}ms</div>`;
+        if (result.stage === 'dns') {
+            html += `<div style="font-family: monospace; word-break: break-all;">${escapeHtml(result.message)}</div>`;
+        }
+        (result.addresses || []).forEach(addr => {
+            const status = addr.reachable ? '✅' : '❌';
+            const detail = addr.reachable ? `${addr.connectMs}ms` : escapeHtml(addr.error || '');
+            html += `<div style="font-family: monospace; word-break: break-all;">${status} ${escapeHtml(addr.ip)} · ${detail}</div>`;
+        });
+        if (result.proxy) {
+            html += `<small style="color: #666;">${t('test.preflightProxyNote')}</small>`;
+        }
+        container.innerHTML = html;
+    } catch (error) {
+        container.innerHTML = `<span style="color: #721c24;">${escapeHtml(error.toString())}</span>`;
+    } finally {
+        button.disabled = false;
+    }
+}
+
 // External URLs
 export function openGitHub() {
     if (window.go?.main?.App) {
@@ -99,6 +99,12 @@ function handleMonitorEvent(event) {
                 updateEndpointHealthFromMetrics(event.metrics);
             }
             break;
+
+        case 'endpoint_test_progress':
+            if (event.testProgress) {
+                renderTestAllProgress(event.testProgress);
+            }
+            break;
     }
 }
 
@@ -1727,12 +1733,37 @@ export async function testAllEndpointsAndOptimize() {
             isTestingAllEndpoints = false;
             if (btn) {
                 btn.disabled = false;
+                btn.title = '';
                 btn.innerHTML = `🔍 ${t('monitor.testAllEndpoints')}`;
             }
         }
     }, 0);
 }
 
+// 一键检测进行中时在按钮上显示进度条：已完成数/总数、最近完成的端点及结果
+function renderTestAllProgress(progress) {
+    if (!isTestingAllEndpoints) return;
+    const clientType = getCurrentClientType() || 'claude';
+    if (progress.clientType && progress.clientType !== clientType) return;
+
+    const btn = document.getElementById('testAllEndpointsBtn');
+    if (!btn) return;
+
+    const percent = progress.total > 0 ? Math.round(progress.completed * 100 / progress.total) : 0;
+    const resultText = progress.success
+        ? `✓ ${Math.round(progress.latencyMs)}ms`
+        : `✗ ${progress.errorMessage || t('monitor.checkFailed')}`;
+
+    btn.title = `${progress.endpointName}: ${resultText}`;
+    btn.innerHTML = `
+        ⏳ ${t('monitor.testProgress')
+            .replace('{completed}', progress.completed)
+            .replace('{total}', progress.total)}
+        <span class="test-all-progress-name">${escapeHtml(progress.endpointName)} ${progress.success ? '✓' : '✗'}</span>
+        <span class="test-all-progress"><span class="test-all-progress-fill" style="width: ${percent}%"></span></span>
+    `;
+}
+
 // 显示检测错误通知
 function showTestErrorNotification(message) {
     const container = document.getElementById('testResultNotification');
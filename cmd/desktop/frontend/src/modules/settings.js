@@ -1,6 +1,7 @@
 import { t } from '../i18n/index.js';
 import { changeLanguage } from './ui.js';
 import { destroyFestivalEffects, initFestivalEffects } from './festival.js';
+import { escapeHtml } from '../utils/format.js';
 
 // Auto theme check interval ID
 let autoThemeIntervalId = null;
@@ -275,6 +276,20 @@ async function loadCurrentSettings() {
             healthCheckSelect.value = healthCheckInterval.toString();
         }
 
+        // Load auto optimize interval
+        const autoOptimizeInterval = await window.go.main.App.GetAutoOptimizeInterval();
+        const autoOptimizeSelect = document.getElementById('settingsAutoOptimizeInterval');
+        if (autoOptimizeSelect) {
+            autoOptimizeSelect.value = autoOptimizeInterval.toString();
+        }
+
+        // Load health check method
+        const healthCheckMethod = await window.go.main.App.GetHealthCheckMethod();
+        const healthCheckMethodSelect = document.getElementById('settingsHealthCheckMethod');
+        if (healthCheckMethodSelect) {
+            healthCheckMethodSelect.value = healthCheckMethod || 'models';
+        }
+
         // Load request timeout
         const requestTimeout = await window.go.main.App.GetRequestTimeout();
         const requestTimeoutSelect = document.getElementById('settingsRequestTimeout');
@@ -282,6 +297,46 @@ async function loadCurrentSettings() {
             requestTimeoutSelect.value = requestTimeout.toString();
         }
 
+        // Load upstream HTTP/2 switch
+        const enableHTTP2Checkbox = document.getElementById('settingsEnableHTTP2');
+        if (enableHTTP2Checkbox) {
+            enableHTTP2Checkbox.checked = await window.go.main.App.GetEnableHTTP2();
+        }
+
+        // Load per-endpoint log file switch
+        const perEndpointLoggingCheckbox = document.getElementById('settingsPerEndpointLogging');
+        if (perEndpointLoggingCheckbox) {
+            perEndpointLoggingCheckbox.checked = await window.go.main.App.GetPerEndpointLogging();
+        }
+
+        // Load stream heartbeat interval
+        const streamHeartbeat = await window.go.main.App.GetStreamHeartbeatInterval();
+        const streamHeartbeatSelect = document.getElementById('settingsStreamHeartbeat');
+        if (streamHeartbeatSelect) {
+            streamHeartbeatSelect.value = streamHeartbeat.toString();
+        }
+
+        // Load stream first byte timeout
+        const streamFirstByteTimeout = await window.go.main.App.GetStreamFirstByteTimeout();
+        const streamFirstByteTimeoutSelect = document.getElementById('settingsStreamFirstByteTimeout');
+        if (streamFirstByteTimeoutSelect) {
+            streamFirstByteTimeoutSelect.value = streamFirstByteTimeout.toString();
+        }
+
+        // Load max request body size
+        const maxRequestBody = await window.go.main.App.GetMaxRequestBodyBytes();
+        const maxRequestBodySelect = document.getElementById('settingsMaxRequestBody');
+        if (maxRequestBodySelect) {
+            const value = maxRequestBody.toString();
+            if (!Array.from(maxRequestBodySelect.options).some(opt => opt.value === value)) {
+                const option = document.createElement('option');
+                option.value = value;
+                option.textContent = `${(maxRequestBody / 1048576).toFixed(1)} MB`;
+                maxRequestBodySelect.appendChild(option);
+            }
+            maxRequestBodySelect.value = value;
+        }
+
         // Load health history retention days
         const healthHistoryRetention = await window.go.main.App.GetHealthHistoryRetentionDays();
         const healthHistoryRetentionSelect = document.getElementById('settingsHealthHistoryRetention');
@@ -289,6 +344,25 @@ async function loadCurrentSettings() {
             healthHistoryRetentionSelect.value = healthHistoryRetention.toString();
         }
 
+        // Load interaction retention days
+        const interactionRetention = await window.go.main.App.GetInteractionRetentionDays();
+        const interactionRetentionSelect = document.getElementById('settingsInteractionRetention');
+        if (interactionRetentionSelect) {
+            if (!interactionRetentionSelect.querySelector(`option[value="${interactionRetention}"]`)) {
+                const option = document.createElement('option');
+                option.value = interactionRetention.toString();
+                option.textContent = `${interactionRetention} ${t('settings.healthHistoryRetentionDays')}`;
+                interactionRetentionSelect.appendChild(option);
+            }
+            interactionRetentionSelect.value = interactionRetention.toString();
+        }
+
+        // Load stats time zone
+        const statsTimezoneInput = document.getElementById('settingsStatsTimezone');
+        if (statsTimezoneInput) {
+            statsTimezoneInput.value = await window.go.main.App.GetStatsTimezone();
+        }
+
         // Load alert config
         const alertConfigStr = await window.go.main.App.GetAlertConfig();
         const alertConfig = JSON.parse(alertConfigStr);
@@ -296,6 +370,7 @@ async function loadCurrentSettings() {
         const alertConfigDetails = document.getElementById('alertConfigDetails');
         const alertConsecutiveFailuresSelect = document.getElementById('settingsAlertConsecutiveFailures');
         const alertCooldownSelect = document.getElementById('settingsAlertCooldown');
+        const alertAggregationWindowSelect = document.getElementById('settingsAlertAggregationWindow');
         const alertNotifyOnRecoveryCheckbox = document.getElementById('settingsAlertNotifyOnRecovery');
         const alertSystemNotificationCheckbox = document.getElementById('settingsAlertSystemNotification');
 
@@ -318,12 +393,23 @@ async function loadCurrentSettings() {
         if (alertCooldownSelect) {
             alertCooldownSelect.value = (alertConfig.alertCooldownMinutes || 5).toString();
         }
+        if (alertAggregationWindowSelect) {
+            alertAggregationWindowSelect.value = (alertConfig.aggregationWindowSeconds ?? 30).toString();
+        }
         if (alertNotifyOnRecoveryCheckbox) {
             alertNotifyOnRecoveryCheckbox.checked = alertConfig.notifyOnRecovery !== false;
         }
         if (alertSystemNotificationCheckbox) {
             alertSystemNotificationCheckbox.checked = alertConfig.systemNotification !== false;
         }
+        const alertWebhookUrlInput = document.getElementById('settingsAlertWebhookUrl');
+        if (alertWebhookUrlInput) {
+            alertWebhookUrlInput.value = alertConfig.webhookUrl || '';
+        }
+        const alertWebhookTemplateInput = document.getElementById('settingsAlertWebhookTemplate');
+        if (alertWebhookTemplateInput) {
+            alertWebhookTemplateInput.value = alertConfig.webhookTemplate || '';
+        }
 
         // Load auto-enable config
         const autoEnableCheckbox = document.getElementById('settingsAutoEnableOnRecovery');
@@ -388,6 +474,61 @@ async function loadCurrentSettings() {
         if (sessionAffinityMaxConcurrentSelect) {
             sessionAffinityMaxConcurrentSelect.value = (sessionAffinityConfig ? (sessionAffinityConfig.maxConcurrentPerEndpoint || 0) : 0).toString();
         }
+        const sessionKeySourceSelect = document.getElementById('settingsSessionKeySource');
+        const sessionKeyNameInput = document.getElementById('settingsSessionKeyName');
+        const sessionKeyNameGroup = document.getElementById('sessionKeyNameGroup');
+        if (sessionKeySourceSelect) {
+            sessionKeySourceSelect.value = (sessionAffinityConfig && sessionAffinityConfig.sessionKeySource) || '';
+            if (sessionKeyNameInput) {
+                sessionKeyNameInput.value = (sessionAffinityConfig && sessionAffinityConfig.sessionKeyName) || '';
+            }
+            if (sessionKeyNameGroup) {
+                sessionKeyNameGroup.style.display = sessionKeySourceSelect.value ? 'block' : 'none';
+            }
+            sessionKeySourceSelect.onchange = function() {
+                if (sessionKeyNameGroup) {
+                    sessionKeyNameGroup.style.display = this.value ? 'block' : 'none';
+                }
+            };
+        }
+
+        // Load SLA config
+        const slaConfig = JSON.parse(await window.go.main.App.GetSLAConfig());
+        const slaEnabledCheckbox = document.getElementById('settingsSlaEnabled');
+        const slaConfigDetails = document.getElementById('slaConfigDetails');
+        if (slaEnabledCheckbox) {
+            slaEnabledCheckbox.checked = slaConfig.enabled;
+            if (slaConfigDetails) {
+                slaConfigDetails.style.display = slaConfig.enabled ? 'block' : 'none';
+            }
+            slaEnabledCheckbox.onchange = function() {
+                if (slaConfigDetails) {
+                    slaConfigDetails.style.display = this.checked ? 'block' : 'none';
+                }
+            };
+        }
+        document.getElementById('settingsSlaDefaultP95').value = (slaConfig.defaultP95Ms || 3000).toString();
+        document.getElementById('settingsSlaWindow').value = (slaConfig.windowMinutes || 60).toString();
+
+        // Load token rate alert config
+        const tokenRateAlertConfig = JSON.parse(await window.go.main.App.GetTokenRateAlertConfig());
+        const tokenRateAlertEnabledCheckbox = document.getElementById('settingsTokenRateAlertEnabled');
+        const tokenRateAlertConfigDetails = document.getElementById('tokenRateAlertConfigDetails');
+        if (tokenRateAlertEnabledCheckbox) {
+            tokenRateAlertEnabledCheckbox.checked = tokenRateAlertConfig.enabled;
+            if (tokenRateAlertConfigDetails) {
+                tokenRateAlertConfigDetails.style.display = tokenRateAlertConfig.enabled ? 'block' : 'none';
+            }
+            tokenRateAlertEnabledCheckbox.onchange = function() {
+                if (tokenRateAlertConfigDetails) {
+                    tokenRateAlertConfigDetails.style.display = this.checked ? 'block' : 'none';
+                }
+            };
+        }
+        document.getElementById('settingsTokenRateAlertThreshold').value = (tokenRateAlertConfig.thresholdPerMinute || 200000).toString();
+        document.getElementById('settingsTokenRateAlertWindow').value = (tokenRateAlertConfig.windowSeconds || 60).toString();
+        document.getElementById('settingsTokenRateAlertCooldown').value = (tokenRateAlertConfig.cooldownMinutes || 10).toString();
+        refreshTokenRateStatus();
 
         // Load cache config
         const cacheConfigStr = await window.go.main.App.GetCacheConfig();
@@ -457,12 +598,108 @@ async function loadCurrentSettings() {
         if (rateLimitPerEndpointSelect) {
             rateLimitPerEndpointSelect.value = (rateLimitConfig.perEndpointLimit || 30).toString();
         }
+        const rateLimitPerClientIpSelect = document.getElementById('settingsRateLimitPerClientIp');
+        if (rateLimitPerClientIpSelect) {
+            rateLimitPerClientIpSelect.value = (rateLimitConfig.perClientIpLimit || 0).toString();
+        }
 
         // Load rate limit stats if enabled
         if (rateLimitConfig.enabled) {
             refreshRateLimitStats();
         }
 
+        // Load retry budget config
+        const retryBudgetConfig = JSON.parse(await window.go.main.App.GetRetryBudgetConfig());
+        const retryBudgetEnabledCheckbox = document.getElementById('settingsRetryBudgetEnabled');
+        const retryBudgetConfigDetails = document.getElementById('retryBudgetConfigDetails');
+        if (retryBudgetEnabledCheckbox) {
+            retryBudgetEnabledCheckbox.checked = retryBudgetConfig.enabled;
+            if (retryBudgetConfigDetails) {
+                retryBudgetConfigDetails.style.display = retryBudgetConfig.enabled ? 'block' : 'none';
+            }
+            retryBudgetEnabledCheckbox.onchange = function() {
+                if (retryBudgetConfigDetails) {
+                    retryBudgetConfigDetails.style.display = this.checked ? 'block' : 'none';
+                }
+                if (this.checked) {
+                    refreshRetryBudgetStats();
+                }
+            };
+        }
+        const retryBudgetRatioSelect = document.getElementById('settingsRetryBudgetRatio');
+        if (retryBudgetRatioSelect) {
+            retryBudgetRatioSelect.value = (retryBudgetConfig.ratio || 0.1).toString();
+        }
+        const retryBudgetMinRetriesSelect = document.getElementById('settingsRetryBudgetMinRetries');
+        if (retryBudgetMinRetriesSelect) {
+            retryBudgetMinRetriesSelect.value = (retryBudgetConfig.minRetries ?? 10).toString();
+        }
+        const retryBudgetWindowSelect = document.getElementById('settingsRetryBudgetWindow');
+        if (retryBudgetWindowSelect) {
+            retryBudgetWindowSelect.value = (retryBudgetConfig.windowSeconds || 60).toString();
+        }
+        if (retryBudgetConfig.enabled) {
+            refreshRetryBudgetStats();
+        }
+
+        // Load auto continue config
+        const autoContinueConfig = JSON.parse(await window.go.main.App.GetAutoContinueConfig());
+        const autoContinueEnabledCheckbox = document.getElementById('settingsAutoContinueEnabled');
+        const autoContinueConfigDetails = document.getElementById('autoContinueConfigDetails');
+        if (autoContinueEnabledCheckbox) {
+            autoContinueEnabledCheckbox.checked = autoContinueConfig.enabled;
+            if (autoContinueConfigDetails) {
+                autoContinueConfigDetails.style.display = autoContinueConfig.enabled ? 'block' : 'none';
+            }
+            autoContinueEnabledCheckbox.onchange = function() {
+                if (autoContinueConfigDetails) {
+                    autoContinueConfigDetails.style.display = this.checked ? 'block' : 'none';
+                }
+            };
+        }
+        const autoContinueMaxSelect = document.getElementById('settingsAutoContinueMax');
+        if (autoContinueMaxSelect) {
+            autoContinueMaxSelect.value = (autoContinueConfig.maxContinuations || 3).toString();
+        }
+        const autoContinueResumeCheckbox = document.getElementById('settingsAutoContinueResume');
+        if (autoContinueResumeCheckbox) {
+            autoContinueResumeCheckbox.checked = !!autoContinueConfig.resumeOnDisconnect;
+        }
+
+        // Load circuit breaker config
+        const circuitBreakerConfig = JSON.parse(await window.go.main.App.GetCircuitBreakerConfig());
+        const circuitBreakerEnabledCheckbox = document.getElementById('settingsCircuitBreakerEnabled');
+        const circuitBreakerConfigDetails = document.getElementById('circuitBreakerConfigDetails');
+        if (circuitBreakerEnabledCheckbox) {
+            circuitBreakerEnabledCheckbox.checked = circuitBreakerConfig.enabled;
+            if (circuitBreakerConfigDetails) {
+                circuitBreakerConfigDetails.style.display = circuitBreakerConfig.enabled ? 'block' : 'none';
+            }
+            circuitBreakerEnabledCheckbox.onchange = function() {
+                if (circuitBreakerConfigDetails) {
+                    circuitBreakerConfigDetails.style.display = this.checked ? 'block' : 'none';
+                }
+                if (this.checked) {
+                    refreshCircuitBreakerStatus();
+                }
+            };
+        }
+        const circuitBreakerThresholdSelect = document.getElementById('settingsCircuitBreakerThreshold');
+        if (circuitBreakerThresholdSelect) {
+            circuitBreakerThresholdSelect.value = (circuitBreakerConfig.failureThreshold || 5).toString();
+        }
+        const circuitBreakerCooldownSelect = document.getElementById('settingsCircuitBreakerCooldown');
+        if (circuitBreakerCooldownSelect) {
+            circuitBreakerCooldownSelect.value = (circuitBreakerConfig.cooldownSeconds || 60).toString();
+        }
+        const circuitBreakerProbesSelect = document.getElementById('settingsCircuitBreakerProbes');
+        if (circuitBreakerProbesSelect) {
+            circuitBreakerProbesSelect.value = (circuitBreakerConfig.halfOpenProbes || 1).toString();
+        }
+        if (circuitBreakerConfig.enabled) {
+            refreshCircuitBreakerStatus();
+        }
+
         // Load routing config
         const routingConfigStr = await window.go.main.App.GetRoutingConfig();
         const routingConfig = JSON.parse(routingConfigStr);
@@ -629,8 +866,14 @@ export async function saveSettings() {
         const themeAuto = document.getElementById('settingsThemeAuto').checked;
         const proxyUrl = document.getElementById('settingsProxyUrl').value.trim();
         const healthCheckInterval = parseInt(document.getElementById('settingsHealthCheckInterval').value, 10);
+        const healthCheckMethod = document.getElementById('settingsHealthCheckMethod').value;
+        const autoOptimizeInterval = parseInt(document.getElementById('settingsAutoOptimizeInterval').value, 10);
         const requestTimeout = parseInt(document.getElementById('settingsRequestTimeout').value, 10);
+        const streamHeartbeat = parseInt(document.getElementById('settingsStreamHeartbeat').value, 10);
+        const streamFirstByteTimeout = parseInt(document.getElementById('settingsStreamFirstByteTimeout').value, 10);
+        const maxRequestBody = parseInt(document.getElementById('settingsMaxRequestBody').value, 10);
         const healthHistoryRetention = parseInt(document.getElementById('settingsHealthHistoryRetention').value, 10);
+        const interactionRetention = parseInt(document.getElementById('settingsInteractionRetention').value, 10);
 
         // Save close window behavior
         await window.go.main.App.SetCloseWindowBehavior(closeWindowBehavior);
@@ -641,16 +884,44 @@ export async function saveSettings() {
         // Save health check interval
         await window.go.main.App.SetHealthCheckInterval(healthCheckInterval);
 
+        // Save health check method
+        await window.go.main.App.SetHealthCheckMethod(healthCheckMethod);
+
+        // Save auto optimize interval
+        await window.go.main.App.SetAutoOptimizeInterval(autoOptimizeInterval);
+
         // Save request timeout
         await window.go.main.App.SetRequestTimeout(requestTimeout);
 
+        // Save upstream HTTP/2 switch
+        await window.go.main.App.SetEnableHTTP2(document.getElementById('settingsEnableHTTP2').checked);
+
+        // Save per-endpoint log file switch
+        await window.go.main.App.SetPerEndpointLogging(document.getElementById('settingsPerEndpointLogging').checked);
+
+        // Save stream heartbeat interval
+        await window.go.main.App.SetStreamHeartbeatInterval(streamHeartbeat);
+
+        // Save stream first byte timeout
+        await window.go.main.App.SetStreamFirstByteTimeout(streamFirstByteTimeout);
+
+        // Save max request body size
+        await window.go.main.App.SetMaxRequestBodyBytes(maxRequestBody);
+
         // Save health history retention days
         await window.go.main.App.SetHealthHistoryRetentionDays(healthHistoryRetention);
 
+        // Save interaction retention days
+        await window.go.main.App.SetInteractionRetentionDays(interactionRetention);
+
+        // Save stats time zone
+        await window.go.main.App.SetStatsTimezone(document.getElementById('settingsStatsTimezone').value.trim());
+
         // Save alert config
         const alertEnabled = document.getElementById('settingsAlertEnabled').checked;
         const alertConsecutiveFailures = parseInt(document.getElementById('settingsAlertConsecutiveFailures').value, 10);
         const alertCooldown = parseInt(document.getElementById('settingsAlertCooldown').value, 10);
+        const alertAggregationWindow = parseInt(document.getElementById('settingsAlertAggregationWindow').value, 10);
         const alertNotifyOnRecovery = document.getElementById('settingsAlertNotifyOnRecovery').checked;
         const alertSystemNotification = document.getElementById('settingsAlertSystemNotification').checked;
         const performanceAlertEnabled = document.getElementById('settingsPerformanceAlertEnabled').checked;
@@ -658,6 +929,8 @@ export async function saveSettings() {
         const latencyIncrease = parseInt(document.getElementById('settingsLatencyIncrease').value, 10);
         const autoEnableOnRecovery = document.getElementById('settingsAutoEnableOnRecovery').checked;
         const autoEnableSuccessThreshold = parseInt(document.getElementById('settingsAutoEnableSuccessThreshold').value, 10);
+        const alertWebhookUrl = document.getElementById('settingsAlertWebhookUrl').value.trim();
+        const alertWebhookTemplate = document.getElementById('settingsAlertWebhookTemplate').value.trim();
         await window.go.main.App.SetAlertConfig(
             alertEnabled,
             alertConsecutiveFailures,
@@ -668,14 +941,36 @@ export async function saveSettings() {
             latencyThreshold,
             latencyIncrease,
             autoEnableOnRecovery,
-            autoEnableSuccessThreshold
+            autoEnableSuccessThreshold,
+            alertAggregationWindow,
+            alertWebhookUrl,
+            alertWebhookTemplate
         );
 
         // Save session affinity config
         const sessionAffinityEnabled = document.getElementById('settingsSessionAffinityEnabled').checked;
         const sessionAffinityTimeout = parseInt(document.getElementById('settingsSessionAffinityTimeout').value, 10);
         const sessionAffinityMaxConcurrent = parseInt(document.getElementById('settingsSessionAffinityMaxConcurrent').value, 10);
-        await window.go.main.App.UpdateSessionAffinityConfig(sessionAffinityEnabled, sessionAffinityTimeout, sessionAffinityMaxConcurrent);
+        const sessionKeyName = document.getElementById('settingsSessionKeyName').value.trim();
+        // 未填写字段名时回退到默认提取方式
+        const sessionKeySource = sessionKeyName ? document.getElementById('settingsSessionKeySource').value : '';
+        await window.go.main.App.UpdateSessionAffinityConfig(sessionAffinityEnabled, sessionAffinityTimeout, sessionAffinityMaxConcurrent, sessionKeySource, sessionKeyName);
+
+        // Save SLA config (check interval and min samples are kept as is)
+        const currentSlaConfig = JSON.parse(await window.go.main.App.GetSLAConfig());
+        const slaEnabled = document.getElementById('settingsSlaEnabled').checked;
+        const slaDefaultP95 = parseInt(document.getElementById('settingsSlaDefaultP95').value, 10);
+        const slaWindow = parseInt(document.getElementById('settingsSlaWindow').value, 10);
+        await window.go.main.App.UpdateSLAConfig(slaEnabled, slaDefaultP95, slaWindow,
+            currentSlaConfig.checkIntervalMinutes || 5, currentSlaConfig.minSamples || 10);
+
+        // Save token rate alert config
+        const tokenRateAlertEnabled = document.getElementById('settingsTokenRateAlertEnabled').checked;
+        const tokenRateAlertThreshold = parseInt(document.getElementById('settingsTokenRateAlertThreshold').value, 10);
+        const tokenRateAlertWindow = parseInt(document.getElementById('settingsTokenRateAlertWindow').value, 10);
+        const tokenRateAlertCooldown = parseInt(document.getElementById('settingsTokenRateAlertCooldown').value, 10);
+        await window.go.main.App.UpdateTokenRateAlertConfig(tokenRateAlertEnabled, tokenRateAlertThreshold,
+            tokenRateAlertWindow, tokenRateAlertCooldown);
 
         // Save cache config
         const cacheEnabled = document.getElementById('settingsCacheEnabled').checked;
@@ -687,7 +982,28 @@ export async function saveSettings() {
         const rateLimitEnabled = document.getElementById('settingsRateLimitEnabled').checked;
         const rateLimitGlobal = parseInt(document.getElementById('settingsRateLimitGlobal').value, 10);
         const rateLimitPerEndpoint = parseInt(document.getElementById('settingsRateLimitPerEndpoint').value, 10);
-        await window.go.main.App.SetRateLimitConfig(rateLimitEnabled, rateLimitGlobal, rateLimitPerEndpoint);
+        const rateLimitPerClientIp = parseInt(document.getElementById('settingsRateLimitPerClientIp').value, 10);
+        await window.go.main.App.SetRateLimitConfig(rateLimitEnabled, rateLimitGlobal, rateLimitPerEndpoint, rateLimitPerClientIp);
+
+        // Save retry budget config
+        const retryBudgetEnabled = document.getElementById('settingsRetryBudgetEnabled').checked;
+        const retryBudgetRatio = parseFloat(document.getElementById('settingsRetryBudgetRatio').value);
+        const retryBudgetMinRetries = parseInt(document.getElementById('settingsRetryBudgetMinRetries').value, 10);
+        const retryBudgetWindow = parseInt(document.getElementById('settingsRetryBudgetWindow').value, 10);
+        await window.go.main.App.SetRetryBudgetConfig(retryBudgetEnabled, retryBudgetRatio, retryBudgetMinRetries, retryBudgetWindow);
+
+        // Save auto continue config
+        const autoContinueEnabled = document.getElementById('settingsAutoContinueEnabled').checked;
+        const autoContinueMax = parseInt(document.getElementById('settingsAutoContinueMax').value, 10);
+        const autoContinueResume = document.getElementById('settingsAutoContinueResume').checked;
+        await window.go.main.App.SetAutoContinueConfig(autoContinueEnabled, autoContinueMax, autoContinueResume);
+
+        // Save circuit breaker config
+        const circuitBreakerEnabled = document.getElementById('settingsCircuitBreakerEnabled').checked;
+        const circuitBreakerThreshold = parseInt(document.getElementById('settingsCircuitBreakerThreshold').value, 10);
+        const circuitBreakerCooldown = parseInt(document.getElementById('settingsCircuitBreakerCooldown').value, 10);
+        const circuitBreakerProbes = parseInt(document.getElementById('settingsCircuitBreakerProbes').value, 10);
+        await window.go.main.App.SetCircuitBreakerConfig(circuitBreakerEnabled, circuitBreakerThreshold, circuitBreakerCooldown, circuitBreakerProbes);
 
         // Save routing config
         const routingEnabled = document.getElementById('settingsRoutingEnabled').checked;
@@ -846,6 +1162,21 @@ async function refreshRateLimitStats() {
         if (rpmEl) rpmEl.textContent = stats.currentGlobalRpm || 0;
         if (allowedEl) allowedEl.textContent = stats.totalAllowed || 0;
         if (rejectedEl) rejectedEl.textContent = stats.totalRejected || 0;
+
+        // 被按 IP 限流的客户端
+        const rejectedIpsEl = document.getElementById('rateLimitRejectedIps');
+        if (rejectedIpsEl) {
+            const rejectedKeys = stats.rejectedKeys || [];
+            rejectedIpsEl.innerHTML = rejectedKeys.length === 0 ? '' : `
+                <div style="margin: 4px 0;">${t('settings.rateLimitRejectedIps')}:</div>
+                ${rejectedKeys.slice(0, 10).map(item => `
+                    <div style="display: flex; justify-content: space-between; margin-bottom: 4px; padding-left: 8px;">
+                        <span>${escapeHtml(item.key)}</span>
+                        <span>${item.rejected}</span>
+                    </div>
+                `).join('')}
+            `;
+        }
     } catch (error) {
         console.error('Failed to refresh rate limit stats:', error);
     }
@@ -866,6 +1197,148 @@ export async function resetRateLimitStats() {
 // 导出 resetRateLimitStats 到 window 对象
 window.resetRateLimitStats = resetRateLimitStats;
 
+// 刷新当前 token 消耗速率
+async function refreshTokenRateStatus() {
+    try {
+        const status = JSON.parse(await window.go.main.App.GetTokenRateStatus());
+        const currentEl = document.getElementById('tokenRateAlertCurrent');
+        if (!currentEl || !status.success) return;
+
+        currentEl.textContent = `${Math.round(status.tokensPerMinute || 0).toLocaleString()} ${t('settings.tokenRateAlertPerMin')}`;
+        currentEl.style.color = status.exceeded ? '#dc3545' : '';
+    } catch (error) {
+        console.error('Failed to refresh token rate status:', error);
+    }
+}
+
+// 刷新重试预算统计
+async function refreshRetryBudgetStats() {
+    try {
+        const stats = JSON.parse(await window.go.main.App.GetRetryBudgetStats());
+
+        const requestsEl = document.getElementById('retryBudgetStatRequests');
+        const retriesEl = document.getElementById('retryBudgetStatRetries');
+        const rejectedEl = document.getElementById('retryBudgetStatRejected');
+
+        if (requestsEl) requestsEl.textContent = stats.requests || 0;
+        if (retriesEl) retriesEl.textContent = `${stats.retries || 0} / ${stats.budget || 0}`;
+        if (rejectedEl) rejectedEl.textContent = stats.rejected || 0;
+    } catch (error) {
+        console.error('Failed to refresh retry budget stats:', error);
+    }
+}
+
+// 重置重试预算统计
+export async function resetRetryBudgetStats() {
+    try {
+        await window.go.main.App.ResetRetryBudgetStats();
+        await refreshRetryBudgetStats();
+        showNotification('Retry budget stats reset', 'success');
+    } catch (error) {
+        console.error('Failed to reset retry budget stats:', error);
+        showNotification('Failed to reset retry budget stats: ' + error, 'error');
+    }
+}
+
+// 导出 resetRetryBudgetStats 到 window 对象
+window.resetRetryBudgetStats = resetRetryBudgetStats;
+
+// 刷新端点熔断状态
+async function refreshCircuitBreakerStatus() {
+    const listEl = document.getElementById('circuitBreakerStatusList');
+    if (!listEl) return;
+    try {
+        const statuses = JSON.parse(await window.go.main.App.GetCircuitBreakerStatus()) || [];
+        const tripped = statuses.filter(s => s.state !== 'closed');
+        if (tripped.length === 0) {
+            listEl.textContent = t('settings.circuitBreakerNone');
+            return;
+        }
+        listEl.innerHTML = tripped.map(s => {
+            const state = s.state === 'open'
+                ? t('settings.circuitBreakerOpen').replace('{seconds}', s.remainingSeconds)
+                : t('settings.circuitBreakerHalfOpen');
+            return `<div style="display: flex; justify-content: space-between; margin-bottom: 4px;">
+                <span>[${escapeHtml(s.clientType)}] ${escapeHtml(s.endpointName)}</span>
+                <span>${state}</span>
+            </div>`;
+        }).join('');
+    } catch (error) {
+        console.error('Failed to refresh circuit breaker status:', error);
+    }
+}
+
+// 重置端点熔断状态
+export async function resetCircuitBreaker() {
+    try {
+        await window.go.main.App.ResetCircuitBreaker();
+        await refreshCircuitBreakerStatus();
+        showNotification('Circuit breaker reset', 'success');
+    } catch (error) {
+        console.error('Failed to reset circuit breaker:', error);
+        showNotification('Failed to reset circuit breaker: ' + error, 'error');
+    }
+}
+
+window.resetCircuitBreaker = resetCircuitBreaker;
+
+// 测试代理连通性（使用输入框中尚未保存的代理地址）
+export async function testProxyUrl() {
+    const proxyUrl = document.getElementById('settingsProxyUrl').value.trim();
+    const btn = document.getElementById('settingsProxyTestBtn');
+    if (!proxyUrl) {
+        showNotification(t('settings.proxyTestEmpty'), 'warning');
+        return;
+    }
+    try {
+        btn.disabled = true;
+        btn.innerHTML = '⏳';
+        const result = JSON.parse(await window.go.main.App.TestProxyURL(proxyUrl));
+        if (result.success) {
+            showNotification(t('settings.proxyTestSuccess').replace('{latency}', result.latencyMs), 'success');
+        } else {
+            showNotification(t('settings.proxyTestFailed') + ': ' + result.error, 'error');
+        }
+    } catch (error) {
+        console.error('Failed to test proxy:', error);
+        showNotification(t('settings.proxyTestFailed') + ': ' + error, 'error');
+    } finally {
+        btn.disabled = false;
+        btn.innerHTML = t('settings.proxyTest');
+    }
+}
+
+window.testProxyUrl = testProxyUrl;
+
+// 发送测试告警到 webhook（使用输入框中尚未保存的地址和模板）
+export async function testAlertWebhook() {
+    const webhookUrl = document.getElementById('settingsAlertWebhookUrl').value.trim();
+    const webhookTemplate = document.getElementById('settingsAlertWebhookTemplate').value.trim();
+    const btn = document.getElementById('settingsAlertWebhookTestBtn');
+    if (!webhookUrl) {
+        showNotification(t('settings.alertWebhookEmpty'), 'warning');
+        return;
+    }
+    try {
+        btn.disabled = true;
+        btn.innerHTML = '⏳';
+        const result = JSON.parse(await window.go.main.App.TestAlertWebhook(webhookUrl, webhookTemplate));
+        if (result.success) {
+            showNotification(t('settings.alertWebhookTestSuccess'), 'success');
+        } else {
+            showNotification(t('settings.alertWebhookTestFailed') + ': ' + result.error, 'error');
+        }
+    } catch (error) {
+        console.error('Failed to test alert webhook:', error);
+        showNotification(t('settings.alertWebhookTestFailed') + ': ' + error, 'error');
+    } finally {
+        btn.disabled = false;
+        btn.innerHTML = t('settings.proxyTest');
+    }
+}
+
+window.testAlertWebhook = testAlertWebhook;
+
 // 刷新配额状态
 async function refreshQuotaStatus() {
     try {
@@ -894,7 +1367,9 @@ async function refreshQuotaStatus() {
                         <span style="color: ${barColor};">${usagePercent.toFixed(1)}%</span>
                     </div>
                     <div style="font-size: 11px; color: var(--text-secondary); margin-bottom: 4px;">
-                        ${status.tokensUsed.toLocaleString()} / ${status.quotaLimit > 0 ? status.quotaLimit.toLocaleString() : '∞'}
+                        ${status.quotaMode === 'cost'
+                            ? `$${(status.costUsed || 0).toFixed(2)} / ${status.quotaLimit > 0 ? '$' + status.quotaLimit.toLocaleString() : '∞'}`
+                            : `${status.tokensUsed.toLocaleString()} / ${status.quotaLimit > 0 ? status.quotaLimit.toLocaleString() : '∞'}`}
                     </div>
                     <div style="height: 4px; background: var(--border-color); border-radius: 2px; overflow: hidden;">
                         <div style="height: 100%; width: ${Math.min(usagePercent, 100)}%; background: ${barColor}; transition: width 0.3s;"></div>
@@ -1,5 +1,6 @@
-import { formatTokens } from '../utils/format.js';
-import { loadCostByPeriod, loadCostTrend } from './cost.js';
+import { formatTokens, escapeHtml } from '../utils/format.js';
+import { t } from '../i18n/index.js';
+import { loadCostByPeriod, loadCostTrend, formatCost } from './cost.js';
 
 let endpointStats = {};
 let currentPeriod = 'daily'; // 'daily', 'weekly', 'monthly'
@@ -123,6 +124,14 @@ export async function loadStatsByPeriod(period = 'daily') {
         const cacheHitRateValue = totalCacheTokens ? (totalCacheReadTokens / totalCacheTokens) * 100 : 0;
         document.getElementById('cacheHitRate').textContent = formatPercentageValue(cacheHitRateValue);
 
+        // Estimated cost based on endpoint unit prices
+        const estimatedCost = stats.estimatedCost || {};
+        document.getElementById('periodEstimatedCost').textContent = `≈ ${formatCost(estimatedCost.totalCost || 0)}`;
+        document.getElementById('periodEstimatedInputCost').textContent = formatCost(estimatedCost.inputCost || 0);
+        document.getElementById('periodEstimatedOutputCost').textContent = formatCost(estimatedCost.outputCost || 0);
+        document.getElementById('periodEstimatedCacheCost').textContent =
+            formatCost((estimatedCost.cacheWriteCost || 0) + (estimatedCost.cacheReadCost || 0));
+
         // Update endpoint stats (active / total)
         const activeEndpoints = stats.activeEndpoints || 0;
         const totalEndpoints = stats.totalEndpoints || 0;
@@ -134,6 +143,8 @@ export async function loadStatsByPeriod(period = 'daily') {
 
         // Load performance metrics for current period
         await loadPerformanceMetrics(period);
+        await loadReliabilityRanking(period);
+        await loadErrorBreakdown(period);
 
         // Load cost statistics for current period
         await loadCostByPeriod(period);
@@ -168,6 +179,7 @@ async function loadPerformanceMetrics(period = 'daily') {
             avgDurationEl.textContent = overall.avgDurationMs > 0
                 ? `${(overall.avgDurationMs / 1000).toFixed(1)}s`
                 : '-';
+            avgDurationEl.title = formatDurationPercentiles(overall);
         }
 
         // Min/Max Duration
@@ -217,11 +229,214 @@ async function loadPerformanceMetrics(period = 'daily') {
                 : '-';
         }
 
+        // Per request type performance (streaming durations include the whole generation)
+        renderRequestTypeMetrics(metrics.requestTypeMetrics, metrics.endpointRequestTypeMetrics);
+
     } catch (error) {
         console.error('Failed to load performance metrics:', error);
     }
 }
 
+// Load endpoint success rate ranking for specified period
+async function loadReliabilityRanking(period = 'daily') {
+    const content = document.getElementById('reliabilityRankingContent');
+    if (!content) {
+        return;
+    }
+    try {
+        const result = JSON.parse(await window.go.main.App.GetEndpointReliabilityRanking(period));
+        if (!result.success) {
+            console.error('Failed to load reliability ranking:', result.message);
+            return;
+        }
+
+        const ranking = result.ranking || [];
+        if (ranking.length === 0) {
+            content.innerHTML = `
+                <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                    ${t('statistics.reliabilityEmpty')}
+                </div>
+            `;
+            return;
+        }
+
+        const insufficientText = t('statistics.reliabilityInsufficient').replace('{count}', result.minSamples);
+        const rows = ranking.map(item => {
+            const latency = item.avgLatencyMs > 0 ? `${(item.avgLatencyMs / 1000).toFixed(1)}s` : '-';
+            const rank = item.insufficientSamples
+                ? `<span style="font-size: 12px; color: var(--text-secondary);" title="${escapeHtml(insufficientText)}">${t('statistics.reliabilityInsufficientShort')}</span>`
+                : `#${item.rank}`;
+            return `
+                <tr style="border-bottom: 1px solid var(--border-color);${item.insufficientSamples ? ' opacity: 0.6;' : ''}">
+                    <td style="padding: 10px; text-align: center; font-size: 13px;">${rank}</td>
+                    <td style="padding: 10px; font-size: 13px;">${escapeHtml(item.endpointName)} <span style="font-size: 12px; color: var(--text-secondary);">(${escapeHtml(item.clientType)})</span></td>
+                    <td style="padding: 10px; text-align: center; font-size: 13px;">${formatPercentageValue(item.successRate)}</td>
+                    <td style="padding: 10px; text-align: center; font-size: 13px;">${item.success} / ${item.requests}</td>
+                    <td style="padding: 10px; text-align: center; font-size: 13px;">${latency}</td>
+                </tr>
+            `;
+        }).join('');
+
+        content.innerHTML = `
+            <div style="overflow-x: auto;">
+                <table style="width: 100%; border-collapse: collapse;">
+                    <thead>
+                        <tr style="background: var(--bg-secondary); border-bottom: 2px solid var(--border-color);">
+                            <th style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.reliabilityRank')}</th>
+                            <th style="padding: 10px; text-align: left; font-size: 13px;">${t('statistics.reliabilityEndpoint')}</th>
+                            <th style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.successRate')}</th>
+                            <th style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.reliabilitySuccessTotal')}</th>
+                            <th style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.avgDuration')}</th>
+                        </tr>
+                    </thead>
+                    <tbody>${rows}</tbody>
+                </table>
+            </div>
+        `;
+    } catch (error) {
+        console.error('Failed to load reliability ranking:', error);
+    }
+}
+
+// Load failed request breakdown by error category for specified period
+async function loadErrorBreakdown(period = 'daily') {
+    const content = document.getElementById('errorBreakdownContent');
+    const summary = document.getElementById('errorBreakdownSummary');
+    if (!content) {
+        return;
+    }
+    try {
+        const result = JSON.parse(await window.go.main.App.GetErrorBreakdown(period));
+        if (!result.success) {
+            console.error('Failed to load error breakdown:', result.message);
+            return;
+        }
+
+        if (summary) {
+            summary.textContent = result.totalRequests > 0
+                ? t('statistics.errorBreakdownSummary')
+                    .replace('{errors}', result.totalErrors)
+                    .replace('{total}', result.totalRequests)
+                    .replace('{rate}', formatPercentageValue(result.errorRate))
+                : '';
+        }
+
+        if (!result.totalErrors) {
+            content.innerHTML = `
+                <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                    ${t('statistics.errorBreakdownEmpty')}
+                </div>
+            `;
+            return;
+        }
+
+        content.innerHTML = (result.categories || []).filter(item => item.count > 0).map(item => `
+            <div style="display: flex; align-items: center; gap: 10px; margin-bottom: 8px; font-size: 13px;">
+                <span style="width: 120px; flex-shrink: 0;">${escapeHtml(t(`statistics.errorCategories.${item.category}`))}</span>
+                <div style="flex: 1; height: 8px; background: var(--bg-secondary); border-radius: 4px; overflow: hidden;">
+                    <div style="width: ${item.percent.toFixed(1)}%; height: 100%; background: #ef4444;"></div>
+                </div>
+                <span style="width: 110px; text-align: right; color: var(--text-secondary);">${item.count} (${formatPercentageValue(item.percent)})</span>
+            </div>
+        `).join('');
+    } catch (error) {
+        console.error('Failed to load error breakdown:', error);
+    }
+}
+
+// Render streaming / non-streaming performance per endpoint, with a total row on top
+function renderRequestTypeMetrics(overall, byEndpoint) {
+    const content = document.getElementById('requestTypeMetricsContent');
+    if (!content) {
+        return;
+    }
+
+    const keys = Object.keys(byEndpoint || {}).sort();
+    if (!overall || keys.length === 0) {
+        content.innerHTML = `
+            <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                ${t('statistics.reliabilityEmpty')}
+            </div>
+        `;
+        return;
+    }
+
+    const cells = (m) => {
+        if (!m || !m.validRequests) {
+            return `
+                <td style="padding: 10px; text-align: center; font-size: 13px;">0</td>
+                <td style="padding: 10px; text-align: center; font-size: 13px;">-</td>
+                <td style="padding: 10px; text-align: center; font-size: 13px;">-</td>
+            `;
+        }
+        const outputSpeed = m.outputTokensPerSec > 0 ? m.outputTokensPerSec.toFixed(1) : '-';
+        return `
+            <td style="padding: 10px; text-align: center; font-size: 13px;">${m.validRequests}</td>
+            <td style="padding: 10px; text-align: center; font-size: 13px;" title="${escapeHtml(formatDurationPercentiles(m))}">${(m.avgDurationMs / 1000).toFixed(1)}s</td>
+            <td style="padding: 10px; text-align: center; font-size: 13px;">${outputSpeed}</td>
+        `;
+    };
+
+    // Keys are "clientType:endpointName"
+    const rows = keys.map(key => {
+        const sep = key.indexOf(':');
+        const clientType = key.slice(0, sep);
+        const endpointName = key.slice(sep + 1);
+        return `
+            <tr style="border-bottom: 1px solid var(--border-color);">
+                <td style="padding: 10px; font-size: 13px;">${escapeHtml(endpointName)} <span style="font-size: 12px; color: var(--text-secondary);">(${escapeHtml(clientType)})</span></td>
+                ${cells(byEndpoint[key].streaming)}
+                ${cells(byEndpoint[key].nonStreaming)}
+            </tr>
+        `;
+    }).join('');
+
+    const subHeader = `
+        <th style="padding: 6px 10px; text-align: center; font-size: 12px;">${t('statistics.requestCount')}</th>
+        <th style="padding: 6px 10px; text-align: center; font-size: 12px;">${t('statistics.avgDuration')}</th>
+        <th style="padding: 6px 10px; text-align: center; font-size: 12px;">${t('statistics.avgOutputSpeed')} (${t('statistics.outputTokensPerSec')})</th>
+    `;
+
+    content.innerHTML = `
+        <div style="overflow-x: auto;">
+            <table style="width: 100%; border-collapse: collapse;">
+                <thead>
+                    <tr style="background: var(--bg-secondary);">
+                        <th rowspan="2" style="padding: 10px; text-align: left; font-size: 13px; border-bottom: 2px solid var(--border-color);">${t('statistics.reliabilityEndpoint')}</th>
+                        <th colspan="3" style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.streaming')}</th>
+                        <th colspan="3" style="padding: 10px; text-align: center; font-size: 13px;">${t('statistics.nonStreaming')}</th>
+                    </tr>
+                    <tr style="background: var(--bg-secondary); border-bottom: 2px solid var(--border-color);">
+                        ${subHeader}
+                        ${subHeader}
+                    </tr>
+                </thead>
+                <tbody>
+                    <tr style="border-bottom: 1px solid var(--border-color); font-weight: 600;">
+                        <td style="padding: 10px; font-size: 13px;">${t('statistics.requestTypeAll')}</td>
+                        ${cells(overall.streaming)}
+                        ${cells(overall.nonStreaming)}
+                    </tr>
+                    ${rows}
+                </tbody>
+            </table>
+        </div>
+    `;
+}
+
+// Format P50/P95/P99 durations as a tooltip line
+function formatDurationPercentiles(m) {
+    if (!m || !m.validRequests) {
+        return '';
+    }
+    const fmt = ms => `${(ms / 1000).toFixed(1)}s`;
+    let text = `P50/P95/P99: ${fmt(m.p50DurationMs)} / ${fmt(m.p95DurationMs)} / ${fmt(m.p99DurationMs)}`;
+    if (m.insufficientSamples) {
+        text += ` (${t('statistics.percentileInsufficient').replace('{count}', m.validRequests)})`;
+    }
+    return text;
+}
+
 // Load trend comparison data for specified period
 async function loadTrend(period = 'daily') {
     try {
@@ -383,6 +598,65 @@ export async function switchStatsPeriod(period) {
     }));
 }
 
+// Export daily stats of the current period as a CSV file
+export async function exportStatsCSV() {
+    try {
+        const csv = await window.go.main.App.ExportStatsCSV(currentPeriod);
+        if (csv.startsWith('{')) {
+            const result = JSON.parse(csv);
+            throw new Error(result.error);
+        }
+
+        const timestamp = new Date().toISOString().slice(0, 10);
+        const blob = new Blob([csv], { type: 'text/csv;charset=utf-8' });
+        const url = URL.createObjectURL(blob);
+        const a = document.createElement('a');
+        a.href = url;
+        a.download = `ccnexus-stats-${currentPeriod}-${timestamp}.csv`;
+        document.body.appendChild(a);
+        a.click();
+        document.body.removeChild(a);
+        URL.revokeObjectURL(url);
+    } catch (error) {
+        console.error('Failed to export stats CSV:', error);
+        showNotification(t('statistics.exportCSVFailed') + ': ' + error.message, 'error');
+    }
+}
+
+// Import Stats Modal
+export function showImportStatsModal() {
+    document.getElementById('importStatsModal').classList.add('active');
+    document.getElementById('importStatsUrl').focus();
+}
+
+export function closeImportStatsModal() {
+    document.getElementById('importStatsModal').classList.remove('active');
+}
+
+// Import daily stats from another ccNexus instance
+export async function importStatsFromURL() {
+    const url = document.getElementById('importStatsUrl').value.trim();
+    const strategy = document.getElementById('importStatsStrategy').value;
+    if (!url) {
+        showNotification(t('statistics.importStatsUrlRequired'), 'error');
+        return;
+    }
+
+    const btn = document.getElementById('importStatsBtn');
+    btn.disabled = true;
+    try {
+        await window.go.main.App.ImportStatsFromURL(url, strategy);
+        closeImportStatsModal();
+        showNotification(t('statistics.importStatsSuccess'), 'success');
+        await loadStatsByPeriod(currentPeriod);
+    } catch (error) {
+        console.error('Failed to import stats:', error);
+        showNotification(t('statistics.importStatsFailed') + ': ' + error, 'error');
+    } finally {
+        btn.disabled = false;
+    }
+}
+
 // Refresh session statistics
 export async function refreshSessionStats() {
     try {
@@ -40,6 +40,12 @@ export function initUI() {
                         <button class="stats-detail-btn" onclick="window.showDailyDetailsModal()" title="${t('statistics.viewDetails')}">
                             📋 ${t('statistics.details')}
                         </button>
+                        <button class="stats-detail-btn" onclick="window.exportStatsCSV()" title="${t('statistics.exportCSVTitle')}">
+                            📥 ${t('statistics.exportCSV')}
+                        </button>
+                        <button class="stats-detail-btn" onclick="window.showImportStatsModal()" title="${t('statistics.importStatsTitle')}">
+                            📤 ${t('statistics.importStats')}
+                        </button>
                         <button class="stats-tab-btn active" data-period="daily" onclick="window.switchStatsPeriod('daily')">
                             📅 ${t('statistics.daily')}
                         </button>
@@ -225,6 +231,24 @@ export function initUI() {
                                     <span id="periodCacheSavings" class="stat-primary savings-value">$0.00</span>
                                 </div>
                             </div>
+                            <div class="stat-box-compact stat-box-condensed cost-stat-box" title="${t('cost.estimatedCostHelp')}">
+                                <div class="stat-info">
+                                    <div class="stat-label">💵 ${t('cost.estimatedCost')}</div>
+                                    <div class="stat-detail">
+                                        <span>⬇️</span>
+                                        <span id="periodEstimatedInputCost">$0.00</span>
+                                        <span class="stat-divider">/</span>
+                                        <span>⬆️</span>
+                                        <span id="periodEstimatedOutputCost">$0.00</span>
+                                        <span class="stat-divider">/</span>
+                                        <span>📖</span>
+                                        <span id="periodEstimatedCacheCost">$0.00</span>
+                                    </div>
+                                </div>
+                                <div class="stat-value">
+                                    <span id="periodEstimatedCost" class="stat-primary cost-value">≈ $0.00</span>
+                                </div>
+                            </div>
                             <!-- Endpoint Metrics (merged into same grid) -->
                             <div id="endpointMetricsGrid" style="display: contents;"></div>
                         </div>
@@ -290,6 +314,43 @@ export function initUI() {
                 </div>
             </div>
 
+            <!-- Streaming / Non-streaming Performance -->
+            <div class="card" id="requestTypeMetricsCard">
+                <div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 15px;">
+                    <h2 style="margin: 0;">⚡ ${t('statistics.requestTypeTitle')}</h2>
+                </div>
+                <div id="requestTypeMetricsContent">
+                    <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                        ${t('statistics.reliabilityEmpty')}
+                    </div>
+                </div>
+            </div>
+
+            <!-- Endpoint Reliability Ranking -->
+            <div class="card" id="reliabilityRankingCard">
+                <div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 15px;">
+                    <h2 style="margin: 0;">🏆 ${t('statistics.reliabilityTitle')}</h2>
+                </div>
+                <div id="reliabilityRankingContent">
+                    <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                        ${t('statistics.reliabilityEmpty')}
+                    </div>
+                </div>
+            </div>
+
+            <!-- Error Breakdown -->
+            <div class="card" id="errorBreakdownCard">
+                <div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 15px;">
+                    <h2 style="margin: 0;">🧯 ${t('statistics.errorBreakdownTitle')}</h2>
+                    <span id="errorBreakdownSummary" style="font-size: 13px; color: var(--text-secondary);"></span>
+                </div>
+                <div id="errorBreakdownContent">
+                    <div style="text-align: center; padding: 20px; color: var(--text-secondary);">
+                        ${t('statistics.errorBreakdownEmpty')}
+                    </div>
+                </div>
+            </div>
+
             <!-- Session Affinity Statistics -->
             <div class="card" id="sessionStatsCard" style="display: none;">
                 <div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 15px;">
@@ -472,6 +533,12 @@ export function initUI() {
                             <div class="details-info">
                                 <span>${t('statistics.totalRecords')}: <strong id="detailsTotalCount">0</strong></span>
                             </div>
+                            <div class="details-search">
+                                <input type="text" id="detailsSearchKeyword" placeholder="${t('statistics.searchPlaceholder')}"
+                                    onkeydown="if (event.key === 'Enter') window.searchDetails()">
+                                <button class="btn btn-secondary btn-sm" onclick="window.searchDetails()">🔍 ${t('statistics.search')}</button>
+                                <button class="btn btn-secondary btn-sm" onclick="window.clearDetailsSearch()">${t('statistics.clearSearch')}</button>
+                            </div>
                             <div class="details-pagination">
                                 <label>${t('statistics.pageSize')}:</label>
                                 <select id="detailsPageSize" onchange="window.changeDetailsPageSize()">
@@ -547,6 +614,9 @@ export function initUI() {
                         <button id="testAllEndpointsBtn" class="btn btn-secondary" onclick="window.testAllEndpointsAndOptimize && window.testAllEndpointsAndOptimize()">
                             🔍 ${t('monitor.testAllEndpoints')}
                         </button>
+                        <button class="btn btn-secondary" onclick="window.autoSortEndpointsByLatency()" title="${t('endpoints.sortByLatencyTitle')}">
+                            ⚡ ${t('endpoints.sortByLatency')}
+                        </button>
                         <button class="btn btn-secondary" onclick="window.showInteractionsModal()">
                             📝 ${t('interactions.viewInteractions')}
                         </button>
@@ -679,6 +749,27 @@ export function initUI() {
                             ${t('modal.modelHelp')}
                         </p>
                     </div>
+                    <div class="form-group" id="hideThinkingGroup" style="display: none;">
+                        <div style="display: flex; align-items: center; gap: 8px;">
+                            <input type="checkbox" id="endpointHideThinking" style="flex-shrink: 0; width: 16px; height: 16px; margin: 0;">
+                            <span style="font-size: 13px; flex: 1;">${t('modal.hideThinking')}</span>
+                        </div>
+                        <p class="form-help">${t('modal.hideThinkingHelp')}</p>
+                    </div>
+                    <div class="form-group" id="streamUsageGroup" style="display: none;">
+                        <div style="display: flex; align-items: center; gap: 8px;">
+                            <input type="checkbox" id="endpointDisableStreamUsage" style="flex-shrink: 0; width: 16px; height: 16px; margin: 0;">
+                            <span style="font-size: 13px; flex: 1;">${t('modal.disableStreamUsage')}</span>
+                        </div>
+                        <p class="form-help">${t('modal.disableStreamUsageHelp')}</p>
+                    </div>
+                    <div class="form-group">
+                        <div style="display: flex; align-items: center; gap: 8px;">
+                            <input type="checkbox" id="endpointPinStatus" style="flex-shrink: 0; width: 16px; height: 16px; margin: 0;">
+                            <span style="font-size: 13px; flex: 1;">${t('modal.pinStatus')}</span>
+                        </div>
+                        <p class="form-help">${t('modal.pinStatusHelp')}</p>
+                    </div>
                     <div class="form-group">
                         <label>${t('modal.remark')}</label>
                         <input type="text" id="endpointRemark" placeholder="${t('modal.remarkHelp')}">
@@ -688,6 +779,11 @@ export function initUI() {
                         <input type="text" id="endpointTags" placeholder="${t('modal.tagsPlaceholder')}">
                         <p class="form-help">${t('modal.tagsHelp')}</p>
                     </div>
+                    <div class="form-group">
+                        <label>${t('modal.group')}</label>
+                        <input type="text" id="endpointGroup" placeholder="${t('modal.groupPlaceholder')}">
+                        <p class="form-help">${t('modal.groupHelp')}</p>
+                    </div>
 
                     <!-- 智能路由高级设置 -->
                     <div class="form-section-divider" onclick="window.toggleRoutingSettings()">
@@ -700,6 +796,17 @@ export function initUI() {
                             <input type="text" id="endpointModelPatterns" placeholder="${t('modal.modelPatternsPlaceholder') || 'claude-*,gpt-4*'}">
                             <p class="form-help">${t('modal.modelPatternsHelp') || '逗号分隔，支持通配符 * 如 claude-*,gpt-4*'}</p>
                         </div>
+                        <div class="form-group">
+                            <label>${t('modal.models')}</label>
+                            <div id="endpointModelsList" class="endpoint-models-list"></div>
+                            <button type="button" class="btn btn-secondary btn-sm" onclick="window.addEndpointModelRow()">➕ ${t('modal.addModel')}</button>
+                            <p class="form-help">${t('modal.modelsHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.modelRewrite')}</label>
+                            <textarea id="endpointModelRewrite" rows="3" placeholder="${t('modal.modelRewritePlaceholder')}"></textarea>
+                            <p class="form-help">${t('modal.modelRewriteHelp')}</p>
+                        </div>
                         <div class="form-row">
                             <div class="form-group form-group-half">
                                 <label>${t('modal.costPerInputToken') || '输入成本 ($/M)'}</label>
@@ -711,6 +818,11 @@ export function initUI() {
                                 <input type="number" id="endpointCostOutput" step="0.01" min="0" placeholder="0.00">
                             </div>
                         </div>
+                        <div class="form-group">
+                            <label>${t('modal.costPerCacheReadToken')}</label>
+                            <input type="number" id="endpointCostCacheRead" step="0.01" min="0" placeholder="0.00">
+                            <p class="form-help">${t('modal.costPerCacheReadTokenHelp')}</p>
+                        </div>
                         <div class="form-row">
                             <div class="form-group form-group-half">
                                 <label>${t('modal.quotaLimit') || 'Token 配额'}</label>
@@ -726,11 +838,111 @@ export function initUI() {
                                 </select>
                             </div>
                         </div>
+                        <div class="form-group">
+                            <label>${t('modal.quotaMode')}</label>
+                            <select id="endpointQuotaMode">
+                                <option value="">${t('modal.quotaModeToken')}</option>
+                                <option value="cost">${t('modal.quotaModeCost')}</option>
+                            </select>
+                            <p class="form-help">${t('modal.quotaModeHelp')}</p>
+                        </div>
                         <div class="form-group">
                             <label>${t('modal.priority') || '优先级'}</label>
                             <input type="number" id="endpointPriority" min="1" max="999" placeholder="100">
                             <p class="form-help">${t('modal.priorityHelp') || '数字越小优先级越高，默认100'}</p>
                         </div>
+                        <div class="form-group">
+                            <label>${t('modal.weight')}</label>
+                            <input type="number" id="endpointWeight" min="1" max="100" placeholder="1">
+                            <p class="form-help">${t('modal.weightHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.userAgent')}</label>
+                            <input type="text" id="endpointUserAgent" list="endpointUserAgentPresets" placeholder="${t('modal.userAgentPlaceholder')}">
+                            <datalist id="endpointUserAgentPresets">
+                                <option value="claude-cli/1.0.0 (external, cli)">
+                                <option value="codex_cli_rs/0.1.0">
+                                <option value="ccNexus">
+                            </datalist>
+                            <p class="form-help">${t('modal.userAgentHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.headerMode')}</label>
+                            <select id="endpointHeaderMode" onchange="window.handleHeaderModeChange()">
+                                <option value="">${t('modal.headerModeAll')}</option>
+                                <option value="whitelist">${t('modal.headerModeWhitelist')}</option>
+                                <option value="minimal">${t('modal.headerModeMinimal')}</option>
+                            </select>
+                            <p class="form-help">${t('modal.headerModeHelp')}</p>
+                        </div>
+                        <div class="form-group" id="endpointHeaderWhitelistGroup" style="display: none;">
+                            <label>${t('modal.headerWhitelist')}</label>
+                            <input type="text" id="endpointHeaderWhitelist" placeholder="x-stainless-*, x-app">
+                            <p class="form-help">${t('modal.headerWhitelistHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.healthFields')}</label>
+                            <input type="text" id="endpointHealthFields" placeholder="content, choices.0.message">
+                            <p class="form-help">${t('modal.healthFieldsHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.healthErrorWords')}</label>
+                            <input type="text" id="endpointHealthErrorWords" placeholder="${t('modal.healthErrorWordsPlaceholder')}">
+                            <p class="form-help">${t('modal.healthErrorWordsHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.healthCheckPath')}</label>
+                            <div style="display: flex; gap: 8px;">
+                                <select id="endpointHealthCheckMethod" style="width: 110px;">
+                                    <option value="GET">GET</option>
+                                    <option value="HEAD">HEAD</option>
+                                    <option value="POST">POST</option>
+                                    <option value="OPTIONS">OPTIONS</option>
+                                </select>
+                                <input type="text" id="endpointHealthCheckPath" placeholder="/health" style="flex: 1;">
+                            </div>
+                            <p class="form-help">${t('modal.healthCheckPathHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.activeHours')}</label>
+                            <input type="text" id="endpointActiveHours" placeholder="${t('modal.activeHoursPlaceholder')}">
+                            <p class="form-help">${t('modal.activeHoursHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.keyExpiryDate')}</label>
+                            <input type="date" id="endpointKeyExpiryDate">
+                            <p class="form-help">${t('modal.keyExpiryDateHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.extraApiKeys')}</label>
+                            <textarea id="endpointExtraKeys" rows="3" placeholder="${t('modal.extraApiKeysPlaceholder')}" autocomplete="off"></textarea>
+                            <p class="form-help">${t('modal.extraApiKeysHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.refreshToken')}</label>
+                            <input type="password" id="endpointRefreshToken" placeholder="${t('modal.refreshTokenPlaceholder')}" autocomplete="off">
+                            <p class="form-help">${t('modal.refreshTokenHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.tokenExpiry')}</label>
+                            <input type="datetime-local" id="endpointTokenExpiry">
+                            <p class="form-help">${t('modal.tokenExpiryHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.slaP95')}</label>
+                            <input type="number" id="endpointSlaP95" min="0" step="100" placeholder="${t('modal.slaP95Placeholder')}">
+                            <p class="form-help">${t('modal.slaP95Help')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.maxConcurrency')}</label>
+                            <input type="number" id="endpointMaxConcurrency" min="0" step="1" placeholder="${t('modal.maxConcurrencyPlaceholder')}">
+                            <p class="form-help">${t('modal.maxConcurrencyHelp')}</p>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('modal.timeoutSeconds')}</label>
+                            <input type="number" id="endpointTimeoutSeconds" min="0" step="1" placeholder="${t('modal.timeoutSecondsPlaceholder')}">
+                            <p class="form-help">${t('modal.timeoutSecondsHelp')}</p>
+                        </div>
                     </div>
                 </div>
                 <div class="modal-footer">
@@ -752,6 +964,17 @@ export function initUI() {
                         <label><span class="required">*</span>${t('modal.portLabel')}</label>
                         <input type="number" id="portInput" min="1" max="65535" placeholder="3003">
                     </div>
+                    <div class="form-group">
+                        <label>${t('modal.clientPortsLabel')}</label>
+                        <div style="display: flex; gap: 8px;">
+                            <input type="number" id="clientPortClaude" min="1" max="65535" placeholder="Claude">
+                            <input type="number" id="clientPortGemini" min="1" max="65535" placeholder="Gemini">
+                            <input type="number" id="clientPortCodex" min="1" max="65535" placeholder="Codex">
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('modal.clientPortsHelp')}
+                        </p>
+                    </div>
                     <p style="color: #666; font-size: 14px; margin-top: 10px;">
                         ⚠️ ${t('modal.portNote')}
                     </p>
@@ -763,6 +986,36 @@ export function initUI() {
             </div>
         </div>
 
+        <!-- Import Stats Modal -->
+        <div id="importStatsModal" class="modal">
+            <div class="modal-content">
+                <div class="modal-header">
+                    <h2>📤 ${t('statistics.importStatsTitle')}</h2>
+                    <button class="modal-close" onclick="window.closeImportStatsModal()">&times;</button>
+                </div>
+                <div class="modal-body">
+                    <div class="form-group">
+                        <label><span class="required">*</span>${t('statistics.importStatsUrl')}</label>
+                        <input type="text" id="importStatsUrl" placeholder="http://192.168.1.10:3003">
+                    </div>
+                    <div class="form-group">
+                        <label>${t('statistics.importStatsStrategy')}</label>
+                        <select id="importStatsStrategy">
+                            <option value="keep_local">${t('statistics.importStatsKeepLocal')}</option>
+                            <option value="overwrite_local">${t('statistics.importStatsOverwrite')}</option>
+                        </select>
+                    </div>
+                    <p style="color: #666; font-size: 14px; margin-top: 10px;">
+                        💡 ${t('statistics.importStatsNote')}
+                    </p>
+                </div>
+                <div class="modal-footer">
+                    <button class="btn btn-secondary" onclick="window.closeImportStatsModal()">${t('modal.cancel')}</button>
+                    <button class="btn btn-primary" id="importStatsBtn" onclick="window.importStatsFromURL()">${t('statistics.importStats')}</button>
+                </div>
+            </div>
+        </div>
+
         <!-- Welcome Modal -->
         <div id="welcomeModal" class="modal">
             <div class="modal-content" style="max-width: min(600px, 90vw);">
@@ -829,6 +1082,25 @@ export function initUI() {
                     <div id="testResultContent" style="font-size: 14px; line-height: 1.6;">
                         <!-- Test result will be inserted here -->
                     </div>
+                    <details style="margin-top: 15px;">
+                        <summary style="cursor: pointer;">${t('test.customTitle')}</summary>
+                        <div class="form-group" style="margin-top: 10px;">
+                            <label>${t('test.customPrompt')}</label>
+                            <textarea id="testPromptInput" rows="3" placeholder="${t('test.customPromptPlaceholder')}"></textarea>
+                        </div>
+                        <div class="form-group">
+                            <label>${t('test.customMaxTokens')}</label>
+                            <input type="number" id="testMaxTokensInput" min="0" placeholder="16">
+                            <small style="color: #666; font-size: 12px;">${t('test.customMaxTokensHelp')}</small>
+                        </div>
+                        <button class="btn btn-primary" onclick="window.retestEndpoint()">${t('test.retest')}</button>
+                    </details>
+                    <details style="margin-top: 10px;">
+                        <summary style="cursor: pointer;">${t('test.preflightTitle')}</summary>
+                        <small style="display: block; color: #666; font-size: 12px; margin: 8px 0;">${t('test.preflightHelp')}</small>
+                        <button class="btn btn-secondary" id="preflightBtn" onclick="window.runPreflightCheck()">${t('test.preflightRun')}</button>
+                        <div id="preflightResult" style="margin-top: 10px; font-size: 13px;"></div>
+                    </details>
                 </div>
             </div>
         </div>
@@ -959,7 +1231,10 @@ export function initUI() {
                     </div>
                     <div class="form-group">
                         <label>${t('settings.proxy')}</label>
-                        <input type="text" id="settingsProxyUrl" placeholder="${t('settings.proxyUrlPlaceholder')}">
+                        <div style="display: flex; gap: 8px;">
+                            <input type="text" id="settingsProxyUrl" placeholder="${t('settings.proxyUrlPlaceholder')}" style="flex: 1;">
+                            <button class="btn btn-secondary" id="settingsProxyTestBtn" onclick="window.testProxyUrl()">${t('settings.proxyTest')}</button>
+                        </div>
                         <p style="color: #666; font-size: 12px; margin-top: 5px;">
                             ${t('settings.proxyHelp')}
                         </p>
@@ -977,6 +1252,30 @@ export function initUI() {
                        ${t('settings.healthCheckHelp')}
                         </p>
                  </div>
+                    <div class="form-group">
+                        <label>${t('settings.autoOptimize')}</label>
+                        <select id="settingsAutoOptimizeInterval">
+                            <option value="0">${t('settings.autoOptimizeOptions.disabled')}</option>
+                            <option value="60">${t('settings.autoOptimizeOptions.hour1')}</option>
+                            <option value="360">${t('settings.autoOptimizeOptions.hour6')}</option>
+                            <option value="720">${t('settings.autoOptimizeOptions.hour12')}</option>
+                            <option value="1440">${t('settings.autoOptimizeOptions.day1')}</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.autoOptimizeHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.healthCheckMethod')}</label>
+                        <select id="settingsHealthCheckMethod">
+                            <option value="models">${t('settings.healthCheckMethodOptions.models')}</option>
+                            <option value="token_count">${t('settings.healthCheckMethodOptions.tokenCount')}</option>
+                            <option value="minimal">${t('settings.healthCheckMethodOptions.minimal')}</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.healthCheckMethodHelp')}
+                        </p>
+                    </div>
                     <div class="form-group">
                         <label>${t('settings.requestTimeout')}</label>
                         <select id="settingsRequestTimeout">
@@ -993,6 +1292,68 @@ export function initUI() {
                             ${t('settings.requestTimeoutHelp')}
                         </p>
                     </div>
+                    <div class="form-group">
+                        <label>${t('settings.enableHTTP2')}</label>
+                        <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                            <input type="checkbox" id="settingsEnableHTTP2">
+                            <span class="toggle-slider" style="border-radius: 20px;"></span>
+                        </label>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.enableHTTP2Help')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.perEndpointLogging')}</label>
+                        <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                            <input type="checkbox" id="settingsPerEndpointLogging">
+                            <span class="toggle-slider" style="border-radius: 20px;"></span>
+                        </label>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.perEndpointLoggingHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.streamHeartbeat')}</label>
+                        <select id="settingsStreamHeartbeat">
+                            <option value="0">${t('settings.streamHeartbeatOptions.disabled')}</option>
+                            <option value="5">5 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="10">10 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="15">15 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="30">30 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="60">60 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.streamHeartbeatHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.streamFirstByteTimeout')}</label>
+                        <select id="settingsStreamFirstByteTimeout">
+                            <option value="0">${t('settings.streamHeartbeatOptions.disabled')}</option>
+                            <option value="15">15 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="30">30 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="60">60 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="120">120 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                            <option value="300">300 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.streamFirstByteTimeoutHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.maxRequestBody')}</label>
+                        <select id="settingsMaxRequestBody">
+                            <option value="0">${t('settings.maxRequestBodyUnlimited')}</option>
+                            <option value="8388608">8 MB</option>
+                            <option value="16777216">16 MB</option>
+                            <option value="33554432">32 MB</option>
+                            <option value="67108864">64 MB</option>
+                            <option value="134217728">128 MB</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.maxRequestBodyHelp')}
+                        </p>
+                    </div>
                     <div class="form-group">
                         <label>${t('settings.healthHistoryRetention')}</label>
                         <select id="settingsHealthHistoryRetention">
@@ -1006,6 +1367,27 @@ export function initUI() {
                             ${t('settings.healthHistoryRetentionHelp')}
                         </p>
                     </div>
+                    <div class="form-group">
+                        <label>${t('settings.interactionRetention')}</label>
+                        <select id="settingsInteractionRetention">
+                            <option value="1">1 ${t('settings.healthHistoryRetentionDays')}</option>
+                            <option value="3">3 ${t('settings.healthHistoryRetentionDays')}</option>
+                            <option value="7">7 ${t('settings.healthHistoryRetentionDays')}</option>
+                            <option value="14">14 ${t('settings.healthHistoryRetentionDays')}</option>
+                            <option value="30">30 ${t('settings.healthHistoryRetentionDays')}</option>
+                            <option value="90">90 ${t('settings.healthHistoryRetentionDays')}</option>
+                        </select>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.interactionRetentionHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.statsTimezone')}</label>
+                        <input type="text" id="settingsStatsTimezone" placeholder="${t('settings.statsTimezonePlaceholder')}">
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.statsTimezoneHelp')}
+                        </p>
+                    </div>
                     <div class="form-group">
                         <label>${t('settings.alertConfig')}</label>
                         <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
@@ -1036,6 +1418,17 @@ export function initUI() {
                                     <option value="60">60 ${t('settings.alertMinutes')}</option>
                                 </select>
                             </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.alertAggregationWindow')}</label>
+                                <select id="settingsAlertAggregationWindow" style="width: 100%; margin-top: 5px;">
+                                    <option value="0">${t('settings.alertNoAggregation')}</option>
+                                    <option value="10">10 ${t('settings.alertSeconds')}</option>
+                                    <option value="30">30 ${t('settings.alertSeconds')}</option>
+                                    <option value="60">60 ${t('settings.alertSeconds')}</option>
+                                    <option value="120">120 ${t('settings.alertSeconds')}</option>
+                                    <option value="300">300 ${t('settings.alertSeconds')}</option>
+                                </select>
+                            </div>
                             <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
                                 <input type="checkbox" id="settingsAlertNotifyOnRecovery" checked style="flex-shrink: 0; width: 16px; height: 16px; margin: 0;">
                                 <span style="font-size: 13px; flex: 1;">${t('settings.alertNotifyOnRecovery')}</span>
@@ -1059,6 +1452,7 @@ export function initUI() {
                                             <option value="10000">10000 ${t('settings.performanceLatencyMs')}</option>
                                             <option value="15000">15000 ${t('settings.performanceLatencyMs')}</option>
                                         </select>
+                                        <p style="color: #666; font-size: 12px; margin-top: 5px;">${t('settings.performanceLatencyThresholdHelp')}</p>
                                     </div>
                                     <div style="margin-bottom: 10px;">
                                         <label style="font-size: 13px;">${t('settings.performanceLatencyIncrease')}</label>
@@ -1094,6 +1488,18 @@ export function initUI() {
                                     ${t('settings.autoEnableHelp')}
                                 </p>
                             </div>
+                            <div style="margin-top: 15px; padding-top: 10px; border-top: 1px solid var(--border-color);">
+                                <label style="font-size: 13px;">${t('settings.alertWebhookUrl')}</label>
+                                <div style="display: flex; gap: 8px; margin-top: 5px;">
+                                    <input type="text" id="settingsAlertWebhookUrl" placeholder="https://" style="flex: 1;">
+                                    <button class="btn btn-secondary" id="settingsAlertWebhookTestBtn" onclick="window.testAlertWebhook()">${t('settings.proxyTest')}</button>
+                                </div>
+                                <label style="font-size: 13px; display: block; margin-top: 10px;">${t('settings.alertWebhookTemplate')}</label>
+                                <textarea id="settingsAlertWebhookTemplate" rows="3" placeholder='{"msg_type":"text","content":{"text":"[{{.AlertType}}] {{.Message}}"}}' style="width: 100%; margin-top: 5px; font-family: monospace; font-size: 12px;"></textarea>
+                                <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                                    ${t('settings.alertWebhookHelp')}
+                                </p>
+                            </div>
                         </div>
                         <p style="color: #666; font-size: 12px; margin-top: 5px;">
                             ${t('settings.alertConfigHelp')}
@@ -1131,11 +1537,108 @@ export function initUI() {
                                     <option value="10">10</option>
                                 </select>
                             </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.sessionKeySource')}</label>
+                                <select id="settingsSessionKeySource" style="width: 100%; margin-top: 5px;">
+                                    <option value="">${t('settings.sessionKeySourceDefault')}</option>
+                                    <option value="header">${t('settings.sessionKeySourceHeader')}</option>
+                                    <option value="body">${t('settings.sessionKeySourceBody')}</option>
+                                </select>
+                            </div>
+                            <div id="sessionKeyNameGroup" style="display: none;">
+                                <label style="font-size: 13px;">${t('settings.sessionKeyName')}</label>
+                                <input type="text" id="settingsSessionKeyName" style="width: 100%; margin-top: 5px;" placeholder="X-Session-Id / metadata.user_id">
+                                <p style="color: #666; font-size: 12px; margin-top: 5px;">${t('settings.sessionKeyNameHelp')}</p>
+                            </div>
                         </div>
                         <p style="color: #666; font-size: 12px; margin-top: 5px;">
                             ${t('settings.sessionAffinityHelp')}
                         </p>
                     </div>
+                    <div class="form-group">
+                        <label>${t('settings.slaConfig')}</label>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.slaEnabled')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsSlaEnabled">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <div id="slaConfigDetails" style="display: none; padding: 10px; background: var(--bg-secondary); border-radius: 8px;">
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.slaDefaultP95')}</label>
+                                <select id="settingsSlaDefaultP95" style="width: 100%; margin-top: 5px;">
+                                    <option value="1000">1000 ms</option>
+                                    <option value="2000">2000 ms</option>
+                                    <option value="3000">3000 ms</option>
+                                    <option value="5000">5000 ms</option>
+                                    <option value="10000">10000 ms</option>
+                                    <option value="30000">30000 ms</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.slaWindow')}</label>
+                                <select id="settingsSlaWindow" style="width: 100%; margin-top: 5px;">
+                                    <option value="15">15 ${t('settings.alertMinutes')}</option>
+                                    <option value="30">30 ${t('settings.alertMinutes')}</option>
+                                    <option value="60">60 ${t('settings.alertMinutes')}</option>
+                                    <option value="180">180 ${t('settings.alertMinutes')}</option>
+                                    <option value="1440">1440 ${t('settings.alertMinutes')}</option>
+                                </select>
+                            </div>
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.slaHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.tokenRateAlertConfig')}</label>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.tokenRateAlertEnabled')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsTokenRateAlertEnabled">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <div id="tokenRateAlertConfigDetails" style="display: none; padding: 10px; background: var(--bg-secondary); border-radius: 8px;">
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.tokenRateAlertThreshold')}</label>
+                                <select id="settingsTokenRateAlertThreshold" style="width: 100%; margin-top: 5px;">
+                                    <option value="50000">50,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                    <option value="100000">100,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                    <option value="200000">200,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                    <option value="500000">500,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                    <option value="1000000">1,000,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                    <option value="2000000">2,000,000 ${t('settings.tokenRateAlertPerMin')}</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.tokenRateAlertWindow')}</label>
+                                <select id="settingsTokenRateAlertWindow" style="width: 100%; margin-top: 5px;">
+                                    <option value="30">30 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="60">60 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="300">300 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="600">600 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.tokenRateAlertCooldown')}</label>
+                                <select id="settingsTokenRateAlertCooldown" style="width: 100%; margin-top: 5px;">
+                                    <option value="5">5 ${t('settings.alertMinutes')}</option>
+                                    <option value="10">10 ${t('settings.alertMinutes')}</option>
+                                    <option value="30">30 ${t('settings.alertMinutes')}</option>
+                                    <option value="60">60 ${t('settings.alertMinutes')}</option>
+                                </select>
+                            </div>
+                            <div style="font-size: 12px; color: var(--text-secondary); display: flex; justify-content: space-between;">
+                                <span>${t('settings.tokenRateAlertCurrent')}:</span>
+                                <span id="tokenRateAlertCurrent">-</span>
+                            </div>
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.tokenRateAlertHelp')}
+                        </p>
+                    </div>
                     <div class="form-group">
                         <label>${t('settings.cacheConfig')}</label>
                         <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
@@ -1224,6 +1727,17 @@ export function initUI() {
                                     <option value="300">300 ${t('settings.rateLimitRequestsPerMin')}</option>
                                 </select>
                             </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.rateLimitPerClientIp')}</label>
+                                <select id="settingsRateLimitPerClientIp" style="width: 100%; margin-top: 5px;">
+                                    <option value="0">${t('settings.rateLimitUnlimited')}</option>
+                                    <option value="10">10 ${t('settings.rateLimitRequestsPerMin')}</option>
+                                    <option value="20">20 ${t('settings.rateLimitRequestsPerMin')}</option>
+                                    <option value="30">30 ${t('settings.rateLimitRequestsPerMin')}</option>
+                                    <option value="60">60 ${t('settings.rateLimitRequestsPerMin')}</option>
+                                    <option value="120">120 ${t('settings.rateLimitRequestsPerMin')}</option>
+                                </select>
+                            </div>
                             <div style="margin-top: 15px; padding-top: 10px; border-top: 1px solid var(--border-color);">
                                 <label style="font-size: 13px; margin-bottom: 8px; display: block;">${t('settings.rateLimitStats')}</label>
                                 <div id="rateLimitStatsDisplay" style="font-size: 12px; color: var(--text-secondary);">
@@ -1239,6 +1753,7 @@ export function initUI() {
                                         <span>${t('settings.rateLimitRejected')}:</span>
                                         <span id="rateLimitStatRejected">0</span>
                                     </div>
+                                    <div id="rateLimitRejectedIps" style="margin-bottom: 8px;"></div>
                                 </div>
                                 <button class="btn btn-secondary" style="width: 100%; padding: 6px;" onclick="window.resetRateLimitStats()">${t('settings.rateLimitReset')}</button>
                             </div>
@@ -1247,6 +1762,146 @@ export function initUI() {
                             ${t('settings.rateLimitConfigHelp')}
                         </p>
                     </div>
+                    <div class="form-group">
+                        <label>${t('settings.retryBudgetConfig')}</label>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.retryBudgetEnabled')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsRetryBudgetEnabled">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <div id="retryBudgetConfigDetails" style="display: none; padding: 10px; background: var(--bg-secondary); border-radius: 8px;">
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.retryBudgetRatio')}</label>
+                                <select id="settingsRetryBudgetRatio" style="width: 100%; margin-top: 5px;">
+                                    <option value="0.05">5%</option>
+                                    <option value="0.1">10%</option>
+                                    <option value="0.2">20%</option>
+                                    <option value="0.5">50%</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.retryBudgetMinRetries')}</label>
+                                <select id="settingsRetryBudgetMinRetries" style="width: 100%; margin-top: 5px;">
+                                    <option value="0">0</option>
+                                    <option value="5">5</option>
+                                    <option value="10">10</option>
+                                    <option value="20">20</option>
+                                    <option value="50">50</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.retryBudgetWindow')}</label>
+                                <select id="settingsRetryBudgetWindow" style="width: 100%; margin-top: 5px;">
+                                    <option value="10">10 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="30">30 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="60">60 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="300">300 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                </select>
+                            </div>
+                            <div style="margin-top: 15px; padding-top: 10px; border-top: 1px solid var(--border-color);">
+                                <label style="font-size: 13px; margin-bottom: 8px; display: block;">${t('settings.retryBudgetStats')}</label>
+                                <div style="font-size: 12px; color: var(--text-secondary);">
+                                    <div style="display: flex; justify-content: space-between; margin-bottom: 4px;">
+                                        <span>${t('settings.retryBudgetRequests')}:</span>
+                                        <span id="retryBudgetStatRequests">0</span>
+                                    </div>
+                                    <div style="display: flex; justify-content: space-between; margin-bottom: 4px;">
+                                        <span>${t('settings.retryBudgetRetries')}:</span>
+                                        <span id="retryBudgetStatRetries">0 / 0</span>
+                                    </div>
+                                    <div style="display: flex; justify-content: space-between; margin-bottom: 8px;">
+                                        <span>${t('settings.retryBudgetRejected')}:</span>
+                                        <span id="retryBudgetStatRejected">0</span>
+                                    </div>
+                                </div>
+                                <button class="btn btn-secondary" style="width: 100%; padding: 6px;" onclick="window.resetRetryBudgetStats()">${t('settings.rateLimitReset')}</button>
+                            </div>
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.retryBudgetConfigHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.autoContinueConfig')}</label>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.autoContinueEnabled')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsAutoContinueEnabled">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <div id="autoContinueConfigDetails" style="display: none; padding: 10px; background: var(--bg-secondary); border-radius: 8px;">
+                            <div>
+                                <label style="font-size: 13px;">${t('settings.autoContinueMax')}</label>
+                                <select id="settingsAutoContinueMax" style="width: 100%; margin-top: 5px;">
+                                    <option value="1">1</option>
+                                    <option value="2">2</option>
+                                    <option value="3">3</option>
+                                    <option value="5">5</option>
+                                    <option value="10">10</option>
+                                </select>
+                            </div>
+                        </div>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-top: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.autoContinueResume')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsAutoContinueResume">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.autoContinueConfigHelp')}
+                        </p>
+                    </div>
+                    <div class="form-group">
+                        <label>${t('settings.circuitBreakerConfig')}</label>
+                        <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
+                            <span style="font-size: 13px; color: var(--text-secondary);">${t('settings.circuitBreakerEnabled')}</span>
+                            <label class="toggle-switch" style="width: 40px; height: 20px; margin-top: 7px;">
+                                <input type="checkbox" id="settingsCircuitBreakerEnabled">
+                                <span class="toggle-slider" style="border-radius: 20px;"></span>
+                            </label>
+                        </div>
+                        <div id="circuitBreakerConfigDetails" style="display: none; padding: 10px; background: var(--bg-secondary); border-radius: 8px;">
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.circuitBreakerThreshold')}</label>
+                                <select id="settingsCircuitBreakerThreshold" style="width: 100%; margin-top: 5px;">
+                                    <option value="3">3</option>
+                                    <option value="5">5</option>
+                                    <option value="10">10</option>
+                                    <option value="20">20</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.circuitBreakerCooldown')}</label>
+                                <select id="settingsCircuitBreakerCooldown" style="width: 100%; margin-top: 5px;">
+                                    <option value="30">30 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="60">60 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="120">120 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="300">300 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                    <option value="600">600 ${t('settings.streamHeartbeatOptions.seconds')}</option>
+                                </select>
+                            </div>
+                            <div style="margin-bottom: 10px;">
+                                <label style="font-size: 13px;">${t('settings.circuitBreakerProbes')}</label>
+                                <select id="settingsCircuitBreakerProbes" style="width: 100%; margin-top: 5px;">
+                                    <option value="1">1</option>
+                                    <option value="2">2</option>
+                                    <option value="3">3</option>
+                                </select>
+                            </div>
+                            <div style="margin-top: 15px; padding-top: 10px; border-top: 1px solid var(--border-color);">
+                                <label style="font-size: 13px; margin-bottom: 8px; display: block;">${t('settings.circuitBreakerStatus')}</label>
+                                <div id="circuitBreakerStatusList" style="font-size: 12px; color: var(--text-secondary); margin-bottom: 8px;">${t('settings.circuitBreakerNone')}</div>
+                                <button class="btn btn-secondary" style="width: 100%; padding: 6px;" onclick="window.resetCircuitBreaker()">${t('settings.rateLimitReset')}</button>
+                            </div>
+                        </div>
+                        <p style="color: #666; font-size: 12px; margin-top: 5px;">
+                            ${t('settings.circuitBreakerConfigHelp')}
+                        </p>
+                    </div>
                     <div class="form-group">
                         <label>${t('settings.routingConfig')}</label>
                         <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
@@ -1271,6 +1926,8 @@ export function initUI() {
                                     <option value="fastest">${t('settings.loadBalanceAlgorithms.fastest')}</option>
                                     <option value="weighted">${t('settings.loadBalanceAlgorithms.weighted')}</option>
                                     <option value="round_robin">${t('settings.loadBalanceAlgorithms.roundRobin')}</option>
+                                    <option value="weighted_round_robin">${t('settings.loadBalanceAlgorithms.weightedRoundRobin')}</option>
+                                    <option value="least_connections">${t('settings.loadBalanceAlgorithms.leastConnections')}</option>
                                 </select>
                             </div>
                             <div style="display: flex; align-items: center; gap: 8px; margin-bottom: 10px;">
@@ -1428,6 +2085,14 @@ export function initUI() {
                                 📥 ${t('interactions.export')}
                             </button>
                         </div>
+                        <details class="interactions-redact">
+                            <summary>${t('interactions.redactPatterns')}</summary>
+                            <textarea id="interactionRedactPatterns" rows="4" placeholder="${t('interactions.redactPatternsPlaceholder')}"></textarea>
+                            <div class="interactions-redact-footer">
+                                <span class="form-help">${t('interactions.redactPatternsHelp')}</span>
+                                <button class="btn btn-secondary" onclick="window.saveInteractionRedactPatterns()">${t('interactions.redactPatternsSave')}</button>
+                            </div>
+                        </details>
                     </div>
                     <div class="table-container">
                   <table id="interactionsTable" class="data-table">
@@ -1484,12 +2149,17 @@ export function initUI() {
                         <button class="interaction-tab-btn" data-tab="response-transformed" onclick="window.switchDetailTab('response-transformed')">
                             ${t('interactions.responseTransformed')}
                         </button>
+                        <button class="interaction-tab-btn" data-tab="replay" onclick="window.switchDetailTab('replay')">
+                            ${t('interactions.replayResult')}
+                        </button>
                     </div>
                     <div id="interactionDetailContent" class="interaction-content">
                         <pre class="json-viewer"></pre>
                     </div>
                 </div>
                 <div class="modal-footer">
+                    <input type="text" id="interactionReplayEndpoint" placeholder="${t('interactions.replayEndpointPlaceholder')}">
+                    <button class="btn btn-secondary" id="interactionReplayBtn" onclick="window.replayInteraction()">${t('interactions.replay')}</button>
                     <button class="btn btn-primary" onclick="window.closeInteractionDetailModal()">${t('interactions.close')}</button>
                 </div>
             </div>
@@ -26,7 +26,7 @@ let currentWebDAVConfig = {
 
 let currentBackupConfig = {
   provider: "webdav",
-  local: { dir: "" },
+  local: { dir: "", keepVersions: 0 },
   s3: {
     endpoint: "",
     region: "",
@@ -179,6 +179,8 @@ async function loadDataSyncConfig() {
       provider: backupCfg.provider || "webdav",
       local: {
         dir: backupCfg.local && backupCfg.local.dir ? backupCfg.local.dir : "",
+        keepVersions:
+          backupCfg.local && backupCfg.local.keepVersions ? backupCfg.local.keepVersions : 0,
       },
       s3: {
         endpoint:
@@ -292,6 +294,11 @@ function renderLocalTab() {
                     <button class="btn btn-secondary" onclick="window.selectBackupLocalDir()">📁 ${t('backup.local.chooseDir')}</button>
                 </div>
             </div>
+            <div class="form-group">
+                <label>${t('backup.local.keepVersions')}</label>
+                <input type="number" id="backupLocalKeepVersions" class="form-input" min="0" value="${currentBackupConfig.local.keepVersions || 0}">
+                <p class="form-help">${t('backup.local.keepVersionsHelp')}</p>
+            </div>
         </div>
         <div class="data-sync-actions" style="display: flex; gap: 10px;">
             <button class="btn btn-secondary" style="flex: 1;" onclick="window.saveLocalBackupConfig()">💾 ${t('backup.saveConfig')}</button>
@@ -488,9 +495,12 @@ window.saveLocalBackupConfig = async function () {
     showNotification(t("backup.local.dirRequired"), "error");
     return;
   }
+  const keepVersions = parseInt(document.getElementById("backupLocalKeepVersions")?.value, 10) || 0;
   try {
     await window.go.main.App.UpdateLocalBackupDir(dir);
     currentBackupConfig.local.dir = dir;
+    await window.go.main.App.UpdateLocalBackupKeepVersions(Math.max(0, keepVersions));
+    currentBackupConfig.local.keepVersions = Math.max(0, keepVersions);
     showNotification(t("backup.configSaved"), "success");
   } catch (error) {
     showNotification(translateError(error), "error");
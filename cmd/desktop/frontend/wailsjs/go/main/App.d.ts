@@ -1,18 +1,31 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {service} from '../models';
 
-export function AddEndpoint(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:string,arg9:string,arg10:number,arg11:number,arg12:number,arg13:string,arg14:number):Promise<void>;
+export function AddEndpoint(arg1:string,arg2:service.EndpointInput):Promise<void>;
+
+export function AutoSortEndpointsByLatency(arg1:string):Promise<void>;
 
 export function BackupToProvider(arg1:string,arg2:string):Promise<void>;
 
 export function BackupToWebDAV(arg1:string):Promise<void>;
 
+export function CheckSLANow():Promise<string>;
+
 export function CleanupInteractions(arg1:number):Promise<string>;
 
 export function ClearCache():Promise<void>;
 
+export function ClearClientRoutingConfig(arg1:string):Promise<void>;
+
+export function ClearDedupCache():Promise<void>;
+
+export function ClearIdempotencyCache():Promise<void>;
+
 export function ClearLogs():Promise<void>;
 
+export function CloneEndpoint(arg1:string,arg2:number,arg3:string):Promise<void>;
+
 export function DeleteArchive(arg1:string):Promise<string>;
 
 export function DeleteBackups(arg1:string,arg2:Array<string>):Promise<void>;
@@ -23,12 +36,18 @@ export function DetectBackupConflict(arg1:string,arg2:string):Promise<string>;
 
 export function DetectWebDAVConflict(arg1:string):Promise<string>;
 
+export function ExplainRouting(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function ExportAllEndpoints(arg1:boolean):Promise<string>;
 
 export function ExportEndpoints(arg1:string,arg2:boolean):Promise<string>;
 
+export function ExportFullConfig(arg1:boolean):Promise<string>;
+
 export function ExportInteractions(arg1:string):Promise<string>;
 
+export function ExportStatsCSV(arg1:string):Promise<string>;
+
 export function FetchBroadcast(arg1:string):Promise<string>;
 
 export function FetchImageAsBase64(arg1:string):Promise<string>;
@@ -47,10 +66,14 @@ export function GetArchiveData(arg1:string):Promise<string>;
 
 export function GetArchiveTrend(arg1:string):Promise<string>;
 
+export function GetAutoContinueConfig():Promise<string>;
+
 export function GetAutoDarkTheme():Promise<string>;
 
 export function GetAutoLightTheme():Promise<string>;
 
+export function GetAutoOptimizeInterval():Promise<number>;
+
 export function GetAutoThemeMode():Promise<string>;
 
 export function GetCacheConfig():Promise<string>;
@@ -59,8 +82,18 @@ export function GetCacheStats():Promise<string>;
 
 export function GetChangelog(arg1:string):Promise<string>;
 
+export function GetCircuitBreakerConfig():Promise<string>;
+
+export function GetCircuitBreakerStatus():Promise<string>;
+
+export function GetClientRoutingConfigs():Promise<string>;
+
+export function GetConcurrencyStats():Promise<string>;
+
 export function GetConfig():Promise<string>;
 
+export function GetConfigAuditLog(arg1:number,arg2:number):Promise<string>;
+
 export function GetConnectedClients(arg1:number):Promise<string>;
 
 export function GetCostByPeriod(arg1:string):Promise<string>;
@@ -75,44 +108,84 @@ export function GetCostWeekly():Promise<string>;
 
 export function GetCostYesterday():Promise<string>;
 
+export function GetCrossClientFallbackConfig():Promise<string>;
+
 export function GetCurrentEndpoint(arg1:string):Promise<string>;
 
 export function GetDailyRequestDetails(arg1:number,arg2:number):Promise<string>;
 
+export function GetDashboardConfig():Promise<string>;
+
+export function GetDedupConfig():Promise<string>;
+
+export function GetDedupStats():Promise<string>;
+
+export function GetDeviceStats(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
+export function GetEnableHTTP2():Promise<boolean>;
+
 export function GetEndpointCheckResults():Promise<string>;
 
 export function GetEndpointHealth():Promise<string>;
 
 export function GetEndpointMetrics():Promise<string>;
 
+export function GetEndpointReliabilityRanking(arg1:string):Promise<string>;
+
+export function GetErrorBreakdown(arg1:string):Promise<string>;
+
+export function GetFailoverStatus():Promise<string>;
+
 export function GetHealthCheckInterval():Promise<number>;
 
+export function GetHealthCheckMethod():Promise<string>;
+
 export function GetHealthHistory(arg1:string,arg2:string,arg3:number):Promise<Array<Record<string, any>>>;
 
 export function GetHealthHistoryRetentionDays():Promise<number>;
 
+export function GetHealthHistorySummary(arg1:string,arg2:string,arg3:number,arg4:number):Promise<Array<Record<string, any>>>;
+
+export function GetIdempotencyConfig():Promise<string>;
+
+export function GetIdempotencyStats():Promise<string>;
+
 export function GetInteractionDates():Promise<string>;
 
 export function GetInteractionDetail(arg1:string,arg2:string):Promise<string>;
 
 export function GetInteractionEnabled():Promise<string>;
 
+export function GetInteractionRedactPatterns():Promise<string>;
+
+export function GetInteractionRetentionDays():Promise<number>;
+
 export function GetInteractionStoragePath():Promise<string>;
 
 export function GetInteractions(arg1:string):Promise<string>;
 
 export function GetLanguage():Promise<string>;
 
+export function GetLastTestResult(arg1:string,arg2:number):Promise<string>;
+
 export function GetLogLevel():Promise<number>;
 
 export function GetLogs():Promise<string>;
 
 export function GetLogsByLevel(arg1:number):Promise<string>;
 
+export function GetMaxRequestBodyBytes():Promise<number>;
+
+export function GetModelStats(arg1:string):Promise<string>;
+
 export function GetMonitorSnapshot():Promise<string>;
 
+export function GetPerEndpointLogging():Promise<boolean>;
+
 export function GetPerformanceStats(arg1:string):Promise<string>;
 
+export function GetPinnedEndpoints():Promise<string>;
+
 export function GetPricingInfo():Promise<string>;
 
 export function GetProxyURL():Promise<string>;
@@ -131,39 +204,67 @@ export function GetRecentRequestsByEndpoint(arg1:string,arg2:string,arg3:number)
 
 export function GetRequestTimeout():Promise<number>;
 
+export function GetRetryBudgetConfig():Promise<string>;
+
+export function GetRetryBudgetStats():Promise<string>;
+
 export function GetRoutingConfig():Promise<string>;
 
+export function GetSLAConfig():Promise<string>;
+
+export function GetSLAStatus():Promise<string>;
+
+export function GetSLAViolations(arg1:number):Promise<string>;
+
 export function GetSessionAffinityConfig():Promise<string>;
 
 export function GetSessionStats():Promise<string>;
 
 export function GetStats():Promise<string>;
 
+export function GetStatsByPeriod(arg1:string,arg2:boolean):Promise<string>;
+
 export function GetStatsDaily():Promise<string>;
 
 export function GetStatsMonthly():Promise<string>;
 
+export function GetStatsTimezone():Promise<string>;
+
 export function GetStatsTrend():Promise<string>;
 
 export function GetStatsTrendByPeriod(arg1:string):Promise<string>;
 
+export function GetStatsTrendByRange(arg1:string,arg2:string):Promise<string>;
+
 export function GetStatsWeekly():Promise<string>;
 
 export function GetStatsYesterday():Promise<string>;
 
+export function GetStreamFirstByteTimeout():Promise<number>;
+
+export function GetStreamHeartbeatInterval():Promise<number>;
+
 export function GetSystemLanguage():Promise<string>;
 
 export function GetTheme():Promise<string>;
 
 export function GetThemeAuto():Promise<boolean>;
 
+export function GetTokenRateAlertConfig():Promise<string>;
+
+export function GetTokenRateStatus():Promise<string>;
+
 export function GetTokenTrendData(arg1:string,arg2:string,arg3:string,arg4:string):Promise<string>;
 
 export function GetVersion():Promise<string>;
 
 export function HideWindow():Promise<void>;
 
-export function ImportEndpoints(arg1:string,arg2:string):Promise<string>;
+export function ImportEndpoints(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
+export function ImportFullConfig(arg1:string,arg2:string):Promise<string>;
+
+export function ImportStatsFromURL(arg1:string,arg2:string):Promise<void>;
 
 export function ListArchives():Promise<string>;
 
@@ -173,50 +274,96 @@ export function ListWebDAVBackups():Promise<string>;
 
 export function OpenURL(arg1:string):Promise<void>;
 
+export function PinEndpoint(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function PreflightCheck(arg1:string,arg2:number):Promise<string>;
+
+export function PreviewImport(arg1:string,arg2:string,arg3:boolean):Promise<string>;
+
 export function Quit():Promise<void>;
 
 export function RemoveEndpoint(arg1:string,arg2:number):Promise<void>;
 
 export function ReorderEndpoints(arg1:string,arg2:Array<string>):Promise<void>;
 
+export function ReplayRequest(arg1:string,arg2:string):Promise<string>;
+
+export function ResetCircuitBreaker():Promise<void>;
+
 export function ResetMonitorMetrics():Promise<void>;
 
 export function ResetQuota(arg1:string,arg2:string):Promise<void>;
 
 export function ResetRateLimitStats():Promise<void>;
 
+export function ResetRetryBudgetStats():Promise<void>;
+
 export function RestoreFromProvider(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function RestoreFromWebDAV(arg1:string,arg2:string):Promise<void>;
 
-export function SetAlertConfig(arg1:boolean,arg2:number,arg3:boolean,arg4:boolean,arg5:number,arg6:boolean,arg7:number,arg8:number,arg9:boolean,arg10:number):Promise<void>;
+export function SearchRequestStats(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number,arg6:number):Promise<string>;
+
+export function SetAlertConfig(arg1:boolean,arg2:number,arg3:boolean,arg4:boolean,arg5:number,arg6:boolean,arg7:number,arg8:number,arg9:boolean,arg10:number,arg11:number,arg12:string,arg13:string):Promise<void>;
+
+export function SetAutoContinueConfig(arg1:boolean,arg2:number,arg3:boolean):Promise<void>;
 
 export function SetAutoDarkTheme(arg1:string):Promise<void>;
 
 export function SetAutoLightTheme(arg1:string):Promise<void>;
 
+export function SetAutoOptimizeInterval(arg1:number):Promise<void>;
+
 export function SetAutoThemeMode(arg1:string):Promise<void>;
 
 export function SetCacheConfig(arg1:boolean,arg2:number,arg3:number):Promise<void>;
 
+export function SetCircuitBreakerConfig(arg1:boolean,arg2:number,arg3:number,arg4:number):Promise<void>;
+
 export function SetCloseWindowBehavior(arg1:string):Promise<void>;
 
+export function SetDedupConfig(arg1:boolean,arg2:number):Promise<void>;
+
+export function SetEnableHTTP2(arg1:boolean):Promise<void>;
+
+export function SetEndpointMaintenance(arg1:string,arg2:number,arg3:boolean):Promise<void>;
+
 export function SetHealthCheckInterval(arg1:number):Promise<void>;
 
+export function SetHealthCheckMethod(arg1:string):Promise<void>;
+
 export function SetHealthHistoryRetentionDays(arg1:number):Promise<void>;
 
+export function SetIdempotencyConfig(arg1:boolean,arg2:number):Promise<void>;
+
 export function SetInteractionEnabled(arg1:boolean):Promise<string>;
 
+export function SetInteractionRedactPatterns(arg1:Array<string>):Promise<string>;
+
+export function SetInteractionRetentionDays(arg1:number):Promise<void>;
+
 export function SetLanguage(arg1:string):Promise<void>;
 
 export function SetLogLevel(arg1:number):Promise<void>;
 
+export function SetMaxRequestBodyBytes(arg1:number):Promise<void>;
+
+export function SetPerEndpointLogging(arg1:boolean):Promise<void>;
+
 export function SetProxyURL(arg1:string):Promise<void>;
 
-export function SetRateLimitConfig(arg1:boolean,arg2:number,arg3:number):Promise<void>;
+export function SetRateLimitConfig(arg1:boolean,arg2:number,arg3:number,arg4:number):Promise<void>;
 
 export function SetRequestTimeout(arg1:number):Promise<void>;
 
+export function SetRetryBudgetConfig(arg1:boolean,arg2:number,arg3:number,arg4:number):Promise<void>;
+
+export function SetStatsTimezone(arg1:string):Promise<void>;
+
+export function SetStreamFirstByteTimeout(arg1:number):Promise<void>;
+
+export function SetStreamHeartbeatInterval(arg1:number):Promise<void>;
+
 export function SetTheme(arg1:string):Promise<void>;
 
 export function SetThemeAuto(arg1:boolean):Promise<void>;
@@ -225,36 +372,58 @@ export function ShowWindow():Promise<void>;
 
 export function SwitchToEndpoint(arg1:string,arg2:string):Promise<void>;
 
+export function TestAlertWebhook(arg1:string,arg2:string):Promise<string>;
+
 export function TestAllEndpointsAndOptimize(arg1:string):Promise<string>;
 
 export function TestAllEndpointsZeroCost(arg1:string):Promise<string>;
 
-export function TestEndpoint(arg1:string,arg2:number):Promise<string>;
+export function TestEndpoint(arg1:string,arg2:number,arg3:string,arg4:number):Promise<string>;
 
 export function TestEndpointLight(arg1:string,arg2:number):Promise<string>;
 
+export function TestProxyURL(arg1:string):Promise<string>;
+
 export function TestS3Connection(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:boolean,arg9:boolean):Promise<string>;
 
 export function TestWebDAVConnection(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function ToggleEndpoint(arg1:string,arg2:number,arg3:boolean):Promise<void>;
 
+export function ToggleEndpointsByTag(arg1:string,arg2:string,arg3:boolean):Promise<number>;
+
 export function UnbindSession(arg1:string):Promise<void>;
 
+export function UnpinEndpoint(arg1:string):Promise<void>;
+
 export function UpdateBackupProvider(arg1:string):Promise<void>;
 
+export function UpdateClientPorts(arg1:Record<string, number>):Promise<void>;
+
+export function UpdateClientRoutingConfig(arg1:string,arg2:boolean,arg3:boolean,arg4:boolean,arg5:boolean,arg6:string):Promise<void>;
+
 export function UpdateConfig(arg1:string):Promise<void>;
 
-export function UpdateEndpoint(arg1:string,arg2:number,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:string,arg9:string,arg10:string,arg11:number,arg12:number,arg13:number,arg14:string,arg15:number):Promise<void>;
+export function UpdateCrossClientFallbackConfig(arg1:boolean,arg2:Array<string>):Promise<void>;
+
+export function UpdateDashboardConfig(arg1:Array<string>,arg2:string):Promise<void>;
+
+export function UpdateEndpoint(arg1:string,arg2:number,arg3:service.EndpointInput):Promise<void>;
 
 export function UpdateLocalBackupDir(arg1:string):Promise<void>;
 
+export function UpdateLocalBackupKeepVersions(arg1:number):Promise<void>;
+
 export function UpdatePort(arg1:number):Promise<void>;
 
 export function UpdateRoutingConfig(arg1:boolean,arg2:boolean,arg3:boolean,arg4:boolean,arg5:string):Promise<void>;
 
 export function UpdateS3BackupConfig(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:string,arg7:string,arg8:boolean,arg9:boolean):Promise<void>;
 
-export function UpdateSessionAffinityConfig(arg1:boolean,arg2:number,arg3:number):Promise<void>;
+export function UpdateSLAConfig(arg1:boolean,arg2:number,arg3:number,arg4:number,arg5:number):Promise<void>;
+
+export function UpdateSessionAffinityConfig(arg1:boolean,arg2:number,arg3:number,arg4:string,arg5:string):Promise<void>;
+
+export function UpdateTokenRateAlertConfig(arg1:boolean,arg2:number,arg3:number,arg4:number):Promise<void>;
 
 export function UpdateWebDAVConfig(arg1:string,arg2:string,arg3:string):Promise<void>;
@@ -2,8 +2,12 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function AddEndpoint(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14) {
-  return window['go']['main']['App']['AddEndpoint'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14);
+export function AddEndpoint(arg1, arg2) {
+  return window['go']['main']['App']['AddEndpoint'](arg1, arg2);
+}
+
+export function AutoSortEndpointsByLatency(arg1) {
+  return window['go']['main']['App']['AutoSortEndpointsByLatency'](arg1);
 }
 
 export function BackupToProvider(arg1, arg2) {
@@ -14,6 +18,10 @@ export function BackupToWebDAV(arg1) {
   return window['go']['main']['App']['BackupToWebDAV'](arg1);
 }
 
+export function CheckSLANow() {
+  return window['go']['main']['App']['CheckSLANow']();
+}
+
 export function CleanupInteractions(arg1) {
   return window['go']['main']['App']['CleanupInteractions'](arg1);
 }
@@ -22,10 +30,26 @@ export function ClearCache() {
   return window['go']['main']['App']['ClearCache']();
 }
 
+export function ClearClientRoutingConfig(arg1) {
+  return window['go']['main']['App']['ClearClientRoutingConfig'](arg1);
+}
+
+export function ClearDedupCache() {
+  return window['go']['main']['App']['ClearDedupCache']();
+}
+
+export function ClearIdempotencyCache() {
+  return window['go']['main']['App']['ClearIdempotencyCache']();
+}
+
 export function ClearLogs() {
   return window['go']['main']['App']['ClearLogs']();
 }
 
+export function CloneEndpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CloneEndpoint'](arg1, arg2, arg3);
+}
+
 export function DeleteArchive(arg1) {
   return window['go']['main']['App']['DeleteArchive'](arg1);
 }
@@ -46,6 +70,10 @@ export function DetectWebDAVConflict(arg1) {
   return window['go']['main']['App']['DetectWebDAVConflict'](arg1);
 }
 
+export function ExplainRouting(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExplainRouting'](arg1, arg2, arg3);
+}
+
 export function ExportAllEndpoints(arg1) {
   return window['go']['main']['App']['ExportAllEndpoints'](arg1);
 }
@@ -54,10 +82,18 @@ export function ExportEndpoints(arg1, arg2) {
   return window['go']['main']['App']['ExportEndpoints'](arg1, arg2);
 }
 
+export function ExportFullConfig(arg1) {
+  return window['go']['main']['App']['ExportFullConfig'](arg1);
+}
+
 export function ExportInteractions(arg1) {
   return window['go']['main']['App']['ExportInteractions'](arg1);
 }
 
+export function ExportStatsCSV(arg1) {
+  return window['go']['main']['App']['ExportStatsCSV'](arg1);
+}
+
 export function FetchBroadcast(arg1) {
   return window['go']['main']['App']['FetchBroadcast'](arg1);
 }
@@ -94,6 +130,10 @@ export function GetArchiveTrend(arg1) {
   return window['go']['main']['App']['GetArchiveTrend'](arg1);
 }
 
+export function GetAutoContinueConfig() {
+  return window['go']['main']['App']['GetAutoContinueConfig']();
+}
+
 export function GetAutoDarkTheme() {
   return window['go']['main']['App']['GetAutoDarkTheme']();
 }
@@ -102,6 +142,10 @@ export function GetAutoLightTheme() {
   return window['go']['main']['App']['GetAutoLightTheme']();
 }
 
+export function GetAutoOptimizeInterval() {
+  return window['go']['main']['App']['GetAutoOptimizeInterval']();
+}
+
 export function GetAutoThemeMode() {
   return window['go']['main']['App']['GetAutoThemeMode']();
 }
@@ -118,10 +162,30 @@ export function GetChangelog(arg1) {
   return window['go']['main']['App']['GetChangelog'](arg1);
 }
 
+export function GetCircuitBreakerConfig() {
+  return window['go']['main']['App']['GetCircuitBreakerConfig']();
+}
+
+export function GetCircuitBreakerStatus() {
+  return window['go']['main']['App']['GetCircuitBreakerStatus']();
+}
+
+export function GetClientRoutingConfigs() {
+  return window['go']['main']['App']['GetClientRoutingConfigs']();
+}
+
+export function GetConcurrencyStats() {
+  return window['go']['main']['App']['GetConcurrencyStats']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
 
+export function GetConfigAuditLog(arg1, arg2) {
+  return window['go']['main']['App']['GetConfigAuditLog'](arg1, arg2);
+}
+
 export function GetConnectedClients(arg1) {
   return window['go']['main']['App']['GetConnectedClients'](arg1);
 }
@@ -150,6 +214,10 @@ export function GetCostYesterday() {
   return window['go']['main']['App']['GetCostYesterday']();
 }
 
+export function GetCrossClientFallbackConfig() {
+  return window['go']['main']['App']['GetCrossClientFallbackConfig']();
+}
+
 export function GetCurrentEndpoint(arg1) {
   return window['go']['main']['App']['GetCurrentEndpoint'](arg1);
 }
@@ -158,6 +226,26 @@ export function GetDailyRequestDetails(arg1, arg2) {
   return window['go']['main']['App']['GetDailyRequestDetails'](arg1, arg2);
 }
 
+export function GetDashboardConfig() {
+  return window['go']['main']['App']['GetDashboardConfig']();
+}
+
+export function GetDedupConfig() {
+  return window['go']['main']['App']['GetDedupConfig']();
+}
+
+export function GetDedupStats() {
+  return window['go']['main']['App']['GetDedupStats']();
+}
+
+export function GetDeviceStats(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetDeviceStats'](arg1, arg2, arg3);
+}
+
+export function GetEnableHTTP2() {
+  return window['go']['main']['App']['GetEnableHTTP2']();
+}
+
 export function GetEndpointCheckResults() {
   return window['go']['main']['App']['GetEndpointCheckResults']();
 }
@@ -170,10 +258,26 @@ export function GetEndpointMetrics() {
   return window['go']['main']['App']['GetEndpointMetrics']();
 }
 
+export function GetEndpointReliabilityRanking(arg1) {
+  return window['go']['main']['App']['GetEndpointReliabilityRanking'](arg1);
+}
+
+export function GetErrorBreakdown(arg1) {
+  return window['go']['main']['App']['GetErrorBreakdown'](arg1);
+}
+
+export function GetFailoverStatus() {
+  return window['go']['main']['App']['GetFailoverStatus']();
+}
+
 export function GetHealthCheckInterval() {
   return window['go']['main']['App']['GetHealthCheckInterval']();
 }
 
+export function GetHealthCheckMethod() {
+  return window['go']['main']['App']['GetHealthCheckMethod']();
+}
+
 export function GetHealthHistory(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetHealthHistory'](arg1, arg2, arg3);
 }
@@ -182,6 +286,18 @@ export function GetHealthHistoryRetentionDays() {
   return window['go']['main']['App']['GetHealthHistoryRetentionDays']();
 }
 
+export function GetHealthHistorySummary(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetHealthHistorySummary'](arg1, arg2, arg3, arg4);
+}
+
+export function GetIdempotencyConfig() {
+  return window['go']['main']['App']['GetIdempotencyConfig']();
+}
+
+export function GetIdempotencyStats() {
+  return window['go']['main']['App']['GetIdempotencyStats']();
+}
+
 export function GetInteractionDates() {
   return window['go']['main']['App']['GetInteractionDates']();
 }
@@ -194,6 +310,14 @@ export function GetInteractionEnabled() {
   return window['go']['main']['App']['GetInteractionEnabled']();
 }
 
+export function GetInteractionRedactPatterns() {
+  return window['go']['main']['App']['GetInteractionRedactPatterns']();
+}
+
+export function GetInteractionRetentionDays() {
+  return window['go']['main']['App']['GetInteractionRetentionDays']();
+}
+
 export function GetInteractionStoragePath() {
   return window['go']['main']['App']['GetInteractionStoragePath']();
 }
@@ -206,6 +330,10 @@ export function GetLanguage() {
   return window['go']['main']['App']['GetLanguage']();
 }
 
+export function GetLastTestResult(arg1, arg2) {
+  return window['go']['main']['App']['GetLastTestResult'](arg1, arg2);
+}
+
 export function GetLogLevel() {
   return window['go']['main']['App']['GetLogLevel']();
 }
@@ -218,14 +346,30 @@ export function GetLogsByLevel(arg1) {
   return window['go']['main']['App']['GetLogsByLevel'](arg1);
 }
 
+export function GetMaxRequestBodyBytes() {
+  return window['go']['main']['App']['GetMaxRequestBodyBytes']();
+}
+
+export function GetModelStats(arg1) {
+  return window['go']['main']['App']['GetModelStats'](arg1);
+}
+
 export function GetMonitorSnapshot() {
   return window['go']['main']['App']['GetMonitorSnapshot']();
 }
 
+export function GetPerEndpointLogging() {
+  return window['go']['main']['App']['GetPerEndpointLogging']();
+}
+
 export function GetPerformanceStats(arg1) {
   return window['go']['main']['App']['GetPerformanceStats'](arg1);
 }
 
+export function GetPinnedEndpoints() {
+  return window['go']['main']['App']['GetPinnedEndpoints']();
+}
+
 export function GetPricingInfo() {
   return window['go']['main']['App']['GetPricingInfo']();
 }
@@ -262,10 +406,30 @@ export function GetRequestTimeout() {
   return window['go']['main']['App']['GetRequestTimeout']();
 }
 
+export function GetRetryBudgetConfig() {
+  return window['go']['main']['App']['GetRetryBudgetConfig']();
+}
+
+export function GetRetryBudgetStats() {
+  return window['go']['main']['App']['GetRetryBudgetStats']();
+}
+
 export function GetRoutingConfig() {
   return window['go']['main']['App']['GetRoutingConfig']();
 }
 
+export function GetSLAConfig() {
+  return window['go']['main']['App']['GetSLAConfig']();
+}
+
+export function GetSLAStatus() {
+  return window['go']['main']['App']['GetSLAStatus']();
+}
+
+export function GetSLAViolations(arg1) {
+  return window['go']['main']['App']['GetSLAViolations'](arg1);
+}
+
 export function GetSessionAffinityConfig() {
   return window['go']['main']['App']['GetSessionAffinityConfig']();
 }
@@ -278,6 +442,10 @@ export function GetStats() {
   return window['go']['main']['App']['GetStats']();
 }
 
+export function GetStatsByPeriod(arg1, arg2) {
+  return window['go']['main']['App']['GetStatsByPeriod'](arg1, arg2);
+}
+
 export function GetStatsDaily() {
   return window['go']['main']['App']['GetStatsDaily']();
 }
@@ -286,6 +454,10 @@ export function GetStatsMonthly() {
   return window['go']['main']['App']['GetStatsMonthly']();
 }
 
+export function GetStatsTimezone() {
+  return window['go']['main']['App']['GetStatsTimezone']();
+}
+
 export function GetStatsTrend() {
   return window['go']['main']['App']['GetStatsTrend']();
 }
@@ -294,6 +466,10 @@ export function GetStatsTrendByPeriod(arg1) {
   return window['go']['main']['App']['GetStatsTrendByPeriod'](arg1);
 }
 
+export function GetStatsTrendByRange(arg1, arg2) {
+  return window['go']['main']['App']['GetStatsTrendByRange'](arg1, arg2);
+}
+
 export function GetStatsWeekly() {
   return window['go']['main']['App']['GetStatsWeekly']();
 }
@@ -302,6 +478,14 @@ export function GetStatsYesterday() {
   return window['go']['main']['App']['GetStatsYesterday']();
 }
 
+export function GetStreamFirstByteTimeout() {
+  return window['go']['main']['App']['GetStreamFirstByteTimeout']();
+}
+
+export function GetStreamHeartbeatInterval() {
+  return window['go']['main']['App']['GetStreamHeartbeatInterval']();
+}
+
 export function GetSystemLanguage() {
   return window['go']['main']['App']['GetSystemLanguage']();
 }
@@ -314,6 +498,14 @@ export function GetThemeAuto() {
   return window['go']['main']['App']['GetThemeAuto']();
 }
 
+export function GetTokenRateAlertConfig() {
+  return window['go']['main']['App']['GetTokenRateAlertConfig']();
+}
+
+export function GetTokenRateStatus() {
+  return window['go']['main']['App']['GetTokenRateStatus']();
+}
+
 export function GetTokenTrendData(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GetTokenTrendData'](arg1, arg2, arg3, arg4);
 }
@@ -326,8 +518,16 @@ export function HideWindow() {
   return window['go']['main']['App']['HideWindow']();
 }
 
-export function ImportEndpoints(arg1, arg2) {
-  return window['go']['main']['App']['ImportEndpoints'](arg1, arg2);
+export function ImportEndpoints(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportEndpoints'](arg1, arg2, arg3);
+}
+
+export function ImportFullConfig(arg1, arg2) {
+  return window['go']['main']['App']['ImportFullConfig'](arg1, arg2);
+}
+
+export function ImportStatsFromURL(arg1, arg2) {
+  return window['go']['main']['App']['ImportStatsFromURL'](arg1, arg2);
 }
 
 export function ListArchives() {
@@ -346,6 +546,18 @@ export function OpenURL(arg1) {
   return window['go']['main']['App']['OpenURL'](arg1);
 }
 
+export function PinEndpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PinEndpoint'](arg1, arg2, arg3);
+}
+
+export function PreflightCheck(arg1, arg2) {
+  return window['go']['main']['App']['PreflightCheck'](arg1, arg2);
+}
+
+export function PreviewImport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewImport'](arg1, arg2, arg3);
+}
+
 export function Quit() {
   return window['go']['main']['App']['Quit']();
 }
@@ -358,6 +570,14 @@ export function ReorderEndpoints(arg1, arg2) {
   return window['go']['main']['App']['ReorderEndpoints'](arg1, arg2);
 }
 
+export function ReplayRequest(arg1, arg2) {
+  return window['go']['main']['App']['ReplayRequest'](arg1, arg2);
+}
+
+export function ResetCircuitBreaker() {
+  return window['go']['main']['App']['ResetCircuitBreaker']();
+}
+
 export function ResetMonitorMetrics() {
   return window['go']['main']['App']['ResetMonitorMetrics']();
 }
@@ -370,6 +590,10 @@ export function ResetRateLimitStats() {
   return window['go']['main']['App']['ResetRateLimitStats']();
 }
 
+export function ResetRetryBudgetStats() {
+  return window['go']['main']['App']['ResetRetryBudgetStats']();
+}
+
 export function RestoreFromProvider(arg1, arg2, arg3) {
   return window['go']['main']['App']['RestoreFromProvider'](arg1, arg2, arg3);
 }
@@ -378,8 +602,16 @@ export function RestoreFromWebDAV(arg1, arg2) {
   return window['go']['main']['App']['RestoreFromWebDAV'](arg1, arg2);
 }
 
-export function SetAlertConfig(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10) {
-  return window['go']['main']['App']['SetAlertConfig'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10);
+export function SearchRequestStats(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['SearchRequestStats'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function SetAlertConfig(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13) {
+  return window['go']['main']['App']['SetAlertConfig'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13);
+}
+
+export function SetAutoContinueConfig(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetAutoContinueConfig'](arg1, arg2, arg3);
 }
 
 export function SetAutoDarkTheme(arg1) {
@@ -390,6 +622,10 @@ export function SetAutoLightTheme(arg1) {
   return window['go']['main']['App']['SetAutoLightTheme'](arg1);
 }
 
+export function SetAutoOptimizeInterval(arg1) {
+  return window['go']['main']['App']['SetAutoOptimizeInterval'](arg1);
+}
+
 export function SetAutoThemeMode(arg1) {
   return window['go']['main']['App']['SetAutoThemeMode'](arg1);
 }
@@ -398,22 +634,54 @@ export function SetCacheConfig(arg1, arg2, arg3) {
   return window['go']['main']['App']['SetCacheConfig'](arg1, arg2, arg3);
 }
 
+export function SetCircuitBreakerConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetCircuitBreakerConfig'](arg1, arg2, arg3, arg4);
+}
+
 export function SetCloseWindowBehavior(arg1) {
   return window['go']['main']['App']['SetCloseWindowBehavior'](arg1);
 }
 
+export function SetDedupConfig(arg1, arg2) {
+  return window['go']['main']['App']['SetDedupConfig'](arg1, arg2);
+}
+
+export function SetEnableHTTP2(arg1) {
+  return window['go']['main']['App']['SetEnableHTTP2'](arg1);
+}
+
+export function SetEndpointMaintenance(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetEndpointMaintenance'](arg1, arg2, arg3);
+}
+
 export function SetHealthCheckInterval(arg1) {
   return window['go']['main']['App']['SetHealthCheckInterval'](arg1);
 }
 
+export function SetHealthCheckMethod(arg1) {
+  return window['go']['main']['App']['SetHealthCheckMethod'](arg1);
+}
+
 export function SetHealthHistoryRetentionDays(arg1) {
   return window['go']['main']['App']['SetHealthHistoryRetentionDays'](arg1);
 }
 
+export function SetIdempotencyConfig(arg1, arg2) {
+  return window['go']['main']['App']['SetIdempotencyConfig'](arg1, arg2);
+}
+
 export function SetInteractionEnabled(arg1) {
   return window['go']['main']['App']['SetInteractionEnabled'](arg1);
 }
 
+export function SetInteractionRedactPatterns(arg1) {
+  return window['go']['main']['App']['SetInteractionRedactPatterns'](arg1);
+}
+
+export function SetInteractionRetentionDays(arg1) {
+  return window['go']['main']['App']['SetInteractionRetentionDays'](arg1);
+}
+
 export function SetLanguage(arg1) {
   return window['go']['main']['App']['SetLanguage'](arg1);
 }
@@ -422,18 +690,42 @@ export function SetLogLevel(arg1) {
   return window['go']['main']['App']['SetLogLevel'](arg1);
 }
 
+export function SetMaxRequestBodyBytes(arg1) {
+  return window['go']['main']['App']['SetMaxRequestBodyBytes'](arg1);
+}
+
+export function SetPerEndpointLogging(arg1) {
+  return window['go']['main']['App']['SetPerEndpointLogging'](arg1);
+}
+
 export function SetProxyURL(arg1) {
   return window['go']['main']['App']['SetProxyURL'](arg1);
 }
 
-export function SetRateLimitConfig(arg1, arg2, arg3) {
-  return window['go']['main']['App']['SetRateLimitConfig'](arg1, arg2, arg3);
+export function SetRateLimitConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetRateLimitConfig'](arg1, arg2, arg3, arg4);
 }
 
 export function SetRequestTimeout(arg1) {
   return window['go']['main']['App']['SetRequestTimeout'](arg1);
 }
 
+export function SetRetryBudgetConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetRetryBudgetConfig'](arg1, arg2, arg3, arg4);
+}
+
+export function SetStatsTimezone(arg1) {
+  return window['go']['main']['App']['SetStatsTimezone'](arg1);
+}
+
+export function SetStreamFirstByteTimeout(arg1) {
+  return window['go']['main']['App']['SetStreamFirstByteTimeout'](arg1);
+}
+
+export function SetStreamHeartbeatInterval(arg1) {
+  return window['go']['main']['App']['SetStreamHeartbeatInterval'](arg1);
+}
+
 export function SetTheme(arg1) {
   return window['go']['main']['App']['SetTheme'](arg1);
 }
@@ -450,6 +742,10 @@ export function SwitchToEndpoint(arg1, arg2) {
   return window['go']['main']['App']['SwitchToEndpoint'](arg1, arg2);
 }
 
+export function TestAlertWebhook(arg1, arg2) {
+  return window['go']['main']['App']['TestAlertWebhook'](arg1, arg2);
+}
+
 export function TestAllEndpointsAndOptimize(arg1) {
   return window['go']['main']['App']['TestAllEndpointsAndOptimize'](arg1);
 }
@@ -458,14 +754,18 @@ export function TestAllEndpointsZeroCost(arg1) {
   return window['go']['main']['App']['TestAllEndpointsZeroCost'](arg1);
 }
 
-export function TestEndpoint(arg1, arg2) {
-  return window['go']['main']['App']['TestEndpoint'](arg1, arg2);
+export function TestEndpoint(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['TestEndpoint'](arg1, arg2, arg3, arg4);
 }
 
 export function TestEndpointLight(arg1, arg2) {
   return window['go']['main']['App']['TestEndpointLight'](arg1, arg2);
 }
 
+export function TestProxyURL(arg1) {
+  return window['go']['main']['App']['TestProxyURL'](arg1);
+}
+
 export function TestS3Connection(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9) {
   return window['go']['main']['App']['TestS3Connection'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
 }
@@ -478,26 +778,54 @@ export function ToggleEndpoint(arg1, arg2, arg3) {
   return window['go']['main']['App']['ToggleEndpoint'](arg1, arg2, arg3);
 }
 
+export function ToggleEndpointsByTag(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ToggleEndpointsByTag'](arg1, arg2, arg3);
+}
+
 export function UnbindSession(arg1) {
   return window['go']['main']['App']['UnbindSession'](arg1);
 }
 
+export function UnpinEndpoint(arg1) {
+  return window['go']['main']['App']['UnpinEndpoint'](arg1);
+}
+
 export function UpdateBackupProvider(arg1) {
   return window['go']['main']['App']['UpdateBackupProvider'](arg1);
 }
 
+export function UpdateClientPorts(arg1) {
+  return window['go']['main']['App']['UpdateClientPorts'](arg1);
+}
+
+export function UpdateClientRoutingConfig(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['UpdateClientRoutingConfig'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
 export function UpdateConfig(arg1) {
   return window['go']['main']['App']['UpdateConfig'](arg1);
 }
 
-export function UpdateEndpoint(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15) {
-  return window['go']['main']['App']['UpdateEndpoint'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15);
+export function UpdateCrossClientFallbackConfig(arg1, arg2) {
+  return window['go']['main']['App']['UpdateCrossClientFallbackConfig'](arg1, arg2);
+}
+
+export function UpdateDashboardConfig(arg1, arg2) {
+  return window['go']['main']['App']['UpdateDashboardConfig'](arg1, arg2);
+}
+
+export function UpdateEndpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['UpdateEndpoint'](arg1, arg2, arg3);
 }
 
 export function UpdateLocalBackupDir(arg1) {
   return window['go']['main']['App']['UpdateLocalBackupDir'](arg1);
 }
 
+export function UpdateLocalBackupKeepVersions(arg1) {
+  return window['go']['main']['App']['UpdateLocalBackupKeepVersions'](arg1);
+}
+
 export function UpdatePort(arg1) {
   return window['go']['main']['App']['UpdatePort'](arg1);
 }
@@ -510,8 +838,16 @@ export function UpdateS3BackupConfig(arg1, arg2, arg3, arg4, arg5, arg6, arg7, a
   return window['go']['main']['App']['UpdateS3BackupConfig'](arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9);
 }
 
-export function UpdateSessionAffinityConfig(arg1, arg2, arg3) {
-  return window['go']['main']['App']['UpdateSessionAffinityConfig'](arg1, arg2, arg3);
+export function UpdateSLAConfig(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['UpdateSLAConfig'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function UpdateSessionAffinityConfig(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['UpdateSessionAffinityConfig'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function UpdateTokenRateAlertConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['UpdateTokenRateAlertConfig'](arg1, arg2, arg3, arg4);
 }
 
 export function UpdateWebDAVConfig(arg1, arg2, arg3) {
@@ -0,0 +1,89 @@
+export namespace service {
+	
+	export class EndpointInput {
+	    name: string;
+	    apiUrl: string;
+	    apiKey: string;
+	    transformer: string;
+	    model: string;
+	    remark: string;
+	    tags: string;
+	    modelPatterns: string;
+	    costPerInputToken: number;
+	    costPerOutputToken: number;
+	    costPerCacheReadToken: number;
+	    quotaLimit: number;
+	    quotaResetCycle: string;
+	    quotaMode: string;
+	    priority: number;
+	    userAgent: string;
+	    slaP95Ms: number;
+	    weight: number;
+	    models: string;
+	    group: string;
+	    headerMode: string;
+	    headerWhitelist: string;
+	    healthFields: string;
+	    healthErrorWords: string;
+	    healthCheckPath: string;
+	    healthCheckMethod: string;
+	    activeHours: string;
+	    keyExpiryDate: string;
+	    refreshToken: string;
+	    tokenExpiry: number;
+	    apiKeys: string;
+	    hideThinking: boolean;
+	    disableStreamUsage: boolean;
+	    pinStatus: boolean;
+	    maxConcurrency: number;
+	    timeoutSeconds: number;
+	    modelRewrite: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EndpointInput(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.apiUrl = source["apiUrl"];
+	        this.apiKey = source["apiKey"];
+	        this.transformer = source["transformer"];
+	        this.model = source["model"];
+	        this.remark = source["remark"];
+	        this.tags = source["tags"];
+	        this.modelPatterns = source["modelPatterns"];
+	        this.costPerInputToken = source["costPerInputToken"];
+	        this.costPerOutputToken = source["costPerOutputToken"];
+	        this.costPerCacheReadToken = source["costPerCacheReadToken"];
+	        this.quotaLimit = source["quotaLimit"];
+	        this.quotaResetCycle = source["quotaResetCycle"];
+	        this.quotaMode = source["quotaMode"];
+	        this.priority = source["priority"];
+	        this.userAgent = source["userAgent"];
+	        this.slaP95Ms = source["slaP95Ms"];
+	        this.weight = source["weight"];
+	        this.models = source["models"];
+	        this.group = source["group"];
+	        this.headerMode = source["headerMode"];
+	        this.headerWhitelist = source["headerWhitelist"];
+	        this.healthFields = source["healthFields"];
+	        this.healthErrorWords = source["healthErrorWords"];
+	        this.healthCheckPath = source["healthCheckPath"];
+	        this.healthCheckMethod = source["healthCheckMethod"];
+	        this.activeHours = source["activeHours"];
+	        this.keyExpiryDate = source["keyExpiryDate"];
+	        this.refreshToken = source["refreshToken"];
+	        this.tokenExpiry = source["tokenExpiry"];
+	        this.apiKeys = source["apiKeys"];
+	        this.hideThinking = source["hideThinking"];
+	        this.disableStreamUsage = source["disableStreamUsage"];
+	        this.pinStatus = source["pinStatus"];
+	        this.maxConcurrency = source["maxConcurrency"];
+	        this.timeoutSeconds = source["timeoutSeconds"];
+	        this.modelRewrite = source["modelRewrite"];
+	    }
+	}
+
+}
+
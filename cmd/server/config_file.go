@@ -0,0 +1,103 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "time"
+
+    "github.com/lich0821/ccNexus/internal/config"
+    "github.com/lich0821/ccNexus/internal/logger"
+    "github.com/lich0821/ccNexus/internal/proxy"
+    "github.com/lich0821/ccNexus/internal/service"
+)
+
+// configFilePollInterval 配置文件变化检测间隔
+// 使用轮询而不是 fsnotify：docker 挂载的文件被编辑器替换（rename）时轮询同样可靠，且无需额外依赖
+const configFilePollInterval = 2 * time.Second
+
+// loadConfigFile reads and validates a JSON config file (same format as the Web UI config export)
+func loadConfigFile(path string) (*config.Config, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read config file: %w", err)
+    }
+
+    var cfg config.Config
+    if err := json.Unmarshal(data, &cfg); err != nil {
+        return nil, fmt.Errorf("invalid config file format: %w", err)
+    }
+
+    applyEnvOverrides(&cfg)
+    if err := cfg.Validate(); err != nil {
+        return nil, fmt.Errorf("invalid config: %w", err)
+    }
+    return &cfg, nil
+}
+
+// configFileState 用于判断配置文件是否变化
+type configFileState struct {
+    modTime time.Time
+    size    int64
+}
+
+func statConfigFile(path string) (configFileState, error) {
+    info, err := os.Stat(path)
+    if err != nil {
+        return configFileState{}, err
+    }
+    return configFileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// watchConfigFile polls the config file and hot-reloads it on change
+// 校验失败时保留旧配置并记录错误
+func watchConfigFile(path string, cfg *config.Config, p *proxy.Proxy, healthCheck *service.HealthCheckService, stopCh <-chan struct{}) {
+    last, err := statConfigFile(path)
+    if err != nil {
+        logger.Warn("Failed to stat config file %s: %v", path, err)
+    }
+
+    ticker := time.NewTicker(configFilePollInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ticker.C:
+            current, err := statConfigFile(path)
+            if err != nil {
+                // 文件暂时不存在（编辑器替换中），下次再检查
+                continue
+            }
+            if current == last {
+                continue
+            }
+            last = current
+
+            if err := reloadConfigFile(path, cfg, p, healthCheck); err != nil {
+                logger.Error("Config reload from %s failed, keeping previous config: %v", path, err)
+                continue
+            }
+            logger.Info("Config reloaded from %s", path)
+        case <-stopCh:
+            return
+        }
+    }
+}
+
+// reloadConfigFile loads the config file and applies it to the running proxy and health check
+func reloadConfigFile(path string, cfg *config.Config, p *proxy.Proxy, healthCheck *service.HealthCheckService) error {
+    newCfg, err := loadConfigFile(path)
+    if err != nil {
+        return err
+    }
+
+    // 把新配置复制到共享的 cfg，让健康检查和 Web UI 持有的配置同步更新
+    cfg.CopyFrom(newCfg)
+    if err := p.UpdateConfig(cfg); err != nil {
+        return err
+    }
+
+    setLogLevels(cfg.GetLogLevel())
+    healthCheck.Restart()
+    return nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/lich0821/ccNexus/internal/config"
+    "github.com/lich0821/ccNexus/internal/logger"
+)
+
+// 通过环境变量注入端点，便于容器 / CI 等无状态部署：
+//
+//   CCNEXUS_ENDPOINT_<N>=name|apiUrl|apiKey[|transformer[|model[|clientType]]]
+//   CCNEXUS_ENDPOINTS_JSON=[{"name":"...","apiUrl":"...","apiKey":"...","transformer":"openai","model":"gpt-4o"}]
+//
+// <N> 为任意后缀，按数字（非数字按字典序）顺序处理；JSON 中的字段与配置导出格式相同。
+// 端点按 clientType + name 匹配：已存在时更新，否则追加。格式错误的条目记录警告后跳过。
+const (
+    envEndpointPrefix = "CCNEXUS_ENDPOINT_"
+    envEndpointsJSON  = "CCNEXUS_ENDPOINTS_JSON"
+)
+
+// envEndpoint 从环境变量解析出的端点
+// replace 为 true（JSON 格式）时整体替换同名端点；否则只更新环境变量中给出的字段
+type envEndpoint struct {
+    endpoint config.Endpoint
+    replace  bool
+    fields   int // 单行格式中给出的字段数
+}
+
+// applyEnvEndpoints parses endpoints from environment variables and merges them into the config
+func applyEnvEndpoints(cfg *config.Config) {
+    var injected []envEndpoint
+
+    if raw := strings.TrimSpace(os.Getenv(envEndpointsJSON)); raw != "" {
+        endpoints, err := parseEnvEndpointsJSON(raw)
+        if err != nil {
+            logger.Warn("Invalid %s value: %v", envEndpointsJSON, err)
+        }
+        injected = append(injected, endpoints...)
+    }
+
+    for _, key := range envEndpointKeys() {
+        item, err := parseEnvEndpoint(os.Getenv(key))
+        if err != nil {
+            logger.Warn("Invalid %s value: %v", key, err)
+            continue
+        }
+        injected = append(injected, item)
+    }
+
+    if len(injected) == 0 {
+        return
+    }
+
+    endpoints := cfg.GetEndpoints()
+    for _, item := range injected {
+        endpoints = mergeEnvEndpoint(endpoints, item)
+    }
+    cfg.UpdateEndpoints(endpoints)
+    logger.Info("Applied %d endpoint(s) from environment variables", len(injected))
+}
+
+// hasEnvEndpoints reports whether any endpoint is provided through environment variables
+func hasEnvEndpoints() bool {
+    return strings.TrimSpace(os.Getenv(envEndpointsJSON)) != "" || len(envEndpointKeys()) > 0
+}
+
+// envEndpointKeys returns the CCNEXUS_ENDPOINT_<N> variable names sorted by suffix
+func envEndpointKeys() []string {
+    var keys []string
+    for _, kv := range os.Environ() {
+        key, _, _ := strings.Cut(kv, "=")
+        if strings.HasPrefix(key, envEndpointPrefix) && len(key) > len(envEndpointPrefix) {
+            keys = append(keys, key)
+        }
+    }
+
+    sort.Slice(keys, func(i, j int) bool {
+        a := strings.TrimPrefix(keys[i], envEndpointPrefix)
+        b := strings.TrimPrefix(keys[j], envEndpointPrefix)
+        na, errA := strconv.Atoi(a)
+        nb, errB := strconv.Atoi(b)
+        if errA == nil && errB == nil {
+            return na < nb
+        }
+        if (errA == nil) != (errB == nil) {
+            return errA == nil // 数字后缀排在前面
+        }
+        return a < b
+    })
+    return keys
+}
+
+// parseEnvEndpoint parses name|apiUrl|apiKey[|transformer[|model[|clientType]]]
+func parseEnvEndpoint(value string) (envEndpoint, error) {
+    parts := strings.Split(value, "|")
+    if len(parts) < 3 || len(parts) > 6 {
+        return envEndpoint{}, fmt.Errorf("expected name|apiUrl|apiKey[|transformer[|model[|clientType]]], got %d field(s)", len(parts))
+    }
+    for i := range parts {
+        parts[i] = strings.TrimSpace(parts[i])
+    }
+
+    ep := config.Endpoint{
+        Name:   parts[0],
+        APIUrl: parts[1],
+        APIKey: parts[2],
+    }
+    if len(parts) > 3 {
+        ep.Transformer = parts[3]
+    }
+    if len(parts) > 4 {
+        ep.Model = parts[4]
+    }
+    if len(parts) > 5 {
+        ep.ClientType = parts[5]
+    }
+
+    if err := validateEnvEndpoint(&ep); err != nil {
+        return envEndpoint{}, err
+    }
+    return envEndpoint{endpoint: ep, fields: len(parts)}, nil
+}
+
+// parseEnvEndpointsJSON parses a JSON array of endpoints; invalid entries are skipped with an error summary
+func parseEnvEndpointsJSON(raw string) ([]envEndpoint, error) {
+    var endpoints []config.Endpoint
+    if err := json.Unmarshal([]byte(raw), &endpoints); err != nil {
+        return nil, fmt.Errorf("expected a JSON array of endpoints: %w", err)
+    }
+
+    result := make([]envEndpoint, 0, len(endpoints))
+    var errs []string
+    for i := range endpoints {
+        if err := validateEnvEndpoint(&endpoints[i]); err != nil {
+            errs = append(errs, fmt.Sprintf("endpoint %d: %v", i+1, err))
+            continue
+        }
+        result = append(result, envEndpoint{endpoint: endpoints[i], replace: true})
+    }
+    if len(errs) > 0 {
+        return result, fmt.Errorf("%s", strings.Join(errs, "; "))
+    }
+    return result, nil
+}
+
+// validateEnvEndpoint checks required fields and fills defaults for an injected endpoint
+func validateEnvEndpoint(ep *config.Endpoint) error {
+    if ep.Name == "" {
+        return fmt.Errorf("name is required")
+    }
+    if ep.APIUrl == "" {
+        return fmt.Errorf("apiUrl is required")
+    }
+    if ep.APIKey == "" {
+        return fmt.Errorf("apiKey is required")
+    }
+
+    if ep.Transformer == "" {
+        ep.Transformer = "claude"
+    }
+    if ep.Transformer != "claude" && ep.Model == "" {
+        return fmt.Errorf("model is required for transformer %s", ep.Transformer)
+    }
+
+    if ep.ClientType == "" {
+        ep.ClientType = "claude"
+    }
+    switch ep.ClientType {
+    case "claude", "gemini", "codex":
+    default:
+        return fmt.Errorf("invalid client type: %s", ep.ClientType)
+    }
+
+    // 注入的端点默认启用，等待健康检查确认状态
+    if ep.Status == "" {
+        ep.Status = config.EndpointStatusUntested
+    }
+    ep.Enabled = ep.IsEnabled()
+    if ep.Priority == 0 {
+        ep.Priority = 100
+    }
+    return nil
+}
+
+// mergeEnvEndpoint updates the endpoint with the same client type and name, or appends it
+func mergeEnvEndpoint(endpoints []config.Endpoint, item envEndpoint) []config.Endpoint {
+    injected := item.endpoint
+    for i := range endpoints {
+        clientType := endpoints[i].ClientType
+        if clientType == "" {
+            clientType = "claude"
+        }
+        if endpoints[i].Name != injected.Name || clientType != injected.ClientType {
+            continue
+        }
+
+        if item.replace {
+            endpoints[i] = injected
+        } else {
+            endpoints[i].APIUrl = injected.APIUrl
+            endpoints[i].APIKey = injected.APIKey
+            if item.fields > 3 {
+                endpoints[i].Transformer = injected.Transformer
+            }
+            if injected.Model != "" {
+                endpoints[i].Model = injected.Model
+            }
+        }
+        logger.Info("Endpoint %s (%s) updated from environment", injected.Name, injected.ClientType)
+        return endpoints
+    }
+
+    logger.Info("Endpoint %s (%s) added from environment", injected.Name, injected.ClientType)
+    return append(endpoints, injected)
+}
@@ -10,6 +10,7 @@ import (
     "syscall"
 
     "github.com/lich0821/ccNexus/internal/config"
+    "github.com/lich0821/ccNexus/internal/interaction"
     "github.com/lich0821/ccNexus/internal/logger"
     "github.com/lich0821/ccNexus/internal/proxy"
     "github.com/lich0821/ccNexus/internal/service"
@@ -35,15 +36,37 @@ func main() {
     }
     defer sqliteStorage.Close()
 
-    cfg, err := loadConfig(sqliteStorage)
-    if err != nil {
-        logger.Error("Unable to load configuration: %v", err)
-        os.Exit(1)
+    // CCNEXUS_CONFIG_FILE 指定时从 JSON 配置文件启动，并监听文件变化热重载
+    configFile := os.Getenv("CCNEXUS_CONFIG_FILE")
+
+    var cfg *config.Config
+    if configFile != "" {
+        cfg, err = loadConfigFile(configFile)
+        if err != nil {
+            logger.Error("Unable to load config file %s: %v", configFile, err)
+            os.Exit(1)
+        }
+        logger.Info("Loaded configuration from %s", configFile)
+    } else {
+        cfg, err = loadConfig(sqliteStorage)
+        if err != nil {
+            logger.Error("Unable to load configuration: %v", err)
+            os.Exit(1)
+        }
+        // 配置文件模式下 loadConfigFile 已应用环境变量覆盖
+        applyEnvOverrides(cfg)
     }
 
-    applyEnvOverrides(cfg)
     setLogLevels(cfg.GetLogLevel())
 
+    // 按端点分文件记录请求日志
+    logger.GetLogger().SetKeyedDir(filepath.Join(dataDir, "logs", "endpoints"))
+    if cfg.GetPerEndpointLogging() {
+        if err := logger.GetLogger().SetKeyedEnabled(true); err != nil {
+            logger.Warn("Failed to enable per-endpoint logging: %v", err)
+        }
+    }
+
     if err := cfg.Validate(); err != nil {
         logger.Error("Invalid configuration: %v", err)
         os.Exit(1)
@@ -61,10 +84,32 @@ func main() {
     // 初始化智能路由器和配额跟踪器
     p.SetupRouter(sqliteStorage)
 
+    // 交互记录：服务端默认不录制，仅在数据库中已开启时录制；旧记录按保留天数定期清理
+    interactionStorage := interaction.NewStorage(filepath.Join(dataDir, "interactions"))
+    interactionStorage.SetEnabled(false)
+    interactionService := service.NewInteractionService(interactionStorage, sqliteStorage)
+    p.SetInteractionStorage(interactionStorage)
+    interactionService.StartAutoCleanup(cfg)
+    defer interactionService.StopAutoCleanup()
+
     // Initialize health check service
     healthCheck := service.NewHealthCheckService(cfg, p.GetMonitor())
+    healthCheck.SetProxy(p)
+    // 服务端模式没有系统通知，告警只推送到 webhook
+    healthCheck.SetAlertCallback(func(event service.AlertEvent) {
+        service.SendAlertWebhook(cfg.GetAlert(), event)
+    })
+    // 开始接收请求前先同步检查一次所有端点，把 untested 状态刷新为 available/unavailable
+    healthCheck.RunOnceBlocking()
     healthCheck.Start()
 
+    stopWatchCh := make(chan struct{})
+    defer close(stopWatchCh)
+    if configFile != "" {
+        go watchConfigFile(configFile, cfg, p, healthCheck, stopWatchCh)
+        logger.Info("Watching config file %s for changes", configFile)
+    }
+
     // Create HTTP mux
     mux := http.NewServeMux()
 
@@ -126,7 +171,8 @@ func loadConfig(sqliteStorage *storage.SQLiteStorage) (*config.Config, error) {
     }
 
     // Seed a default endpoint when none are configured to avoid boot failure
-    if len(cfg.Endpoints) == 0 {
+    // 环境变量中注入了端点时不需要占位端点
+    if len(cfg.Endpoints) == 0 && !hasEnvEndpoints() {
         logger.Warn("No endpoints found; seeding a default endpoint")
         cfg.Endpoints = config.DefaultConfig().Endpoints
         if saveErr := cfg.SaveToStorage(adapter); saveErr != nil {
@@ -152,6 +198,16 @@ func applyEnvOverrides(cfg *config.Config) {
             logger.Warn("Invalid CCNEXUS_LOG_LEVEL value %q: %v", levelStr, err)
         }
     }
+
+    if perEndpointLogging := os.Getenv("CCNEXUS_PER_ENDPOINT_LOGGING"); perEndpointLogging != "" {
+        if enabled, err := strconv.ParseBool(perEndpointLogging); err == nil {
+            cfg.UpdatePerEndpointLogging(enabled)
+        } else {
+            logger.Warn("Invalid CCNEXUS_PER_ENDPOINT_LOGGING value %q: %v", perEndpointLogging, err)
+        }
+    }
+
+    applyEnvEndpoints(cfg)
 }
 
 func setLogLevels(level int) {
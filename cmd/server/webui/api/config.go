@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/logger"
 	"github.com/lich0821/ccNexus/internal/storage"
 )
@@ -148,3 +149,39 @@ func (h *Handler) handleConfigLogLevel(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
 	}
 }
+
+// handleConfigDashboard handles GET and PUT for statistics dashboard configuration
+func (h *Handler) handleConfigDashboard(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		WriteSuccess(w, h.config.GetDashboard())
+	case http.MethodPut:
+		var req config.DashboardConfig
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := req.Validate(); err != nil {
+			WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		h.config.UpdateDashboard(&req)
+
+		// Save to storage
+		adapter := storage.NewConfigStorageAdapter(h.storage)
+		if err := h.config.SaveToStorage(adapter); err != nil {
+			logger.Error("Failed to save config: %v", err)
+			WriteError(w, http.StatusInternalServerError, "Failed to save configuration")
+			return
+		}
+
+		WriteSuccess(w, map[string]interface{}{
+			"dashboard": req,
+			"message":   "Dashboard configuration updated successfully",
+		})
+	default:
+		WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
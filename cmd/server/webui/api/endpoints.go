@@ -79,9 +79,9 @@ func (h *Handler) listEndpoints(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Mask API keys
+	// Mask API keys and tokens
 	for i := range endpoints {
-		endpoints[i].APIKey = maskAPIKey(endpoints[i].APIKey)
+		maskEndpointSecrets(&endpoints[i])
 	}
 
 	WriteSuccess(w, map[string]interface{}{
@@ -106,7 +106,7 @@ func (h *Handler) getEndpoint(w http.ResponseWriter, r *http.Request, name strin
 
 	for _, ep := range endpoints {
 		if ep.Name == name {
-			ep.APIKey = maskAPIKey(ep.APIKey)
+			maskEndpointSecrets(&ep)
 			WriteSuccess(w, ep)
 			return
 		}
@@ -186,7 +186,7 @@ func (h *Handler) createEndpoint(w http.ResponseWriter, r *http.Request) {
 		logger.Error("Failed to reload config: %v", err)
 	}
 
-	endpoint.APIKey = maskAPIKey(endpoint.APIKey)
+	maskEndpointSecrets(endpoint)
 	WriteSuccess(w, endpoint)
 }
 
@@ -266,7 +266,7 @@ func (h *Handler) updateEndpoint(w http.ResponseWriter, r *http.Request, name st
 		logger.Error("Failed to reload config: %v", err)
 	}
 
-	existing.APIKey = maskAPIKey(existing.APIKey)
+	maskEndpointSecrets(existing)
 	WriteSuccess(w, existing)
 }
 
@@ -510,6 +510,21 @@ func (h *Handler) reloadConfig() error {
 	return h.proxy.UpdateConfig(cfg)
 }
 
+// maskEndpointSecrets masks the API keys and OAuth refresh token of an endpoint before returning it
+// 未配置的额外 key 和 refresh token 保持为空，前端据此区分是否已配置
+func maskEndpointSecrets(ep *storage.Endpoint) {
+	ep.APIKey = maskAPIKey(ep.APIKey)
+	if keys := config.ParseAPIKeys(ep.APIKeys); len(keys) > 0 {
+		for i, key := range keys {
+			keys[i] = maskAPIKey(key)
+		}
+		ep.APIKeys = config.EncodeAPIKeys(keys)
+	}
+	if ep.RefreshToken != "" {
+		ep.RefreshToken = maskAPIKey(ep.RefreshToken)
+	}
+}
+
 // maskAPIKey masks an API key, showing only the last 4 characters
 func maskAPIKey(key string) string {
 	if len(key) <= 4 {
@@ -519,6 +534,7 @@ func maskAPIKey(key string) string {
 }
 
 // normalizeAPIUrl ensures the API URL has the correct format
+// 只去掉首尾空白和尾部斜杠，保留用户输入的协议和路径前缀
 func normalizeAPIUrl(apiUrl string) string {
-	return strings.TrimSuffix(apiUrl, "/")
+	return strings.TrimRight(strings.TrimSpace(apiUrl), "/")
 }
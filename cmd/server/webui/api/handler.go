@@ -48,6 +48,7 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/config", h.handleConfig)
 	mux.HandleFunc("/api/config/port", h.handleConfigPort)
 	mux.HandleFunc("/api/config/log-level", h.handleConfigLogLevel)
+	mux.HandleFunc("/api/config/dashboard", h.handleConfigDashboard)
 
 	// Real-time events
 	mux.HandleFunc("/api/events", h.handleEvents)
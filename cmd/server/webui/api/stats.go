@@ -14,7 +14,7 @@ func (h *Handler) handleStatsSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	totalRequests, endpointStats := h.proxy.GetStats().GetStats()
+	totalRequests, endpointStats := h.proxy.GetStats().GetStats(false)
 
 	// Calculate totals
 	totalErrors := 0
@@ -43,7 +43,7 @@ func (h *Handler) handleStatsDaily(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	today := time.Now().Format("2006-01-02")
+	today := h.config.StatsNow().Format("2006-01-02")
 	stats, err := h.getStatsForPeriod(today, today)
 	if err != nil {
 		logger.Error("Failed to get daily stats: %v", err)
@@ -65,7 +65,7 @@ func (h *Handler) handleStatsWeekly(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
+	now := h.config.StatsNow()
 	// Get start of week (Monday)
 	weekday := int(now.Weekday())
 	if weekday == 0 {
@@ -97,7 +97,7 @@ func (h *Handler) handleStatsMonthly(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
+	now := h.config.StatsNow()
 	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	startDate := startOfMonth.Format("2006-01-02")
 	endDate := now.Format("2006-01-02")
@@ -124,7 +124,7 @@ func (h *Handler) handleStatsTrends(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
+	now := h.config.StatsNow()
 	today := now.Format("2006-01-02")
 	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
 
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/logger"
 	"github.com/lich0821/ccNexus/internal/storage"
 )
@@ -75,7 +76,7 @@ func (h *Handler) sendTestRequest(endpoint *storage.Endpoint) (string, error) {
 
 	switch endpoint.Transformer {
 	case "claude":
-		url = fmt.Sprintf("%s/v1/messages", endpoint.APIUrl)
+		url = config.JoinAPIUrl(endpoint.APIUrl, "/v1/messages")
 		reqBody, err = json.Marshal(map[string]interface{}{
 			"model": "claude-3-5-sonnet-20241022",
 			"messages": []map[string]interface{}{
@@ -87,7 +88,7 @@ func (h *Handler) sendTestRequest(endpoint *storage.Endpoint) (string, error) {
 			"max_tokens": 16,
 		})
 	case "openai", "openai2":
-		url = fmt.Sprintf("%s/v1/chat/completions", endpoint.APIUrl)
+		url = config.JoinAPIUrl(endpoint.APIUrl, "/v1/chat/completions")
 		model := endpoint.Model
 		if model == "" {
 			model = "gpt-4"
@@ -107,7 +108,7 @@ func (h *Handler) sendTestRequest(endpoint *storage.Endpoint) (string, error) {
 		if model == "" {
 			model = "gemini-pro"
 		}
-		url = fmt.Sprintf("%s/v1beta/models/%s:generateContent", endpoint.APIUrl, model)
+		url = config.JoinAPIUrl(endpoint.APIUrl, fmt.Sprintf("/v1beta/models/%s:generateContent", model))
 		reqBody, err = json.Marshal(map[string]interface{}{
 			"contents": []map[string]interface{}{
 				{
@@ -249,7 +250,7 @@ func (h *Handler) fetchModelsFromProvider(apiUrl, apiKey, transformer string) ([
 
 	switch transformer {
 	case "openai", "openai2":
-		url = fmt.Sprintf("%s/v1/models", apiUrl)
+		url = config.JoinAPIUrl(apiUrl, "/v1/models")
 		authHeader = "Bearer " + apiKey
 	case "claude":
 		// Claude doesn't have a models endpoint, return known models
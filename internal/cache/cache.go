@@ -19,6 +19,7 @@ type CacheEntry struct {
 	ExpiresAt  time.Time `json:"expiresAt"`
 	HitCount   int       `json:"hitCount"`    // 命中次数
 	IsStreaming bool     `json:"isStreaming"` // 是否为流式响应
+	Events     [][]byte  `json:"events,omitempty"` // 流式响应的完整 SSE 事件序列，命中时逐个回放
 }
 
 // CacheStats 缓存统计
@@ -114,6 +115,11 @@ func GenerateKey(body []byte) string {
 		keyData["max_tokens"] = maxTokens
 	}
 
+	// 流式与非流式响应格式不同，分开缓存；非流式请求不写入该字段，保持原有键不变
+	if stream, ok := req["stream"].(bool); ok && stream {
+		keyData["stream"] = true
+	}
+
 	// 序列化并哈希
 	keyBytes, _ := json.Marshal(keyData)
 	hash := sha256.Sum256(keyBytes)
@@ -184,6 +190,31 @@ func (c *Cache) Set(key string, response, headers []byte, isStreaming bool) {
 	logger.Debug("[CACHE] Set: %s (ttl: %v, streaming: %v)", key[:16], c.ttl, isStreaming)
 }
 
+// SetStream 缓存流式响应的完整 SSE 事件序列
+func (c *Cache) SetStream(key string, events [][]byte) {
+	if !c.enabled || len(events) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		c.evictOldest()
+	}
+
+	now := time.Now()
+	c.entries[key] = &CacheEntry{
+		Key:         key,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(c.ttl),
+		IsStreaming: true,
+		Events:      events,
+	}
+
+	logger.Debug("[CACHE] Set: %s (ttl: %v, streaming: true, events: %d)", key[:16], c.ttl, len(events))
+}
+
 // evictOldest 清除最旧的缓存条目（需要持有锁）
 func (c *Cache) evictOldest() {
 	var oldestKey string
@@ -246,6 +277,9 @@ func (c *Cache) GetStats() CacheStats {
 	var totalSize int64
 	for _, entry := range c.entries {
 		totalSize += int64(len(entry.Response) + len(entry.Headers))
+		for _, event := range entry.Events {
+			totalSize += int64(len(event))
+		}
 	}
 	stats.TotalSize = totalSize
 
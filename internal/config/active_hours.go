@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ActiveHours 端点的活跃时段：一个或多个 HH:MM-HH:MM 区间（逗号分隔），可带一个时区后缀，如
+// "22:00-06:00"、"09:00-12:00,14:00-18:00 Asia/Shanghai"、"22:00-06:00 UTC+8"。
+// 结束时间早于开始时间表示跨午夜；结束时间可写 24:00；没有时区时使用本机时区
+type ActiveHours struct {
+	ranges   []activeRange
+	location *time.Location
+}
+
+// activeRange 一个活跃区间，以当天的分钟数表示，区间左闭右开
+type activeRange struct {
+	start int
+	end   int
+}
+
+// ParseActiveHours parses an active hours expression; an empty value returns nil (always active)
+func ParseActiveHours(value string) (*ActiveHours, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	hours := &ActiveHours{location: time.Local}
+	// 最后一个以非数字开头的空白分隔项为时区
+	if idx := strings.LastIndexAny(value, " \t"); idx >= 0 && !unicode.IsDigit(rune(value[idx+1])) && value[idx+1] != '-' {
+		loc, err := parseActiveHoursLocation(value[idx+1:])
+		if err != nil {
+			return nil, err
+		}
+		hours.location = loc
+		value = strings.TrimSpace(value[:idx])
+	}
+
+	for _, item := range splitCommaList(value) {
+		startStr, endStr, ok := strings.Cut(item, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid active hours '%s', expected HH:MM-HH:MM", item)
+		}
+		start, err := parseClockMinutes(startStr, false)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClockMinutes(endStr, true)
+		if err != nil {
+			return nil, err
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid active hours '%s', start and end must differ", item)
+		}
+		hours.ranges = append(hours.ranges, activeRange{start: start, end: end})
+	}
+	if len(hours.ranges) == 0 {
+		return nil, fmt.Errorf("invalid active hours '%s', expected HH:MM-HH:MM", value)
+	}
+	return hours, nil
+}
+
+// parseClockMinutes 解析 HH:MM 为当天的分钟数；allowEndOfDay 为 true 时允许 24:00
+func parseClockMinutes(value string, allowEndOfDay bool) (int, error) {
+	value = strings.TrimSpace(value)
+	hourStr, minuteStr, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", value)
+	}
+	hour, err1 := strconv.Atoi(hourStr)
+	minute, err2 := strconv.Atoi(minuteStr)
+	if err1 != nil || err2 != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", value)
+	}
+	if hour == 24 && (minute != 0 || !allowEndOfDay) {
+		return 0, fmt.Errorf("invalid time '%s', only an end time can be 24:00", value)
+	}
+	return hour*60 + minute, nil
+}
+
+// parseActiveHoursLocation 解析时区：IANA 名称（如 Asia/Shanghai）、UTC/Local，或 UTC 偏移（如 UTC+8、UTC-05:30、+08:00）
+func parseActiveHoursLocation(name string) (*time.Location, error) {
+	switch strings.ToUpper(name) {
+	case "LOCAL":
+		return time.Local, nil
+	case "UTC", "GMT", "Z":
+		return time.UTC, nil
+	}
+
+	offset := name
+	for _, prefix := range []string{"UTC", "GMT", "utc", "gmt"} {
+		offset = strings.TrimPrefix(offset, prefix)
+	}
+	if offset != "" && (offset[0] == '+' || offset[0] == '-') {
+		sign := 1
+		if offset[0] == '-' {
+			sign = -1
+		}
+		hourStr, minuteStr, _ := strings.Cut(offset[1:], ":")
+		hour, err := strconv.Atoi(hourStr)
+		minute := 0
+		if err == nil && minuteStr != "" {
+			minute, err = strconv.Atoi(minuteStr)
+		}
+		if err != nil || hour < 0 || hour > 14 || minute < 0 || minute > 59 {
+			return nil, fmt.Errorf("invalid time zone offset '%s'", name)
+		}
+		return time.FixedZone(name, sign*(hour*3600+minute*60)), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s'", name)
+	}
+	return loc, nil
+}
+
+// Contains reports whether t falls within any of the active ranges
+func (a *ActiveHours) Contains(t time.Time) bool {
+	if a == nil {
+		return true
+	}
+	local := t.In(a.location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, r := range a.ranges {
+		if r.start < r.end {
+			if minute >= r.start && minute < r.end {
+				return true
+			}
+		} else if minute >= r.start || minute < r.end { // 跨午夜
+			return true
+		}
+	}
+	return false
+}
+
+// IsActiveAt 返回端点在 t 时刻是否处于活跃时段；未配置活跃时段时一直可用，
+// 配置无法解析时（保存时已校验，正常不会出现）也视为可用，避免端点被意外排除
+func (e *Endpoint) IsActiveAt(t time.Time) bool {
+	hours, err := ParseActiveHours(e.ActiveHours)
+	if err != nil {
+		return true
+	}
+	return hours.Contains(t)
+}
@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+// ParseAPIKeys 解析存储中逗号分隔的额外 API key 列表
+func ParseAPIKeys(data string) []string {
+	return splitCommaList(data)
+}
+
+// EncodeAPIKeys 将额外 API key 列表编码为逗号分隔字符串存储
+func EncodeAPIKeys(keys []string) string {
+	return strings.Join(keys, ",")
+}
+
+// KeyPool 返回端点的全部 API key：APIKey 在前，APIKeys 中的额外 key 依次在后（去重、去空）
+// 未配置额外 key 时只包含 APIKey，行为与单 key 一致
+func (e *Endpoint) KeyPool() []string {
+	keys := make([]string, 0, len(e.APIKeys)+1)
+	seen := make(map[string]bool, len(e.APIKeys)+1)
+	for _, key := range append([]string{e.APIKey}, e.APIKeys...) {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
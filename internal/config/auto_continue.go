@@ -0,0 +1,34 @@
+package config
+
+// AutoContinueConfig 自动续写配置：响应因 max_tokens 截断时自动发起续写请求并拼接结果，
+// 以及上游流式响应中途断开时换端点续传
+type AutoContinueConfig struct {
+	Enabled            bool `json:"enabled"`            // 是否启用自动续写
+	MaxContinuations   int  `json:"maxContinuations"`   // 单个请求最多续写次数，用于控制额度消耗，默认3
+	ResumeOnDisconnect bool `json:"resumeOnDisconnect"` // 上游流中途断开时是否换其他端点续传
+}
+
+// DefaultAutoContinueConfig 返回默认自动续写配置
+func DefaultAutoContinueConfig() *AutoContinueConfig {
+	return &AutoContinueConfig{
+		Enabled:          false,
+		MaxContinuations: 3,
+	}
+}
+
+// GetAutoContinue 获取自动续写配置（线程安全），未设置时返回默认配置
+func (c *Config) GetAutoContinue() *AutoContinueConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.AutoContinue == nil {
+		return DefaultAutoContinueConfig()
+	}
+	return c.AutoContinue
+}
+
+// UpdateAutoContinue 更新自动续写配置（线程安全）
+func (c *Config) UpdateAutoContinue(autoContinue *AutoContinueConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AutoContinue = autoContinue
+}
@@ -0,0 +1,37 @@
+package config
+
+// CircuitBreakerConfig 端点熔断配置：端点连续失败达到阈值后进入冷却期，冷却期内不参与选择，
+// 冷却结束后进入半开状态放行少量试探请求，试探成功则恢复，失败则重新冷却
+type CircuitBreakerConfig struct {
+	Enabled          bool `json:"enabled"`          // 是否启用端点熔断
+	FailureThreshold int  `json:"failureThreshold"` // 触发熔断的连续失败次数，默认5
+	CooldownSeconds  int  `json:"cooldownSeconds"`  // 熔断冷却时间（秒），默认60
+	HalfOpenProbes   int  `json:"halfOpenProbes"`   // 半开状态允许的试探请求数，全部成功后恢复，默认1
+}
+
+// DefaultCircuitBreakerConfig 返回默认端点熔断配置
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		Enabled:          false,
+		FailureThreshold: 5,
+		CooldownSeconds:  60,
+		HalfOpenProbes:   1,
+	}
+}
+
+// GetCircuitBreaker 获取端点熔断配置（线程安全），未设置时返回默认配置
+func (c *Config) GetCircuitBreaker() *CircuitBreakerConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CircuitBreaker == nil {
+		return DefaultCircuitBreakerConfig()
+	}
+	return c.CircuitBreaker
+}
+
+// UpdateCircuitBreaker 更新端点熔断配置（线程安全）
+func (c *Config) UpdateCircuitBreaker(breaker *CircuitBreakerConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CircuitBreaker = breaker
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ClientPortTypes 支持独立端口的 client type
+var ClientPortTypes = []string{"claude", "gemini", "codex"}
+
+// ValidateClientPorts 检查独立端口配置：client type 合法、端口范围合法、与主端口及彼此不冲突
+// 端口 <= 0 表示该 client 不启用独立端口
+func ValidateClientPorts(mainPort int, ports map[string]int) error {
+	used := map[int]string{mainPort: "main"}
+
+	clientTypes := make([]string, 0, len(ports))
+	for clientType := range ports {
+		clientTypes = append(clientTypes, clientType)
+	}
+	sort.Strings(clientTypes)
+
+	for _, clientType := range clientTypes {
+		port := ports[clientType]
+		if !isClientPortType(clientType) {
+			return fmt.Errorf("invalid client type for dedicated port: %s", clientType)
+		}
+		if port <= 0 {
+			continue
+		}
+		if port > 65535 {
+			return fmt.Errorf("invalid port for %s: %d", clientType, port)
+		}
+		if owner, exists := used[port]; exists {
+			return fmt.Errorf("port %d of %s conflicts with %s port", port, clientType, owner)
+		}
+		used[port] = clientType
+	}
+	return nil
+}
+
+func isClientPortType(clientType string) bool {
+	for _, ct := range ClientPortTypes {
+		if ct == clientType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientPorts returns the dedicated ports of client types (thread-safe)
+// 只返回已启用（端口 > 0）的条目
+func (c *Config) GetClientPorts() map[string]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ports := make(map[string]int, len(c.ClientPorts))
+	for clientType, port := range c.ClientPorts {
+		if port > 0 {
+			ports[clientType] = port
+		}
+	}
+	return ports
+}
+
+// UpdateClientPorts updates the dedicated ports of client types (thread-safe)
+func (c *Config) UpdateClientPorts(ports map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ClientPorts = make(map[string]int, len(ports))
+	for clientType, port := range ports {
+		if port > 0 {
+			c.ClientPorts[clientType] = port
+		}
+	}
+}
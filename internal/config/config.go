@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -11,9 +12,11 @@ type EndpointStatus string
 
 const (
 	EndpointStatusAvailable   EndpointStatus = "available"   // 可用 - 已验证可用，可接收请求
+	EndpointStatusDegraded    EndpointStatus = "degraded"    // 降级 - 可用但健康检查延迟超过阈值，路由时排在可用端点之后
 	EndpointStatusUnavailable EndpointStatus = "unavailable" // 不可用 - 已验证不可用，不接收请求但继续检查
 	EndpointStatusDisabled    EndpointStatus = "disabled"    // 禁用 - 用户手动禁用，停止所有检查和请求
 	EndpointStatusUntested    EndpointStatus = "untested"    // 未检测 - 未经验证，可以尝试使用
+	EndpointStatusMaintenance EndpointStatus = "maintenance" // 维护中 - 不路由新请求，但继续检查并保留统计
 )
 
 // Endpoint represents a single API endpoint configuration
@@ -22,6 +25,7 @@ type Endpoint struct {
 	ClientType  string         `json:"clientType,omitempty"`  // Client type: claude, gemini, codex (default: claude)
 	APIUrl      string         `json:"apiUrl"`
 	APIKey      string         `json:"apiKey"`
+	APIKeys     []string       `json:"apiKeys,omitempty"`     // 额外的 API key，与 APIKey 组成 key 池轮询使用
 	Status      EndpointStatus `json:"status"`                // 端点状态：available, unavailable, disabled
 	Enabled     bool           `json:"enabled"`               // 向后兼容字段，从 Status 派生
 	Transformer string         `json:"transformer,omitempty"` // Transformer type: claude, openai, gemini, deepseek
@@ -30,12 +34,39 @@ type Endpoint struct {
 	Tags        string         `json:"tags,omitempty"`        // Comma-separated tags for grouping/filtering
 
 	// 智能路由相关字段
-	ModelPatterns      string  `json:"modelPatterns,omitempty"`      // 模型匹配模式，逗号分隔，支持通配符如 claude-*,gpt-4*
-	CostPerInputToken  float64 `json:"costPerInputToken,omitempty"`  // 每百万输入 Token 成本（美元）
-	CostPerOutputToken float64 `json:"costPerOutputToken,omitempty"` // 每百万输出 Token 成本（美元）
-	QuotaLimit         int64   `json:"quotaLimit,omitempty"`         // Token 配额限制，0 表示无限制
-	QuotaResetCycle    string  `json:"quotaResetCycle,omitempty"`    // 配额重置周期：daily/weekly/monthly/never
-	Priority           int     `json:"priority,omitempty"`           // 优先级，数字越小优先级越高，默认100
+	ModelPatterns         string  `json:"modelPatterns,omitempty"`         // 模型匹配模式，逗号分隔，支持通配符如 claude-*,gpt-4*
+	CostPerInputToken     float64 `json:"costPerInputToken,omitempty"`     // 每百万输入 Token 成本（美元）
+	CostPerOutputToken    float64 `json:"costPerOutputToken,omitempty"`    // 每百万输出 Token 成本（美元）
+	CostPerCacheReadToken float64 `json:"costPerCacheReadToken,omitempty"` // 每百万缓存读取 Token 成本（美元），0 时按输入单价估算
+	QuotaLimit            int64   `json:"quotaLimit,omitempty"`            // 配额限制（token 模式为 Token 数，cost 模式为美元），0 表示无限制
+	QuotaResetCycle       string  `json:"quotaResetCycle,omitempty"`       // 配额重置周期：daily/weekly/monthly/never
+	QuotaMode             string  `json:"quotaMode,omitempty"`             // 配额模式：token（默认，QuotaLimit 为 Token 数）/cost（QuotaLimit 为美元上限）
+	Priority              int     `json:"priority,omitempty"`              // 优先级，数字越小优先级越高，默认100
+	UserAgent             string  `json:"userAgent,omitempty"`             // 发往上游的 User-Agent，为空时透传客户端的 User-Agent
+	SLAP95Ms              int     `json:"slaP95Ms,omitempty"`              // SLA p95 响应时间阈值（毫秒），0 表示使用全局默认阈值
+	Weight                int     `json:"weight,omitempty"`                // 加权轮询权重，0 视为 1
+	Group                 string  `json:"group,omitempty"`                 // 端点分组，请求路径 /{client}/group/{name}/... 只在组内端点间路由
+	HeaderMode            string  `json:"headerMode,omitempty"`            // 请求头透传模式：all（全透传，默认）/whitelist（白名单）/minimal（最小化）
+	HeaderWhitelist       string  `json:"headerWhitelist,omitempty"`       // 白名单模式下额外透传的请求头，逗号分隔，支持前缀通配如 x-stainless-*
+	HealthFields          string  `json:"healthFields,omitempty"`          // 健康检查响应体必须包含的字段，逗号分隔，支持点号路径如 choices.0.message
+	HealthErrorWords      string  `json:"healthErrorWords,omitempty"`      // 健康检查响应体包含任一关键词即判定失败，逗号分隔，不区分大小写
+	HealthCheckPath       string  `json:"healthCheckPath,omitempty"`       // 自定义健康检查路径（如 /health），配置后健康检查只请求该路径，2xx 即健康，不消耗 token
+	HealthCheckMethod     string  `json:"healthCheckMethod,omitempty"`     // 自定义健康检查的 HTTP 方法：GET（默认）/HEAD/POST/OPTIONS
+	ActiveHours           string  `json:"activeHours,omitempty"`           // 活跃时段（如 22:00-06:00，可带时区后缀），不在时段内时不参与路由；为空时一直可用
+	KeyExpiryDate         string  `json:"keyExpiryDate,omitempty"`         // API Key 到期日（YYYY-MM-DD，本机时区），临近到期时告警，过期后自动禁用端点；为空时不过期
+	HideThinking          bool    `json:"hideThinking,omitempty"`          // 不向客户端转发上游的推理内容（reasoning_content → thinking）
+	DisableStreamUsage    bool    `json:"disableStreamUsage,omitempty"`    // openai 流式请求不注入 stream_options.include_usage（上游不支持该字段时开启）
+	PinStatus             bool    `json:"pinStatus,omitempty"`             // 锁定状态：代理请求结果和健康检查不自动修改 status，只能手动修改
+	MaxConcurrency        int     `json:"maxConcurrency,omitempty"`        // 端点最大并发请求数（0 表示不限制）
+	TimeoutSeconds        int     `json:"timeoutSeconds,omitempty"`        // 端点级请求超时（秒），0 表示使用全局 RequestTimeout
+	RefreshToken          string  `json:"refreshToken,omitempty"`          // OAuth refresh token，配置后 APIKey 视为 access token，临近过期时自动刷新
+	TokenExpiry           int64   `json:"tokenExpiry,omitempty"`           // OAuth access token 过期时间（Unix 秒），0 表示未知
+
+	// 多模型配置：端点支持的多个模型（含各自单价、配额），路由时按请求模型在端点内选择
+	Models []EndpointModel `json:"models,omitempty"`
+
+	// 模型名重写规则：原模型名 → 上游模型名，在 transformer 转换前替换请求体中的 model 字段（精确匹配）
+	ModelRewrite map[string]string `json:"modelRewrite,omitempty"`
 }
 
 // IsEnabled 返回端点是否启用（非禁用状态）
@@ -43,10 +74,33 @@ func (e *Endpoint) IsEnabled() bool {
 	return e.Status != EndpointStatusDisabled
 }
 
+// IsRoutable 返回端点是否参与路由（非禁用且非维护状态）
+func (e *Endpoint) IsRoutable() bool {
+	return e.Status != EndpointStatusDisabled && e.Status != EndpointStatusMaintenance
+}
+
 // IsAvailable 返回端点是否可用（可接收请求）
-// 包括已验证可用和未检测状态
+// 包括已验证可用、降级和未检测状态
 func (e *Endpoint) IsAvailable() bool {
-	return e.Status == EndpointStatusAvailable || e.Status == EndpointStatusUntested
+	return e.Status == EndpointStatusAvailable || e.Status == EndpointStatusDegraded || e.Status == EndpointStatusUntested
+}
+
+// HasTag 返回端点标签（逗号分隔）中是否包含指定标签，不区分大小写
+func (e *Endpoint) HasTag(tag string) bool {
+	for _, t := range splitCommaList(e.Tags) {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveWeight 返回加权轮询使用的权重，未设置（0）或非法时视为 1
+func (e *Endpoint) EffectiveWeight() int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
 }
 
 // SetDisabled 设置为禁用状态
@@ -84,7 +138,8 @@ type WebDAVConfig struct {
 
 // LocalBackupConfig represents local backup configuration
 type LocalBackupConfig struct {
-	Dir string `json:"dir"` // Local directory to store backups
+	Dir          string `json:"dir"`                    // Local directory to store backups
+	KeepVersions int    `json:"keepVersions,omitempty"` // 保留的历史版本数，0 表示每次覆盖同名文件
 }
 
 // S3BackupConfig represents S3-compatible backup configuration
@@ -119,13 +174,17 @@ type AlertConfig struct {
 	NotifyOnRecovery     bool `json:"notifyOnRecovery"`     // 恢复时是否通知
 	SystemNotification   bool `json:"systemNotification"`   // 是否发送系统通知
 	AlertCooldownMinutes int  `json:"alertCooldownMinutes"` // 告警冷却时间（分钟），避免频繁告警，默认5分钟
+	AggregationWindowSeconds int `json:"aggregationWindowSeconds"` // 告警聚合窗口（秒），窗口内的通知合并为一条摘要，0表示不聚合，默认30秒
 	// 性能异常告警配置
 	PerformanceAlertEnabled   bool `json:"performanceAlertEnabled"`   // 是否启用性能异常告警
-	LatencyThresholdMs        int  `json:"latencyThresholdMs"`        // 延迟阈值（毫秒），超过此值触发告警，默认5000ms
+	LatencyThresholdMs        int  `json:"latencyThresholdMs"`        // 延迟阈值（毫秒），超过此值触发告警，健康检查超过此值时端点标记为 degraded，默认5000ms
 	LatencyIncreasePercent    int  `json:"latencyIncreasePercent"`    // 延迟增加百分比，相比平均值增加此比例触发告警，默认200%
 	// 自动启用配置
 	AutoEnableOnRecovery      bool `json:"autoEnableOnRecovery"`      // 是否自动启用恢复的端点
 	AutoEnableSuccessThreshold int  `json:"autoEnableSuccessThreshold"` // 连续成功次数阈值，默认3次
+	// Webhook 通知配置
+	WebhookURL      string `json:"webhookUrl"`      // 告警 webhook 地址，为空时不推送
+	WebhookTemplate string `json:"webhookTemplate"` // 自定义请求体模板（Go template），为空时使用默认 JSON
 }
 
 // CacheConfig 请求缓存配置
@@ -135,23 +194,46 @@ type CacheConfig struct {
 	MaxEntries int  `json:"maxEntries"` // 最大缓存条目数，默认1000
 }
 
+// IdempotencyConfig 幂等键去重配置
+type IdempotencyConfig struct {
+	Enabled    bool `json:"enabled"`    // 是否启用幂等键去重
+	TTLSeconds int  `json:"ttlSeconds"` // 幂等结果保留时间（秒），默认600秒（10分钟）
+}
+
+// DedupConfig 请求去重配置（按请求体 hash，防止客户端短时间内重复提交）
+type DedupConfig struct {
+	Enabled       bool `json:"enabled"`       // 是否启用请求去重
+	WindowSeconds int  `json:"windowSeconds"` // 去重时间窗口（秒），默认10秒
+}
+
 // RateLimitConfig 速率限制配置
 type RateLimitConfig struct {
 	Enabled          bool `json:"enabled"`          // 是否启用速率限制
 	GlobalLimit      int  `json:"globalLimit"`      // 全局每分钟最大请求数，默认60
 	PerEndpointLimit int  `json:"perEndpointLimit"` // 每端点每分钟最大请求数，默认30
+	PerClientIPLimit int  `json:"perClientIpLimit"` // 每个客户端 IP 每分钟最大请求数，0 表示不限制
 }
 
+// 会话 ID 的提取来源
+const (
+	SessionKeySourceDefault = ""       // 默认：X-CCNexus-Session-ID / X-Request-ID，否则按客户端 IP + User-Agent 生成
+	SessionKeySourceHeader  = "header" // 从 SessionKeyName 指定的请求头提取
+	SessionKeySourceBody    = "body"   // 从 SessionKeyName 指定的请求体字段提取（点号路径，如 metadata.user_id）
+)
+
 // SessionAffinityConfig 会话亲和性配置
 type SessionAffinityConfig struct {
 	Enabled              bool `json:"enabled"`              // 是否启用会话亲和性
 	SessionTimeoutHours  int  `json:"sessionTimeoutHours"`  // 会话超时时间（小时），默认24
 	MaxConcurrentPerEndpoint int `json:"maxConcurrentPerEndpoint"` // 每端点最大并发会话数，0表示无限制
+	SessionKeySource     string `json:"sessionKeySource,omitempty"` // 会话 ID 来源：空（默认）、header、body
+	SessionKeyName       string `json:"sessionKeyName,omitempty"`   // 来源为 header 时的请求头名，为 body 时的字段路径
 }
 
 // Config represents the application configuration
 type Config struct {
 	Port                       int              `json:"port"`
+	ClientPorts                map[string]int   `json:"clientPorts,omitempty"`         // 各 client type 的独立监听端口（可选），如 {"gemini": 3004}
 	Endpoints                  []Endpoint       `json:"endpoints"`
 	LogLevel                   int              `json:"logLevel"`                      // 0=DEBUG, 1=INFO, 2=WARN, 3=ERROR
 	Language                   string           `json:"language"`                      // UI language: en, zh-CN
@@ -164,13 +246,32 @@ type Config struct {
 	WindowHeight               int              `json:"windowHeight"`                  // Window height in pixels
 	CloseWindowBehavior        string           `json:"closeWindowBehavior,omitempty"` // "quit", "minimize", "ask"
 	HealthCheckInterval        int              `json:"healthCheckInterval"`           // Health check interval in seconds, 0 to disable
+	HealthCheckMethod          string           `json:"healthCheckMethod,omitempty"`   // 健康检查方式: models（默认）, token_count, minimal
+	AutoOptimizeInterval       int              `json:"autoOptimizeInterval"`          // 定时检测并优化端点的间隔（分钟），0 表示关闭
+	StatsTimezone              string           `json:"statsTimezone,omitempty"`       // 统计日期（今天、按天汇总）使用的时区，空为本机时区，如 UTC、Asia/Shanghai、UTC+8
 	HealthHistoryRetentionDays int              `json:"healthHistoryRetentionDays"`    // Health history retention days, default 7
+	InteractionRetentionDays   int              `json:"interactionRetentionDays"`      // 交互记录保留天数，默认 30
 	RequestTimeout             int              `json:"requestTimeout"`                // Request timeout in seconds, 0 for default (300s)
+	StreamHeartbeatInterval    int              `json:"streamHeartbeatInterval"`       // 流式请求首字节前的心跳间隔（秒），0 表示关闭
+	StreamFirstByteTimeout     int              `json:"streamFirstByteTimeout"`        // 流式请求首字节超时（秒），超时切换端点，0 表示禁用
+	MaxRequestBodyBytes        int64            `json:"maxRequestBodyBytes"`           // 请求体大小上限（字节），超过返回 413，0 表示不限制
+	EnableHTTP2                bool             `json:"enableHTTP2"`                   // 上游请求复用共享连接池并优先协商 HTTP/2
+	PerEndpointLogging         bool             `json:"perEndpointLogging"`            // 请求级日志额外按端点名写入单独的日志文件
 	Alert                      *AlertConfig     `json:"alert,omitempty"`               // 端点故障告警配置
 	Cache                      *CacheConfig     `json:"cache,omitempty"`               // 请求缓存配置
+	Idempotency                *IdempotencyConfig `json:"idempotency,omitempty"`       // 幂等键去重配置
+	Dedup                      *DedupConfig     `json:"dedup,omitempty"`               // 请求去重配置
 	RateLimit                  *RateLimitConfig `json:"rateLimit,omitempty"`           // 速率限制配置
 	Routing                    *RoutingConfig   `json:"routing,omitempty"`             // 智能路由配置
+	RoutingByClient            map[string]*RoutingConfig `json:"routingByClient,omitempty"` // 按 client type 覆盖的路由配置，如 {"codex": {...}}，未配置的 client 使用 Routing
 	SessionAffinity            *SessionAffinityConfig `json:"sessionAffinity,omitempty"` // 会话亲和性配置
+	Dashboard                  *DashboardConfig `json:"dashboard,omitempty"`           // 统计仪表盘自定义配置
+	CrossClientFallback        *CrossClientFallbackConfig `json:"crossClientFallback,omitempty"` // 跨 client type 回退配置
+	SLA                        *SLAConfig       `json:"sla,omitempty"`                 // 响应时间 SLA 监控配置
+	RetryBudget                *RetryBudgetConfig `json:"retryBudget,omitempty"`       // 全局重试预算配置
+	TokenRateAlert             *TokenRateAlertConfig `json:"tokenRateAlert,omitempty"` // token 消耗速率告警配置
+	AutoContinue               *AutoContinueConfig `json:"autoContinue,omitempty"`   // max_tokens 截断自动续写配置
+	CircuitBreaker             *CircuitBreakerConfig `json:"circuitBreaker,omitempty"` // 端点熔断配置
 	WebDAV                     *WebDAVConfig    `json:"webdav,omitempty"`              // WebDAV synchronization config
 	Backup                     *BackupConfig    `json:"backup,omitempty"`              // Backup/sync configuration
 	Proxy                      *ProxyConfig     `json:"proxy,omitempty"`               // HTTP proxy config
@@ -186,6 +287,10 @@ func (c *Config) CopyFrom(other *Config) {
 	defer other.mu.RUnlock()
 
 	c.Port = other.Port
+	c.ClientPorts = make(map[string]int, len(other.ClientPorts))
+	for clientType, port := range other.ClientPorts {
+		c.ClientPorts[clientType] = port
+	}
 	c.Endpoints = make([]Endpoint, len(other.Endpoints))
 	copy(c.Endpoints, other.Endpoints)
 	c.LogLevel = other.LogLevel
@@ -199,8 +304,17 @@ func (c *Config) CopyFrom(other *Config) {
 	c.WindowHeight = other.WindowHeight
 	c.CloseWindowBehavior = other.CloseWindowBehavior
 	c.HealthCheckInterval = other.HealthCheckInterval
+	c.HealthCheckMethod = other.HealthCheckMethod
+	c.AutoOptimizeInterval = other.AutoOptimizeInterval
+	c.StatsTimezone = other.StatsTimezone
 	c.HealthHistoryRetentionDays = other.HealthHistoryRetentionDays
+	c.InteractionRetentionDays = other.InteractionRetentionDays
 	c.RequestTimeout = other.RequestTimeout
+	c.StreamHeartbeatInterval = other.StreamHeartbeatInterval
+	c.StreamFirstByteTimeout = other.StreamFirstByteTimeout
+	c.MaxRequestBodyBytes = other.MaxRequestBodyBytes
+	c.EnableHTTP2 = other.EnableHTTP2
+	c.PerEndpointLogging = other.PerEndpointLogging
 
 	if other.WebDAV != nil {
 		c.WebDAV = &WebDAVConfig{
@@ -231,7 +345,8 @@ func (c *Config) CopyFrom(other *Config) {
 		}
 		if other.Backup.Local != nil {
 			c.Backup.Local = &LocalBackupConfig{
-				Dir: other.Backup.Local.Dir,
+				Dir:          other.Backup.Local.Dir,
+				KeepVersions: other.Backup.Local.KeepVersions,
 			}
 		}
 		c.Backup.Provider = other.Backup.Provider
@@ -252,11 +367,14 @@ func (c *Config) CopyFrom(other *Config) {
 			NotifyOnRecovery:           other.Alert.NotifyOnRecovery,
 			SystemNotification:         other.Alert.SystemNotification,
 			AlertCooldownMinutes:       other.Alert.AlertCooldownMinutes,
+			AggregationWindowSeconds:   other.Alert.AggregationWindowSeconds,
 			PerformanceAlertEnabled:    other.Alert.PerformanceAlertEnabled,
 			LatencyThresholdMs:         other.Alert.LatencyThresholdMs,
 			LatencyIncreasePercent:     other.Alert.LatencyIncreasePercent,
 			AutoEnableOnRecovery:       other.Alert.AutoEnableOnRecovery,
 			AutoEnableSuccessThreshold: other.Alert.AutoEnableSuccessThreshold,
+			WebhookURL:                 other.Alert.WebhookURL,
+			WebhookTemplate:            other.Alert.WebhookTemplate,
 		}
 	} else {
 		c.Alert = nil
@@ -272,11 +390,85 @@ func (c *Config) CopyFrom(other *Config) {
 		c.Cache = nil
 	}
 
+	if other.Idempotency != nil {
+		c.Idempotency = &IdempotencyConfig{
+			Enabled:    other.Idempotency.Enabled,
+			TTLSeconds: other.Idempotency.TTLSeconds,
+		}
+	} else {
+		c.Idempotency = nil
+	}
+
+	if other.Dedup != nil {
+		c.Dedup = &DedupConfig{
+			Enabled:       other.Dedup.Enabled,
+			WindowSeconds: other.Dedup.WindowSeconds,
+		}
+	} else {
+		c.Dedup = nil
+	}
+
+	if other.SLA != nil {
+		c.SLA = &SLAConfig{
+			Enabled:              other.SLA.Enabled,
+			DefaultP95Ms:         other.SLA.DefaultP95Ms,
+			WindowMinutes:        other.SLA.WindowMinutes,
+			CheckIntervalMinutes: other.SLA.CheckIntervalMinutes,
+			MinSamples:           other.SLA.MinSamples,
+		}
+	} else {
+		c.SLA = nil
+	}
+
+	if other.RetryBudget != nil {
+		c.RetryBudget = &RetryBudgetConfig{
+			Enabled:       other.RetryBudget.Enabled,
+			Ratio:         other.RetryBudget.Ratio,
+			MinRetries:    other.RetryBudget.MinRetries,
+			WindowSeconds: other.RetryBudget.WindowSeconds,
+		}
+	} else {
+		c.RetryBudget = nil
+	}
+
+	if other.TokenRateAlert != nil {
+		c.TokenRateAlert = &TokenRateAlertConfig{
+			Enabled:            other.TokenRateAlert.Enabled,
+			ThresholdPerMinute: other.TokenRateAlert.ThresholdPerMinute,
+			WindowSeconds:      other.TokenRateAlert.WindowSeconds,
+			CooldownMinutes:    other.TokenRateAlert.CooldownMinutes,
+		}
+	} else {
+		c.TokenRateAlert = nil
+	}
+
+	if other.AutoContinue != nil {
+		c.AutoContinue = &AutoContinueConfig{
+			Enabled:            other.AutoContinue.Enabled,
+			MaxContinuations:   other.AutoContinue.MaxContinuations,
+			ResumeOnDisconnect: other.AutoContinue.ResumeOnDisconnect,
+		}
+	} else {
+		c.AutoContinue = nil
+	}
+
+	if other.CircuitBreaker != nil {
+		c.CircuitBreaker = &CircuitBreakerConfig{
+			Enabled:          other.CircuitBreaker.Enabled,
+			FailureThreshold: other.CircuitBreaker.FailureThreshold,
+			CooldownSeconds:  other.CircuitBreaker.CooldownSeconds,
+			HalfOpenProbes:   other.CircuitBreaker.HalfOpenProbes,
+		}
+	} else {
+		c.CircuitBreaker = nil
+	}
+
 	if other.RateLimit != nil {
 		c.RateLimit = &RateLimitConfig{
 			Enabled:          other.RateLimit.Enabled,
 			GlobalLimit:      other.RateLimit.GlobalLimit,
 			PerEndpointLimit: other.RateLimit.PerEndpointLimit,
+			PerClientIPLimit: other.RateLimit.PerClientIPLimit,
 		}
 	} else {
 		c.RateLimit = nil
@@ -294,25 +486,59 @@ func (c *Config) CopyFrom(other *Config) {
 		c.Routing = nil
 	}
 
+	c.RoutingByClient = make(map[string]*RoutingConfig, len(other.RoutingByClient))
+	for clientType, routing := range other.RoutingByClient {
+		if routing != nil {
+			copied := *routing
+			c.RoutingByClient[clientType] = &copied
+		}
+	}
+
 	if other.SessionAffinity != nil {
 		c.SessionAffinity = &SessionAffinityConfig{
 			Enabled:              other.SessionAffinity.Enabled,
 			SessionTimeoutHours:  other.SessionAffinity.SessionTimeoutHours,
 			MaxConcurrentPerEndpoint: other.SessionAffinity.MaxConcurrentPerEndpoint,
+			SessionKeySource:     other.SessionAffinity.SessionKeySource,
+			SessionKeyName:       other.SessionAffinity.SessionKeyName,
 		}
 	} else {
 		c.SessionAffinity = nil
 	}
+
+	if other.CrossClientFallback != nil {
+		clientTypes := make([]string, len(other.CrossClientFallback.ClientTypes))
+		copy(clientTypes, other.CrossClientFallback.ClientTypes)
+		c.CrossClientFallback = &CrossClientFallbackConfig{
+			Enabled:     other.CrossClientFallback.Enabled,
+			ClientTypes: clientTypes,
+		}
+	} else {
+		c.CrossClientFallback = nil
+	}
+
+	if other.Dashboard != nil {
+		cards := make([]string, len(other.Dashboard.Cards))
+		copy(cards, other.Dashboard.Cards)
+		c.Dashboard = &DashboardConfig{
+			Cards:     cards,
+			TimeRange: other.Dashboard.TimeRange,
+		}
+	} else {
+		c.Dashboard = nil
+	}
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Port:         3003,
-		LogLevel:     1,       // Default to INFO level
-		Language:     "zh-CN", // Default to Chinese
-		WindowWidth:  1024,    // Default window width
-		WindowHeight: 768,     // Default window height
+		Port:                    3003,
+		LogLevel:                1,       // Default to INFO level
+		Language:                "zh-CN", // Default to Chinese
+		WindowWidth:             1024,    // Default window width
+		WindowHeight:            768,     // Default window height
+		StreamHeartbeatInterval: DefaultStreamHeartbeatInterval,
+		MaxRequestBodyBytes:     DefaultMaxRequestBodyBytes,
 		Endpoints: []Endpoint{
 			{
 				Name:        "Claude Official",
@@ -395,8 +621,8 @@ func (c *Config) GetEndpointsByClient(clientType string) []Endpoint {
 	return filtered
 }
 
-// GetEnabledEndpointsByClient returns enabled endpoints filtered by client type (thread-safe)
-// 返回非禁用的端点（可用+不可用）
+// GetEnabledEndpointsByClient returns routable endpoints filtered by client type (thread-safe)
+// 禁用和维护状态的端点不参与路由
 func (c *Config) GetEnabledEndpointsByClient(clientType string) []Endpoint {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -412,7 +638,7 @@ func (c *Config) GetEnabledEndpointsByClient(clientType string) []Endpoint {
 		if epClientType == "" {
 			epClientType = "claude"
 		}
-		if epClientType == clientType && ep.Status != EndpointStatusDisabled {
+		if epClientType == clientType && ep.IsRoutable() {
 			filtered = append(filtered, ep)
 		}
 	}
@@ -494,6 +720,68 @@ func (c *Config) SetEndpointStatus(endpointName, clientType string, status Endpo
 	return fmt.Errorf("endpoint not found: %s (client: %s)", endpointName, clientType)
 }
 
+// SetEndpointStatusAuto 自动设置端点状态（代理请求结果、健康检查等），开启 PinStatus 的端点保持原状态
+// 返回状态是否被修改
+func (c *Config) SetEndpointStatusAuto(endpointName, clientType string, status EndpointStatus) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if clientType == "" {
+		clientType = "claude"
+	}
+
+	for i := range c.Endpoints {
+		epClientType := c.Endpoints[i].ClientType
+		if epClientType == "" {
+			epClientType = "claude"
+		}
+		if c.Endpoints[i].Name == endpointName && epClientType == clientType {
+			if c.Endpoints[i].PinStatus {
+				return false, nil
+			}
+			c.Endpoints[i].Status = status
+			c.Endpoints[i].Enabled = (status != EndpointStatusDisabled)
+			return true, nil
+		}
+	}
+
+	return false, fmt.Errorf("endpoint not found: %s (client: %s)", endpointName, clientType)
+}
+
+// UpdateEndpointToken 更新端点的 OAuth access token、refresh token 和过期时间（token 刷新后调用）
+func (c *Config) UpdateEndpointToken(endpointName, clientType, apiKey, refreshToken string, tokenExpiry int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if clientType == "" {
+		clientType = "claude"
+	}
+
+	for i := range c.Endpoints {
+		epClientType := c.Endpoints[i].ClientType
+		if epClientType == "" {
+			epClientType = "claude"
+		}
+		if c.Endpoints[i].Name == endpointName && epClientType == clientType {
+			c.Endpoints[i].APIKey = apiKey
+			c.Endpoints[i].RefreshToken = refreshToken
+			c.Endpoints[i].TokenExpiry = tokenExpiry
+			return nil
+		}
+	}
+
+	return fmt.Errorf("endpoint not found: %s (client: %s)", endpointName, clientType)
+}
+
+// SetEndpointMaintenance 进入或退出维护模式（用户操作）
+// 进入维护后不再路由新请求；退出维护时直接恢复为可用状态
+func (c *Config) SetEndpointMaintenance(endpointName, clientType string, maintenance bool) error {
+	if maintenance {
+		return c.SetEndpointStatus(endpointName, clientType, EndpointStatusMaintenance)
+	}
+	return c.SetEndpointStatus(endpointName, clientType, EndpointStatusAvailable)
+}
+
 // SetEndpointDisabled 禁用端点（用户操作）
 func (c *Config) SetEndpointDisabled(endpointName, clientType string) error {
 	return c.SetEndpointStatus(endpointName, clientType, EndpointStatusDisabled)
@@ -771,6 +1059,56 @@ func (c *Config) UpdateHealthCheckInterval(interval int) {
 	c.HealthCheckInterval = interval
 }
 
+// GetAutoOptimizeInterval returns the interval in minutes of the scheduled endpoint optimization (thread-safe)
+// Returns 0 if scheduled optimization is disabled
+func (c *Config) GetAutoOptimizeInterval() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AutoOptimizeInterval
+}
+
+// UpdateAutoOptimizeInterval updates the scheduled endpoint optimization interval in minutes (thread-safe)
+// Set to 0 to disable scheduled optimization
+func (c *Config) UpdateAutoOptimizeInterval(interval int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AutoOptimizeInterval = interval
+}
+
+// 健康检查方式
+const (
+	HealthCheckMethodModels     = "models"      // 请求 /v1/models，不消耗 token，不可用时回退到 minimal
+	HealthCheckMethodTokenCount = "token_count" // 请求 count_tokens（仅 Claude，其他 transformer 按 models 处理），不消耗 token
+	HealthCheckMethodMinimal    = "minimal"     // 发送 max_tokens=1 的最小请求，每次约消耗 1-2 个 token
+)
+
+// IsValidHealthCheckMethod reports whether method is a supported health check method
+func IsValidHealthCheckMethod(method string) bool {
+	switch method {
+	case HealthCheckMethodModels, HealthCheckMethodTokenCount, HealthCheckMethodMinimal:
+		return true
+	}
+	return false
+}
+
+// GetHealthCheckMethod returns the health check method (thread-safe)
+// Returns HealthCheckMethodModels if not set
+func (c *Config) GetHealthCheckMethod() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !IsValidHealthCheckMethod(c.HealthCheckMethod) {
+		return HealthCheckMethodModels
+	}
+	return c.HealthCheckMethod
+}
+
+// UpdateHealthCheckMethod updates the health check method (thread-safe)
+func (c *Config) UpdateHealthCheckMethod(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HealthCheckMethod = method
+}
+
 // GetRequestTimeout returns the request timeout in seconds (thread-safe)
 // Returns 0 if using default (300 seconds)
 func (c *Config) GetRequestTimeout() int {
@@ -787,6 +1125,88 @@ func (c *Config) UpdateRequestTimeout(timeout int) {
 	c.RequestTimeout = timeout
 }
 
+// GetEnableHTTP2 returns whether upstream requests use the shared HTTP/2 connection pool (thread-safe)
+func (c *Config) GetEnableHTTP2() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.EnableHTTP2
+}
+
+// UpdateEnableHTTP2 updates the upstream HTTP/2 switch (thread-safe)
+func (c *Config) UpdateEnableHTTP2(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.EnableHTTP2 = enabled
+}
+
+// GetPerEndpointLogging returns whether request logs are also written to per-endpoint files (thread-safe)
+func (c *Config) GetPerEndpointLogging() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PerEndpointLogging
+}
+
+// UpdatePerEndpointLogging updates the per-endpoint log file switch (thread-safe)
+func (c *Config) UpdatePerEndpointLogging(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PerEndpointLogging = enabled
+}
+
+// DefaultStreamHeartbeatInterval 默认流式心跳间隔（秒）
+const DefaultStreamHeartbeatInterval = 15
+
+// GetStreamHeartbeatInterval returns the streaming heartbeat interval in seconds (thread-safe)
+// Returns 0 if heartbeat is disabled
+func (c *Config) GetStreamHeartbeatInterval() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StreamHeartbeatInterval
+}
+
+// UpdateStreamHeartbeatInterval updates the streaming heartbeat interval (thread-safe)
+// Set to 0 to disable heartbeat
+func (c *Config) UpdateStreamHeartbeatInterval(interval int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.StreamHeartbeatInterval = interval
+}
+
+// GetStreamFirstByteTimeout returns the streaming first-byte timeout in seconds (thread-safe)
+// Returns 0 if disabled
+func (c *Config) GetStreamFirstByteTimeout() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StreamFirstByteTimeout
+}
+
+// UpdateStreamFirstByteTimeout updates the streaming first-byte timeout (thread-safe)
+// Set to 0 to disable
+func (c *Config) UpdateStreamFirstByteTimeout(timeout int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.StreamFirstByteTimeout = timeout
+}
+
+// DefaultMaxRequestBodyBytes 默认请求体大小上限（32MB）
+const DefaultMaxRequestBodyBytes int64 = 32 << 20
+
+// GetMaxRequestBodyBytes returns the maximum request body size in bytes (thread-safe)
+// Returns 0 if unlimited
+func (c *Config) GetMaxRequestBodyBytes() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxRequestBodyBytes
+}
+
+// UpdateMaxRequestBodyBytes updates the maximum request body size (thread-safe)
+// Set to 0 for unlimited
+func (c *Config) UpdateMaxRequestBodyBytes(size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxRequestBodyBytes = size
+}
+
 // GetHealthHistoryRetentionDays returns the health history retention days (thread-safe)
 // Returns default 7 if not set
 func (c *Config) GetHealthHistoryRetentionDays() int {
@@ -805,6 +1225,24 @@ func (c *Config) UpdateHealthHistoryRetentionDays(days int) {
 	c.HealthHistoryRetentionDays = days
 }
 
+// GetInteractionRetentionDays returns the interaction record retention days (thread-safe)
+// Returns default 30 if not set
+func (c *Config) GetInteractionRetentionDays() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.InteractionRetentionDays <= 0 {
+		return 30
+	}
+	return c.InteractionRetentionDays
+}
+
+// UpdateInteractionRetentionDays updates the interaction record retention days (thread-safe)
+func (c *Config) UpdateInteractionRetentionDays(days int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.InteractionRetentionDays = days
+}
+
 // GetAlert returns the alert configuration (thread-safe)
 // Returns default config if not set
 func (c *Config) GetAlert() *AlertConfig {
@@ -817,6 +1255,7 @@ func (c *Config) GetAlert() *AlertConfig {
 			NotifyOnRecovery:          true,
 			SystemNotification:        true,
 			AlertCooldownMinutes:      5,
+			AggregationWindowSeconds:  30,
 			PerformanceAlertEnabled:   false,
 			LatencyThresholdMs:        5000,
 			LatencyIncreasePercent:    200,
@@ -827,6 +1266,16 @@ func (c *Config) GetAlert() *AlertConfig {
 	return c.Alert
 }
 
+// HealthyStatusForLatency returns the status of an endpoint that passed a check with the given latency:
+// degraded when it exceeds the alert LatencyThresholdMs, otherwise available (threshold 0 disables degraded)
+func (c *Config) HealthyStatusForLatency(latencyMs float64) EndpointStatus {
+	threshold := c.GetAlert().LatencyThresholdMs
+	if threshold > 0 && latencyMs > float64(threshold) {
+		return EndpointStatusDegraded
+	}
+	return EndpointStatusAvailable
+}
+
 // UpdateAlert updates the alert configuration (thread-safe)
 func (c *Config) UpdateAlert(alert *AlertConfig) {
 	c.mu.Lock()
@@ -856,6 +1305,48 @@ func (c *Config) UpdateCache(cache *CacheConfig) {
 	c.Cache = cache
 }
 
+// GetIdempotency returns the idempotency configuration (thread-safe)
+// Returns default config if not set
+func (c *Config) GetIdempotency() *IdempotencyConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Idempotency == nil {
+		return &IdempotencyConfig{
+			Enabled:    false,
+			TTLSeconds: 600,
+		}
+	}
+	return c.Idempotency
+}
+
+// UpdateIdempotency updates the idempotency configuration (thread-safe)
+func (c *Config) UpdateIdempotency(idempotency *IdempotencyConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Idempotency = idempotency
+}
+
+// GetDedup returns the request dedup configuration (thread-safe)
+// Returns default config if not set
+func (c *Config) GetDedup() *DedupConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Dedup == nil {
+		return &DedupConfig{
+			Enabled:       false,
+			WindowSeconds: 10,
+		}
+	}
+	return c.Dedup
+}
+
+// UpdateDedup updates the request dedup configuration (thread-safe)
+func (c *Config) UpdateDedup(dedup *DedupConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Dedup = dedup
+}
+
 // GetRateLimit returns the rate limit configuration (thread-safe)
 // Returns default config if not set
 func (c *Config) GetRateLimit() *RateLimitConfig {
@@ -926,12 +1417,36 @@ type StorageEndpoint struct {
 	SortOrder   int
 
 	// 智能路由相关字段
-	ModelPatterns      string
-	CostPerInputToken  float64
-	CostPerOutputToken float64
-	QuotaLimit         int64
-	QuotaResetCycle    string
-	Priority           int
+	ModelPatterns         string
+	CostPerInputToken     float64
+	CostPerOutputToken    float64
+	CostPerCacheReadToken float64
+	QuotaLimit            int64
+	QuotaResetCycle       string
+	Priority              int
+	UserAgent             string
+	SLAP95Ms              int
+	Weight                int
+	Group                 string
+	HeaderMode            string
+	HeaderWhitelist       string
+	HealthFields          string
+	HealthErrorWords      string
+	HideThinking          bool
+	MaxConcurrency        int
+	TimeoutSeconds        int
+	RefreshToken          string
+	TokenExpiry           int64
+	APIKeys               string // 逗号分隔的额外 API key
+	Models                string
+	ModelRewrite          string // 模型名重写规则 JSON
+	QuotaMode             string
+	DisableStreamUsage    bool
+	PinStatus             bool
+	HealthCheckPath       string
+	HealthCheckMethod     string
+	ActiveHours           string
+	KeyExpiryDate         string
 }
 
 // LoadFromStorage loads configuration from SQLite storage
@@ -952,22 +1467,46 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 			clientType = "claude"
 		}
 		endpoint := Endpoint{
-			Name:               ep.Name,
-			ClientType:         clientType,
-			APIUrl:             ep.APIUrl,
-			APIKey:             ep.APIKey,
-			Status:             ep.Status, // 加载状态字段
-			Enabled:            ep.Enabled,
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            clientType,
+			APIUrl:                ep.APIUrl,
+			APIKey:                ep.APIKey,
+			Status:                ep.Status, // 加载状态字段
+			Enabled:               ep.Enabled,
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			RefreshToken:          ep.RefreshToken,
+			TokenExpiry:           ep.TokenExpiry,
+			APIKeys:               ParseAPIKeys(ep.APIKeys),
+			Models:                ParseEndpointModels(ep.Models),
+			ModelRewrite:          ParseModelRewrite(ep.ModelRewrite),
+			QuotaMode:             ep.QuotaMode,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
 		}
 
 		// 兼容处理：如果 status 为空，从 enabled 推断
@@ -1002,6 +1541,18 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		config.Port = 3003
 	}
 
+	// Load dedicated client ports
+	for _, clientType := range ClientPortTypes {
+		if portStr, err := storage.GetConfig("client_port_" + clientType); err == nil && portStr != "" {
+			if port, err := strconv.Atoi(portStr); err == nil && port > 0 {
+				if config.ClientPorts == nil {
+					config.ClientPorts = make(map[string]int)
+				}
+				config.ClientPorts[clientType] = port
+			}
+		}
+	}
+
 	if logLevelStr, err := storage.GetConfig("logLevel"); err == nil && logLevelStr != "" {
 		if logLevel, err := strconv.Atoi(logLevelStr); err == nil {
 			config.LogLevel = logLevel
@@ -1104,6 +1655,11 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 	if provider == "local" {
 		backupDir, _ := storage.GetConfig("backup_local_dir")
 		config.Backup.Local = &LocalBackupConfig{Dir: backupDir}
+		if keepStr, _ := storage.GetConfig("backup_local_keepVersions"); keepStr != "" {
+			if keep, err := strconv.Atoi(keepStr); err == nil && keep > 0 {
+				config.Backup.Local.KeepVersions = keep
+			}
+		}
 	}
 	if provider == "s3" {
 		s3Endpoint, _ := storage.GetConfig("backup_s3_endpoint")
@@ -1141,6 +1697,25 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		}
 	}
 
+	// Load auto optimize interval
+	if intervalStr, err := storage.GetConfig("autoOptimizeInterval"); err == nil && intervalStr != "" {
+		if interval, err := strconv.Atoi(intervalStr); err == nil {
+			config.AutoOptimizeInterval = interval
+		}
+	}
+
+	// Load stats time zone
+	if tz, err := storage.GetConfig("statsTimezone"); err == nil {
+		if _, err := ParseStatsTimezone(tz); err == nil {
+			config.StatsTimezone = strings.TrimSpace(tz)
+		}
+	}
+
+	// Load health check method
+	if method, err := storage.GetConfig("healthCheckMethod"); err == nil && IsValidHealthCheckMethod(method) {
+		config.HealthCheckMethod = method
+	}
+
 	// Load health history retention days
 	if retentionStr, err := storage.GetConfig("healthHistoryRetentionDays"); err == nil && retentionStr != "" {
 		if retention, err := strconv.Atoi(retentionStr); err == nil {
@@ -1152,6 +1727,13 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		config.HealthHistoryRetentionDays = 7
 	}
 
+	// Load interaction retention days
+	if retentionStr, err := storage.GetConfig("interactionRetentionDays"); err == nil && retentionStr != "" {
+		if retention, err := strconv.Atoi(retentionStr); err == nil {
+			config.InteractionRetentionDays = retention
+		}
+	}
+
 	// Load request timeout
 	if timeoutStr, err := storage.GetConfig("requestTimeout"); err == nil && timeoutStr != "" {
 		if timeout, err := strconv.Atoi(timeoutStr); err == nil {
@@ -1159,6 +1741,39 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		}
 	}
 
+	// Load upstream HTTP/2 switch
+	if enableHTTP2, err := storage.GetConfig("enableHTTP2"); err == nil && enableHTTP2 != "" {
+		config.EnableHTTP2 = enableHTTP2 == "true"
+	}
+
+	// Load per-endpoint log file switch
+	if perEndpointLogging, err := storage.GetConfig("perEndpointLogging"); err == nil && perEndpointLogging != "" {
+		config.PerEndpointLogging = perEndpointLogging == "true"
+	}
+
+	// Load stream heartbeat interval (default enabled when not set)
+	config.StreamHeartbeatInterval = DefaultStreamHeartbeatInterval
+	if intervalStr, err := storage.GetConfig("streamHeartbeatInterval"); err == nil && intervalStr != "" {
+		if interval, err := strconv.Atoi(intervalStr); err == nil && interval >= 0 {
+			config.StreamHeartbeatInterval = interval
+		}
+	}
+
+	// Load stream first byte timeout
+	if timeoutStr, err := storage.GetConfig("streamFirstByteTimeout"); err == nil && timeoutStr != "" {
+		if timeout, err := strconv.Atoi(timeoutStr); err == nil && timeout >= 0 {
+			config.StreamFirstByteTimeout = timeout
+		}
+	}
+
+	// Load max request body size (default 32MB when not set)
+	config.MaxRequestBodyBytes = DefaultMaxRequestBodyBytes
+	if sizeStr, err := storage.GetConfig("maxRequestBodyBytes"); err == nil && sizeStr != "" {
+		if size, err := strconv.ParseInt(sizeStr, 10, 64); err == nil && size >= 0 {
+			config.MaxRequestBodyBytes = size
+		}
+	}
+
 	// Load alert config
 	if alertEnabled, err := storage.GetConfig("alert_enabled"); err == nil && alertEnabled != "" {
 		config.Alert = &AlertConfig{
@@ -1167,6 +1782,7 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 			NotifyOnRecovery:     true,
 			SystemNotification:   true,
 			AlertCooldownMinutes: 5,
+			AggregationWindowSeconds: 30,
 			AutoEnableOnRecovery: false,
 			AutoEnableSuccessThreshold: 3,
 		}
@@ -1186,6 +1802,11 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 				config.Alert.AlertCooldownMinutes = cooldown
 			}
 		}
+		if windowStr, err := storage.GetConfig("alert_aggregationWindowSeconds"); err == nil && windowStr != "" {
+			if window, err := strconv.Atoi(windowStr); err == nil {
+				config.Alert.AggregationWindowSeconds = window
+			}
+		}
 		if autoEnable, err := storage.GetConfig("alert_autoEnableOnRecovery"); err == nil && autoEnable != "" {
 			config.Alert.AutoEnableOnRecovery = autoEnable == "true"
 		}
@@ -1208,6 +1829,13 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 				config.Alert.LatencyIncreasePercent = latencyIncrease
 			}
 		}
+		// Load webhook fields
+		if webhookURL, err := storage.GetConfig("alert_webhookUrl"); err == nil {
+			config.Alert.WebhookURL = webhookURL
+		}
+		if webhookTemplate, err := storage.GetConfig("alert_webhookTemplate"); err == nil {
+			config.Alert.WebhookTemplate = webhookTemplate
+		}
 	}
 
 	// Load cache config
@@ -1229,6 +1857,135 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		}
 	}
 
+	// Load idempotency config
+	if idempotencyEnabled, err := storage.GetConfig("idempotency_enabled"); err == nil && idempotencyEnabled != "" {
+		config.Idempotency = &IdempotencyConfig{
+			Enabled:    idempotencyEnabled == "true",
+			TTLSeconds: 600,
+		}
+		if ttlStr, err := storage.GetConfig("idempotency_ttlSeconds"); err == nil && ttlStr != "" {
+			if ttl, err := strconv.Atoi(ttlStr); err == nil {
+				config.Idempotency.TTLSeconds = ttl
+			}
+		}
+	}
+
+	// Load dedup config
+	if dedupEnabled, err := storage.GetConfig("dedup_enabled"); err == nil && dedupEnabled != "" {
+		config.Dedup = &DedupConfig{
+			Enabled:       dedupEnabled == "true",
+			WindowSeconds: 10,
+		}
+		if windowStr, err := storage.GetConfig("dedup_windowSeconds"); err == nil && windowStr != "" {
+			if window, err := strconv.Atoi(windowStr); err == nil {
+				config.Dedup.WindowSeconds = window
+			}
+		}
+	}
+
+	// Load SLA config
+	if slaEnabled, err := storage.GetConfig("sla_enabled"); err == nil && slaEnabled != "" {
+		config.SLA = DefaultSLAConfig()
+		config.SLA.Enabled = slaEnabled == "true"
+		if v, err := storage.GetConfig("sla_defaultP95Ms"); err == nil && v != "" {
+			if ms, err := strconv.Atoi(v); err == nil {
+				config.SLA.DefaultP95Ms = ms
+			}
+		}
+		if v, err := storage.GetConfig("sla_windowMinutes"); err == nil && v != "" {
+			if minutes, err := strconv.Atoi(v); err == nil {
+				config.SLA.WindowMinutes = minutes
+			}
+		}
+		if v, err := storage.GetConfig("sla_checkIntervalMinutes"); err == nil && v != "" {
+			if minutes, err := strconv.Atoi(v); err == nil {
+				config.SLA.CheckIntervalMinutes = minutes
+			}
+		}
+		if v, err := storage.GetConfig("sla_minSamples"); err == nil && v != "" {
+			if samples, err := strconv.Atoi(v); err == nil {
+				config.SLA.MinSamples = samples
+			}
+		}
+	}
+
+	// Load retry budget config
+	if budgetEnabled, err := storage.GetConfig("retryBudget_enabled"); err == nil && budgetEnabled != "" {
+		config.RetryBudget = DefaultRetryBudgetConfig()
+		config.RetryBudget.Enabled = budgetEnabled == "true"
+		if v, err := storage.GetConfig("retryBudget_ratio"); err == nil && v != "" {
+			if ratio, err := strconv.ParseFloat(v, 64); err == nil {
+				config.RetryBudget.Ratio = ratio
+			}
+		}
+		if v, err := storage.GetConfig("retryBudget_minRetries"); err == nil && v != "" {
+			if minRetries, err := strconv.Atoi(v); err == nil {
+				config.RetryBudget.MinRetries = minRetries
+			}
+		}
+		if v, err := storage.GetConfig("retryBudget_windowSeconds"); err == nil && v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				config.RetryBudget.WindowSeconds = seconds
+			}
+		}
+	}
+
+	// Load token rate alert config
+	if tokenRateEnabled, err := storage.GetConfig("tokenRateAlert_enabled"); err == nil && tokenRateEnabled != "" {
+		config.TokenRateAlert = DefaultTokenRateAlertConfig()
+		config.TokenRateAlert.Enabled = tokenRateEnabled == "true"
+		if v, err := storage.GetConfig("tokenRateAlert_thresholdPerMinute"); err == nil && v != "" {
+			if threshold, err := strconv.Atoi(v); err == nil {
+				config.TokenRateAlert.ThresholdPerMinute = threshold
+			}
+		}
+		if v, err := storage.GetConfig("tokenRateAlert_windowSeconds"); err == nil && v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				config.TokenRateAlert.WindowSeconds = seconds
+			}
+		}
+		if v, err := storage.GetConfig("tokenRateAlert_cooldownMinutes"); err == nil && v != "" {
+			if minutes, err := strconv.Atoi(v); err == nil {
+				config.TokenRateAlert.CooldownMinutes = minutes
+			}
+		}
+	}
+
+	// Load auto continue config
+	if autoContinueEnabled, err := storage.GetConfig("autoContinue_enabled"); err == nil && autoContinueEnabled != "" {
+		config.AutoContinue = DefaultAutoContinueConfig()
+		config.AutoContinue.Enabled = autoContinueEnabled == "true"
+		if v, err := storage.GetConfig("autoContinue_maxContinuations"); err == nil && v != "" {
+			if maxContinuations, err := strconv.Atoi(v); err == nil {
+				config.AutoContinue.MaxContinuations = maxContinuations
+			}
+		}
+		if v, err := storage.GetConfig("autoContinue_resumeOnDisconnect"); err == nil && v != "" {
+			config.AutoContinue.ResumeOnDisconnect = v == "true"
+		}
+	}
+
+	// Load circuit breaker config
+	if breakerEnabled, err := storage.GetConfig("circuitBreaker_enabled"); err == nil && breakerEnabled != "" {
+		config.CircuitBreaker = DefaultCircuitBreakerConfig()
+		config.CircuitBreaker.Enabled = breakerEnabled == "true"
+		if v, err := storage.GetConfig("circuitBreaker_failureThreshold"); err == nil && v != "" {
+			if threshold, err := strconv.Atoi(v); err == nil {
+				config.CircuitBreaker.FailureThreshold = threshold
+			}
+		}
+		if v, err := storage.GetConfig("circuitBreaker_cooldownSeconds"); err == nil && v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				config.CircuitBreaker.CooldownSeconds = seconds
+			}
+		}
+		if v, err := storage.GetConfig("circuitBreaker_halfOpenProbes"); err == nil && v != "" {
+			if probes, err := strconv.Atoi(v); err == nil {
+				config.CircuitBreaker.HalfOpenProbes = probes
+			}
+		}
+	}
+
 	// Load rate limit config
 	if rateLimitEnabled, err := storage.GetConfig("rateLimit_enabled"); err == nil && rateLimitEnabled != "" {
 		config.RateLimit = &RateLimitConfig{
@@ -1246,6 +2003,11 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 				config.RateLimit.PerEndpointLimit = perEndpointLimit
 			}
 		}
+		if v, err := storage.GetConfig("rateLimit_perClientIpLimit"); err == nil && v != "" {
+			if perClientIPLimit, err := strconv.Atoi(v); err == nil {
+				config.RateLimit.PerClientIPLimit = perClientIPLimit
+			}
+		}
 	}
 
 	// Load routing config
@@ -1267,6 +2029,34 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 		}
 	}
 
+	// Load per-client routing config
+	for _, clientType := range ClientPortTypes {
+		prefix := "routing_" + clientType + "_"
+		if override, err := storage.GetConfig(prefix + "override"); err != nil || override != "true" {
+			continue
+		}
+		routing := &RoutingConfig{}
+		if v, err := storage.GetConfig(prefix + "enableModelRouting"); err == nil {
+			routing.EnableModelRouting = v == "true"
+		}
+		if v, err := storage.GetConfig(prefix + "enableLoadBalance"); err == nil {
+			routing.EnableLoadBalance = v == "true"
+		}
+		if v, err := storage.GetConfig(prefix + "enableCostPriority"); err == nil {
+			routing.EnableCostPriority = v == "true"
+		}
+		if v, err := storage.GetConfig(prefix + "enableQuotaRouting"); err == nil {
+			routing.EnableQuotaRouting = v == "true"
+		}
+		if v, err := storage.GetConfig(prefix + "loadBalanceAlgorithm"); err == nil {
+			routing.LoadBalanceAlgorithm = v
+		}
+		if config.RoutingByClient == nil {
+			config.RoutingByClient = make(map[string]*RoutingConfig)
+		}
+		config.RoutingByClient[clientType] = routing
+	}
+
 	// Load session affinity config
 	if sessionAffinityEnabled, err := storage.GetConfig("sessionAffinity_enabled"); err == nil && sessionAffinityEnabled != "" {
 		config.SessionAffinity = &SessionAffinityConfig{
@@ -1284,6 +2074,39 @@ func LoadFromStorage(storage StorageAdapter) (*Config, error) {
 				config.SessionAffinity.MaxConcurrentPerEndpoint = maxConcurrent
 			}
 		}
+		if source, err := storage.GetConfig("sessionAffinity_sessionKeySource"); err == nil {
+			config.SessionAffinity.SessionKeySource = source
+		}
+		if name, err := storage.GetConfig("sessionAffinity_sessionKeyName"); err == nil {
+			config.SessionAffinity.SessionKeyName = name
+		}
+	}
+
+	// Load cross client fallback config
+	if fallbackEnabled, err := storage.GetConfig("crossClientFallback_enabled"); err == nil && fallbackEnabled != "" {
+		config.CrossClientFallback = &CrossClientFallbackConfig{
+			Enabled:     fallbackEnabled == "true",
+			ClientTypes: []string{},
+		}
+		if clientTypes, err := storage.GetConfig("crossClientFallback_clientTypes"); err == nil && clientTypes != "" {
+			for _, t := range strings.Split(clientTypes, ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					config.CrossClientFallback.ClientTypes = append(config.CrossClientFallback.ClientTypes, t)
+				}
+			}
+		}
+	}
+
+	// Load dashboard config
+	if cards, err := storage.GetConfig("dashboard_cards"); err == nil && cards != "" {
+		config.Dashboard = DefaultDashboardConfig()
+		// 存储的卡片全部无效时保留默认卡片
+		if parsed := parseDashboardCards(cards); len(parsed) > 0 {
+			config.Dashboard.Cards = parsed
+		}
+		if timeRange, err := storage.GetConfig("dashboard_timeRange"); err == nil && IsValidDashboardTimeRange(timeRange) {
+			config.Dashboard.TimeRange = timeRange
+		}
 	}
 
 	return config, nil
@@ -1318,23 +2141,47 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 			clientType = "claude"
 		}
 		endpoint := &StorageEndpoint{
-			Name:               ep.Name,
-			ClientType:         clientType,
-			APIUrl:             ep.APIUrl,
-			APIKey:             ep.APIKey,
-			Status:             ep.Status,  // 保存状态字段
-			Enabled:            ep.Enabled, // 保持向后兼容
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			SortOrder:          i, // Use array index as sort order
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            clientType,
+			APIUrl:                ep.APIUrl,
+			APIKey:                ep.APIKey,
+			Status:                ep.Status,  // 保存状态字段
+			Enabled:               ep.Enabled, // 保持向后兼容
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			SortOrder:             i, // Use array index as sort order
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			RefreshToken:          ep.RefreshToken,
+			TokenExpiry:           ep.TokenExpiry,
+			APIKeys:               EncodeAPIKeys(ep.APIKeys),
+			Models:                EncodeEndpointModels(ep.Models),
+			ModelRewrite:          EncodeModelRewrite(ep.ModelRewrite),
+			QuotaMode:             ep.QuotaMode,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
 		}
 
 		key := clientType + ":" + ep.Name
@@ -1361,6 +2208,9 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 
 	// Save app config
 	storage.SetConfig("port", strconv.Itoa(c.Port))
+	for _, clientType := range ClientPortTypes {
+		storage.SetConfig("client_port_"+clientType, strconv.Itoa(c.ClientPorts[clientType]))
+	}
 	storage.SetConfig("logLevel", strconv.Itoa(c.LogLevel))
 	storage.SetConfig("language", c.Language)
 	storage.SetConfig("theme", c.Theme)
@@ -1386,6 +2236,7 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 		storage.SetConfig("backup_provider", c.Backup.Provider)
 		if c.Backup.Local != nil {
 			storage.SetConfig("backup_local_dir", c.Backup.Local.Dir)
+			storage.SetConfig("backup_local_keepVersions", strconv.Itoa(c.Backup.Local.KeepVersions))
 		}
 		if c.Backup.S3 != nil {
 			storage.SetConfig("backup_s3_endpoint", c.Backup.S3.Endpoint)
@@ -1409,13 +2260,36 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 
 	// Save health check interval
 	storage.SetConfig("healthCheckInterval", strconv.Itoa(c.HealthCheckInterval))
+	storage.SetConfig("autoOptimizeInterval", strconv.Itoa(c.AutoOptimizeInterval))
+	storage.SetConfig("statsTimezone", c.StatsTimezone)
+
+	// Save health check method
+	storage.SetConfig("healthCheckMethod", c.HealthCheckMethod)
 
 	// Save health history retention days
 	storage.SetConfig("healthHistoryRetentionDays", strconv.Itoa(c.HealthHistoryRetentionDays))
 
+	// Save interaction retention days
+	storage.SetConfig("interactionRetentionDays", strconv.Itoa(c.InteractionRetentionDays))
+
 	// Save request timeout
 	storage.SetConfig("requestTimeout", strconv.Itoa(c.RequestTimeout))
 
+	// Save upstream HTTP/2 switch
+	storage.SetConfig("enableHTTP2", strconv.FormatBool(c.EnableHTTP2))
+
+	// Save per-endpoint log file switch
+	storage.SetConfig("perEndpointLogging", strconv.FormatBool(c.PerEndpointLogging))
+
+	// Save stream heartbeat interval
+	storage.SetConfig("streamHeartbeatInterval", strconv.Itoa(c.StreamHeartbeatInterval))
+
+	// Save stream first byte timeout
+	storage.SetConfig("streamFirstByteTimeout", strconv.Itoa(c.StreamFirstByteTimeout))
+
+	// Save max request body size
+	storage.SetConfig("maxRequestBodyBytes", strconv.FormatInt(c.MaxRequestBodyBytes, 10))
+
 	// Save alert config
 	if c.Alert != nil {
 		storage.SetConfig("alert_enabled", strconv.FormatBool(c.Alert.Enabled))
@@ -1423,11 +2297,14 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 		storage.SetConfig("alert_notifyOnRecovery", strconv.FormatBool(c.Alert.NotifyOnRecovery))
 		storage.SetConfig("alert_systemNotification", strconv.FormatBool(c.Alert.SystemNotification))
 		storage.SetConfig("alert_cooldownMinutes", strconv.Itoa(c.Alert.AlertCooldownMinutes))
+		storage.SetConfig("alert_aggregationWindowSeconds", strconv.Itoa(c.Alert.AggregationWindowSeconds))
 		storage.SetConfig("alert_autoEnableOnRecovery", strconv.FormatBool(c.Alert.AutoEnableOnRecovery))
 		storage.SetConfig("alert_autoEnableSuccessThreshold", strconv.Itoa(c.Alert.AutoEnableSuccessThreshold))
 		storage.SetConfig("alert_performanceAlertEnabled", strconv.FormatBool(c.Alert.PerformanceAlertEnabled))
 		storage.SetConfig("alert_latencyThresholdMs", strconv.Itoa(c.Alert.LatencyThresholdMs))
 		storage.SetConfig("alert_latencyIncreasePercent", strconv.Itoa(c.Alert.LatencyIncreasePercent))
+		storage.SetConfig("alert_webhookUrl", c.Alert.WebhookURL)
+		storage.SetConfig("alert_webhookTemplate", c.Alert.WebhookTemplate)
 	}
 
 	// Save cache config
@@ -1437,11 +2314,64 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 		storage.SetConfig("cache_maxEntries", strconv.Itoa(c.Cache.MaxEntries))
 	}
 
+	// Save idempotency config
+	if c.Idempotency != nil {
+		storage.SetConfig("idempotency_enabled", strconv.FormatBool(c.Idempotency.Enabled))
+		storage.SetConfig("idempotency_ttlSeconds", strconv.Itoa(c.Idempotency.TTLSeconds))
+	}
+
+	// Save dedup config
+	if c.Dedup != nil {
+		storage.SetConfig("dedup_enabled", strconv.FormatBool(c.Dedup.Enabled))
+		storage.SetConfig("dedup_windowSeconds", strconv.Itoa(c.Dedup.WindowSeconds))
+	}
+
+	// Save SLA config
+	if c.SLA != nil {
+		storage.SetConfig("sla_enabled", strconv.FormatBool(c.SLA.Enabled))
+		storage.SetConfig("sla_defaultP95Ms", strconv.Itoa(c.SLA.DefaultP95Ms))
+		storage.SetConfig("sla_windowMinutes", strconv.Itoa(c.SLA.WindowMinutes))
+		storage.SetConfig("sla_checkIntervalMinutes", strconv.Itoa(c.SLA.CheckIntervalMinutes))
+		storage.SetConfig("sla_minSamples", strconv.Itoa(c.SLA.MinSamples))
+	}
+
+	// Save retry budget config
+	if c.RetryBudget != nil {
+		storage.SetConfig("retryBudget_enabled", strconv.FormatBool(c.RetryBudget.Enabled))
+		storage.SetConfig("retryBudget_ratio", strconv.FormatFloat(c.RetryBudget.Ratio, 'f', -1, 64))
+		storage.SetConfig("retryBudget_minRetries", strconv.Itoa(c.RetryBudget.MinRetries))
+		storage.SetConfig("retryBudget_windowSeconds", strconv.Itoa(c.RetryBudget.WindowSeconds))
+	}
+
+	// Save token rate alert config
+	if c.TokenRateAlert != nil {
+		storage.SetConfig("tokenRateAlert_enabled", strconv.FormatBool(c.TokenRateAlert.Enabled))
+		storage.SetConfig("tokenRateAlert_thresholdPerMinute", strconv.Itoa(c.TokenRateAlert.ThresholdPerMinute))
+		storage.SetConfig("tokenRateAlert_windowSeconds", strconv.Itoa(c.TokenRateAlert.WindowSeconds))
+		storage.SetConfig("tokenRateAlert_cooldownMinutes", strconv.Itoa(c.TokenRateAlert.CooldownMinutes))
+	}
+
+	// Save auto continue config
+	if c.AutoContinue != nil {
+		storage.SetConfig("autoContinue_enabled", strconv.FormatBool(c.AutoContinue.Enabled))
+		storage.SetConfig("autoContinue_maxContinuations", strconv.Itoa(c.AutoContinue.MaxContinuations))
+		storage.SetConfig("autoContinue_resumeOnDisconnect", strconv.FormatBool(c.AutoContinue.ResumeOnDisconnect))
+	}
+
+	// Save circuit breaker config
+	if c.CircuitBreaker != nil {
+		storage.SetConfig("circuitBreaker_enabled", strconv.FormatBool(c.CircuitBreaker.Enabled))
+		storage.SetConfig("circuitBreaker_failureThreshold", strconv.Itoa(c.CircuitBreaker.FailureThreshold))
+		storage.SetConfig("circuitBreaker_cooldownSeconds", strconv.Itoa(c.CircuitBreaker.CooldownSeconds))
+		storage.SetConfig("circuitBreaker_halfOpenProbes", strconv.Itoa(c.CircuitBreaker.HalfOpenProbes))
+	}
+
 	// Save rate limit config
 	if c.RateLimit != nil {
 		storage.SetConfig("rateLimit_enabled", strconv.FormatBool(c.RateLimit.Enabled))
 		storage.SetConfig("rateLimit_globalLimit", strconv.Itoa(c.RateLimit.GlobalLimit))
 		storage.SetConfig("rateLimit_perEndpointLimit", strconv.Itoa(c.RateLimit.PerEndpointLimit))
+		storage.SetConfig("rateLimit_perClientIpLimit", strconv.Itoa(c.RateLimit.PerClientIPLimit))
 	}
 
 	// Save routing config
@@ -1453,11 +2383,40 @@ func (c *Config) SaveToStorage(storage StorageAdapter) error {
 		storage.SetConfig("routing_loadBalanceAlgorithm", c.Routing.LoadBalanceAlgorithm)
 	}
 
+	// Save per-client routing config
+	for _, clientType := range ClientPortTypes {
+		prefix := "routing_" + clientType + "_"
+		routing := c.RoutingByClient[clientType]
+		storage.SetConfig(prefix+"override", strconv.FormatBool(routing != nil))
+		if routing == nil {
+			continue
+		}
+		storage.SetConfig(prefix+"enableModelRouting", strconv.FormatBool(routing.EnableModelRouting))
+		storage.SetConfig(prefix+"enableLoadBalance", strconv.FormatBool(routing.EnableLoadBalance))
+		storage.SetConfig(prefix+"enableCostPriority", strconv.FormatBool(routing.EnableCostPriority))
+		storage.SetConfig(prefix+"enableQuotaRouting", strconv.FormatBool(routing.EnableQuotaRouting))
+		storage.SetConfig(prefix+"loadBalanceAlgorithm", routing.LoadBalanceAlgorithm)
+	}
+
 	// Save session affinity config
 	if c.SessionAffinity != nil {
 		storage.SetConfig("sessionAffinity_enabled", strconv.FormatBool(c.SessionAffinity.Enabled))
 		storage.SetConfig("sessionAffinity_timeoutHours", strconv.Itoa(c.SessionAffinity.SessionTimeoutHours))
 		storage.SetConfig("sessionAffinity_maxConcurrentPerEndpoint", strconv.Itoa(c.SessionAffinity.MaxConcurrentPerEndpoint))
+		storage.SetConfig("sessionAffinity_sessionKeySource", c.SessionAffinity.SessionKeySource)
+		storage.SetConfig("sessionAffinity_sessionKeyName", c.SessionAffinity.SessionKeyName)
+	}
+
+	// Save cross client fallback config
+	if c.CrossClientFallback != nil {
+		storage.SetConfig("crossClientFallback_enabled", strconv.FormatBool(c.CrossClientFallback.Enabled))
+		storage.SetConfig("crossClientFallback_clientTypes", strings.Join(c.CrossClientFallback.ClientTypes, ","))
+	}
+
+	// Save dashboard config
+	if c.Dashboard != nil {
+		storage.SetConfig("dashboard_cards", strings.Join(c.Dashboard.Cards, ","))
+		storage.SetConfig("dashboard_timeRange", c.Dashboard.TimeRange)
 	}
 
 	return nil
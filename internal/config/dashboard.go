@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DashboardCards 统计面板支持的指标卡片（默认展示顺序）
+var DashboardCards = []string{"requests", "tokens", "successRate", "avgTokens", "cache", "speed", "cost"}
+
+// DashboardConfig 统计仪表盘自定义配置
+type DashboardConfig struct {
+	Cards     []string `json:"cards"`     // 展示的指标卡片，按数组顺序排列
+	TimeRange string   `json:"timeRange"` // 默认时间范围：daily、yesterday、weekly、monthly
+}
+
+// DefaultDashboardConfig 返回默认仪表盘配置（展示全部卡片，时间范围为今日）
+func DefaultDashboardConfig() *DashboardConfig {
+	cards := make([]string, len(DashboardCards))
+	copy(cards, DashboardCards)
+	return &DashboardConfig{
+		Cards:     cards,
+		TimeRange: "daily",
+	}
+}
+
+// IsValidDashboardCard 检查卡片标识是否受支持
+func IsValidDashboardCard(card string) bool {
+	for _, c := range DashboardCards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidDashboardTimeRange 检查时间范围是否受支持
+func IsValidDashboardTimeRange(timeRange string) bool {
+	switch timeRange {
+	case "daily", "yesterday", "weekly", "monthly":
+		return true
+	}
+	return false
+}
+
+// Validate 校验仪表盘配置：至少一个卡片，卡片不可重复，时间范围需受支持
+func (d *DashboardConfig) Validate() error {
+	if len(d.Cards) == 0 {
+		return fmt.Errorf("at least one dashboard card is required")
+	}
+	seen := make(map[string]bool)
+	for _, card := range d.Cards {
+		if !IsValidDashboardCard(card) {
+			return fmt.Errorf("invalid dashboard card: %s", card)
+		}
+		if seen[card] {
+			return fmt.Errorf("duplicate dashboard card: %s", card)
+		}
+		seen[card] = true
+	}
+	if !IsValidDashboardTimeRange(d.TimeRange) {
+		return fmt.Errorf("invalid dashboard time range: %s", d.TimeRange)
+	}
+	return nil
+}
+
+// GetDashboard 获取仪表盘配置的副本（线程安全），未设置时返回默认配置
+func (c *Config) GetDashboard() *DashboardConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Dashboard == nil {
+		return DefaultDashboardConfig()
+	}
+	dashboard := *c.Dashboard
+	dashboard.Cards = append([]string(nil), c.Dashboard.Cards...)
+	return &dashboard
+}
+
+// UpdateDashboard 更新仪表盘配置（线程安全）
+func (c *Config) UpdateDashboard(dashboard *DashboardConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Dashboard = dashboard
+}
+
+// parseDashboardCards 解析逗号分隔的卡片列表，忽略不支持或重复的卡片
+func parseDashboardCards(value string) []string {
+	cards := []string{}
+	seen := make(map[string]bool)
+	for _, card := range strings.Split(value, ",") {
+		card = strings.TrimSpace(card)
+		if card == "" || seen[card] || !IsValidDashboardCard(card) {
+			continue
+		}
+		seen[card] = true
+		cards = append(cards, card)
+	}
+	return cards
+}
@@ -0,0 +1,39 @@
+package config
+
+import "strings"
+
+// 请求头透传模式
+const (
+	HeaderModeAll       = "all"       // 全透传：转发客户端的全部请求头（包括 x-stainless-* 等客户端指纹），默认
+	HeaderModeWhitelist = "whitelist" // 白名单：只转发协议必需的请求头和 HeaderWhitelist 中列出的请求头
+	HeaderModeMinimal   = "minimal"   // 最小化：只转发协议必需的请求头
+)
+
+// IsValidHeaderMode 判断请求头透传模式是否合法，空值视为全透传
+func IsValidHeaderMode(mode string) bool {
+	switch mode {
+	case "", HeaderModeAll, HeaderModeWhitelist, HeaderModeMinimal:
+		return true
+	}
+	return false
+}
+
+// EffectiveHeaderMode 返回端点实际使用的请求头透传模式，未设置时为全透传
+func (e *Endpoint) EffectiveHeaderMode() string {
+	if e.HeaderMode == "" {
+		return HeaderModeAll
+	}
+	return e.HeaderMode
+}
+
+// HeaderWhitelistPatterns 解析白名单请求头（逗号分隔，统一小写，支持 x-stainless-* 前缀通配）
+func (e *Endpoint) HeaderWhitelistPatterns() []string {
+	var patterns []string
+	for _, item := range strings.Split(e.HeaderWhitelist, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
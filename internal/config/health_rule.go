@@ -0,0 +1,29 @@
+package config
+
+import "strings"
+
+// splitCommaList 解析逗号分隔的配置项，去除空白和空项
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// HealthFieldList 返回健康检查响应体必须包含的字段路径（点号分隔，如 choices.0.message）
+func (e *Endpoint) HealthFieldList() []string {
+	return splitCommaList(e.HealthFields)
+}
+
+// HealthErrorWordList 返回健康检查的错误关键词（统一小写，匹配时不区分大小写）
+func (e *Endpoint) HealthErrorWordList() []string {
+	words := splitCommaList(e.HealthErrorWords)
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+	return words
+}
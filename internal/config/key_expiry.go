@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeyExpiryDateLayout 端点 API Key 到期日的格式
+const KeyExpiryDateLayout = "2006-01-02"
+
+// ParseKeyExpiryDate parses a key expiry date (YYYY-MM-DD, local time zone); an empty value returns the zero time
+// 返回值为到期日次日 0 点，即到期日当天仍可用
+func ParseKeyExpiryDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation(KeyExpiryDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid key expiry date '%s', expected YYYY-MM-DD", value)
+	}
+	return date.AddDate(0, 0, 1), nil
+}
+
+// KeyExpiresAt 返回端点 API Key 失效的时刻；未配置或无法解析时 ok 为 false
+func (e *Endpoint) KeyExpiresAt() (expiresAt time.Time, ok bool) {
+	expiresAt, err := ParseKeyExpiryDate(e.KeyExpiryDate)
+	if err != nil || expiresAt.IsZero() {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/pricing"
+)
+
+// EndpointModel 端点支持的单个模型配置
+// 单价、配额为 0 时沿用端点级别的配置
+type EndpointModel struct {
+	Name               string  `json:"name"`                         // 模型名称，支持通配符如 claude-*、*-opus
+	CostPerInputToken  float64 `json:"costPerInputToken,omitempty"`  // 每百万输入 Token 成本（美元）
+	CostPerOutputToken float64 `json:"costPerOutputToken,omitempty"` // 每百万输出 Token 成本（美元）
+	QuotaLimit         int64   `json:"quotaLimit,omitempty"`         // 该模型的配额（单位随端点 QuotaMode），0 表示不单独限制
+}
+
+// ParseEndpointModels 解析存储中的模型列表 JSON，无效数据返回 nil
+func ParseEndpointModels(data string) []EndpointModel {
+	if strings.TrimSpace(data) == "" {
+		return nil
+	}
+	var models []EndpointModel
+	if err := json.Unmarshal([]byte(data), &models); err != nil {
+		return nil
+	}
+	return models
+}
+
+// EncodeEndpointModels 将模型列表编码为 JSON 用于存储，空列表返回空字符串
+func EncodeEndpointModels(models []EndpointModel) string {
+	if len(models) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(models)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ParseModelRewrite 解析存储中的模型名重写规则 JSON（原模型名 → 上游模型名），无效数据返回 nil
+func ParseModelRewrite(data string) map[string]string {
+	if strings.TrimSpace(data) == "" {
+		return nil
+	}
+	var rules map[string]string
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		return nil
+	}
+	return rules
+}
+
+// EncodeModelRewrite 将模型名重写规则编码为 JSON 用于存储，无规则返回空字符串
+func EncodeModelRewrite(rules map[string]string) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// RewriteModel 按端点的重写规则返回发往上游的模型名，规则只做精确匹配，没有匹配规则时原样返回
+func (e *Endpoint) RewriteModel(model string) (string, bool) {
+	if model == "" || len(e.ModelRewrite) == 0 {
+		return model, false
+	}
+	if target, ok := e.ModelRewrite[model]; ok && target != "" {
+		return target, true
+	}
+	return model, false
+}
+
+// matchModelName 通配符匹配："*" 匹配所有，"claude-*" 前缀匹配，"*-opus" 后缀匹配，否则精确匹配
+func matchModelName(pattern, model string) bool {
+	pattern = strings.TrimSpace(pattern)
+	switch {
+	case pattern == "":
+		return false
+	case pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(model, strings.TrimSuffix(pattern, "*"))
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(model, strings.TrimPrefix(pattern, "*"))
+	default:
+		return model == pattern
+	}
+}
+
+// MatchModel 返回端点中与请求模型匹配的模型配置，精确匹配优先于通配符匹配
+func (e *Endpoint) MatchModel(model string) *EndpointModel {
+	if model == "" || len(e.Models) == 0 {
+		return nil
+	}
+	for i := range e.Models {
+		if e.Models[i].Name == model {
+			return &e.Models[i]
+		}
+	}
+	for i := range e.Models {
+		if matchModelName(e.Models[i].Name, model) {
+			return &e.Models[i]
+		}
+	}
+	return nil
+}
+
+// CostForModel 返回请求模型在该端点上的单价（每百万 Token），模型未配置单价时使用端点单价
+func (e *Endpoint) CostForModel(model string) (input, output float64) {
+	input, output = e.CostPerInputToken, e.CostPerOutputToken
+	if m := e.MatchModel(model); m != nil {
+		if m.CostPerInputToken > 0 {
+			input = m.CostPerInputToken
+		}
+		if m.CostPerOutputToken > 0 {
+			output = m.CostPerOutputToken
+		}
+	}
+	return input, output
+}
+
+// EndpointPricing 将端点配置的单价（每百万 Token）转换为成本估算使用的定价：
+// 缓存写入按输入单价计算，未配置缓存读取单价时同样按输入单价估算（偏保守）；单价为 0 时对应花费记为 0
+func EndpointPricing(input, output, cacheRead float64) pricing.ModelPricing {
+	if cacheRead <= 0 {
+		cacheRead = input
+	}
+	return pricing.ModelPricing{
+		InputPrice:      input,
+		OutputPrice:     output,
+		CacheWritePrice: input,
+		CacheReadPrice:  cacheRead,
+	}
+}
+
+// Pricing 返回端点级别单价对应的定价，用于按端点汇总的统计估算花费
+func (e *Endpoint) Pricing() pricing.ModelPricing {
+	return EndpointPricing(e.CostPerInputToken, e.CostPerOutputToken, e.CostPerCacheReadToken)
+}
+
+// ForModel 返回用于转发该请求的端点副本：
+// 请求模型在端点的多模型列表中时，将其作为发往上游的模型，而不是使用端点的默认 Model
+func (e Endpoint) ForModel(model string) Endpoint {
+	if e.MatchModel(model) != nil {
+		e.Model = model
+	}
+	return e
+}
+
+// 配额模式
+const (
+	QuotaModeToken = "token" // 按 Token 数限额（默认）
+	QuotaModeCost  = "cost"  // 按成本限额，QuotaLimit 表示美元上限
+)
+
+// IsValidQuotaMode 检查配额模式是否合法，空值视为 token
+func IsValidQuotaMode(mode string) bool {
+	switch mode {
+	case "", QuotaModeToken, QuotaModeCost:
+		return true
+	}
+	return false
+}
+
+// IsCostQuota 返回端点配额（含模型级配额）是否按成本计算
+func (e *Endpoint) IsCostQuota() bool {
+	return e.QuotaMode == QuotaModeCost
+}
+
+// QuotaCost 按请求模型在该端点上的单价计算一次请求的成本（美元），用于 cost 模式的配额累计
+func (e *Endpoint) QuotaCost(model string, inputTokens, cacheWriteTokens, cacheReadTokens, outputTokens int) float64 {
+	input, output := e.CostForModel(model)
+	return pricing.CalculateCost(inputTokens, outputTokens, cacheWriteTokens, cacheReadTokens,
+		EndpointPricing(input, output, e.CostPerCacheReadToken))
+}
+
+// ModelQuotaName 返回模型级配额在配额跟踪中使用的名称
+func ModelQuotaName(endpointName, model string) string {
+	return endpointName + "#" + model
+}
+
+// SplitModelQuotaName 拆分模型级配额名称，非模型级配额时 model 为空
+func SplitModelQuotaName(name string) (endpointName, model string) {
+	if idx := strings.Index(name, "#"); idx >= 0 {
+		return name[:idx], name[idx+1:]
+	}
+	return name, ""
+}
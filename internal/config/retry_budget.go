@@ -0,0 +1,36 @@
+package config
+
+// RetryBudgetConfig 全局重试预算配置，用于限制单位时间内的重试次数，避免上游故障时重试放大
+type RetryBudgetConfig struct {
+	Enabled       bool    `json:"enabled"`       // 是否启用重试预算
+	Ratio         float64 `json:"ratio"`         // 窗口内允许的重试次数占正常请求数的比例，默认0.1（10%）
+	MinRetries    int     `json:"minRetries"`    // 窗口内始终允许的最少重试次数，避免低流量时无法重试，默认10
+	WindowSeconds int     `json:"windowSeconds"` // 统计窗口（秒），默认60
+}
+
+// DefaultRetryBudgetConfig 返回默认重试预算配置
+func DefaultRetryBudgetConfig() *RetryBudgetConfig {
+	return &RetryBudgetConfig{
+		Enabled:       false,
+		Ratio:         0.1,
+		MinRetries:    10,
+		WindowSeconds: 60,
+	}
+}
+
+// GetRetryBudget 获取重试预算配置（线程安全），未设置时返回默认配置
+func (c *Config) GetRetryBudget() *RetryBudgetConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RetryBudget == nil {
+		return DefaultRetryBudgetConfig()
+	}
+	return c.RetryBudget
+}
+
+// UpdateRetryBudget 更新重试预算配置（线程安全）
+func (c *Config) UpdateRetryBudget(budget *RetryBudgetConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RetryBudget = budget
+}
@@ -8,7 +8,8 @@ type RoutingConfig struct {
 	EnableCostPriority bool `json:"enableCostPriority"` // 启用成本优先
 	EnableQuotaRouting bool `json:"enableQuotaRouting"` // 启用配额路由
 
-	// 负载均衡算法：fastest（最快响应）、weighted（加权随机）、round_robin（轮询）
+	// 负载均衡算法：fastest（最快响应）、weighted（加权随机）、round_robin（轮询）、
+	// weighted_round_robin（按端点 Weight 加权轮询）、least_connections（在途请求数最少）
 	LoadBalanceAlgorithm string `json:"loadBalanceAlgorithm"`
 }
 
@@ -34,6 +35,50 @@ func (c *Config) GetRoutingConfig() *RoutingConfig {
 	return c.Routing
 }
 
+// GetRoutingConfigForClient 获取指定 client type 生效的路由配置：有独立配置时使用独立配置，否则使用全局配置（线程安全）
+func (c *Config) GetRoutingConfigForClient(clientType string) *RoutingConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if routing, ok := c.RoutingByClient[clientType]; ok && routing != nil {
+		return routing
+	}
+	if c.Routing == nil {
+		return DefaultRoutingConfig()
+	}
+	return c.Routing
+}
+
+// GetRoutingByClient 获取各 client type 的独立路由配置（线程安全）
+func (c *Config) GetRoutingByClient() map[string]*RoutingConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[string]*RoutingConfig, len(c.RoutingByClient))
+	for clientType, routing := range c.RoutingByClient {
+		if routing != nil {
+			copied := *routing
+			result[clientType] = &copied
+		}
+	}
+	return result
+}
+
+// UpdateClientRoutingConfig 设置指定 client type 的独立路由配置，routing 为 nil 时删除独立配置、改用全局配置（线程安全）
+func (c *Config) UpdateClientRoutingConfig(clientType string, routing *RoutingConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if routing == nil {
+		delete(c.RoutingByClient, clientType)
+		return
+	}
+	if c.RoutingByClient == nil {
+		c.RoutingByClient = make(map[string]*RoutingConfig)
+	}
+	c.RoutingByClient[clientType] = routing
+}
+
 // UpdateRoutingConfig 更新路由配置（线程安全）
 func (c *Config) UpdateRoutingConfig(routing *RoutingConfig) {
 	c.mu.Lock()
@@ -78,3 +123,55 @@ func (c *Config) GetLoadBalanceAlgorithm() string {
 	}
 	return c.Routing.LoadBalanceAlgorithm
 }
+
+// GetLoadBalanceAlgorithmForClient 获取指定 client type 生效的负载均衡算法
+func (c *Config) GetLoadBalanceAlgorithmForClient(clientType string) string {
+	if algorithm := c.GetRoutingConfigForClient(clientType).LoadBalanceAlgorithm; algorithm != "" {
+		return algorithm
+	}
+	return "round_robin"
+}
+
+// CrossClientFallbackConfig 跨 client type 兜底回退配置
+// 当本 client type 的所有端点都失败时，按顺序尝试其它 client type 中可用且 transformer 能转换的端点
+type CrossClientFallbackConfig struct {
+	Enabled     bool     `json:"enabled"`     // 是否启用跨 client type 回退（默认关闭，需显式开启）
+	ClientTypes []string `json:"clientTypes"` // 允许回退到的 client type，按顺序尝试，例如 ["gemini", "codex"]
+}
+
+// GetCrossClientFallback 获取跨 client type 回退配置（线程安全）
+func (c *Config) GetCrossClientFallback() *CrossClientFallbackConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CrossClientFallback == nil {
+		return &CrossClientFallbackConfig{
+			Enabled:     false,
+			ClientTypes: []string{},
+		}
+	}
+	return c.CrossClientFallback
+}
+
+// UpdateCrossClientFallback 更新跨 client type 回退配置（线程安全）
+func (c *Config) UpdateCrossClientFallback(fallback *CrossClientFallbackConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CrossClientFallback = fallback
+}
+
+// GetCrossClientFallbackTypes 获取指定 client type 可回退到的其它 client type（未启用时返回 nil）
+func (c *Config) GetCrossClientFallbackTypes(clientType string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.CrossClientFallback == nil || !c.CrossClientFallback.Enabled {
+		return nil
+	}
+
+	var types []string
+	for _, t := range c.CrossClientFallback.ClientTypes {
+		if t != "" && t != clientType {
+			types = append(types, t)
+		}
+	}
+	return types
+}
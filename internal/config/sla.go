@@ -0,0 +1,46 @@
+package config
+
+// SLAConfig 端点响应时间 SLA 监控配置
+type SLAConfig struct {
+	Enabled              bool `json:"enabled"`              // 是否启用 SLA 监控
+	DefaultP95Ms         int  `json:"defaultP95Ms"`         // 默认 p95 响应时间阈值（毫秒），端点未单独配置时使用，默认3000
+	WindowMinutes        int  `json:"windowMinutes"`        // 计算 p95 的统计窗口（分钟），默认60
+	CheckIntervalMinutes int  `json:"checkIntervalMinutes"` // 检查间隔（分钟），默认5
+	MinSamples           int  `json:"minSamples"`           // 窗口内最少请求数，不足时不判定，默认10
+}
+
+// DefaultSLAConfig 返回默认 SLA 配置
+func DefaultSLAConfig() *SLAConfig {
+	return &SLAConfig{
+		Enabled:              false,
+		DefaultP95Ms:         3000,
+		WindowMinutes:        60,
+		CheckIntervalMinutes: 5,
+		MinSamples:           10,
+	}
+}
+
+// GetSLA 获取 SLA 配置（线程安全），未设置时返回默认配置
+func (c *Config) GetSLA() *SLAConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.SLA == nil {
+		return DefaultSLAConfig()
+	}
+	return c.SLA
+}
+
+// UpdateSLA 更新 SLA 配置（线程安全）
+func (c *Config) UpdateSLA(sla *SLAConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SLA = sla
+}
+
+// GetEndpointSLAThreshold 返回端点的 p95 阈值（毫秒），端点未配置时使用默认阈值
+func (c *Config) GetEndpointSLAThreshold(endpoint Endpoint) int {
+	if endpoint.SLAP95Ms > 0 {
+		return endpoint.SLAP95Ms
+	}
+	return c.GetSLA().DefaultP95Ms
+}
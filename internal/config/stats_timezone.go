@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// statsLocations 已解析的统计时区，避免每次记录统计都重新加载时区数据
+var statsLocations sync.Map // map[string]*time.Location
+
+// ParseStatsTimezone parses a stats time zone: empty for the local time zone, an IANA name (e.g. Asia/Shanghai),
+// UTC, or a UTC offset (e.g. UTC+8, +05:30)
+func ParseStatsTimezone(tz string) (*time.Location, error) {
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		return time.Local, nil
+	}
+	if loc, ok := statsLocations.Load(tz); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := parseActiveHoursLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	statsLocations.Store(tz, loc)
+	return loc, nil
+}
+
+// GetStatsTimezone returns the time zone used for stats dates, empty for the local time zone (thread-safe)
+func (c *Config) GetStatsTimezone() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StatsTimezone
+}
+
+// UpdateStatsTimezone validates and updates the time zone used for stats dates (thread-safe)
+func (c *Config) UpdateStatsTimezone(tz string) error {
+	tz = strings.TrimSpace(tz)
+	if _, err := ParseStatsTimezone(tz); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.StatsTimezone = tz
+	return nil
+}
+
+// StatsLocation returns the location used to compute stats dates ("today", date columns);
+// 未配置或配置无法解析时使用本机时区
+func (c *Config) StatsLocation() *time.Location {
+	loc, err := ParseStatsTimezone(c.GetStatsTimezone())
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// StatsNow returns the current time in the stats time zone
+func (c *Config) StatsNow() time.Time {
+	return time.Now().In(c.StatsLocation())
+}
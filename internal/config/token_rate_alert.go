@@ -0,0 +1,36 @@
+package config
+
+// TokenRateAlertConfig token 消耗速率告警配置
+type TokenRateAlertConfig struct {
+	Enabled            bool `json:"enabled"`            // 是否启用 token 消耗速率告警
+	ThresholdPerMinute int  `json:"thresholdPerMinute"` // 每分钟 token 消耗阈值（含缓存 token），超过时告警，默认200000
+	WindowSeconds      int  `json:"windowSeconds"`      // 计算速率的滑动窗口（秒），默认60
+	CooldownMinutes    int  `json:"cooldownMinutes"`    // 告警冷却时间（分钟），持续超阈值时避免重复告警，默认10
+}
+
+// DefaultTokenRateAlertConfig 返回默认 token 消耗速率告警配置
+func DefaultTokenRateAlertConfig() *TokenRateAlertConfig {
+	return &TokenRateAlertConfig{
+		Enabled:            false,
+		ThresholdPerMinute: 200000,
+		WindowSeconds:      60,
+		CooldownMinutes:    10,
+	}
+}
+
+// GetTokenRateAlert 获取 token 消耗速率告警配置（线程安全），未设置时返回默认配置
+func (c *Config) GetTokenRateAlert() *TokenRateAlertConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.TokenRateAlert == nil {
+		return DefaultTokenRateAlertConfig()
+	}
+	return c.TokenRateAlert
+}
+
+// UpdateTokenRateAlert 更新 token 消耗速率告警配置（线程安全）
+func (c *Config) UpdateTokenRateAlert(alert *TokenRateAlertConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TokenRateAlert = alert
+}
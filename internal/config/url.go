@@ -0,0 +1,51 @@
+package config
+
+import "strings"
+
+// NormalizeAPIUrl ensures the endpoint base URL has a scheme and no trailing slash.
+// The base URL may carry a path prefix, e.g. https://host/proxy/anthropic
+func NormalizeAPIUrl(apiUrl string) string {
+	apiUrl = strings.TrimSpace(apiUrl)
+	if !strings.HasPrefix(apiUrl, "http://") && !strings.HasPrefix(apiUrl, "https://") {
+		apiUrl = "https://" + apiUrl
+	}
+	return strings.TrimRight(apiUrl, "/")
+}
+
+// JoinAPIUrl joins an endpoint base URL with an API path such as /v1/messages.
+// 路径前缀原样保留：https://host/proxy/anthropic + /v1/messages → https://host/proxy/anthropic/v1/messages；
+// base URL 已以版本段结尾时（如 https://host/api/v1）不再重复拼接 /v1
+func JoinAPIUrl(apiUrl, apiPath string) string {
+	base := NormalizeAPIUrl(apiUrl)
+	if apiPath == "" {
+		return base
+	}
+	if !strings.HasPrefix(apiPath, "/") {
+		apiPath = "/" + apiPath
+	}
+
+	// base URL 的最后一段路径与 apiPath 的第一段相同且为版本号时去掉重复
+	if idx := strings.LastIndex(base, "/"); idx >= 0 && idx > strings.Index(base, "://")+2 {
+		lastSegment := base[idx:]
+		if isAPIVersionSegment(lastSegment) && (apiPath == lastSegment ||
+			strings.HasPrefix(apiPath, lastSegment+"/") || strings.HasPrefix(apiPath, lastSegment+"?")) {
+			apiPath = strings.TrimPrefix(apiPath, lastSegment)
+		}
+	}
+
+	return base + apiPath
+}
+
+// isAPIVersionSegment reports whether a path segment (with leading slash) is an API version like /v1 or /v1beta
+func isAPIVersionSegment(segment string) bool {
+	version := strings.TrimPrefix(segment, "/")
+	if len(version) < 2 || version[0] != 'v' || version[1] < '0' || version[1] > '9' {
+		return false
+	}
+	for _, c := range version[1:] {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// HeaderNames 识别的幂等键请求头（按顺序查找）
+var HeaderNames = []string{"Idempotency-Key", "X-Idempotency-Key"}
+
+// State 幂等键检查结果
+type State int
+
+const (
+	StateNew      State = iota // 首次出现，调用方应正常请求上游
+	StateReplay                // 已有完成的结果，直接回放
+	StateInFlight              // 相同 key 的请求仍在处理中
+	StateMismatch              // 相同 key 但请求体不同（key 被误复用）
+)
+
+// Entry 幂等结果条目
+type Entry struct {
+	Key         string    `json:"key"`
+	BodyHash    string    `json:"bodyHash"` // 首次请求体的 SHA256，用于识别 key 被复用到不同请求
+	StatusCode  int       `json:"statusCode"`
+	ContentType string    `json:"contentType"`
+	Response    []byte    `json:"response"` // 首次请求返回给客户端的响应
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	ReplayCount int       `json:"replayCount"` // 回放次数
+}
+
+// Stats 幂等缓存统计
+type Stats struct {
+	Enabled        bool  `json:"enabled"`
+	TTLSeconds     int   `json:"ttlSeconds"`
+	TotalEntries   int   `json:"totalEntries"`
+	InFlight       int   `json:"inFlight"`
+	TotalReplays   int64 `json:"totalReplays"`
+	TotalConflicts int64 `json:"totalConflicts"` // 处理中或请求体不一致而被拒绝的次数
+}
+
+// Cache 幂等键缓存，与普通内容缓存（internal/cache）相互独立：
+// 内容缓存按请求内容命中，这里只按客户端显式携带的幂等键命中
+type Cache struct {
+	entries        map[string]*Entry
+	inFlight       map[string]string // key -> bodyHash，处理中的请求
+	mu             sync.Mutex
+	ttl            time.Duration
+	maxEntries     int
+	enabled        bool
+	totalReplays   int64
+	totalConflicts int64
+}
+
+// New 创建幂等键缓存
+func New(enabled bool, ttlSeconds int) *Cache {
+	if ttlSeconds <= 0 {
+		ttlSeconds = 600 // 默认10分钟
+	}
+
+	c := &Cache{
+		entries:    make(map[string]*Entry),
+		inFlight:   make(map[string]string),
+		ttl:        time.Duration(ttlSeconds) * time.Second,
+		maxEntries: 1000,
+		enabled:    enabled,
+	}
+
+	// 启动清理协程
+	go c.cleanupLoop()
+
+	return c
+}
+
+// ExtractKey 从请求头提取幂等键，未携带时返回空字符串
+func ExtractKey(r *http.Request) string {
+	for _, name := range HeaderNames {
+		if key := strings.TrimSpace(r.Header.Get(name)); key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+// HashBody 计算请求体哈希
+func HashBody(body []byte) string {
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// IsEnabled 返回是否启用
+func (c *Cache) IsEnabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enabled
+}
+
+// Begin 开始处理带幂等键的请求
+// 返回 StateNew 时 key 被标记为处理中，调用方必须在结束时调用 Complete 或 Release
+func (c *Cache) Begin(key, bodyHash string) (*Entry, State) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, exists := c.entries[key]; exists {
+		if time.Now().After(entry.ExpiresAt) {
+			delete(c.entries, key)
+		} else if entry.BodyHash != bodyHash {
+			c.totalConflicts++
+			return nil, StateMismatch
+		} else {
+			entry.ReplayCount++
+			c.totalReplays++
+			replay := *entry
+			return &replay, StateReplay
+		}
+	}
+
+	if hash, exists := c.inFlight[key]; exists {
+		c.totalConflicts++
+		if hash != bodyHash {
+			return nil, StateMismatch
+		}
+		return nil, StateInFlight
+	}
+
+	c.inFlight[key] = bodyHash
+	return nil, StateNew
+}
+
+// Complete 保存首次请求的结果并结束处理中状态
+func (c *Cache) Complete(key string, statusCode int, contentType string, response []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bodyHash, exists := c.inFlight[key]
+	if !exists {
+		return
+	}
+	delete(c.inFlight, key)
+
+	if len(c.entries) >= c.maxEntries {
+		c.evictOldest()
+	}
+
+	now := time.Now()
+	c.entries[key] = &Entry{
+		Key:         key,
+		BodyHash:    bodyHash,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Response:    response,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(c.ttl),
+	}
+	logger.Debug("[IDEMPOTENCY] Stored result for key: %s (ttl: %v)", key, c.ttl)
+}
+
+// Release 结束处理中状态但不保存结果（请求失败或结果不可回放时调用，允许客户端用同一 key 重试）
+func (c *Cache) Release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inFlight, key)
+}
+
+// evictOldest 清除最旧的条目（需要持有锁）
+func (c *Cache) evictOldest() {
+	var oldestKey string
+	var oldestTime time.Time
+
+	for key, entry := range c.entries {
+		if oldestKey == "" || entry.CreatedAt.Before(oldestTime) {
+			oldestKey = key
+			oldestTime = entry.CreatedAt
+		}
+	}
+
+	if oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// cleanupLoop 定期清理过期条目
+func (c *Cache) cleanupLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.cleanup()
+	}
+}
+
+// cleanup 清理过期条目
+func (c *Cache) cleanup() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	expired := 0
+	for key, entry := range c.entries {
+		if now.After(entry.ExpiresAt) {
+			delete(c.entries, key)
+			expired++
+		}
+	}
+
+	if expired > 0 {
+		logger.Debug("[IDEMPOTENCY] Cleaned up %d expired entries", expired)
+	}
+}
+
+// GetStats 获取统计信息
+func (c *Cache) GetStats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Enabled:        c.enabled,
+		TTLSeconds:     int(c.ttl / time.Second),
+		TotalEntries:   len(c.entries),
+		InFlight:       len(c.inFlight),
+		TotalReplays:   c.totalReplays,
+		TotalConflicts: c.totalConflicts,
+	}
+}
+
+// Clear 清空已保存的结果（处理中的请求不受影响）
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*Entry)
+	logger.Info("[IDEMPOTENCY] Cache cleared")
+}
+
+// UpdateConfig 更新配置
+func (c *Cache) UpdateConfig(enabled bool, ttlSeconds int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.enabled = enabled
+	if ttlSeconds > 0 {
+		c.ttl = time.Duration(ttlSeconds) * time.Second
+	}
+
+	logger.Info("[IDEMPOTENCY] Config updated: enabled=%v, ttl=%v", enabled, c.ttl)
+}
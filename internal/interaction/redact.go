@@ -0,0 +1,81 @@
+package interaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactMask 脱敏后替换匹配内容的文本
+const RedactMask = "***"
+
+// CompileRedactPatterns 编译脱敏正则列表，忽略空白项；任一正则无效时返回错误
+func CompileRedactPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// redactRecord 返回脱敏后的记录副本：只替换请求/响应 JSON 中的字符串值，对象的键和结构保持不变
+func redactRecord(record *Record, patterns []*regexp.Regexp) *Record {
+	if len(patterns) == 0 {
+		return record
+	}
+	redacted := *record
+	redacted.Request.Raw = redactValue(record.Request.Raw, patterns)
+	redacted.Request.Transformed = redactValue(record.Request.Transformed, patterns)
+	redacted.Response.Raw = redactValue(record.Response.Raw, patterns)
+	redacted.Response.Transformed = redactValue(record.Response.Transformed, patterns)
+	return &redacted
+}
+
+// redactValue 递归遍历 JSON 值并对字符串做替换，返回新值，不修改原值（原值可能仍被代理流程使用）
+func redactValue(value interface{}, patterns []*regexp.Regexp) interface{} {
+	switch v := value.(type) {
+	case nil, bool, float64, json.Number:
+		return v
+	case string:
+		return redactString(v, patterns)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = redactValue(item, patterns)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = redactValue(item, patterns)
+		}
+		return result
+	default:
+		// 其他类型（如结构体）先转为通用 JSON 值再脱敏
+		data, err := json.Marshal(v)
+		if err != nil {
+			return v
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return v
+		}
+		return redactValue(generic, patterns)
+	}
+}
+
+func redactString(s string, patterns []*regexp.Regexp) string {
+	for _, re := range patterns {
+		s = re.ReplaceAllLiteralString(s, RedactMask)
+	}
+	return s
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -15,9 +16,11 @@ import (
 
 // Storage 交互记录文件存储
 type Storage struct {
-	baseDir string
-	enabled bool
-	mu      sync.RWMutex
+	baseDir        string
+	enabled        bool
+	redactPatterns []string         // 脱敏正则（原始文本）
+	redactRegexps  []*regexp.Regexp // 编译后的脱敏正则
+	mu             sync.RWMutex
 }
 
 // NewStorage 创建新的存储实例
@@ -42,6 +45,34 @@ func (s *Storage) IsEnabled() bool {
 	return s.enabled
 }
 
+// SetRedactPatterns 设置保存记录前用于脱敏的正则列表，任一正则无效时不做修改并返回错误
+func (s *Storage) SetRedactPatterns(patterns []string) error {
+	compiled, err := CompileRedactPatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	kept := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			kept = append(kept, pattern)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.redactPatterns = kept
+	s.redactRegexps = compiled
+	return nil
+}
+
+// GetRedactPatterns 获取脱敏正则列表
+func (s *Storage) GetRedactPatterns() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string{}, s.redactPatterns...)
+}
+
 // GenerateRequestID 生成唯一的请求 ID
 func GenerateRequestID() string {
 	return uuid.New().String()
@@ -70,8 +101,11 @@ func (s *Storage) Save(record *Record) error {
 	fileName := fmt.Sprintf("%s-%s.json", timeStr, shortID)
 	filePath := filepath.Join(dirPath, fileName)
 
-	// 序列化为 JSON
-	data, err := json.MarshalIndent(record, "", "  ")
+	// 脱敏后序列化为 JSON
+	s.mu.RLock()
+	redactRegexps := s.redactRegexps
+	s.mu.RUnlock()
+	data, err := json.MarshalIndent(redactRecord(record, redactRegexps), "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal record: %w", err)
 	}
@@ -184,6 +218,22 @@ func (s *Storage) GetInteraction(date, requestID string) (*Record, error) {
 	return nil, fmt.Errorf("interaction not found: %s", requestID)
 }
 
+// GetInteractionByID 按 requestID 查找交互记录，从最近的日期开始查找
+func (s *Storage) GetInteractionByID(requestID string) (*Record, error) {
+	dates, err := s.GetDates()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, date := range dates {
+		if record, err := s.GetInteraction(date, requestID); err == nil {
+			return record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("interaction not found: %s", requestID)
+}
+
 // readRecordFile 读取单个记录文件
 func (s *Storage) readRecordFile(filePath string) (*Record, error) {
 	data, err := os.ReadFile(filePath)
@@ -199,9 +249,9 @@ func (s *Storage) readRecordFile(filePath string) (*Record, error) {
 	return &record, nil
 }
 
-// Cleanup 清理超过指定天数的旧记录
-func (s *Storage) Cleanup(daysToKeep int) (int, error) {
-	cutoffDate := time.Now().AddDate(0, 0, -daysToKeep).Format("2006-01-02")
+// CleanupOlderThan 清理超过指定天数的旧记录，返回删除的日期目录数
+func (s *Storage) CleanupOlderThan(days int) (int, error) {
+	cutoffDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
 
 	entries, err := os.ReadDir(s.baseDir)
 	if err != nil {
@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 按 key 分文件记录日志（如按端点名记录请求级日志），文件为 <dir>/<key>.log
+const (
+	keyedMaxFileSize  = 10 << 20 // 单个文件超过 10MB 时轮转
+	keyedMaxBackups   = 3        // 轮转保留的历史文件数：<key>.log.1 ~ <key>.log.3
+	keyedMaxOpenFiles = 64       // 同时打开的文件句柄上限，超过时关闭最久未写入的文件
+)
+
+// keyedFile 一个 key 对应的日志文件
+type keyedFile struct {
+	file     *os.File
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// SetKeyedDir sets the directory for per-key log files; open files are closed when the directory changes
+func (l *Logger) SetKeyedDir(dir string) {
+	l.keyedMu.Lock()
+	defer l.keyedMu.Unlock()
+
+	if dir == l.keyedDir {
+		return
+	}
+	l.closeKeyedFilesLocked()
+	l.keyedDir = dir
+}
+
+// SetKeyedEnabled enables or disables per-key log files; disabling closes all open files
+func (l *Logger) SetKeyedEnabled(enabled bool) error {
+	l.keyedMu.Lock()
+	defer l.keyedMu.Unlock()
+
+	if !enabled {
+		l.closeKeyedFilesLocked()
+		l.keyedEnabled = false
+		return nil
+	}
+
+	if l.keyedDir == "" {
+		return fmt.Errorf("per-key log directory is not set")
+	}
+	if err := os.MkdirAll(l.keyedDir, 0755); err != nil {
+		return err
+	}
+	l.keyedEnabled = true
+	return nil
+}
+
+// KeyedEnabled returns whether per-key log files are enabled
+func (l *Logger) KeyedEnabled() bool {
+	l.keyedMu.Lock()
+	defer l.keyedMu.Unlock()
+	return l.keyedEnabled
+}
+
+// LogKey adds a log entry like Log and also appends it to the log file of the given key
+func (l *Logger) LogKey(key string, level LogLevel, format string, args ...interface{}) {
+	l.Log(level, format, args...)
+
+	if key == "" || level < l.GetMinLevel() {
+		return
+	}
+
+	l.keyedMu.Lock()
+	defer l.keyedMu.Unlock()
+
+	if !l.keyedEnabled {
+		return
+	}
+
+	line := fmt.Sprintf("%s [%s] %s\n", time.Now().Format("2006-01-02 15:04:05.000"), level.String(), fmt.Sprintf(format, args...))
+	if err := l.writeKeyedLocked(key, line); err != nil {
+		fmt.Printf("%s [%s] Failed to write log file for %s: %v\n", WARN.Icon(), WARN.String(), key, err)
+	}
+}
+
+// writeKeyedLocked 写入 key 对应的文件，必要时先轮转，调用方需持有 keyedMu
+func (l *Logger) writeKeyedLocked(key, line string) error {
+	kf, err := l.openKeyedLocked(key)
+	if err != nil {
+		return err
+	}
+
+	if kf.size > 0 && kf.size+int64(len(line)) > keyedMaxFileSize {
+		if err := l.rotateKeyedLocked(key, kf); err != nil {
+			return err
+		}
+		if kf, err = l.openKeyedLocked(key); err != nil {
+			return err
+		}
+	}
+
+	n, err := kf.file.WriteString(line)
+	kf.size += int64(n)
+	kf.lastUsed = time.Now()
+	return err
+}
+
+// openKeyedLocked 返回 key 对应的已打开文件，句柄数达到上限时先关闭最久未写入的文件
+func (l *Logger) openKeyedLocked(key string) (*keyedFile, error) {
+	if kf, ok := l.keyedFiles[key]; ok {
+		return kf, nil
+	}
+
+	if len(l.keyedFiles) >= keyedMaxOpenFiles {
+		var oldestKey string
+		var oldest time.Time
+		for k, kf := range l.keyedFiles {
+			if oldestKey == "" || kf.lastUsed.Before(oldest) {
+				oldestKey, oldest = k, kf.lastUsed
+			}
+		}
+		l.keyedFiles[oldestKey].file.Close()
+		delete(l.keyedFiles, oldestKey)
+	}
+
+	path := filepath.Join(l.keyedDir, keyedFileName(key))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	var size int64
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+
+	if l.keyedFiles == nil {
+		l.keyedFiles = make(map[string]*keyedFile)
+	}
+	kf := &keyedFile{file: f, path: path, size: size, lastUsed: time.Now()}
+	l.keyedFiles[key] = kf
+	return kf, nil
+}
+
+// rotateKeyedLocked 关闭当前文件并依次重命名：<key>.log.2 → .3，.1 → .2，<key>.log → .1，最旧的被覆盖
+func (l *Logger) rotateKeyedLocked(key string, kf *keyedFile) error {
+	kf.file.Close()
+	delete(l.keyedFiles, key)
+
+	for i := keyedMaxBackups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", kf.path, i)
+		if _, err := os.Stat(src); err == nil {
+			os.Rename(src, fmt.Sprintf("%s.%d", kf.path, i+1))
+		}
+	}
+	return os.Rename(kf.path, kf.path+".1")
+}
+
+// closeKeyedFilesLocked 关闭所有已打开的文件，调用方需持有 keyedMu
+func (l *Logger) closeKeyedFilesLocked() {
+	for key, kf := range l.keyedFiles {
+		kf.file.Close()
+		delete(l.keyedFiles, key)
+	}
+}
+
+// keyedFileName 将 key 转换为安全的文件名，路径分隔符等特殊字符替换为下划线
+func keyedFileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		case r > 127:
+			return r // 保留中文等非 ASCII 字符
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.Trim(name, ".")
+	if name == "" {
+		name = "_"
+	}
+	return name + ".log"
+}
+
+// Per-key convenience methods, e.g. logger.InfoKey(endpoint.Name, "[%s] ...", endpoint.Name)
+func DebugKey(key, format string, args ...interface{}) {
+	GetLogger().LogKey(key, DEBUG, format, args...)
+}
+
+func InfoKey(key, format string, args ...interface{}) {
+	GetLogger().LogKey(key, INFO, format, args...)
+}
+
+func WarnKey(key, format string, args ...interface{}) {
+	GetLogger().LogKey(key, WARN, format, args...)
+}
+
+func ErrorKey(key, format string, args ...interface{}) {
+	GetLogger().LogKey(key, ERROR, format, args...)
+}
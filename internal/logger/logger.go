@@ -65,6 +65,12 @@ type Logger struct {
 	consoleLevel LogLevel // Minimum level to print to console
 	debugFile    *os.File // Debug log file (only in debug mode)
 	debugMu      sync.Mutex
+
+	// 按 key 分文件记录的日志（见 keyed.go）
+	keyedMu      sync.Mutex
+	keyedDir     string
+	keyedEnabled bool
+	keyedFiles   map[string]*keyedFile
 }
 
 var (
@@ -220,7 +226,7 @@ func (l *Logger) DebugLog(format string, args ...interface{}) {
 	fmt.Fprintf(l.debugFile, "[%s] %s\n", timestamp, message)
 }
 
-// Close closes the debug log file
+// Close closes the debug log file and per-key log files
 func (l *Logger) Close() {
 	l.debugMu.Lock()
 	if l.debugFile != nil {
@@ -228,6 +234,10 @@ func (l *Logger) Close() {
 		l.debugFile = nil
 	}
 	l.debugMu.Unlock()
+
+	l.keyedMu.Lock()
+	l.closeKeyedFilesLocked()
+	l.keyedMu.Unlock()
 }
 
 // DebugLog writes to debug.log file (convenience function)
@@ -3,6 +3,7 @@ package notify
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -32,8 +33,12 @@ type NotificationBatcher struct {
 	mu            sync.Mutex
 	timer         *time.Timer
 	batchWindow   time.Duration // 批处理窗口时间
+	aggregate     bool          // 聚合模式：窗口固定从第一条通知开始计时，窗口内的通知合并为一条摘要
 }
 
+// defaultBatchWindow 未启用聚合时的默认批处理窗口
+const defaultBatchWindow = 2 * time.Second
+
 // defaultBatcher 默认批处理器
 var defaultBatcher *NotificationBatcher
 
@@ -41,7 +46,23 @@ func init() {
 	// 创建默认批处理器，批处理窗口为 2 秒
 	defaultBatcher = &NotificationBatcher{
 		notifications: make([]batchedNotification, 0),
-		batchWindow:   2 * time.Second,
+		batchWindow:   defaultBatchWindow,
+	}
+}
+
+// SetAggregationWindow 设置告警聚合窗口
+// seconds > 0 时，窗口内的所有故障/恢复/警告通知合并为一条摘要（如「3 个端点故障，2 个已恢复」）；
+// seconds <= 0 时恢复默认的 2 秒批处理（按类型分别发送）
+func SetAggregationWindow(seconds int) {
+	defaultBatcher.mu.Lock()
+	defer defaultBatcher.mu.Unlock()
+
+	if seconds > 0 {
+		defaultBatcher.batchWindow = time.Duration(seconds) * time.Second
+		defaultBatcher.aggregate = true
+	} else {
+		defaultBatcher.batchWindow = defaultBatchWindow
+		defaultBatcher.aggregate = false
 	}
 }
 
@@ -57,6 +78,11 @@ func (b *NotificationBatcher) addNotification(title, message, notifyType string)
 		notifyType: notifyType,
 	})
 
+	// 聚合模式下窗口从第一条通知开始计时，不因新通知而延后，避免持续抖动时一直不发送
+	if b.aggregate && b.timer != nil {
+		return
+	}
+
 	// 如果定时器已存在，重置它
 	if b.timer != nil {
 		b.timer.Stop()
@@ -96,6 +122,21 @@ func (b *NotificationBatcher) flush() {
 		}
 	}
 
+	// 聚合模式：多条通知合并为一条摘要
+	if b.aggregate && len(b.notifications) > 1 {
+		notifyType := "info"
+		if len(failures) > 0 {
+			notifyType = "error"
+		} else if len(warnings) > 0 {
+			notifyType = "warning"
+		}
+		sendDirect("ccNexus", formatSummaryMessage(failures, recoveries, warnings, others), notifyType)
+
+		b.notifications = b.notifications[:0]
+		b.timer = nil
+		return
+	}
+
 	// 发送合并后的通知
 	if len(recoveries) > 0 {
 		if len(recoveries) == 1 {
@@ -154,6 +195,39 @@ func formatBatchMessage(category string, messages []string) string {
 	return result
 }
 
+// formatSummaryMessage 格式化聚合摘要消息
+// 第一行为汇总（如「3 个端点故障，2 个已恢复」），之后列出最多 3 条明细，故障优先
+func formatSummaryMessage(failures, recoveries, warnings, others []string) string {
+	parts := make([]string, 0, 3)
+	if len(failures) > 0 {
+		parts = append(parts, fmt.Sprintf("%d 个端点故障", len(failures)))
+	}
+	if len(recoveries) > 0 {
+		parts = append(parts, fmt.Sprintf("%d 个已恢复", len(recoveries)))
+	}
+	if len(warnings) > 0 {
+		parts = append(parts, fmt.Sprintf("%d 个警告", len(warnings)))
+	}
+	summary := strings.Join(parts, "，")
+	if summary == "" {
+		summary = fmt.Sprintf("%d 条通知", len(others))
+	}
+
+	details := make([]string, 0, len(failures)+len(recoveries)+len(warnings)+len(others))
+	details = append(details, failures...)
+	details = append(details, recoveries...)
+	details = append(details, warnings...)
+	details = append(details, others...)
+
+	const maxDetails = 3
+	if len(details) > maxDetails {
+		remaining := len(details) - maxDetails
+		details = append(details[:maxDetails], fmt.Sprintf("…另有 %d 条", remaining))
+	}
+
+	return summary + "\n" + strings.Join(details, "\n")
+}
+
 // sendDirect 直接发送通知（不经过批处理）
 func sendDirect(title, message, notifyType string) error {
 	if DefaultNotifier == nil {
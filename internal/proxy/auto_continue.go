@@ -0,0 +1,720 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/transformer"
+)
+
+// stopReasonMaxTokens 响应因达到 max_tokens 被截断时的 stop_reason
+const stopReasonMaxTokens = "max_tokens"
+
+// maxStreamResumes 上游流中途断开时单个请求最多换端点续传的次数
+const maxStreamResumes = 2
+
+// errContinuationRejected 重试预算耗尽、端点熔断或并发名额等待超时，续写请求未发出
+var errContinuationRejected = errors.New("continuation request not admitted")
+
+// autoContinuer 在响应因 max_tokens 截断时向同一端点发起续写请求，在上游流中途断开时换其他端点续传。
+// 续写请求在原始请求的消息末尾追加已生成的 assistant 文本（prefill），由模型接着写下去。
+// 每次续写/续传都是一次独立的上游请求，与普通请求一样计入重试预算、熔断和端点并发，并单独记录统计。
+type autoContinuer struct {
+	p                *Proxy
+	r                *http.Request
+	endpoint         config.Endpoint
+	trans            transformer.Transformer
+	transformerName  string
+	body             []byte          // 客户端格式的原始请求体
+	model            string          // 客户端请求的模型
+	requestID        string          // 链路追踪 ID，续写请求沿用原请求的 ID
+	priority         RequestPriority // 续写请求排队占用并发名额时沿用原请求的优先级
+	maxContinuations int             // 为 0 表示未启用 max_tokens 自动续写
+	resume           bool            // 上游流中途断开时是否换端点续传
+	resumed          bool            // 已切换到续传端点，该端点不是 client type 的当前端点
+}
+
+// newAutoContinuer 返回请求可用的续写器，未启用或请求不适合续写时返回 nil。
+// 仅支持 Claude 格式的请求；开启 thinking 的请求无法使用 assistant prefill，不续写。
+// 声明了 tools 的请求可能产生有副作用的工具调用，重放不安全，不做断线续传。
+func (p *Proxy) newAutoContinuer(r *http.Request, clientFormat ClientFormat, endpoint config.Endpoint, trans transformer.Transformer, transformerName string, body []byte, requestID string, priority RequestPriority) *autoContinuer {
+	cfg := p.config.GetAutoContinue()
+	maxContinuations := 0
+	if cfg.Enabled && cfg.MaxContinuations > 0 {
+		maxContinuations = cfg.MaxContinuations
+	}
+	if (maxContinuations == 0 && !cfg.ResumeOnDisconnect) || clientFormat != ClientFormatClaude {
+		return nil
+	}
+
+	var req struct {
+		Model    string        `json:"model"`
+		Messages []interface{} `json:"messages"`
+		Tools    []interface{} `json:"tools"`
+		Thinking *struct {
+			Type string `json:"type"`
+		} `json:"thinking"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || len(req.Messages) == 0 {
+		return nil
+	}
+	if req.Thinking != nil && req.Thinking.Type == "enabled" {
+		return nil
+	}
+	resume := cfg.ResumeOnDisconnect && len(req.Tools) == 0
+	if maxContinuations == 0 && !resume {
+		return nil
+	}
+
+	return &autoContinuer{
+		p:                p,
+		r:                r,
+		endpoint:         endpoint,
+		trans:            trans,
+		transformerName:  transformerName,
+		body:             body,
+		model:            req.Model,
+		requestID:        requestID,
+		priority:         priority,
+		maxContinuations: maxContinuations,
+		resume:           resume,
+	}
+}
+
+// buildContinuationBody 在原始请求的消息末尾追加已生成的文本作为 assistant prefill
+func buildContinuationBody(body []byte, generated string) ([]byte, error) {
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	messages, _ := req["messages"].([]interface{})
+
+	// Claude 不接受以空白结尾的 assistant prefill
+	generated = strings.TrimRight(generated, " \t\r\n")
+	if generated == "" {
+		return nil, fmt.Errorf("no generated text to continue from")
+	}
+
+	// 原始请求本身以 assistant prefill 结尾时，把已生成文本接在它后面
+	if n := len(messages); n > 0 {
+		if last, ok := messages[n-1].(map[string]interface{}); ok && last["role"] == "assistant" {
+			prefill := map[string]interface{}{
+				"role":    "assistant",
+				"content": extractTextContent(last["content"]) + generated,
+			}
+			messages = append(append([]interface{}{}, messages[:n-1]...), prefill)
+			req["messages"] = messages
+			return json.Marshal(req)
+		}
+	}
+
+	req["messages"] = append(append([]interface{}{}, messages...), map[string]interface{}{
+		"role":    "assistant",
+		"content": generated,
+	})
+	return json.Marshal(req)
+}
+
+// continuationAttempt 一次已发往上游的续写/续传请求，结束时按实际发往的端点记录统计
+type continuationAttempt struct {
+	c             *autoContinuer
+	endpoint      config.Endpoint
+	upstreamModel string
+	requestBytes  int64
+	stream        bool
+	startTime     time.Time
+	respCounter   *countingReadCloser
+}
+
+// send 向当前端点发起一次续写请求，返回上游的成功响应
+func (c *autoContinuer) send(generated string) (*continuationAttempt, *http.Response, error) {
+	return c.sendTo(c.endpoint, c.trans, generated)
+}
+
+// sendTo 向指定端点发起一次续写请求，返回上游的成功响应。
+// 发出前先准入（重试预算、熔断、并发名额），无法准入时返回 errContinuationRejected；
+// 请求失败时已记录结果，成功时调用方读完响应后须调用 attempt.finish
+func (c *autoContinuer) sendTo(endpoint config.Endpoint, trans transformer.Transformer, generated string) (*continuationAttempt, *http.Response, error) {
+	body, err := buildContinuationBody(c.body, generated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transformedBody, err := trans.TransformRequest(rewriteRequestModel(body, endpoint))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to transform request: %w", err)
+	}
+	if cleaned, err := cleanIncompleteToolCalls(transformedBody); err == nil {
+		transformedBody = cleaned
+	}
+
+	// 续写请求沿用客户端原始请求的 stream 设置
+	var streamReq struct {
+		Stream bool `json:"stream"`
+	}
+	json.Unmarshal(body, &streamReq)
+
+	if !c.admit(endpoint) {
+		return nil, nil, errContinuationRejected
+	}
+	attempt := &continuationAttempt{
+		c:             c,
+		endpoint:      endpoint,
+		upstreamModel: extractUpstreamModel(transformedBody, endpoint.ForModel(c.model)),
+		requestBytes:  int64(len(transformedBody)),
+		stream:        streamReq.Stream,
+		startTime:     time.Now(),
+	}
+
+	proxyReq, err := buildProxyRequest(c.r, endpoint, transformedBody, trans.Name(), c.requestID, streamReq.Stream)
+	if err != nil {
+		err = fmt.Errorf("failed to create request: %w", err)
+		attempt.finish(transformer.TokenUsageDetail{}, err)
+		return nil, nil, err
+	}
+
+	// 客户端断开时中止续写请求；响应体关闭时释放 context
+	ctx, stopUpstream := c.p.upstreamContext(c.r.Context(), endpoint.Name)
+	resp, err := sendRequest(ctx, proxyReq, c.p.config, endpoint)
+	if err != nil {
+		stopUpstream()
+		attempt.finish(transformer.TokenUsageDetail{}, err)
+		return nil, nil, err
+	}
+	attempt.respCounter = newCountingReadCloser(resp.Body)
+	resp.Body = &releaseOnCloseReadCloser{ReadCloser: attempt.respCounter, release: stopUpstream}
+	if resp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		resp.Body.Close()
+		err = fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(errBody))
+		attempt.finish(transformer.TokenUsageDetail{}, err)
+		return nil, nil, err
+	}
+	return attempt, resp, nil
+}
+
+// admit 续写请求发出前按普通重试的方式准入：计入重试预算、通过熔断检查并占用试探名额、
+// 在端点并发上限内占用一个名额，并计入端点请求数
+func (c *autoContinuer) admit(endpoint config.Endpoint) bool {
+	epClientType := string(endpointClientType(endpoint))
+	if !c.p.retryBudget.AllowRetry() {
+		logger.WarnKey(endpoint.Name, "[%s] Retry budget exhausted, skipping continuation", endpoint.Name)
+		return false
+	}
+	if !c.p.circuitBreaker.Allow(epClientType, endpoint.Name) {
+		logger.WarnKey(endpoint.Name, "[%s] Circuit open, skipping continuation", endpoint.Name)
+		return false
+	}
+	if !c.p.acquireRequestSlot(c.r.Context(), endpoint.Name, endpoint.MaxConcurrency, c.priority) {
+		logger.WarnKey(endpoint.Name, "[%s] Concurrency limit (%d) reached, skipping continuation", endpoint.Name, endpoint.MaxConcurrency)
+		return false
+	}
+	c.p.circuitBreaker.Acquire(epClientType, endpoint.Name)
+	c.p.stats.RecordRequest(endpoint.Name, epClientType)
+	return true
+}
+
+// finish 续写请求结束：归还并发名额，结果计入熔断器，token、配额和请求统计记到该请求实际发往的端点
+func (a *continuationAttempt) finish(usage transformer.TokenUsageDetail, err error) {
+	p := a.c.p
+	endpoint := a.endpoint
+	epClientType := string(endpointClientType(endpoint))
+	p.markRequestInactive(endpoint.Name)
+
+	var errorMsg, category string
+	switch {
+	case err == nil:
+		p.circuitBreaker.RecordSuccess(epClientType, endpoint.Name)
+		p.recordQuotaUsage(endpoint, epClientType, a.c.model, usage)
+	case a.c.r.Context().Err() != nil:
+		// 客户端断开不是端点的错误，只释放试探名额
+		p.stats.RecordError(endpoint.Name, epClientType)
+		p.circuitBreaker.Release(epClientType, endpoint.Name)
+		errorMsg, category = errClientGone.Error(), ErrorCategoryClientGone
+	default:
+		p.stats.RecordError(endpoint.Name, epClientType)
+		p.circuitBreaker.RecordFailure(epClientType, endpoint.Name)
+		errorMsg, category = err.Error(), classifyError(err)
+	}
+	if len(errorMsg) > 500 {
+		errorMsg = errorMsg[:500]
+	}
+
+	p.stats.RecordTokens(endpoint.Name, epClientType, usage)
+	p.tokenRate.Record(endpoint.Name, usage.TotalInputTokens()+usage.OutputTokens)
+
+	var responseBytes int64
+	if a.respCounter != nil {
+		responseBytes = a.respCounter.Count()
+	}
+	p.stats.RecordRequestStat(&RequestStatRecord{
+		EndpointName:        endpoint.Name,
+		ClientType:          epClientType,
+		ClientIP:            getClientIP(a.c.r),
+		Timestamp:           time.Now(),
+		InputTokens:         usage.InputTokens,
+		CacheCreationTokens: usage.CacheCreationInputTokens,
+		CacheReadTokens:     usage.CacheReadInputTokens,
+		OutputTokens:        usage.OutputTokens,
+		Model:               a.c.model,
+		UpstreamModel:       a.upstreamModel,
+		IsStreaming:         a.stream,
+		Success:             err == nil,
+		DurationMs:          time.Since(a.startTime).Milliseconds(),
+		ErrorMessage:        errorMsg,
+		ErrorCategory:       category,
+		RequestBytes:        a.requestBytes,
+		ResponseBytes:       responseBytes,
+	})
+}
+
+// truncatedText 响应因 max_tokens 截断且只包含文本块时，返回已生成的全部文本
+func truncatedText(message map[string]interface{}) (string, bool) {
+	if message["stop_reason"] != stopReasonMaxTokens {
+		return "", false
+	}
+	content, _ := message["content"].([]interface{})
+	var text strings.Builder
+	for _, item := range content {
+		block, ok := item.(map[string]interface{})
+		if !ok || block["type"] != "text" {
+			return "", false
+		}
+		t, _ := block["text"].(string)
+		text.WriteString(t)
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return "", false
+	}
+	return text.String(), true
+}
+
+// mergeContinuation 把续写响应拼接到已有响应：文本接到最后一个文本块，stop_reason 取续写结果，usage 累加
+func mergeContinuation(base, next map[string]interface{}) {
+	content, _ := base["content"].([]interface{})
+	nextContent, _ := next["content"].([]interface{})
+	for i, item := range nextContent {
+		block, ok := item.(map[string]interface{})
+		if i == 0 && ok && block["type"] == "text" && len(content) > 0 {
+			if last, ok := content[len(content)-1].(map[string]interface{}); ok && last["type"] == "text" {
+				lastText, _ := last["text"].(string)
+				nextText, _ := block["text"].(string)
+				if endsWithSpace(lastText) {
+					nextText = strings.TrimLeft(nextText, " \t\r\n")
+				}
+				last["text"] = lastText + nextText
+				continue
+			}
+		}
+		content = append(content, item)
+	}
+	base["content"] = content
+	base["stop_reason"] = next["stop_reason"]
+	base["stop_sequence"] = next["stop_sequence"]
+
+	baseUsage, _ := base["usage"].(map[string]interface{})
+	nextUsage, _ := next["usage"].(map[string]interface{})
+	if baseUsage == nil {
+		baseUsage = make(map[string]interface{})
+		base["usage"] = baseUsage
+	}
+	for key, value := range nextUsage {
+		n, ok := value.(float64)
+		if !ok {
+			continue
+		}
+		prev, _ := baseUsage[key].(float64)
+		baseUsage[key] = prev + n
+	}
+}
+
+// continueResponse 非流式响应被截断时循环续写，返回拼接后的 Claude 格式响应
+func (c *autoContinuer) continueResponse(respBody []byte) []byte {
+	var merged map[string]interface{}
+	if err := json.Unmarshal(respBody, &merged); err != nil {
+		return respBody
+	}
+
+	continued := 0
+	for continued < c.maxContinuations {
+		generated, ok := truncatedText(merged)
+		if !ok {
+			break
+		}
+		logger.InfoKey(c.endpoint.Name, "[%s] Response truncated by max_tokens, continuing (%d/%d)", c.endpoint.Name, continued+1, c.maxContinuations)
+
+		attempt, resp, err := c.send(generated)
+		if err != nil {
+			logger.WarnKey(c.endpoint.Name, "[%s] Auto continue request failed: %v", c.endpoint.Name, err)
+			break
+		}
+		var body []byte
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			body, err = decompressGzip(resp.Body)
+		} else {
+			body, err = io.ReadAll(resp.Body)
+		}
+		resp.Body.Close()
+		if err != nil {
+			attempt.finish(transformer.TokenUsageDetail{}, err)
+			logger.WarnKey(c.endpoint.Name, "[%s] Failed to read auto continue response: %v", c.endpoint.Name, err)
+			break
+		}
+
+		transformed, err := c.trans.TransformResponse(body, false)
+		if err != nil {
+			attempt.finish(transformer.TokenUsageDetail{}, err)
+			logger.WarnKey(c.endpoint.Name, "[%s] Failed to transform auto continue response: %v", c.endpoint.Name, err)
+			break
+		}
+		var next map[string]interface{}
+		if err := json.Unmarshal(transformed, &next); err != nil {
+			attempt.finish(transformer.TokenUsageDetail{}, err)
+			break
+		}
+		attempt.finish(extractTokenUsage(transformed), nil)
+		mergeContinuation(merged, next)
+		continued++
+	}
+
+	if continued == 0 {
+		return respBody
+	}
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return respBody
+	}
+	return out
+}
+
+// continueStream 流式响应被截断时循环续写，续写内容以新的内容块接在原有流后面；
+// 结束时补发最终的 message_delta 和 message_stop
+func (c *autoContinuer) continueStream(cw *continuationWriter, clientType ClientType, thinkingEnabled bool, modelName string) {
+	// 已切换到续传端点时，续写流同样不做端点切换检测
+	if c.resumed {
+		clientType = ""
+	}
+	for i := 0; i < c.maxContinuations && cw.truncated() && cw.textOnly; i++ {
+		logger.InfoKey(c.endpoint.Name, "[%s] Stream truncated by max_tokens, continuing (%d/%d)", c.endpoint.Name, i+1, c.maxContinuations)
+
+		attempt, resp, err := c.send(cw.text.String())
+		if err != nil {
+			logger.WarnKey(c.endpoint.Name, "[%s] Auto continue request failed: %v", c.endpoint.Name, err)
+			break
+		}
+
+		cw.beginContinuation()
+		usage, outputText, _, _, err := c.p.handleStreamingResponse(cw, resp, c.endpoint, c.trans, c.transformerName, thinkingEnabled, modelName, nil, clientType, time.Now())
+		if usage.OutputTokens == 0 {
+			usage.OutputTokens = c.p.estimateOutputTokens(outputText)
+		}
+		attempt.finish(usage, err)
+		if err != nil {
+			logger.WarnKey(c.endpoint.Name, "[%s] Auto continue stream failed: %v", c.endpoint.Name, err)
+			break
+		}
+	}
+	cw.finish()
+}
+
+// resumeStream 上游流在中途断开时，以已发给客户端的文本作为 assistant prefill 换其他端点续写，
+// 续写内容以新的内容块接在原有流后面。interruptedTokens 为中断段已输出的 token 数。
+// 续传成功时切换到续传端点（后续 max_tokens 续写也发往该端点），返回续传端点名称；
+// 含工具调用等非文本内容或没有已输出文本时不续传，返回空名称
+func (c *autoContinuer) resumeStream(cw *continuationWriter, interruptedTokens int, clientType ClientType, group string, thinkingEnabled bool, modelName string) string {
+	if !c.resume || !cw.textOnly || strings.TrimSpace(cw.text.String()) == "" {
+		return ""
+	}
+
+	tried := map[string]bool{c.endpoint.Name: true}
+	for i := 0; i < maxStreamResumes; i++ {
+		endpoint, ok := c.nextResumeEndpoint(clientType, group, tried)
+		if !ok {
+			logger.WarnKey(c.endpoint.Name, "[%s] No other endpoint available to resume the interrupted stream", c.endpoint.Name)
+			break
+		}
+		tried[endpoint.Name] = true
+		logger.InfoKey(c.endpoint.Name, "[%s] Upstream stream interrupted, resuming on %s (%d/%d)", c.endpoint.Name, endpoint.Name, i+1, maxStreamResumes)
+
+		endpoint = c.p.tokenRefresher.EnsureFresh(endpoint)
+		endpoint.APIKey = c.p.keyPool.Select(endpoint)
+		trans, err := prepareTransformerForClient(ClientFormatClaude, endpoint.ForModel(modelName))
+		if err != nil {
+			logger.WarnKey(endpoint.Name, "[%s] Stream resume skipped: %v", endpoint.Name, err)
+			continue
+		}
+
+		attempt, resp, err := c.sendTo(endpoint, trans, cw.text.String())
+		if err != nil {
+			logger.WarnKey(endpoint.Name, "[%s] Stream resume request failed: %v", endpoint.Name, err)
+			continue
+		}
+
+		// 续传端点不是 client type 的当前端点，不做端点切换检测
+		cw.beginResume(interruptedTokens)
+		usage, outputText, _, _, err := c.p.handleStreamingResponse(cw, resp, endpoint, trans, trans.Name(), thinkingEnabled, modelName, nil, "", time.Now())
+		if usage.OutputTokens == 0 {
+			usage.OutputTokens = c.p.estimateOutputTokens(outputText)
+		}
+		attempt.finish(usage, err)
+		if err != nil {
+			// 续传段同样中断时，把它已输出的部分计入后再换下一个端点
+			logger.WarnKey(endpoint.Name, "[%s] Resumed stream failed: %v", endpoint.Name, err)
+			interruptedTokens = usage.OutputTokens
+			if !cw.textOnly {
+				break
+			}
+			continue
+		}
+
+		c.endpoint = endpoint
+		c.trans = trans
+		c.transformerName = trans.Name()
+		c.resumed = true
+		return endpoint.Name
+	}
+	return ""
+}
+
+// nextResumeEndpoint 选出下一个可用于续传的端点（同一分组、未熔断、未达并发上限且本次请求未尝试过），
+// 不轮换 client type 的当前端点
+func (c *autoContinuer) nextResumeEndpoint(clientType ClientType, group string, tried map[string]bool) (config.Endpoint, bool) {
+	for _, endpoint := range c.p.selectableEndpoints(clientType, group) {
+		if !tried[endpoint.Name] {
+			return endpoint, true
+		}
+	}
+	return config.Endpoint{}, false
+}
+
+// endsWithSpace 文本是否以空白结尾。prefill 去掉了结尾空白，模型续写时常会补回，拼接时需去掉重复的空白
+func endsWithSpace(text string) bool {
+	return text != "" && strings.TrimRight(text, " \t\r\n") != text
+}
+
+// continuationWriter 包装客户端 ResponseWriter，按 SSE 事件过滤 Claude 格式的流：
+// 截断时暂存 message_delta/message_stop，续写段丢弃 message_start 并把内容块序号顺延，
+// 使多段上游响应在客户端看来是同一条消息
+type continuationWriter struct {
+	w             http.ResponseWriter
+	flusher       http.Flusher
+	headerWritten bool
+
+	pending     []byte // 尚未凑成完整事件的数据
+	continuing  bool   // 当前是否为续写段
+	indexOffset int    // 续写段内容块序号偏移
+	maxIndex    int    // 已发给客户端的最大内容块序号
+	openIndex   int    // 已开始但尚未结束的内容块序号，-1 表示没有
+
+	text        strings.Builder // 已生成的全部文本
+	textOnly    bool            // 是否只包含文本块（含工具调用等内容时无法续写）
+	trimLeading bool            // 续写段开头的空白是否需要去掉
+
+	held         map[string]interface{} // 因 max_tokens 暂存的 message_delta
+	outputTokens int                    // 已完成各段的累计输出 token（写入 message_delta.usage）
+}
+
+func newContinuationWriter(w http.ResponseWriter) *continuationWriter {
+	flusher, _ := w.(http.Flusher)
+	return &continuationWriter{w: w, flusher: flusher, maxIndex: -1, openIndex: -1, textOnly: true}
+}
+
+func (cw *continuationWriter) Header() http.Header {
+	return cw.w.Header()
+}
+
+// WriteHeader 只向客户端发送一次响应头（续写段会再次调用）
+func (cw *continuationWriter) WriteHeader(statusCode int) {
+	if cw.headerWritten {
+		return
+	}
+	cw.headerWritten = true
+	cw.w.WriteHeader(statusCode)
+}
+
+func (cw *continuationWriter) Flush() {
+	if cw.flusher != nil {
+		cw.flusher.Flush()
+	}
+}
+
+// WriteHeartbeat 心跳不参与事件过滤，直接交给下层写出
+func (cw *continuationWriter) WriteHeartbeat() error {
+	return writeHeartbeat(cw.w)
+}
+
+func (cw *continuationWriter) Write(b []byte) (int, error) {
+	cw.pending = append(cw.pending, b...)
+	for {
+		idx := bytes.Index(cw.pending, []byte("\n\n"))
+		if idx < 0 {
+			break
+		}
+		event := cw.pending[:idx+2]
+		cw.pending = cw.pending[idx+2:]
+		if out := cw.filterEvent(event); len(out) > 0 {
+			if _, err := cw.w.Write(out); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(b), nil
+}
+
+// truncated 最近一段是否因 max_tokens 截断
+func (cw *continuationWriter) truncated() bool {
+	return cw.held != nil
+}
+
+// beginContinuation 准备接收下一段续写流
+func (cw *continuationWriter) beginContinuation() {
+	cw.continuing = true
+	cw.indexOffset = cw.maxIndex + 1
+	cw.pending = nil
+	cw.trimLeading = endsWithSpace(cw.text.String())
+	if usage, ok := cw.held["usage"].(map[string]interface{}); ok {
+		if n, ok := usage["output_tokens"].(float64); ok {
+			cw.outputTokens = int(n)
+		}
+	}
+}
+
+// beginResume 准备接收上游中断后换端点续传的流：先替中断段补发未结束内容块的 content_block_stop，
+// interruptedTokens 为中断段的输出 token 数，累加到后续 message_delta.usage
+func (cw *continuationWriter) beginResume(interruptedTokens int) {
+	if cw.openIndex >= 0 {
+		data, _ := json.Marshal(map[string]interface{}{"type": "content_block_stop", "index": cw.openIndex})
+		cw.w.Write(formatSSEEvent("content_block_stop", data))
+		cw.Flush()
+		cw.openIndex = -1
+	}
+	cw.held = nil
+	cw.continuing = true
+	cw.indexOffset = cw.maxIndex + 1
+	cw.pending = nil
+	cw.trimLeading = endsWithSpace(cw.text.String())
+	cw.outputTokens += interruptedTokens
+}
+
+// finish 续写结束后补发暂存的 message_delta 和 message_stop
+func (cw *continuationWriter) finish() {
+	if cw.held == nil {
+		return
+	}
+	data, err := json.Marshal(cw.held)
+	cw.held = nil
+	if err != nil {
+		return
+	}
+	cw.w.Write(formatSSEEvent("message_delta", data))
+	cw.w.Write(formatSSEEvent("message_stop", []byte(`{"type":"message_stop"}`)))
+	cw.Flush()
+}
+
+// filterEvent 处理一个完整的 SSE 事件，返回需要发给客户端的内容（为空表示丢弃）
+func (cw *continuationWriter) filterEvent(event []byte) []byte {
+	var eventType string
+	var data []byte
+	for _, line := range strings.Split(string(event), "\n") {
+		if strings.HasPrefix(line, "event:") {
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		} else if strings.HasPrefix(line, "data:") {
+			data = []byte(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	var payload map[string]interface{}
+	if len(data) == 0 || json.Unmarshal(data, &payload) != nil {
+		// 心跳注释、[DONE] 等非 JSON 事件原样透传
+		return event
+	}
+	if eventType == "" {
+		eventType, _ = payload["type"].(string)
+	}
+
+	switch eventType {
+	case "message_start", "ping":
+		if cw.continuing {
+			return nil
+		}
+		return event
+
+	case "content_block_start", "content_block_delta", "content_block_stop":
+		if index, ok := payload["index"].(float64); ok {
+			newIndex := int(index) + cw.indexOffset
+			if newIndex > cw.maxIndex {
+				cw.maxIndex = newIndex
+			}
+			if cw.indexOffset > 0 {
+				payload["index"] = newIndex
+			}
+			switch eventType {
+			case "content_block_start":
+				cw.openIndex = newIndex
+			case "content_block_stop":
+				cw.openIndex = -1
+			}
+		}
+		if block, ok := payload["content_block"].(map[string]interface{}); ok && block["type"] != "text" {
+			cw.textOnly = false
+		}
+		if delta, ok := payload["delta"].(map[string]interface{}); ok {
+			if delta["type"] == "text_delta" {
+				t, _ := delta["text"].(string)
+				if cw.trimLeading {
+					t = strings.TrimLeft(t, " \t\r\n")
+					cw.trimLeading = t == ""
+					delta["text"] = t
+				}
+				cw.text.WriteString(t)
+			} else {
+				cw.textOnly = false
+			}
+		}
+		if cw.indexOffset == 0 {
+			return event
+		}
+
+	case "message_delta":
+		if usage, ok := payload["usage"].(map[string]interface{}); ok && cw.outputTokens > 0 {
+			n, _ := usage["output_tokens"].(float64)
+			usage["output_tokens"] = float64(cw.outputTokens) + n
+		}
+		cw.held = nil
+		if delta, ok := payload["delta"].(map[string]interface{}); ok && delta["stop_reason"] == stopReasonMaxTokens {
+			cw.held = payload
+			return nil
+		}
+		if !cw.continuing {
+			return event
+		}
+
+	case "message_stop":
+		if cw.held != nil {
+			return nil
+		}
+		return event
+
+	default:
+		return event
+	}
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return event
+	}
+	return formatSSEEvent(eventType, out)
+}
+
+// formatSSEEvent 生成一条 SSE 事件
+func formatSSEEvent(eventType string, data []byte) []byte {
+	return []byte("event: " + eventType + "\ndata: " + string(data) + "\n\n")
+}
@@ -0,0 +1,287 @@
+package proxy
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// 熔断器状态
+const (
+	BreakerClosed   = "closed"    // 正常
+	BreakerOpen     = "open"      // 熔断冷却中，不参与选择
+	BreakerHalfOpen = "half_open" // 冷却结束，放行少量试探请求
+)
+
+// endpointBreaker 单个端点的熔断状态
+type endpointBreaker struct {
+	state          string
+	failures       int       // 连续失败次数
+	openedAt       time.Time // 进入熔断的时间
+	probesInFlight int       // 半开状态下已放行、未完成的试探请求数
+	probeSuccesses int       // 半开状态下已成功的试探请求数
+	trips          int64     // 累计熔断次数
+}
+
+// CircuitBreakerStatus 端点熔断状态（供前端展示）
+type CircuitBreakerStatus struct {
+	ClientType       string    `json:"clientType"`
+	EndpointName     string    `json:"endpointName"`
+	State            string    `json:"state"`
+	Failures         int       `json:"failures"`
+	OpenedAt         time.Time `json:"openedAt,omitempty"`
+	RemainingSeconds int64     `json:"remainingSeconds"` // 熔断冷却剩余秒数
+	Trips            int64     `json:"trips"`
+}
+
+// CircuitBreaker 按端点记录连续失败次数，达到阈值后在冷却期内将端点从候选中排除，
+// 冷却结束后进入半开状态试探，试探成功恢复，失败重新冷却
+type CircuitBreaker struct {
+	config *config.Config
+
+	mu       sync.Mutex
+	breakers map[string]*endpointBreaker // clientType:endpointName -> state
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker reading its settings from cfg on every call
+func NewCircuitBreaker(cfg *config.Config) *CircuitBreaker {
+	return &CircuitBreaker{
+		config:   cfg,
+		breakers: make(map[string]*endpointBreaker),
+	}
+}
+
+func breakerKey(clientType, endpointName string) string {
+	return clientType + ":" + endpointName
+}
+
+// available 判断端点当前是否可参与选择，冷却期满的熔断端点转为半开（调用方需持有 mu）
+func (b *CircuitBreaker) available(cfg *config.CircuitBreakerConfig, br *endpointBreaker, now time.Time) bool {
+	switch br.state {
+	case BreakerOpen:
+		if now.Sub(br.openedAt) < breakerCooldown(cfg) {
+			return false
+		}
+		br.state = BreakerHalfOpen
+		br.probesInFlight = 0
+		br.probeSuccesses = 0
+		return true
+	case BreakerHalfOpen:
+		return br.probesInFlight < breakerProbes(cfg)
+	default:
+		return true
+	}
+}
+
+// Filter 过滤掉熔断冷却中的端点；全部端点都被熔断时返回原列表，避免完全无端点可用
+func (b *CircuitBreaker) Filter(clientType ClientType, endpoints []config.Endpoint) []config.Endpoint {
+	cfg := b.config.GetCircuitBreaker()
+	if !cfg.Enabled || len(endpoints) == 0 {
+		return endpoints
+	}
+
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	filtered := make([]config.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		br, exists := b.breakers[breakerKey(string(clientType), ep.Name)]
+		if !exists || b.available(cfg, br, now) {
+			filtered = append(filtered, ep)
+		}
+	}
+	if len(filtered) == 0 {
+		logger.Warn("[BREAKER:%s] All endpoints are open, ignoring circuit breaker", clientType)
+		return endpoints
+	}
+	return filtered
+}
+
+// Allow 判断单个端点是否可用（用于会话亲和性等直接指定端点的场景）
+func (b *CircuitBreaker) Allow(clientType, endpointName string) bool {
+	cfg := b.config.GetCircuitBreaker()
+	if !cfg.Enabled {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, exists := b.breakers[breakerKey(clientType, endpointName)]
+	return !exists || b.available(cfg, br, time.Now())
+}
+
+// Acquire 请求实际发往端点前调用，半开状态下占用一个试探名额
+func (b *CircuitBreaker) Acquire(clientType, endpointName string) {
+	if !b.config.GetCircuitBreaker().Enabled {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br, exists := b.breakers[breakerKey(clientType, endpointName)]; exists && br.state == BreakerHalfOpen {
+		br.probesInFlight++
+	}
+}
+
+// Release 释放 Acquire 占用的试探名额，不计成功也不计失败（如客户端中途断开）
+func (b *CircuitBreaker) Release(clientType, endpointName string) {
+	if !b.config.GetCircuitBreaker().Enabled {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br, exists := b.breakers[breakerKey(clientType, endpointName)]; exists && br.state == BreakerHalfOpen && br.probesInFlight > 0 {
+		br.probesInFlight--
+	}
+}
+
+// RecordSuccess 记录端点请求成功，半开状态下试探全部成功后恢复
+func (b *CircuitBreaker) RecordSuccess(clientType, endpointName string) {
+	cfg := b.config.GetCircuitBreaker()
+	if !cfg.Enabled {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, exists := b.breakers[breakerKey(clientType, endpointName)]
+	if !exists {
+		return
+	}
+	br.failures = 0
+	if br.state != BreakerHalfOpen {
+		return
+	}
+	if br.probesInFlight > 0 {
+		br.probesInFlight--
+	}
+	br.probeSuccesses++
+	if br.probeSuccesses >= breakerProbes(cfg) {
+		br.state = BreakerClosed
+		br.probeSuccesses = 0
+		br.probesInFlight = 0
+		logger.Info("[BREAKER:%s] Endpoint %s recovered, circuit closed", clientType, endpointName)
+	}
+}
+
+// RecordFailure 记录端点请求失败，连续失败达到阈值或半开试探失败时进入熔断
+func (b *CircuitBreaker) RecordFailure(clientType, endpointName string) {
+	cfg := b.config.GetCircuitBreaker()
+	if !cfg.Enabled {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := breakerKey(clientType, endpointName)
+	br, exists := b.breakers[key]
+	if !exists {
+		br = &endpointBreaker{state: BreakerClosed}
+		b.breakers[key] = br
+	}
+	br.failures++
+
+	switch br.state {
+	case BreakerHalfOpen:
+		b.trip(br)
+		logger.Warn("[BREAKER:%s] Probe to endpoint %s failed, circuit reopened for %v", clientType, endpointName, breakerCooldown(cfg))
+	case BreakerClosed:
+		if br.failures >= breakerThreshold(cfg) {
+			b.trip(br)
+			logger.Warn("[BREAKER:%s] Endpoint %s failed %d times in a row, circuit opened for %v", clientType, endpointName, br.failures, breakerCooldown(cfg))
+		}
+	}
+}
+
+// trip 进入熔断状态（调用方需持有 mu）
+func (b *CircuitBreaker) trip(br *endpointBreaker) {
+	br.state = BreakerOpen
+	br.openedAt = time.Now()
+	br.probesInFlight = 0
+	br.probeSuccesses = 0
+	br.trips++
+}
+
+// GetStatus 返回有失败记录或处于熔断中的端点状态
+func (b *CircuitBreaker) GetStatus() []CircuitBreakerStatus {
+	cooldown := breakerCooldown(b.config.GetCircuitBreaker())
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]CircuitBreakerStatus, 0, len(b.breakers))
+	for key, br := range b.breakers {
+		if br.state == BreakerClosed && br.failures == 0 && br.trips == 0 {
+			continue
+		}
+		clientType, endpointName := splitBreakerKey(key)
+		status := CircuitBreakerStatus{
+			ClientType:   clientType,
+			EndpointName: endpointName,
+			State:        br.state,
+			Failures:     br.failures,
+			Trips:        br.trips,
+		}
+		if br.state != BreakerClosed {
+			status.OpenedAt = br.openedAt
+		}
+		if br.state == BreakerOpen {
+			if remaining := cooldown - now.Sub(br.openedAt); remaining > 0 {
+				status.RemainingSeconds = int64(remaining.Seconds())
+			}
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ClientType != result[j].ClientType {
+			return result[i].ClientType < result[j].ClientType
+		}
+		return result[i].EndpointName < result[j].EndpointName
+	})
+	return result
+}
+
+// Reset 清空所有端点的熔断状态
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.breakers = make(map[string]*endpointBreaker)
+}
+
+func splitBreakerKey(key string) (clientType, endpointName string) {
+	clientType, endpointName, _ = strings.Cut(key, ":")
+	return clientType, endpointName
+}
+
+func breakerThreshold(cfg *config.CircuitBreakerConfig) int {
+	if cfg.FailureThreshold <= 0 {
+		return 5
+	}
+	return cfg.FailureThreshold
+}
+
+func breakerCooldown(cfg *config.CircuitBreakerConfig) time.Duration {
+	if cfg.CooldownSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(cfg.CooldownSeconds) * time.Second
+}
+
+func breakerProbes(cfg *config.CircuitBreakerConfig) int {
+	if cfg.HalfOpenProbes <= 0 {
+		return 1
+	}
+	return cfg.HalfOpenProbes
+}
@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// memoryStatsStorage 测试用的内存统计存储，只保留请求级记录
+type memoryStatsStorage struct {
+	mu       sync.Mutex
+	requests []*RequestStatRecord
+}
+
+func (s *memoryStatsStorage) RecordDailyStat(stat interface{}) error { return nil }
+
+func (s *memoryStatsStorage) RecordRequestStat(stat interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, stat.(*RequestStatRecord))
+	return nil
+}
+
+func (s *memoryStatsStorage) GetTotalStats(excludeTest bool) (int, map[string]interface{}, error) {
+	return 0, nil, nil
+}
+
+func (s *memoryStatsStorage) GetDailyStats(endpointName, clientType, startDate, endDate string) ([]interface{}, error) {
+	return nil, nil
+}
+
+func (s *memoryStatsStorage) GetTestStats(startDate, endDate string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+func (s *memoryStatsStorage) records() []*RequestStatRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*RequestStatRecord(nil), s.requests...)
+}
+
+// newDisconnectTestProxy 创建只有一个端点的代理，端点熔断器处于半开状态（只允许一个试探请求）
+func newDisconnectTestProxy(upstreamURL string) (*Proxy, *memoryStatsStorage) {
+	cfg := config.DefaultConfig()
+	cfg.Endpoints = []config.Endpoint{{
+		Name:        "upstream",
+		ClientType:  "claude",
+		APIUrl:      upstreamURL,
+		APIKey:      "test-key",
+		Status:      config.EndpointStatusAvailable,
+		Enabled:     true,
+		Transformer: "claude",
+	}}
+	cfg.UpdateCircuitBreaker(&config.CircuitBreakerConfig{
+		Enabled:          true,
+		FailureThreshold: 5,
+		CooldownSeconds:  60,
+		HalfOpenProbes:   1,
+	})
+
+	store := &memoryStatsStorage{}
+	p := New(cfg, store, "test")
+	p.circuitBreaker.breakers[breakerKey("claude", "upstream")] = &endpointBreaker{state: BreakerHalfOpen}
+	return p, store
+}
+
+// runDisconnectedRequest 发起代理请求，上游收到请求且代理进入 phase 阶段后断开客户端
+func runDisconnectedRequest(t *testing.T, p *Proxy, upstreamReceived <-chan struct{}, phase RequestPhase, body string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.handleProxy(httptest.NewRecorder(), req)
+	}()
+
+	select {
+	case <-upstreamReceived:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream did not receive the request")
+	}
+	waitForPhase(t, p, phase)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not return after client disconnected")
+	}
+}
+
+// waitForPhase 等待代理中的请求进入指定阶段
+func waitForPhase(t *testing.T, p *Proxy, phase RequestPhase) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, req := range p.monitor.GetActiveRequests() {
+			if req.Phase == phase {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("request did not reach phase %s", phase)
+}
+
+// assertDisconnectBookkeeping 检查断开后在途计数、熔断器试探名额和统计记录都已处理
+func assertDisconnectBookkeeping(t *testing.T, p *Proxy, store *memoryStatsStorage, upstreamHits int32) {
+	t.Helper()
+
+	if upstreamHits != 1 {
+		t.Errorf("upstream hits = %d, want 1 (no retry after client disconnect)", upstreamHits)
+	}
+	if active := p.activeRequestCount("upstream"); active != 0 {
+		t.Errorf("active requests = %d, want 0", active)
+	}
+
+	p.circuitBreaker.mu.Lock()
+	br := p.circuitBreaker.breakers[breakerKey("claude", "upstream")]
+	state, probes := br.state, br.probesInFlight
+	p.circuitBreaker.mu.Unlock()
+	if state != BreakerHalfOpen || probes != 0 {
+		t.Errorf("breaker state = %v, probes in flight = %d, want half-open with 0 probes", state, probes)
+	}
+
+	records := store.records()
+	if len(records) != 1 || records[0].Success || records[0].ErrorCategory != ErrorCategoryClientGone {
+		t.Fatalf("request stats = %+v, want one failed record with category %s", records, ErrorCategoryClientGone)
+	}
+}
+
+func TestClientDisconnectAbortsUpstreamRequest(t *testing.T) {
+	received := make(chan struct{}, 1)
+	aborted := make(chan struct{})
+	var hits atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// 读完请求体后 server 才会在连接关闭时取消 r.Context()
+		io.Copy(io.Discard, r.Body)
+		received <- struct{}{}
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer upstream.Close()
+
+	p, store := newDisconnectTestProxy(upstream.URL)
+	runDisconnectedRequest(t, p, received, PhaseSending, `{"model":"claude-test","max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`)
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream request was not cancelled")
+	}
+	assertDisconnectBookkeeping(t, p, store, hits.Load())
+}
+
+func TestClientDisconnectAbortsUpstreamStream(t *testing.T) {
+	received := make(chan struct{}, 1)
+	aborted := make(chan struct{})
+	var hits atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// 读完请求体后 server 才会在连接关闭时取消 r.Context()
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		received <- struct{}{}
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer upstream.Close()
+
+	p, store := newDisconnectTestProxy(upstream.URL)
+	runDisconnectedRequest(t, p, received, PhaseStreaming, `{"model":"claude-test","max_tokens":16,"stream":true,"messages":[{"role":"user","content":"hi"}]}`)
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream stream was not cancelled")
+	}
+	assertDisconnectBookkeeping(t, p, store, hits.Load())
+}
@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// clientPortHandler 独立端口的请求处理：没有 /{client}/ 前缀的路径补上该端口对应的 clientType 前缀，
+// 之后交给主端口的路由处理，分组路径 /group/{name}/... 同样可用；/health、/stats 等管理路径保持不变
+func clientPortHandler(clientType ClientType, next http.Handler) http.Handler {
+	prefix := "/" + string(clientType)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || isManagementPath(path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = prefix + path
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = prefix + r.URL.RawPath
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// isManagementPath 主端口上注册的非代理路径
+func isManagementPath(path string) bool {
+	switch path {
+	case "/health", "/stats", "/stats/export":
+		return true
+	}
+	return false
+}
+
+// startClientPorts 为配置了独立端口的 client type 额外启动 listener
+// 端口被占用时只记录警告，不影响主端口；独立端口不自动顺延，避免客户端连到意料之外的端口
+func (p *Proxy) startClientPorts(handler http.Handler) {
+	ports := p.config.GetClientPorts()
+	for _, clientType := range []ClientType{ClientTypeClaude, ClientTypeGemini, ClientTypeCodex} {
+		port, ok := ports[string(clientType)]
+		if !ok {
+			continue
+		}
+
+		addr := fmt.Sprintf(":%d", port)
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			logger.Warn("[%s] Dedicated port %d is unavailable: %v", clientType, port, err)
+			continue
+		}
+
+		server := &http.Server{
+			Addr:    addr,
+			Handler: clientPortHandler(clientType, handler),
+		}
+		p.mu.Lock()
+		p.clientServers = append(p.clientServers, server)
+		p.mu.Unlock()
+
+		logger.Info("[%s] Dedicated port listening on %d", clientType, port)
+		go func(ct ClientType, s *http.Server, l net.Listener) {
+			if err := s.Serve(l); err != nil && err != http.ErrServerClosed {
+				logger.Error("[%s] Dedicated port server stopped: %v", ct, err)
+			}
+		}(clientType, server, listener)
+	}
+}
+
+// stopClientPorts 关闭所有独立端口的 listener
+func (p *Proxy) stopClientPorts() error {
+	p.mu.Lock()
+	servers := p.clientServers
+	p.clientServers = nil
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, server := range servers {
+		if err := server.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,243 @@
+package proxy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// concurrencyWaitTimeout 端点并发达到上限时请求的最长排队时间，超时后切换到其他端点
+const concurrencyWaitTimeout = 30 * time.Second
+
+// EndpointConcurrency 端点并发统计
+type EndpointConcurrency struct {
+	EndpointName string `json:"endpointName"`
+	Active       int    `json:"active"`           // 当前在途请求数
+	Waiting      int    `json:"waiting"`          // 排队等待数
+	Peak         int    `json:"peak"`             // 历史峰值并发
+	PeakAt       int64  `json:"peakAt,omitempty"` // 峰值出现时间（毫秒时间戳）
+
+	released          chan struct{}       // 有请求结束时关闭，用于唤醒排队中的请求
+	waitingByPriority [priorityLevels]int // 各优先级的排队数
+}
+
+// getOrCreateConcurrency 获取端点并发计数，不存在时创建（调用方需持有 activeRequestsMu 写锁）
+func (p *Proxy) getOrCreateConcurrency(endpointName string) *EndpointConcurrency {
+	c, exists := p.activeRequests[endpointName]
+	if !exists {
+		c = &EndpointConcurrency{EndpointName: endpointName}
+		p.activeRequests[endpointName] = c
+	}
+	return c
+}
+
+// activate 在途请求数 +1，并更新历史峰值（调用方需持有 activeRequestsMu 写锁）
+func (c *EndpointConcurrency) activate() {
+	c.Active++
+	if c.Active > c.Peak {
+		c.Peak = c.Active
+		c.PeakAt = time.Now().UnixMilli()
+	}
+}
+
+// wake 唤醒所有排队中的请求重新竞争名额（调用方需持有 activeRequestsMu 写锁）
+func (c *EndpointConcurrency) wake() {
+	if c.released != nil {
+		close(c.released)
+		c.released = nil
+	}
+}
+
+// dequeue 排队等待数 -1（调用方需持有 activeRequestsMu 写锁）
+func (c *EndpointConcurrency) dequeue(priority RequestPriority) {
+	if c.Waiting > 0 {
+		c.Waiting--
+	}
+	if c.waitingByPriority[priority] > 0 {
+		c.waitingByPriority[priority]--
+	}
+}
+
+// higherWaiting 是否有优先级高于 priority 的请求在排队（调用方需持有 activeRequestsMu 锁）
+func (c *EndpointConcurrency) higherWaiting(priority RequestPriority) bool {
+	for level := int(priority) + 1; level < priorityLevels; level++ {
+		if c.waitingByPriority[level] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// markRequestActive 在途请求数 +1，并更新历史峰值
+func (p *Proxy) markRequestActive(endpointName string) {
+	p.activeRequestsMu.Lock()
+	c := p.getOrCreateConcurrency(endpointName)
+	c.activate()
+	snapshot := *c
+	p.activeRequestsMu.Unlock()
+
+	p.monitor.NotifyConcurrency(snapshot)
+}
+
+// acquireRequestSlot 在端点并发上限内占用一个名额（在途请求数 +1），limit <= 0 表示不限制。
+// 达到上限时排队等待其他请求结束，等待超过 concurrencyWaitTimeout 或 ctx 结束时返回 false。
+// 有更高优先级的请求在排队时，即使有空闲名额也让其先行
+func (p *Proxy) acquireRequestSlot(ctx context.Context, endpointName string, limit int, priority RequestPriority) bool {
+	if limit <= 0 {
+		p.markRequestActive(endpointName)
+		return true
+	}
+
+	timer := time.NewTimer(concurrencyWaitTimeout)
+	defer timer.Stop()
+
+	queued := false
+	for {
+		p.activeRequestsMu.Lock()
+		c := p.getOrCreateConcurrency(endpointName)
+		if c.Active < limit && !c.higherWaiting(priority) {
+			c.activate()
+			if queued {
+				c.dequeue(priority)
+				// 离开队列后可能仍有空闲名额，唤醒低优先级的请求重新检查
+				c.wake()
+			}
+			snapshot := *c
+			p.activeRequestsMu.Unlock()
+
+			p.monitor.NotifyConcurrency(snapshot)
+			return true
+		}
+
+		if !queued {
+			p.activeRequestsMu.Unlock()
+			// 计入排队数后立即重新检查，期间释放的名额不会被错过
+			queued = true
+			p.markRequestWaiting(endpointName, priority)
+			logger.Debug("[CONCURRENCY] %s reached limit %d, %s priority request queued", endpointName, limit, priority)
+			continue
+		}
+
+		if c.released == nil {
+			c.released = make(chan struct{})
+		}
+		released := c.released
+		p.activeRequestsMu.Unlock()
+
+		select {
+		case <-released:
+		case <-timer.C:
+			p.markRequestDequeued(endpointName, priority)
+			return false
+		case <-ctx.Done():
+			p.markRequestDequeued(endpointName, priority)
+			return false
+		}
+	}
+}
+
+// markRequestInactive 在途请求数 -1
+func (p *Proxy) markRequestInactive(endpointName string) {
+	p.activeRequestsMu.Lock()
+	c := p.getOrCreateConcurrency(endpointName)
+	if c.Active > 0 {
+		c.Active--
+	}
+	// 唤醒排队中的请求重新竞争名额
+	c.wake()
+	snapshot := *c
+	p.activeRequestsMu.Unlock()
+
+	p.monitor.NotifyConcurrency(snapshot)
+}
+
+// markRequestWaiting 排队等待数 +1（请求因并发控制进入等待时调用）
+func (p *Proxy) markRequestWaiting(endpointName string, priority RequestPriority) {
+	p.activeRequestsMu.Lock()
+	c := p.getOrCreateConcurrency(endpointName)
+	c.Waiting++
+	c.waitingByPriority[priority]++
+	snapshot := *c
+	p.activeRequestsMu.Unlock()
+
+	p.monitor.NotifyConcurrency(snapshot)
+}
+
+// markRequestDequeued 排队等待数 -1（请求放弃等待时调用）
+// 放弃的请求可能正阻挡低优先级的请求，因此唤醒其余排队请求重新检查
+func (p *Proxy) markRequestDequeued(endpointName string, priority RequestPriority) {
+	p.activeRequestsMu.Lock()
+	c := p.getOrCreateConcurrency(endpointName)
+	c.dequeue(priority)
+	c.wake()
+	snapshot := *c
+	p.activeRequestsMu.Unlock()
+
+	p.monitor.NotifyConcurrency(snapshot)
+}
+
+// hasActiveRequests checks if an endpoint has active requests
+func (p *Proxy) hasActiveRequests(endpointName string) bool {
+	p.activeRequestsMu.RLock()
+	defer p.activeRequestsMu.RUnlock()
+	c, exists := p.activeRequests[endpointName]
+	return exists && c.Active > 0
+}
+
+// activeRequestCount 返回端点当前的在途请求数
+func (p *Proxy) activeRequestCount(endpointName string) int {
+	p.activeRequestsMu.RLock()
+	defer p.activeRequestsMu.RUnlock()
+	if c, exists := p.activeRequests[endpointName]; exists {
+		return c.Active
+	}
+	return 0
+}
+
+// filterSaturated 过滤掉在途请求数已达 MaxConcurrency 的端点；全部饱和时返回原列表（由调用方排队等待）
+func (p *Proxy) filterSaturated(endpoints []config.Endpoint) []config.Endpoint {
+	p.activeRequestsMu.RLock()
+	defer p.activeRequestsMu.RUnlock()
+
+	filtered := make([]config.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		c, exists := p.activeRequests[ep.Name]
+		if ep.MaxConcurrency <= 0 || !exists || c.Active < ep.MaxConcurrency {
+			filtered = append(filtered, ep)
+		}
+	}
+	if len(filtered) == 0 {
+		return endpoints
+	}
+	return filtered
+}
+
+// GetConcurrencyStats 获取所有端点的并发统计（按端点名称排序）
+func (p *Proxy) GetConcurrencyStats() []EndpointConcurrency {
+	p.activeRequestsMu.RLock()
+	defer p.activeRequestsMu.RUnlock()
+
+	stats := make([]EndpointConcurrency, 0, len(p.activeRequests))
+	for _, c := range p.activeRequests {
+		stats = append(stats, *c)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].EndpointName < stats[j].EndpointName
+	})
+	return stats
+}
+
+// ResetConcurrencyPeaks 重置历史峰值（当前在途数保留，峰值从当前值重新计算）
+func (p *Proxy) ResetConcurrencyPeaks() {
+	p.activeRequestsMu.Lock()
+	defer p.activeRequestsMu.Unlock()
+
+	now := time.Now().UnixMilli()
+	for _, c := range p.activeRequests {
+		c.Peak = c.Active
+		c.PeakAt = now
+	}
+}
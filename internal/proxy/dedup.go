@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/cache"
+)
+
+const (
+	// dedupMaxEntries 去重结果最多保留的条目数，窗口很短，不需要太多
+	dedupMaxEntries = 100
+	// maxRecordedStreamBytes 流式响应需要完整缓冲才能回放，超过该大小的流不保存，避免占用过多内存
+	maxRecordedStreamBytes = 2 << 20
+)
+
+// RequestDeduper 请求去重：时间窗口内请求体完全相同的请求只转发一次，
+// 重复请求直接回放首次的响应；首次请求仍在处理时，重复请求等待其完成后再回放
+type RequestDeduper struct {
+	cache    *cache.Cache
+	mu       sync.Mutex
+	window   time.Duration
+	inFlight map[string]chan struct{} // 正在处理的请求，完成时关闭 channel
+}
+
+// NewRequestDeduper creates a request deduper with the given window
+func NewRequestDeduper(enabled bool, windowSeconds int) *RequestDeduper {
+	if windowSeconds <= 0 {
+		windowSeconds = 10
+	}
+	return &RequestDeduper{
+		cache:    cache.New(enabled, windowSeconds, dedupMaxEntries),
+		window:   time.Duration(windowSeconds) * time.Second,
+		inFlight: make(map[string]chan struct{}),
+	}
+}
+
+// IsEnabled returns whether request dedup is enabled
+func (d *RequestDeduper) IsEnabled() bool {
+	return d.cache.IsEnabled()
+}
+
+// dedupKey 按 client type、客户端 IP、请求路径和原始请求体生成去重键
+// 加入客户端 IP，避免不同使用者恰好发送相同内容时拿到别人的响应
+func dedupKey(clientType ClientType, clientIP, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(string(clientType) + "\n" + clientIP + "\n" + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Acquire 返回窗口内已保存的响应；没有保存的响应时，若同一请求正在处理则等待其完成，
+// 否则占用该 key，由调用方负责转发并在结束后调用 Release
+// 等待超过窗口时长或客户端断开时放弃去重，返回 (nil, false)
+func (d *RequestDeduper) Acquire(ctx context.Context, key string) (entry *cache.CacheEntry, owner bool) {
+	d.mu.Lock()
+	timeout := time.NewTimer(d.window)
+	d.mu.Unlock()
+	defer timeout.Stop()
+
+	for {
+		if entry, found := d.cache.Get(key); found {
+			return entry, false
+		}
+
+		d.mu.Lock()
+		done, busy := d.inFlight[key]
+		if !busy {
+			d.inFlight[key] = make(chan struct{})
+			d.mu.Unlock()
+			return nil, true
+		}
+		d.mu.Unlock()
+
+		select {
+		case <-done:
+			// 首次请求结束，重新检查是否有可回放的结果（失败时不保存，由当前请求接手）
+		case <-timeout.C:
+			return nil, false
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// Complete 保存成功的响应，供窗口内的重复请求回放
+func (d *RequestDeduper) Complete(key string, response []byte, isStreaming bool) {
+	d.cache.Set(key, response, nil, isStreaming)
+}
+
+// Release 释放 Acquire 占用的 key，唤醒等待中的重复请求
+func (d *RequestDeduper) Release(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if done, ok := d.inFlight[key]; ok {
+		close(done)
+		delete(d.inFlight, key)
+	}
+}
+
+// GetStats returns dedup cache statistics
+func (d *RequestDeduper) GetStats() cache.CacheStats {
+	return d.cache.GetStats()
+}
+
+// Clear removes all stored responses
+func (d *RequestDeduper) Clear() {
+	d.cache.Clear()
+}
+
+// UpdateConfig updates the enabled flag and window
+func (d *RequestDeduper) UpdateConfig(enabled bool, windowSeconds int) {
+	d.cache.UpdateConfig(enabled, windowSeconds, dedupMaxEntries)
+	if windowSeconds > 0 {
+		d.mu.Lock()
+		d.window = time.Duration(windowSeconds) * time.Second
+		d.mu.Unlock()
+	}
+}
+
+// replayDedupResponse 将保存的响应原样返回给重复请求
+func replayDedupResponse(w http.ResponseWriter, r *http.Request, entry *cache.CacheEntry) {
+	w.Header().Set("X-CCNexus-Dedup", "HIT")
+	if !entry.IsStreaming {
+		w.Header().Set("Content-Type", "application/json")
+		writeResponseBody(w, r, http.StatusOK, entry.Response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write(entry.Response)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// streamRecorder 包装流式响应的 ResponseWriter，在转发给客户端的同时缓冲写出的数据，供去重和响应缓存回放
+// 超过 maxRecordedStreamBytes 后停止缓冲，该响应不参与去重和缓存
+type streamRecorder struct {
+	w        http.ResponseWriter
+	flusher  http.Flusher
+	buf      bytes.Buffer
+	overflow bool
+}
+
+func newStreamRecorder(w http.ResponseWriter) *streamRecorder {
+	flusher, _ := w.(http.Flusher)
+	return &streamRecorder{w: w, flusher: flusher}
+}
+
+func (rec *streamRecorder) Header() http.Header {
+	return rec.w.Header()
+}
+
+func (rec *streamRecorder) WriteHeader(statusCode int) {
+	rec.w.WriteHeader(statusCode)
+}
+
+func (rec *streamRecorder) Write(data []byte) (int, error) {
+	if !rec.overflow {
+		if rec.buf.Len()+len(data) > maxRecordedStreamBytes {
+			rec.overflow = true
+			rec.buf = bytes.Buffer{}
+		} else {
+			rec.buf.Write(data)
+		}
+	}
+	return rec.w.Write(data)
+}
+
+// WriteHeartbeat 心跳直接写给客户端，不记入回放缓冲
+func (rec *streamRecorder) WriteHeartbeat() error {
+	return writeHeartbeat(rec.w)
+}
+
+func (rec *streamRecorder) Flush() {
+	if rec.flusher != nil {
+		rec.flusher.Flush()
+	}
+}
+
+// Recorded 返回缓冲的完整流，超过大小上限时返回 false
+func (rec *streamRecorder) Recorded() ([]byte, bool) {
+	if rec.overflow {
+		return nil, false
+	}
+	return rec.buf.Bytes(), true
+}
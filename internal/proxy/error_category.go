@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// 请求失败分类，记录在 request_stats.error_category
+const (
+	ErrorCategoryNetwork       = "network"        // 连接失败、连接被重置、读取上游响应失败
+	ErrorCategoryTimeout       = "timeout"        // 请求超时、流式首字节超时
+	ErrorCategoryAuth          = "auth"           // HTTP 401/403
+	ErrorCategoryRateLimit     = "rate_limit"     // HTTP 429
+	ErrorCategoryServerError   = "server_error"   // HTTP 5xx
+	ErrorCategoryUpstreamError = "upstream_error" // 其他上游错误响应（如 400）、流中的错误
+	ErrorCategoryOther         = "other"          // 代理自身的错误（请求转换失败、请求体过大等）
+	ErrorCategoryClientGone    = "client_gone"    // 客户端在响应完成前断开，上游请求被中止
+)
+
+// ErrorCategories 所有请求失败分类，按展示顺序排列
+var ErrorCategories = []string{
+	ErrorCategoryNetwork,
+	ErrorCategoryTimeout,
+	ErrorCategoryAuth,
+	ErrorCategoryRateLimit,
+	ErrorCategoryServerError,
+	ErrorCategoryUpstreamError,
+	ErrorCategoryOther,
+	ErrorCategoryClientGone,
+}
+
+// classifyStatusCode 按上游响应状态码分类
+func classifyStatusCode(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimit
+	case statusCode == http.StatusRequestTimeout:
+		return ErrorCategoryTimeout
+	case statusCode >= 500:
+		return ErrorCategoryServerError
+	default:
+		return ErrorCategoryUpstreamError
+	}
+}
+
+// classifyError 按发送请求或读取响应时的错误分类
+// ErrStreamRetryable 为响应头发出前流式读取失败或上游没有返回数据，按网络错误计
+func classifyError(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ErrorCategoryOther
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, errStreamFirstByteTimeout),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorCategoryTimeout
+	case errors.Is(err, errStreamNoData):
+		return ErrorCategoryUpstreamError
+	case errors.Is(err, ErrStreamRetryable), errors.As(err, &netErr):
+		return ErrorCategoryNetwork
+	}
+
+	errStr := strings.ToLower(err.Error())
+	if strings.Contains(errStr, "timeout") || strings.Contains(errStr, "deadline exceeded") {
+		return ErrorCategoryTimeout
+	}
+	if strings.Contains(errStr, "connection") || strings.Contains(errStr, "eof") ||
+		strings.Contains(errStr, "no such host") || strings.Contains(errStr, "tls") {
+		return ErrorCategoryNetwork
+	}
+	return ErrorCategoryUpstreamError
+}
+
+// recordAttemptError 将一次失败的端点尝试记录到 request_stats，带上错误分类
+func (p *Proxy) recordAttemptError(endpoint config.Endpoint, clientIP, model, upstreamModel string, startTime time.Time, requestBytes int64, isTest bool, errorMsg, category string) {
+	if len(errorMsg) > 500 {
+		errorMsg = errorMsg[:500]
+	}
+	p.stats.RecordRequestStat(&RequestStatRecord{
+		EndpointName:  endpoint.Name,
+		ClientType:    string(endpointClientType(endpoint)),
+		ClientIP:      clientIP,
+		Timestamp:     time.Now(),
+		Model:         model,
+		UpstreamModel: upstreamModel,
+		Success:       false,
+		DurationMs:    time.Since(startTime).Milliseconds(),
+		ErrorMessage:  errorMsg,
+		ErrorCategory: category,
+		RequestBytes:  requestBytes,
+		IsTest:        isTest,
+	})
+}
@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// endpointClientType 返回端点所属的 client type（未设置时默认为 claude）
+func endpointClientType(endpoint config.Endpoint) ClientType {
+	if endpoint.ClientType == "" {
+		return ClientTypeClaude
+	}
+	return ClientType(endpoint.ClientType)
+}
+
+// getCrossClientFallbackEndpoints 获取跨 client type 的兜底端点
+// 仅在本 client type 全部失败后使用，是最后的救命稻草，因此筛选较为严格：
+//   - 需在配置中显式启用并列出允许回退的 client type
+//   - 只选择状态为 available 或 degraded 的端点（未检测或不可用的端点不参与，避免引入更多失败）
+//   - transformer 必须能把当前客户端格式转换为端点格式
+func (p *Proxy) getCrossClientFallbackEndpoints(clientType ClientType, clientFormat ClientFormat) []config.Endpoint {
+	var candidates []config.Endpoint
+	for _, fallbackType := range p.config.GetCrossClientFallbackTypes(string(clientType)) {
+		for _, ep := range p.config.GetEnabledEndpointsByClient(fallbackType) {
+			if ep.Status != config.EndpointStatusAvailable && ep.Status != config.EndpointStatusDegraded {
+				continue
+			}
+			if _, err := prepareTransformerForClient(clientFormat, ep); err != nil {
+				logger.Debug("[FALLBACK:%s] Skip %s (%s): %v", clientType, ep.Name, fallbackType, err)
+				continue
+			}
+			candidates = append(candidates, ep)
+		}
+	}
+	return candidates
+}
@@ -31,6 +31,35 @@ func (p *Proxy) handleStats(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(stats)
 }
 
+// StatsExporter is implemented by stats storages that can export daily stats
+type StatsExporter interface {
+	ExportDailyStats() (interface{}, error)
+}
+
+// handleStatsExport returns all daily stats so another ccNexus instance can import them
+func (p *Proxy) handleStatsExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	exporter, ok := p.stats.GetStorage().(StatsExporter)
+	if !ok {
+		http.Error(w, "Stats export not supported", http.StatusNotImplemented)
+		return
+	}
+
+	export, err := exporter.ExportDailyStats()
+	if err != nil {
+		logger.Error("Failed to export daily stats: %v", err)
+		http.Error(w, "Failed to export stats", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(export)
+}
+
 // GetStats returns current statistics
 func (p *Proxy) GetStats() *Stats {
 	return p.stats
@@ -124,6 +153,7 @@ func (p *Proxy) UpdateConfig(cfg *config.Config) error {
 	}
 
 	p.config = cfg
+	p.stats.SetDateLocation(cfg.StatsLocation)
 
 	// 更新路由器和配额跟踪器的配置引用
 	if p.router != nil {
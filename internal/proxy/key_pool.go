@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// key 失效后的默认冷却时间
+const (
+	keyUnauthorizedCooldown = 10 * time.Minute // 401：key 无效或被吊销
+	keyRateLimitedCooldown  = 1 * time.Minute  // 429：未返回 Retry-After 时使用
+)
+
+// KeyPool 维护多 key 端点的 key 级别状态：按轮询选择 key，返回 401/429 的 key 在冷却期内跳过
+type KeyPool struct {
+	mu       sync.Mutex
+	next     map[string]int       // clientType:endpointName -> 下一次轮询的起始下标
+	cooldown map[string]time.Time // clientType:endpointName:key -> 冷却结束时间
+}
+
+// NewKeyPool creates a new KeyPool
+func NewKeyPool() *KeyPool {
+	return &KeyPool{
+		next:     make(map[string]int),
+		cooldown: make(map[string]time.Time),
+	}
+}
+
+func keyPoolEndpointKey(endpoint config.Endpoint) string {
+	return string(endpointClientType(endpoint)) + ":" + endpoint.Name
+}
+
+// Select 从端点的 key 池中轮询选择一把可用的 key；只有一把 key 时直接返回 APIKey。
+// 所有 key 都在冷却中时选择最早结束冷却的一把，避免端点完全不可用
+func (k *KeyPool) Select(endpoint config.Endpoint) string {
+	keys := endpoint.KeyPool()
+	if len(keys) <= 1 {
+		return endpoint.APIKey
+	}
+
+	epKey := keyPoolEndpointKey(endpoint)
+	now := time.Now()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	start := k.next[epKey] % len(keys)
+	earliest := -1
+	var earliestUntil time.Time
+	for i := 0; i < len(keys); i++ {
+		idx := (start + i) % len(keys)
+		until, cooling := k.cooldown[epKey+":"+keys[idx]]
+		if !cooling || !now.Before(until) {
+			delete(k.cooldown, epKey+":"+keys[idx])
+			k.next[epKey] = idx + 1
+			return keys[idx]
+		}
+		if earliest < 0 || until.Before(earliestUntil) {
+			earliest, earliestUntil = idx, until
+		}
+	}
+
+	logger.Warn("[KEYPOOL] All %d keys of endpoint %s are cooling down, using the one available soonest", len(keys), endpoint.Name)
+	k.next[epKey] = earliest + 1
+	return keys[earliest]
+}
+
+// HasAlternative 端点配置了多把 key，某把 key 失效时可以换 key 重试
+func (k *KeyPool) HasAlternative(endpoint config.Endpoint) bool {
+	return len(endpoint.KeyPool()) > 1
+}
+
+// MarkUnavailable 根据上游响应标记 key 暂时不可用：401 冷却较长时间，429 优先按 Retry-After 冷却。
+// 返回是否做了标记（单 key 端点或其他状态码不标记）
+func (k *KeyPool) MarkUnavailable(endpoint config.Endpoint, apiKey string, resp *http.Response) bool {
+	if !k.HasAlternative(endpoint) || resp == nil {
+		return false
+	}
+
+	var cooldown time.Duration
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		cooldown = keyUnauthorizedCooldown
+	case http.StatusTooManyRequests:
+		cooldown = keyRateLimitedCooldown
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			cooldown = time.Duration(seconds) * time.Second
+		}
+	default:
+		return false
+	}
+
+	k.mu.Lock()
+	k.cooldown[keyPoolEndpointKey(endpoint)+":"+apiKey] = time.Now().Add(cooldown)
+	k.mu.Unlock()
+
+	logger.Warn("[KEYPOOL] Key %s of endpoint %s returned HTTP %d, disabled for %v", maskKey(apiKey), endpoint.Name, resp.StatusCode, cooldown)
+	return true
+}
+
+// maskKey 日志中只显示 key 的首尾几位
+func maskKey(key string) string {
+	if len(key) <= 8 {
+		return "****"
+	}
+	return key[:4] + "****" + key[len(key)-4:]
+}
@@ -0,0 +1,148 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// endpointStatuses 状态指标输出的全部状态值（Prometheus state set：当前状态为 1，其余为 0）
+var endpointStatuses = []config.EndpointStatus{
+	config.EndpointStatusAvailable,
+	config.EndpointStatusDegraded,
+	config.EndpointStatusUnavailable,
+	config.EndpointStatusUntested,
+	config.EndpointStatusDisabled,
+	config.EndpointStatusMaintenance,
+}
+
+// metricsWriter 按 Prometheus 文本格式（0.0.4）输出指标
+type metricsWriter struct {
+	buf bytes.Buffer
+}
+
+// header 输出指标的 HELP 和 TYPE 行
+func (m *metricsWriter) header(name, typ, help string) {
+	fmt.Fprintf(&m.buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample 输出一条样本，labels 按 key, value 成对传入
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	m.buf.WriteString(name)
+	if len(labels) > 0 {
+		m.buf.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.buf.WriteByte(',')
+			}
+			fmt.Fprintf(&m.buf, "%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1]))
+		}
+		m.buf.WriteByte('}')
+	}
+	m.buf.WriteByte(' ')
+	m.buf.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	m.buf.WriteByte('\n')
+}
+
+// escapeLabelValue 转义 label 值中的反斜杠、双引号和换行
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// splitStatsKey 拆分统计数据的 clientType:endpointName 键
+func splitStatsKey(key string) (clientType, endpointName string) {
+	if i := strings.Index(key, ":"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "claude", key
+}
+
+// handleMetrics 以 Prometheus 文本格式输出每个端点的请求、错误、token 累计、状态、健康检查延迟和并发指标
+func (p *Proxy) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m := &metricsWriter{}
+
+	// 累计请求/错误/token（来自统计存储，包含已删除端点的历史数据）
+	_, stats := p.stats.GetStats(false)
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	m.header("ccnexus_endpoint_requests_total", "counter", "Total number of requests forwarded to the endpoint.")
+	for _, key := range keys {
+		clientType, name := splitStatsKey(key)
+		m.sample("ccnexus_endpoint_requests_total", float64(stats[key].Requests), "endpoint", name, "client_type", clientType)
+	}
+	m.header("ccnexus_endpoint_errors_total", "counter", "Total number of failed requests to the endpoint.")
+	for _, key := range keys {
+		clientType, name := splitStatsKey(key)
+		m.sample("ccnexus_endpoint_errors_total", float64(stats[key].Errors), "endpoint", name, "client_type", clientType)
+	}
+	m.header("ccnexus_endpoint_tokens_total", "counter", "Total number of tokens processed by the endpoint, by token type.")
+	for _, key := range keys {
+		clientType, name := splitStatsKey(key)
+		st := stats[key]
+		m.sample("ccnexus_endpoint_tokens_total", float64(st.InputTokens), "endpoint", name, "client_type", clientType, "type", "input")
+		m.sample("ccnexus_endpoint_tokens_total", float64(st.CacheCreationTokens), "endpoint", name, "client_type", clientType, "type", "cache_creation")
+		m.sample("ccnexus_endpoint_tokens_total", float64(st.CacheReadTokens), "endpoint", name, "client_type", clientType, "type", "cache_read")
+		m.sample("ccnexus_endpoint_tokens_total", float64(st.OutputTokens), "endpoint", name, "client_type", clientType, "type", "output")
+	}
+
+	// 当前配置的端点：状态、健康检查延迟、并发
+	endpoints := p.config.GetEndpoints()
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		ci, cj := endpointClientType(endpoints[i]), endpointClientType(endpoints[j])
+		if ci != cj {
+			return ci < cj
+		}
+		return endpoints[i].Name < endpoints[j].Name
+	})
+
+	m.header("ccnexus_endpoint_status", "gauge", "Current endpoint status (1 for the current status, 0 otherwise).")
+	for _, ep := range endpoints {
+		clientType := string(endpointClientType(ep))
+		for _, status := range endpointStatuses {
+			value := 0.0
+			if ep.Status == status {
+				value = 1
+			}
+			m.sample("ccnexus_endpoint_status", value, "endpoint", ep.Name, "client_type", clientType, "status", string(status))
+		}
+	}
+
+	latencies := p.monitor.GetHealthCheckLatencies()
+	m.header("ccnexus_endpoint_health_check_latency_ms", "gauge", "Latency of the last successful health check in milliseconds.")
+	for _, ep := range endpoints {
+		if latency, ok := latencies[ep.Name]; ok {
+			m.sample("ccnexus_endpoint_health_check_latency_ms", latency, "endpoint", ep.Name, "client_type", string(endpointClientType(ep)))
+		}
+	}
+
+	concurrency := make(map[string]EndpointConcurrency)
+	for _, c := range p.GetConcurrencyStats() {
+		concurrency[c.EndpointName] = c
+	}
+	m.header("ccnexus_endpoint_active_requests", "gauge", "Number of in-flight requests to the endpoint.")
+	for _, ep := range endpoints {
+		m.sample("ccnexus_endpoint_active_requests", float64(concurrency[ep.Name].Active), "endpoint", ep.Name, "client_type", string(endpointClientType(ep)))
+	}
+	m.header("ccnexus_endpoint_waiting_requests", "gauge", "Number of requests waiting for a concurrency slot on the endpoint.")
+	for _, ep := range endpoints {
+		m.sample("ccnexus_endpoint_waiting_requests", float64(concurrency[ep.Name].Waiting), "endpoint", ep.Name, "client_type", string(endpointClientType(ep)))
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(m.buf.Bytes())
+}
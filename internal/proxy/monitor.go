@@ -58,6 +58,8 @@ const (
 	EventRequestUpdated   MonitorEventType = "request_updated"
 	EventRequestCompleted MonitorEventType = "request_completed"
 	EventMetricsUpdated   MonitorEventType = "metrics_updated"
+	EventConcurrency      MonitorEventType = "concurrency_updated"
+	EventTestProgress     MonitorEventType = "endpoint_test_progress"
 )
 
 // MonitorEvent represents an event to be sent to the frontend
@@ -65,6 +67,20 @@ type MonitorEvent struct {
 	Type    MonitorEventType `json:"type"`
 	Request *ActiveRequest   `json:"request,omitempty"`
 	Metrics *EndpointMetric  `json:"metrics,omitempty"`
+
+	Concurrency  *EndpointConcurrency  `json:"concurrency,omitempty"`
+	TestProgress *EndpointTestProgress `json:"testProgress,omitempty"`
+}
+
+// EndpointTestProgress 批量检测端点时单个端点完成后的进度
+type EndpointTestProgress struct {
+	ClientType   string  `json:"clientType"`
+	Completed    int     `json:"completed"` // 已完成的端点数（含本次）
+	Total        int     `json:"total"`
+	EndpointName string  `json:"endpointName"`
+	Success      bool    `json:"success"`
+	LatencyMs    float64 `json:"latencyMs"`
+	ErrorMessage string  `json:"errorMessage,omitempty"`
 }
 
 // EventCallback is a function that handles monitor events
@@ -79,6 +95,17 @@ type EndpointCheckResult struct {
 	ErrorMessage string    `json:"errorMessage"` // 错误信息（如果失败）
 }
 
+// EndpointTestResult 端点最近一次手动测试（TestEndpoint）的结果
+type EndpointTestResult struct {
+	EndpointName string    `json:"endpointName"`
+	ClientType   string    `json:"clientType"`
+	TestedAt     time.Time `json:"testedAt"`             // 测试时间
+	Success      bool      `json:"success"`              // 测试是否成功
+	LatencyMs    float64   `json:"latencyMs"`            // 从发出请求到读完响应的耗时（毫秒）
+	StatusCode   int       `json:"statusCode,omitempty"` // 上游 HTTP 状态码，请求未发出或连接失败时为 0
+	Message      string    `json:"message"`              // 模型的回复（如模型自述）或错误信息
+}
+
 // Monitor tracks active requests and endpoint metrics
 type Monitor struct {
 	mu              sync.RWMutex
@@ -86,6 +113,11 @@ type Monitor struct {
 	endpointMetrics map[string]*EndpointMetric
 	eventCallback   EventCallback
 
+	// 事件订阅者（如 /ws/monitor 连接），由 subMu 保护，与 mu 独立以便在持有 mu 时广播
+	subMu       sync.Mutex
+	subscribers map[int]chan MonitorEvent
+	nextSubID   int
+
 	// Rolling window for metrics (last 100 requests per endpoint)
 	responseTimes map[string][]float64
 	maxSamples    int
@@ -96,6 +128,9 @@ type Monitor struct {
 	// 端点检测结果存储
 	checkResults map[string]*EndpointCheckResult // endpointName -> 检测结果
 
+	// 端点最近一次手动测试结果
+	testResults map[string]*EndpointTestResult // clientType:endpointName -> 测试结果
+
 	// 最近5分钟的请求记录（用于统计）
 	recentRequests []recentRequestRecord // 按时间排序的请求记录
 }
@@ -115,6 +150,8 @@ func NewMonitor() *Monitor {
 		responseTimes:        make(map[string][]float64),
 		healthCheckLatencies: make(map[string]float64),
 		checkResults:         make(map[string]*EndpointCheckResult),
+		testResults:          make(map[string]*EndpointTestResult),
+		subscribers:          make(map[int]chan MonitorEvent),
 		maxSamples:           100,
 	}
 }
@@ -149,16 +186,16 @@ func (m *Monitor) StartRequest(requestID, endpointName, clientType, model, messa
 	metric.ActiveCount++
 
 	// Emit events
-	if m.eventCallback != nil {
-		m.eventCallback(MonitorEvent{
+	m.emitLocked(
+		MonitorEvent{
 			Type:    EventRequestStarted,
 			Request: req.clone(),
-		})
-		m.eventCallback(MonitorEvent{
+		},
+		MonitorEvent{
 			Type:    EventMetricsUpdated,
 			Metrics: metric.clone(),
-		})
-	}
+		},
+	)
 }
 
 // UpdatePhase updates the phase of an active request
@@ -173,12 +210,10 @@ func (m *Monitor) UpdatePhase(requestID string, phase RequestPhase) {
 
 	req.Phase = phase
 
-	if m.eventCallback != nil {
-		m.eventCallback(MonitorEvent{
-			Type:    EventRequestUpdated,
-			Request: req.clone(),
-		})
-	}
+	m.emitLocked(MonitorEvent{
+		Type:    EventRequestUpdated,
+		Request: req.clone(),
+	})
 }
 
 // UpdateBytes updates the bytes received for a streaming request
@@ -194,12 +229,10 @@ func (m *Monitor) UpdateBytes(requestID string, bytesReceived int64) {
 	req.BytesReceived = bytesReceived
 	req.Phase = PhaseStreaming
 
-	if m.eventCallback != nil {
-		m.eventCallback(MonitorEvent{
-			Type:    EventRequestUpdated,
-			Request: req.clone(),
-		})
-	}
+	m.emitLocked(MonitorEvent{
+		Type:    EventRequestUpdated,
+		Request: req.clone(),
+	})
 }
 
 // CompleteRequest marks a request as completed
@@ -280,16 +313,98 @@ func (m *Monitor) CompleteRequest(requestID string, success bool, errorMsg strin
 	}
 
 	// Emit events
-	if m.eventCallback != nil {
-		m.eventCallback(MonitorEvent{
+	m.emitLocked(
+		MonitorEvent{
 			Type:    EventRequestCompleted,
 			Request: req.clone(),
-		})
-		m.eventCallback(MonitorEvent{
+		},
+		MonitorEvent{
 			Type:    EventMetricsUpdated,
 			Metrics: metric.clone(),
+		},
+	)
+}
+
+// NotifyConcurrency 推送端点并发变化事件（计数由 Proxy 维护，这里只负责转发给前端）
+func (m *Monitor) NotifyConcurrency(concurrency EndpointConcurrency) {
+	m.mu.RLock()
+	callback := m.eventCallback
+	m.mu.RUnlock()
+
+	event := MonitorEvent{
+		Type:        EventConcurrency,
+		Concurrency: &concurrency,
+	}
+	if callback != nil {
+		callback(event)
+	}
+	m.broadcast(event)
+}
+
+// NotifyTestProgress 推送批量检测端点的进度事件
+func (m *Monitor) NotifyTestProgress(progress EndpointTestProgress) {
+	m.mu.RLock()
+	callback := m.eventCallback
+	m.mu.RUnlock()
+
+	event := MonitorEvent{
+		Type:         EventTestProgress,
+		TestProgress: &progress,
+	}
+	if callback != nil {
+		callback(event)
+	}
+	m.broadcast(event)
+}
+
+// Subscribe registers a subscriber for monitor events and returns its event channel
+// and an unsubscribe function. Events are dropped for subscribers whose buffer is full,
+// so a slow subscriber never blocks request processing
+func (m *Monitor) Subscribe(buffer int) (<-chan MonitorEvent, func()) {
+	if buffer <= 0 {
+		buffer = 64
+	}
+	ch := make(chan MonitorEvent, buffer)
+
+	m.subMu.Lock()
+	id := m.nextSubID
+	m.nextSubID++
+	m.subscribers[id] = ch
+	m.subMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			m.subMu.Lock()
+			delete(m.subscribers, id)
+			m.subMu.Unlock()
+			close(ch)
 		})
 	}
+	return ch, unsubscribe
+}
+
+// emitLocked 将事件发送给回调和所有订阅者，调用方需持有 mu
+func (m *Monitor) emitLocked(events ...MonitorEvent) {
+	for _, event := range events {
+		if m.eventCallback != nil {
+			m.eventCallback(event)
+		}
+		m.broadcast(event)
+	}
+}
+
+// broadcast 非阻塞地将事件发送给所有订阅者，缓冲区已满的订阅者丢弃该事件
+func (m *Monitor) broadcast(event MonitorEvent) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
 }
 
 // GetSnapshot returns a snapshot of current monitoring data
@@ -360,6 +475,30 @@ func (m *Monitor) GetEndpointMetric(endpointName string) *EndpointMetric {
 	return nil
 }
 
+// GetLatencyTrend returns the average response time (seconds) of the last recentCount
+// requests and of the older samples in the rolling window; ok is false when there
+// are not enough samples to compare
+func (m *Monitor) GetLatencyTrend(endpointName string, recentCount int) (recentAvg, baselineAvg float64, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	times := m.responseTimes[endpointName]
+	// 基线至少需要 2 倍于近期窗口的样本，避免冷启动时误判
+	if recentCount <= 0 || len(times) < recentCount*3 {
+		return 0, 0, false
+	}
+
+	split := len(times) - recentCount
+	var baselineSum, recentSum float64
+	for _, t := range times[:split] {
+		baselineSum += t
+	}
+	for _, t := range times[split:] {
+		recentSum += t
+	}
+	return recentSum / float64(recentCount), baselineSum / float64(split), true
+}
+
 // ResetMetrics resets all endpoint metrics (but keeps active requests)
 func (m *Monitor) ResetMetrics() {
 	m.mu.Lock()
@@ -634,6 +773,26 @@ func (m *Monitor) GetCheckResult(endpointName string) *EndpointCheckResult {
 	return nil
 }
 
+// RecordTestResult 记录端点最近一次手动测试的结果，覆盖之前的记录
+func (m *Monitor) RecordTestResult(result EndpointTestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.testResults[result.ClientType+":"+result.EndpointName] = &result
+}
+
+// GetTestResult 获取端点最近一次手动测试的结果，没有测试过时返回 nil
+func (m *Monitor) GetTestResult(clientType, endpointName string) *EndpointTestResult {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if result, exists := m.testResults[clientType+":"+endpointName]; exists {
+		copied := *result
+		return &copied
+	}
+	return nil
+}
+
 // RecentStats 最近5分钟的统计信息
 type RecentStats struct {
 	SuccessCount int
@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/logger"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	monitorWSBuffer       = 256              // 每个连接的事件缓冲，推送跟不上时丢弃多余事件
+	monitorWSWriteTimeout = 10 * time.Second // 单条消息的写超时，超时后断开连接
+)
+
+// EventSnapshot 连接建立后推送的首条消息，携带当前完整的监控快照
+const EventSnapshot MonitorEventType = "snapshot"
+
+// monitorSnapshotMessage /ws/monitor 的首条消息
+type monitorSnapshotMessage struct {
+	Type     MonitorEventType `json:"type"`
+	Snapshot MonitorSnapshot  `json:"snapshot"`
+}
+
+// handleMonitorWS 通过 WebSocket 实时推送监控事件：先推送一次快照，之后推送请求开始、阶段变化、完成等事件
+func (p *Proxy) handleMonitorWS(w http.ResponseWriter, r *http.Request) {
+	// 不校验 Origin，允许没有 Origin 头的非浏览器客户端连接
+	server := websocket.Server{Handler: p.serveMonitorWS}
+	server.ServeHTTP(w, r)
+}
+
+func (p *Proxy) serveMonitorWS(ws *websocket.Conn) {
+	defer ws.Close()
+
+	events, unsubscribe := p.monitor.Subscribe(monitorWSBuffer)
+	defer unsubscribe()
+
+	ws.SetWriteDeadline(time.Now().Add(monitorWSWriteTimeout))
+	if err := websocket.JSON.Send(ws, monitorSnapshotMessage{Type: EventSnapshot, Snapshot: p.monitor.GetSnapshot()}); err != nil {
+		return
+	}
+	logger.Debug("Monitor WebSocket connected: %s", ws.Request().RemoteAddr)
+
+	// 客户端只订阅不发送，读取循环用于响应 ping 和检测连接关闭
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var msg string
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			ws.SetWriteDeadline(time.Now().Add(monitorWSWriteTimeout))
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				logger.Debug("Monitor WebSocket send failed: %v", err)
+				return
+			}
+		case <-closed:
+			logger.Debug("Monitor WebSocket disconnected: %s", ws.Request().RemoteAddr)
+			return
+		}
+	}
+}
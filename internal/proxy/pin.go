@@ -0,0 +1,94 @@
+package proxy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// PinnedEndpoint 手动钉选的端点
+type PinnedEndpoint struct {
+	ClientType       string    `json:"clientType"`
+	EndpointName     string    `json:"endpointName"`
+	PinnedAt         time.Time `json:"pinnedAt"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	RemainingSeconds int64     `json:"remainingSeconds"`
+}
+
+// EndpointPins 按 client type 记录手动钉选的端点，期限内所有请求都走钉选端点
+// 与会话亲和性不同，钉选是全局的，到期自动解除
+type EndpointPins struct {
+	mu   sync.Mutex
+	pins map[string]*PinnedEndpoint // clientType -> pin
+}
+
+// NewEndpointPins creates a new EndpointPins
+func NewEndpointPins() *EndpointPins {
+	return &EndpointPins{pins: make(map[string]*PinnedEndpoint)}
+}
+
+// Pin 钉选端点，同一 client type 只保留最新的钉选
+func (e *EndpointPins) Pin(clientType, endpointName string, duration time.Duration) PinnedEndpoint {
+	now := time.Now()
+	pin := &PinnedEndpoint{
+		ClientType:   clientType,
+		EndpointName: endpointName,
+		PinnedAt:     now,
+		ExpiresAt:    now.Add(duration),
+	}
+
+	e.mu.Lock()
+	e.pins[clientType] = pin
+	e.mu.Unlock()
+
+	result := *pin
+	result.RemainingSeconds = int64(duration.Seconds())
+	return result
+}
+
+// Unpin 解除钉选，返回是否存在钉选
+func (e *EndpointPins) Unpin(clientType string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, exists := e.pins[clientType]
+	delete(e.pins, clientType)
+	return exists
+}
+
+// Get 返回 client type 当前生效的钉选端点名，过期的钉选会被清除
+func (e *EndpointPins) Get(clientType string) (string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pin, exists := e.pins[clientType]
+	if !exists {
+		return "", false
+	}
+	if !time.Now().Before(pin.ExpiresAt) {
+		delete(e.pins, clientType)
+		return "", false
+	}
+	return pin.EndpointName, true
+}
+
+// List 返回所有生效中的钉选（按 client type 排序）
+func (e *EndpointPins) List() []PinnedEndpoint {
+	now := time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]PinnedEndpoint, 0, len(e.pins))
+	for clientType, pin := range e.pins {
+		if !now.Before(pin.ExpiresAt) {
+			delete(e.pins, clientType)
+			continue
+		}
+		item := *pin
+		item.RemainingSeconds = int64(pin.ExpiresAt.Sub(now).Seconds())
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ClientType < result[j].ClientType })
+	return result
+}
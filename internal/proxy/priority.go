@@ -0,0 +1,43 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+)
+
+// priorityHeader 客户端通过该请求头标注请求优先级：high、normal、low，缺省为 normal
+const priorityHeader = "X-CCNexus-Priority"
+
+// RequestPriority 请求优先级，端点并发达到上限时高优先级的排队请求先获得名额
+type RequestPriority int
+
+const (
+	PriorityLow RequestPriority = iota
+	PriorityNormal
+	PriorityHigh
+
+	priorityLevels = int(PriorityHigh) + 1
+)
+
+// requestPriority 从请求头解析优先级，无法识别的值按 normal 处理
+func requestPriority(r *http.Request) RequestPriority {
+	switch strings.ToLower(strings.TrimSpace(r.Header.Get(priorityHeader))) {
+	case "high":
+		return PriorityHigh
+	case "low":
+		return PriorityLow
+	default:
+		return PriorityNormal
+	}
+}
+
+func (rp RequestPriority) String() string {
+	switch rp {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/lich0821/ccNexus/internal/cache"
 	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/idempotency"
 	"github.com/lich0821/ccNexus/internal/interaction"
 	"github.com/lich0821/ccNexus/internal/logger"
 	"github.com/lich0821/ccNexus/internal/ratelimit"
@@ -54,11 +55,14 @@ type Proxy struct {
 	stats            *Stats
 	cache            *cache.Cache                 // 请求缓存
 	rateLimiter      *ratelimit.RateLimiter       // 速率限制器
+	idempotency      *idempotency.Cache           // 幂等键去重缓存（与内容缓存相互独立）
+	dedup            *RequestDeduper              // 按请求体 hash 的短窗口请求去重
 	currentIndex     int                          // Legacy: for backward compatibility
 	currentIndexByClient map[ClientType]int       // Per-client endpoint index
 	mu               sync.RWMutex
 	server           *http.Server
-	activeRequests   map[string]bool              // tracks active requests by endpoint name
+	clientServers    []*http.Server               // 各 client type 独立端口的 server
+	activeRequests   map[string]*EndpointConcurrency // per-endpoint concurrency counters (active/waiting/peak)
 	activeRequestsMu sync.RWMutex                 // protects activeRequests map
 	endpointCtx      map[string]context.Context   // context per endpoint for cancellation
 	endpointCancel   map[string]context.CancelFunc // cancel functions per endpoint
@@ -72,11 +76,18 @@ type Proxy struct {
 	router           *Router                      // 智能路由选择器
 	quotaTracker     *QuotaTracker                // 配额跟踪器
 	sessionAffinity  *SessionAffinityManager      // 会话亲和性管理器
+	retryBudget      *RetryBudget                 // 全局重试预算
+	tokenRate        *TokenRateTracker            // token 消耗速率统计
+	pins             *EndpointPins                // 手动钉选的端点
+	circuitBreaker   *CircuitBreaker              // 端点熔断器
+	tokenRefresher   *TokenRefresher              // OAuth access token 刷新
+	keyPool          *KeyPool                     // 多 key 端点的 key 轮换
 }
 
 // New creates a new Proxy instance
 func New(cfg *config.Config, statsStorage StatsStorage, deviceID string) *Proxy {
 	stats := NewStats(statsStorage, deviceID)
+	stats.SetDateLocation(cfg.StatsLocation)
 
 	// 初始化缓存
 	var reqCache *cache.Cache
@@ -94,17 +105,32 @@ func New(cfg *config.Config, statsStorage StatsStorage, deviceID string) *Proxy
 		rateLimiter = ratelimit.New(false, 60, 30) // 默认禁用
 	}
 
+	// 初始化幂等键缓存
+	idempotencyCfg := cfg.GetIdempotency()
+	idempotencyCache := idempotency.New(idempotencyCfg.Enabled, idempotencyCfg.TTLSeconds)
+
+	// 初始化请求去重
+	dedupCfg := cfg.GetDedup()
+
 	return &Proxy{
 		config:              cfg,
 		stats:               stats,
 		cache:               reqCache,
 		rateLimiter:         rateLimiter,
+		idempotency:         idempotencyCache,
+		dedup:               NewRequestDeduper(dedupCfg.Enabled, dedupCfg.WindowSeconds),
 		currentIndex:        0,
 		currentIndexByClient: make(map[ClientType]int),
-		activeRequests:      make(map[string]bool),
+		activeRequests:      make(map[string]*EndpointConcurrency),
 		endpointCtx:         make(map[string]context.Context),
 		endpointCancel:      make(map[string]context.CancelFunc),
 		monitor:             NewMonitor(),
+		retryBudget:         NewRetryBudget(cfg),
+		tokenRate:           NewTokenRateTracker(),
+		pins:                NewEndpointPins(),
+		circuitBreaker:      NewCircuitBreaker(cfg),
+		tokenRefresher:      NewTokenRefresher(cfg),
+		keyPool:             NewKeyPool(),
 	}
 }
 
@@ -127,7 +153,9 @@ func (p *Proxy) SetInteractionStorage(storage *interaction.Storage) {
 // store: 用于配额持久化的存储接口
 func (p *Proxy) SetupRouter(store storage.Storage) {
 	p.quotaTracker = NewQuotaTracker(p.config, store)
+	p.tokenRefresher.SetStorage(store)
 	p.router = NewRouter(p.config, p.monitor)
+	p.router.SetActiveRequestCounter(p.activeRequestCount)
 
 	// 初始化会话亲和性管理器
 	if p.config.SessionAffinity != nil && p.config.SessionAffinity.Enabled {
@@ -162,6 +190,49 @@ func (p *Proxy) UpdateRouterConfig(cfg *config.Config) {
 	}
 }
 
+// GetRetryBudgetStats returns retry budget statistics
+func (p *Proxy) GetRetryBudgetStats() RetryBudgetStats {
+	return p.retryBudget.GetStats()
+}
+
+// ResetRetryBudgetStats resets retry budget statistics
+func (p *Proxy) ResetRetryBudgetStats() {
+	p.retryBudget.Reset()
+}
+
+// GetTokenRate returns the token consumption rate within the given window
+func (p *Proxy) GetTokenRate(windowSeconds int) TokenRateSnapshot {
+	return p.tokenRate.Snapshot(windowSeconds)
+}
+
+// PinEndpoint pins an endpoint so that all requests of the client type use it until the pin expires
+func (p *Proxy) PinEndpoint(clientType, endpointName string, duration time.Duration) PinnedEndpoint {
+	logger.Info("[PIN:%s] Endpoint %s pinned for %v", clientType, endpointName, duration)
+	return p.pins.Pin(clientType, endpointName, duration)
+}
+
+// UnpinEndpoint removes the pin of the client type
+func (p *Proxy) UnpinEndpoint(clientType string) {
+	if p.pins.Unpin(clientType) {
+		logger.Info("[PIN:%s] Endpoint unpinned", clientType)
+	}
+}
+
+// GetPinnedEndpoints returns all active endpoint pins
+func (p *Proxy) GetPinnedEndpoints() []PinnedEndpoint {
+	return p.pins.List()
+}
+
+// GetCircuitBreakerStatus returns the circuit breaker state of endpoints with recorded failures
+func (p *Proxy) GetCircuitBreakerStatus() []CircuitBreakerStatus {
+	return p.circuitBreaker.GetStatus()
+}
+
+// ResetCircuitBreaker closes all endpoint circuits
+func (p *Proxy) ResetCircuitBreaker() {
+	p.circuitBreaker.Reset()
+}
+
 // GetMonitor returns the monitor instance for real-time request tracking
 func (p *Proxy) GetMonitor() *Monitor {
 	return p.monitor
@@ -207,6 +278,36 @@ func (p *Proxy) ResetRateLimitStats() {
 	p.rateLimiter.Reset()
 }
 
+// GetIdempotencyStats returns idempotency cache statistics
+func (p *Proxy) GetIdempotencyStats() idempotency.Stats {
+	return p.idempotency.GetStats()
+}
+
+// ClearIdempotencyCache clears all stored idempotent results
+func (p *Proxy) ClearIdempotencyCache() {
+	p.idempotency.Clear()
+}
+
+// UpdateIdempotencyConfig updates idempotency configuration
+func (p *Proxy) UpdateIdempotencyConfig(enabled bool, ttlSeconds int) {
+	p.idempotency.UpdateConfig(enabled, ttlSeconds)
+}
+
+// GetDedupStats returns request dedup statistics
+func (p *Proxy) GetDedupStats() cache.CacheStats {
+	return p.dedup.GetStats()
+}
+
+// ClearDedupCache clears all stored dedup responses
+func (p *Proxy) ClearDedupCache() {
+	p.dedup.Clear()
+}
+
+// UpdateDedupConfig updates request dedup configuration
+func (p *Proxy) UpdateDedupConfig(enabled bool, windowSeconds int) {
+	p.dedup.UpdateConfig(enabled, windowSeconds)
+}
+
 // Start starts the proxy server
 func (p *Proxy) Start() error {
 	return p.StartWithMux(nil)
@@ -229,6 +330,9 @@ func (p *Proxy) StartWithMux(customMux *http.ServeMux) error {
 	mux.HandleFunc("/v1/messages/count_tokens", p.handleCountTokens)
 	mux.HandleFunc("/health", p.handleHealth)
 	mux.HandleFunc("/stats", p.handleStats)
+	mux.HandleFunc("/stats/export", p.handleStatsExport)
+	mux.HandleFunc("/metrics", p.handleMetrics)
+	mux.HandleFunc("/ws/monitor", p.handleMonitorWS)
 
 	// Try to find an available port (up to 10 attempts)
 	maxAttempts := 10
@@ -251,6 +355,8 @@ func (p *Proxy) StartWithMux(customMux *http.ServeMux) error {
 		logger.Info("ccNexus starting on port %d", currentPort)
 		logger.Info("Configured %d endpoints", len(p.config.GetEndpoints()))
 
+		p.startClientPorts(mux)
+
 		// Use the listener we already created
 		return p.server.Serve(listener)
 	}
@@ -260,21 +366,24 @@ func (p *Proxy) StartWithMux(customMux *http.ServeMux) error {
 
 // Stop stops the proxy server
 func (p *Proxy) Stop() error {
+	clientErr := p.stopClientPorts()
 	if p.server != nil {
-		return p.server.Close()
+		if err := p.server.Close(); err != nil {
+			return err
+		}
 	}
-	return nil
+	return clientErr
 }
 
 // getEnabledEndpoints returns all non-disabled endpoints
-// 返回所有非禁用状态的端点，包括 available、untested 和 unavailable
-// unavailable 状态的端点也会被返回，以便在没有其他可用端点时尝试使用
+// 返回所有参与路由的端点，包括 available、untested 和 unavailable
+// unavailable 状态的端点也会被返回，以便在没有其他可用端点时尝试使用；维护中的端点不返回
 func (p *Proxy) getEnabledEndpoints() []config.Endpoint {
 	allEndpoints := p.config.GetEndpoints()
 	enabled := make([]config.Endpoint, 0)
 	for _, ep := range allEndpoints {
-		// 返回所有非禁用状态的端点
-		if ep.Status != config.EndpointStatusDisabled {
+		// 返回所有非禁用、非维护状态的端点
+		if ep.IsRoutable() {
 			enabled = append(enabled, ep)
 		}
 	}
@@ -283,9 +392,45 @@ func (p *Proxy) getEnabledEndpoints() []config.Endpoint {
 
 // getEnabledEndpointsForClient returns all non-disabled endpoints for a specific client type
 // 返回所有非禁用状态的端点，包括 available、untested 和 unavailable
-// unavailable 状态的端点也会被返回，以便在没有其他可用端点时尝试使用
+// unavailable 状态的端点也会被返回，以便在没有其他可用端点时尝试使用；不在活跃时段内的端点被排除
 func (p *Proxy) getEnabledEndpointsForClient(clientType ClientType) []config.Endpoint {
-	return p.config.GetEnabledEndpointsByClient(string(clientType))
+	endpoints := p.config.GetEnabledEndpointsByClient(string(clientType))
+
+	// 排除当前不在活跃时段内的端点
+	now := time.Now()
+	active := make([]config.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.IsActiveAt(now) {
+			active = append(active, ep)
+		}
+	}
+	return active
+}
+
+// getEndpointsForClientAndGroup returns the non-disabled endpoints of a client type within a group
+// group 为空时与 getEnabledEndpointsForClient 相同
+func (p *Proxy) getEndpointsForClientAndGroup(clientType ClientType, group string) []config.Endpoint {
+	endpoints := p.getEnabledEndpointsForClient(clientType)
+	if group == "" {
+		return endpoints
+	}
+	grouped := make([]config.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Group == group {
+			grouped = append(grouped, ep)
+		}
+	}
+	return grouped
+}
+
+// groupExists reports whether any endpoint (including disabled ones) of the client type belongs to the group
+func (p *Proxy) groupExists(clientType ClientType, group string) bool {
+	for _, ep := range p.config.GetEndpointsByClient(string(clientType)) {
+		if ep.Group == group {
+			return true
+		}
+	}
+	return false
 }
 
 // getCurrentEndpoint returns the current endpoint (thread-safe) - legacy for backward compatibility
@@ -317,21 +462,47 @@ func (p *Proxy) getCurrentEndpointForClient(clientType ClientType) config.Endpoi
 	return endpoints[index]
 }
 
+// getCurrentEndpointForGroup returns the current round-robin endpoint within a group (thread-safe)
+// 分组内轮询共用 client type 的轮询索引
+func (p *Proxy) getCurrentEndpointForGroup(clientType ClientType, group string) config.Endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	endpoints := p.getEndpointsForClientAndGroup(clientType, group)
+	if len(endpoints) == 0 {
+		return config.Endpoint{}
+	}
+
+	index := p.currentIndexByClient[clientType] % len(endpoints)
+	return endpoints[index]
+}
+
 // selectEndpointForRequest 使用智能路由选择端点（支持会话亲和性）
 // 当路由器可用且启用路由策略时使用智能路由，否则回退到优先级选择
-func (p *Proxy) selectEndpointForRequest(clientType ClientType, requestModel string, sessionID string) config.Endpoint {
+// group 非空时只在该组内的端点中选择
+func (p *Proxy) selectEndpointForRequest(clientType ClientType, group string, requestModel string, sessionID string) config.Endpoint {
+	// 0. 手动钉选的端点优先于路由和会话亲和性（端点被禁用或删除时不生效）
+	if endpointName, pinned := p.pins.Get(string(clientType)); pinned {
+		endpoint := p.config.GetEndpointByName(endpointName, string(clientType))
+		if endpoint != nil && endpoint.IsRoutable() && (group == "" || endpoint.Group == group) {
+			logger.Debug("[PIN:%s] Using pinned endpoint: %s", clientType, endpointName)
+			return *endpoint
+		}
+	}
+
 	// 1. 检查会话亲和性
 	if p.sessionAffinity != nil && sessionID != "" {
 		if endpointName, exists := p.sessionAffinity.GetEndpointForSession(sessionID, string(clientType)); exists {
-			// 验证端点仍然可用（非禁用状态即可尝试使用）
+			// 验证端点仍然可用（非禁用状态即可尝试使用）且属于请求的分组
 			endpoint := p.config.GetEndpointByName(endpointName, string(clientType))
-			if endpoint != nil && endpoint.Status != config.EndpointStatusDisabled {
+			if endpoint != nil && endpoint.IsRoutable() && (group == "" || endpoint.Group == group) &&
+				p.circuitBreaker.Allow(string(clientType), endpointName) {
 				logger.Debug("[SESSION:%s] Using bound endpoint: %s", sessionID, endpointName)
 				return *endpoint
 			} else {
-				// 端点已禁用，解除绑定
+				// 端点已禁用或处于熔断冷却中，解除绑定
 				p.sessionAffinity.UnbindSession(sessionID)
-				logger.Debug("[SESSION:%s] Endpoint %s disabled, unbinding", sessionID, endpointName)
+				logger.Debug("[SESSION:%s] Endpoint %s unavailable, unbinding", sessionID, endpointName)
 			}
 		}
 	}
@@ -339,11 +510,11 @@ func (p *Proxy) selectEndpointForRequest(clientType ClientType, requestModel str
 	// 2. 新会话：检查是否启用智能路由策略
 	var selectedEndpoint config.Endpoint
 	if p.router != nil {
-		routingCfg := p.config.GetRoutingConfig()
+		routingCfg := p.config.GetRoutingConfigForClient(string(clientType))
 		// 如果启用了任一高级路由策略，使用智能路由
 		if routingCfg.EnableModelRouting || routingCfg.EnableLoadBalance ||
 			routingCfg.EnableCostPriority || routingCfg.EnableQuotaRouting {
-			endpoint, err := p.router.SelectEndpoint(clientType, requestModel, p.quotaTracker)
+			endpoint, err := p.router.SelectEndpointFrom(p.selectableEndpoints(clientType, group), clientType, requestModel, p.quotaTracker)
 			if err == nil {
 				logger.Debug("[ROUTER:%s] Selected endpoint: %s (model: %s)", clientType, endpoint.Name, requestModel)
 				selectedEndpoint = endpoint
@@ -361,7 +532,7 @@ func (p *Proxy) selectEndpointForRequest(clientType ClientType, requestModel str
 	// 3. 回退到优先级选择（默认行为）
 	// 即使没有启用高级路由策略，也应该按优先级选择端点
 	if p.router != nil {
-		endpoint, err := p.router.selectByPriority(p.config.GetEnabledEndpointsByClient(string(clientType)))
+		endpoint, err := p.router.selectByPriority(p.selectableEndpoints(clientType, group))
 		if err == nil {
 			logger.Debug("[PRIORITY:%s] Selected endpoint: %s", clientType, endpoint.Name)
 			selectedEndpoint = endpoint
@@ -375,7 +546,11 @@ func (p *Proxy) selectEndpointForRequest(clientType ClientType, requestModel str
 	}
 
 	// 4. 最后回退到传统轮询逻辑（仅当优先级选择也失败时）
-	selectedEndpoint = p.getCurrentEndpointForClient(clientType)
+	if group != "" {
+		selectedEndpoint = p.getCurrentEndpointForGroup(clientType, group)
+	} else {
+		selectedEndpoint = p.getCurrentEndpointForClient(clientType)
+	}
 	if p.sessionAffinity != nil && sessionID != "" {
 		p.sessionAffinity.BindSession(sessionID, selectedEndpoint.Name, string(clientType))
 		logger.Debug("[SESSION:%s] Bound to round-robin endpoint: %s", sessionID, selectedEndpoint.Name)
@@ -383,40 +558,30 @@ func (p *Proxy) selectEndpointForRequest(clientType ClientType, requestModel str
 	return selectedEndpoint
 }
 
+// selectableEndpoints returns the endpoints of a client type within a group, excluding endpoints whose circuit is open
+// and endpoints that have reached their concurrency limit
+func (p *Proxy) selectableEndpoints(clientType ClientType, group string) []config.Endpoint {
+	return p.filterSaturated(p.circuitBreaker.Filter(clientType, p.getEndpointsForClientAndGroup(clientType, group)))
+}
+
 // recordQuotaUsage 记录配额使用量（请求成功后调用）
-func (p *Proxy) recordQuotaUsage(endpointName, clientType string, usage transformer.TokenUsageDetail) {
+// 请求模型匹配端点的多模型配置且该模型设置了配额时，同时记录模型级配额
+func (p *Proxy) recordQuotaUsage(endpoint config.Endpoint, clientType, model string, usage transformer.TokenUsageDetail) {
 	if p.quotaTracker == nil {
 		return
 	}
 
-	// 计算总 Token 数（输入 + 输出）
+	// 计算总 Token 数（输入 + 输出）和按端点单价换算的成本（cost 模式配额使用）
 	totalTokens := int64(usage.TotalInputTokens() + usage.OutputTokens)
 	if totalTokens > 0 {
-		p.quotaTracker.RecordUsage(endpointName, clientType, totalTokens)
+		cost := endpoint.QuotaCost(model, usage.InputTokens, usage.CacheCreationInputTokens, usage.CacheReadInputTokens, usage.OutputTokens)
+		p.quotaTracker.RecordUsage(endpoint.Name, clientType, totalTokens, cost)
+		if m := endpoint.MatchModel(model); m != nil && m.QuotaLimit > 0 {
+			p.quotaTracker.RecordUsage(config.ModelQuotaName(endpoint.Name, m.Name), clientType, totalTokens, cost)
+		}
 	}
 }
 
-// markRequestActive marks an endpoint as having active requests
-func (p *Proxy) markRequestActive(endpointName string) {
-	p.activeRequestsMu.Lock()
-	defer p.activeRequestsMu.Unlock()
-	p.activeRequests[endpointName] = true
-}
-
-// markRequestInactive marks an endpoint as having no active requests
-func (p *Proxy) markRequestInactive(endpointName string) {
-	p.activeRequestsMu.Lock()
-	defer p.activeRequestsMu.Unlock()
-	delete(p.activeRequests, endpointName)
-}
-
-// hasActiveRequests checks if an endpoint has active requests
-func (p *Proxy) hasActiveRequests(endpointName string) bool {
-	p.activeRequestsMu.RLock()
-	defer p.activeRequestsMu.RUnlock()
-	return p.activeRequests[endpointName]
-}
-
 // isCurrentEndpoint checks if the given endpoint is still the current one - legacy for backward compatibility
 func (p *Proxy) isCurrentEndpoint(endpointName string) bool {
 	current := p.getCurrentEndpoint()
@@ -456,6 +621,26 @@ func (p *Proxy) cancelEndpointRequests(endpointName string) {
 	}
 }
 
+// errClientGone 客户端断开连接导致上游请求被取消时的取消原因
+var errClientGone = errors.New("client disconnected")
+
+// upstreamContext returns the context for one upstream attempt: it is cancelled when requests to the
+// endpoint are cancelled (endpoint switch) or when the client goes away, so the upstream connection is
+// released instead of running to completion. The returned stop func must be called once the attempt is done
+func (p *Proxy) upstreamContext(clientCtx context.Context, endpointName string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(p.getEndpointContext(endpointName))
+	stopAfter := context.AfterFunc(clientCtx, func() { cancel(errClientGone) })
+	return ctx, func() {
+		stopAfter()
+		cancel(nil)
+	}
+}
+
+// isClientGone reports whether ctx was cancelled because the client disconnected
+func isClientGone(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errClientGone)
+}
+
 // rotateEndpoint switches to the next endpoint (thread-safe)
 // waitForActive: if true, waits briefly for active requests to complete before switching
 func (p *Proxy) rotateEndpoint() config.Endpoint {
@@ -652,34 +837,61 @@ func detectClientFormat(path string) ClientFormat {
 	}
 }
 
-// extractClientAndFormat parses the request path to determine client type and format
+// extractClientAndFormat parses the request path to determine client type, format and endpoint group
 // New paths: /claude/..., /gemini/..., /codex/...
+// Group paths: /{client}/group/{groupName}/... (only endpoints of the group are used)
 // Legacy paths (backward compatible): /v1/messages, /v1/chat/completions, /v1/responses
-func extractClientAndFormat(path string) (ClientType, ClientFormat, string) {
+func extractClientAndFormat(path string) (ClientType, ClientFormat, string, string) {
 	// New routing: /{client}/...
 	if strings.HasPrefix(path, "/claude/") {
-		subPath := strings.TrimPrefix(path, "/claude")
-		return ClientTypeClaude, ClientFormatClaude, subPath
+		group, subPath := extractGroup(strings.TrimPrefix(path, "/claude"))
+		return ClientTypeClaude, ClientFormatClaude, subPath, group
 	}
 	if strings.HasPrefix(path, "/gemini/") {
-		subPath := strings.TrimPrefix(path, "/gemini")
-		return ClientTypeGemini, ClientFormatClaude, subPath // Gemini uses Claude format from client
+		group, subPath := extractGroup(strings.TrimPrefix(path, "/gemini"))
+		return ClientTypeGemini, ClientFormatClaude, subPath, group // Gemini uses Claude format from client
 	}
 	if strings.HasPrefix(path, "/codex/") {
-		subPath := strings.TrimPrefix(path, "/codex")
+		group, subPath := extractGroup(strings.TrimPrefix(path, "/codex"))
 		if strings.HasPrefix(subPath, "/v1/chat/completions") || strings.HasPrefix(subPath, "/chat/completions") {
-			return ClientTypeCodex, ClientFormatOpenAIChat, subPath
+			return ClientTypeCodex, ClientFormatOpenAIChat, subPath, group
 		}
 		if strings.HasPrefix(subPath, "/v1/responses") || strings.HasPrefix(subPath, "/responses") {
-			return ClientTypeCodex, ClientFormatOpenAIResponses, subPath
+			return ClientTypeCodex, ClientFormatOpenAIResponses, subPath, group
 		}
 		// Default to chat format for codex
-		return ClientTypeCodex, ClientFormatOpenAIChat, subPath
+		return ClientTypeCodex, ClientFormatOpenAIChat, subPath, group
 	}
 
 	// Legacy routing (backward compatible) - defaults to claude client type
 	format := detectClientFormat(path)
-	return ClientTypeClaude, format, path
+	return ClientTypeClaude, format, path, ""
+}
+
+// extractGroup splits "/group/{groupName}/rest" into the group name and "/rest"
+// 不以 /group/ 开头的路径原样返回，group 为空
+func extractGroup(subPath string) (string, string) {
+	if !strings.HasPrefix(subPath, "/group/") {
+		return "", subPath
+	}
+	group, rest, found := strings.Cut(strings.TrimPrefix(subPath, "/group/"), "/")
+	if !found {
+		return group, "/"
+	}
+	return group, "/" + rest
+}
+
+// writeRateLimitError writes a 429 response in Claude error format
+func writeRateLimitError(w http.ResponseWriter, waitTime time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprintf("%.0f", waitTime.Seconds()))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    "rate_limit_error",
+			"message": fmt.Sprintf("Rate limit exceeded. Please retry after %.0f seconds.", waitTime.Seconds()),
+		},
+	})
 }
 
 // getClientIP extracts the real client IP from the request
@@ -712,12 +924,57 @@ func getClientIP(r *http.Request) string {
 	return remoteAddr
 }
 
+// rejectedEndpointName is recorded as the endpoint of requests rejected before endpoint selection
+const rejectedEndpointName = "(rejected)"
+
+// rejectOversizedBody responds 413 and records the rejected request in request_stats
+func (p *Proxy) rejectOversizedBody(w http.ResponseWriter, clientType ClientType, clientIP string, maxBodyBytes int64, requestStartTime time.Time) {
+	errorMsg := fmt.Sprintf("request body too large: exceeds limit of %d bytes", maxBodyBytes)
+	logger.Warn("[%s] Rejected request from %s: %s", clientType, clientIP, errorMsg)
+
+	p.stats.RecordRequestStat(&RequestStatRecord{
+		EndpointName:  rejectedEndpointName,
+		ClientType:    string(clientType),
+		ClientIP:      clientIP,
+		Timestamp:     time.Now(),
+		Success:       false,
+		DurationMs:    time.Since(requestStartTime).Milliseconds(),
+		ErrorMessage:  errorMsg,
+		ErrorCategory: ErrorCategoryOther,
+		RequestBytes:  maxBodyBytes + 1,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    "request_too_large",
+			"message": fmt.Sprintf("Request body exceeds the maximum allowed size of %d bytes", maxBodyBytes),
+		},
+	})
+}
+
 // handleProxy handles the main proxy logic
 func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	// Capture request start time for duration tracking
 	requestStartTime := time.Now()
 
-	bodyBytes, err := io.ReadAll(r.Body)
+	// Extract client type and format from path
+	clientType, clientFormat, _, group := extractClientAndFormat(r.URL.Path)
+
+	// Extract client IP address
+	clientIP := getClientIP(r)
+
+	// 请求优先级，端点并发达到上限时高优先级请求先获得名额
+	priority := requestPriority(r)
+
+	// 限制请求体大小，避免超大 body 占满内存（0 表示不限制）
+	maxBodyBytes := p.config.GetMaxRequestBodyBytes()
+	var bodyReader io.Reader = r.Body
+	if maxBodyBytes > 0 {
+		bodyReader = io.LimitReader(r.Body, maxBodyBytes+1)
+	}
+	bodyBytes, err := io.ReadAll(bodyReader)
 	if err != nil {
 		logger.Error("Failed to read request body: %v", err)
 		http.Error(w, "Failed to read request body", http.StatusBadRequest)
@@ -725,16 +982,15 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	// Extract client type and format from path
-	clientType, clientFormat, _ := extractClientAndFormat(r.URL.Path)
-
-	// Extract client IP address
-	clientIP := getClientIP(r)
+	if maxBodyBytes > 0 && int64(len(bodyBytes)) > maxBodyBytes {
+		p.rejectOversizedBody(w, clientType, clientIP, maxBodyBytes, requestStartTime)
+		return
+	}
 
 	// 提取会话ID（用于会话亲和性）
 	var sessionID string
 	if p.sessionAffinity != nil {
-		sessionID = p.sessionAffinity.ExtractSessionID(r)
+		sessionID = p.sessionAffinity.ExtractSessionID(r, bodyBytes)
 		logger.Debug("[REQUEST] Session ID: %s", sessionID)
 	}
 
@@ -764,9 +1020,10 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var streamReq struct {
-		Model    string      `json:"model"`
-		Thinking interface{} `json:"thinking"`
-		Stream   bool        `json:"stream"`
+		Model       string      `json:"model"`
+		Thinking    interface{} `json:"thinking"`
+		Stream      bool        `json:"stream"`
+		Temperature *float64    `json:"temperature"`
 	}
 	json.Unmarshal(bodyBytes, &streamReq)
 
@@ -775,17 +1032,77 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		interactionRecord.Request.Model = streamReq.Model
 	}
 
-	// 缓存检查（仅对非流式请求启用缓存）
-	// 流式请求不缓存，因为需要实时返回数据
-	if !streamReq.Stream && p.cache.IsEnabled() {
+	// 幂等键检查：相同 key 的重试直接回放首次结果，不再请求上游
+	// 仅非流式的成功响应会被保存；流式请求只防止并发重复，结束后即释放 key
+	var idempotencyKey string
+	if key := idempotency.ExtractKey(r); key != "" && p.idempotency.IsEnabled() {
+		idempotencyKey = string(clientType) + ":" + key
+		entry, state := p.idempotency.Begin(idempotencyKey, idempotency.HashBody(bodyBytes))
+		switch state {
+		case idempotency.StateReplay:
+			logger.Debug("[IDEMPOTENCY] Replaying stored response for key: %s", key)
+			contentType := entry.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("X-CCNexus-Idempotent-Replay", "true")
+			writeResponseBody(w, r, entry.StatusCode, entry.Response)
+			return
+		case idempotency.StateInFlight, idempotency.StateMismatch:
+			status := http.StatusConflict
+			message := "A request with the same idempotency key is still in progress"
+			if state == idempotency.StateMismatch {
+				status = http.StatusUnprocessableEntity
+				message = "Idempotency key was already used with a different request body"
+			}
+			logger.Warn("[IDEMPOTENCY] Rejected request for key %s: %s", key, message)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{
+					"type":    "idempotency_error",
+					"message": message,
+				},
+			})
+			return
+		}
+		// 未保存结果时释放 key（Complete 之后 Release 为空操作）
+		defer p.idempotency.Release(idempotencyKey)
+	}
+
+	// 请求去重：窗口内请求体相同的重复提交直接回放首次响应，首次仍在处理时等待其完成
+	// 指定端点的测试请求不参与去重
+	var dedupKeyStr string
+	if p.dedup.IsEnabled() && r.Header.Get("X-CCNexus-Endpoint") == "" {
+		key := dedupKey(clientType, clientIP, r.URL.Path, bodyBytes)
+		entry, owner := p.dedup.Acquire(r.Context(), key)
+		if entry != nil {
+			logger.Debug("[DEDUP] Replaying response for duplicate request: %s", key[:16])
+			replayDedupResponse(w, r, entry)
+			return
+		}
+		if owner {
+			dedupKeyStr = key
+			defer p.dedup.Release(dedupKeyStr)
+		}
+	}
+
+	// 缓存检查：非流式请求总是参与缓存；流式请求仅在输出确定（temperature=0）或客户端显式允许时缓存，
+	// 命中时按原事件序列逐个 flush 回放
+	cacheable := p.cache.IsEnabled() && (!streamReq.Stream || isStreamCacheable(r, streamReq.Temperature))
+	if cacheable {
 		cacheKey := cache.GenerateKey(bodyBytes)
 		if entry, found := p.cache.Get(cacheKey); found {
 			logger.Debug("[CACHE] Serving cached response for key: %s", cacheKey[:16])
+			w.Header().Set("X-CCNexus-Cache", "HIT")
+			if streamReq.Stream {
+				replayStreamEvents(w, entry.Events)
+				return
+			}
 			// 返回缓存的响应
 			w.Header().Set("Content-Type", "application/json")
-			w.Header().Set("X-CCNexus-Cache", "HIT")
-			w.WriteHeader(http.StatusOK)
-			w.Write(entry.Response)
+			writeResponseBody(w, r, http.StatusOK, entry.Response)
 			return
 		}
 	}
@@ -794,21 +1111,20 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	// 测试请求不受速率限制
 	specifiedEndpoint := r.Header.Get("X-CCNexus-Endpoint")
 	if specifiedEndpoint == "" && p.rateLimiter.IsEnabled() {
+		// 按客户端 IP 限流，避免单个使用者耗尽全局配额
+		if allowed, waitTime := p.rateLimiter.AllowKey(clientIP, p.config.GetRateLimit().PerClientIPLimit); !allowed {
+			logger.Warn("[RATELIMIT] Request from %s rejected, wait: %v", clientIP, waitTime)
+			writeRateLimitError(w, waitTime)
+			return
+		}
+
 		// 先获取当前端点名称用于检查
 		currentEndpoint := p.getCurrentEndpointForClient(clientType)
 		if currentEndpoint.Name != "" {
 			allowed, waitTime := p.rateLimiter.Allow(currentEndpoint.Name)
 			if !allowed {
 				logger.Warn("[RATELIMIT] Request rejected, wait: %v", waitTime)
-				w.Header().Set("Content-Type", "application/json")
-				w.Header().Set("Retry-After", fmt.Sprintf("%.0f", waitTime.Seconds()))
-				w.WriteHeader(http.StatusTooManyRequests)
-				json.NewEncoder(w).Encode(map[string]interface{}{
-					"error": map[string]interface{}{
-						"type":    "rate_limit_error",
-						"message": fmt.Sprintf("Rate limit exceeded. Please retry after %.0f seconds.", waitTime.Seconds()),
-					},
-				})
+				writeRateLimitError(w, waitTime)
 				return
 			}
 		}
@@ -834,8 +1150,20 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	endpoints := p.getEnabledEndpointsForClient(clientType)
+	// 按分组路由：请求的分组不存在时返回 404
+	if group != "" && fixedEndpoint == nil && !p.groupExists(clientType, group) {
+		logger.Warn("[%s] Endpoint group not found: %s", clientType, group)
+		http.Error(w, fmt.Sprintf("Endpoint group '%s' not found for client type: %s", group, clientType), http.StatusNotFound)
+		return
+	}
+
+	endpoints := p.getEndpointsForClientAndGroup(clientType, group)
 	// Only check for enabled endpoints if this is NOT a test request
+	if fixedEndpoint == nil && group != "" && len(endpoints) == 0 {
+		logger.Error("No enabled endpoints in group %s for client type: %s", group, clientType)
+		http.Error(w, fmt.Sprintf("No enabled endpoints in group '%s' for client type: %s", group, clientType), http.StatusServiceUnavailable)
+		return
+	}
 	if fixedEndpoint == nil && len(endpoints) == 0 {
 		logger.Error("No enabled endpoints available for client type: %s", clientType)
 		http.Error(w, fmt.Sprintf("No enabled endpoints configured for client type: %s", clientType), http.StatusServiceUnavailable)
@@ -846,6 +1174,10 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	endpointAttempts := 0
 	lastEndpointName := ""
 
+	// 跨 client type 兜底端点（仅在本 client type 全部失败后加载）
+	var fallbackEndpoints []config.Endpoint
+	fallbackStart := -1
+
 	if fixedEndpoint != nil {
 		// For test requests, use reduced retry count
 		maxRetries = 3
@@ -857,14 +1189,57 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var lastError string // Track the last error message for better error reporting
+	// 最后一次可重试的上游 HTTP 错误响应；之后若出现非 HTTP 错误（如网络错误）则清空
+	var lastUpstreamErr *upstreamError
+
+	// 测试请求不计入重试预算
+	if fixedEndpoint == nil {
+		p.retryBudget.RecordRequest()
+	}
+
+	// 当前尝试的上游 context 释放函数：每次尝试结束（进入下一次尝试或 handler 返回）时调用
+	stopUpstream := func() {}
+	defer func() { stopUpstream() }()
+
+	for retry := 0; ; retry++ {
+		// 结束上一次尝试的上游 context
+		stopUpstream()
+
+		if retry >= maxRetries {
+			// 本 client type 的端点全部失败，尝试跨 client type 兜底（测试请求和按分组路由的请求不参与）
+			if fixedEndpoint != nil || group != "" || fallbackStart >= 0 {
+				break
+			}
+			fallbackEndpoints = p.getCrossClientFallbackEndpoints(clientType, clientFormat)
+			if len(fallbackEndpoints) == 0 {
+				break
+			}
+			logger.Warn("[FALLBACK:%s] All endpoints failed, trying %d endpoint(s) of other client types", clientType, len(fallbackEndpoints))
+			fallbackStart = retry
+			maxRetries += len(fallbackEndpoints)
+		}
+
+		// 全局重试预算耗尽时不再重试，避免上游故障时重试放大
+		if retry > 0 && fixedEndpoint == nil && !p.retryBudget.AllowRetry() {
+			logger.Warn("[%s] Retry budget exhausted, giving up after %d attempt(s)", clientType, retry)
+			if lastError != "" {
+				lastError = fmt.Sprintf("Retry budget exhausted, last error: %s", lastError)
+			} else {
+				lastError = "Retry budget exhausted"
+			}
+			break
+		}
 
-	for retry := 0; retry < maxRetries; retry++ {
 		var endpoint config.Endpoint
 		if fixedEndpoint != nil {
 			endpoint = *fixedEndpoint
+		} else if fallbackStart >= 0 {
+			// 兜底端点每个只尝试一次
+			endpoint = fallbackEndpoints[retry-fallbackStart]
+			logger.WarnKey(endpoint.Name, "[FALLBACK:%s] Trying %s (client: %s)", clientType, endpoint.Name, endpointClientType(endpoint))
 		} else {
 			// 使用智能路由选择端点（如果启用），传递会话ID
-			endpoint = p.selectEndpointForRequest(clientType, streamReq.Model, sessionID)
+			endpoint = p.selectEndpointForRequest(clientType, group, streamReq.Model, sessionID)
 		}
 		if endpoint.Name == "" {
 			http.Error(w, fmt.Sprintf("No enabled endpoints available for client type: %s", clientType), http.StatusServiceUnavailable)
@@ -877,28 +1252,59 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		}
 		lastEndpointName = endpoint.Name
 
+		// 端点所属的 client type（跨 client type 兜底时与请求的 client type 不同），统计、状态和端点轮换按端点归属进行
+		epClientType := endpointClientType(endpoint)
+
+		// OAuth access token 临近过期时先刷新；配置了多把 key 的端点从 key 池中轮询选一把
+		endpoint = p.tokenRefresher.EnsureFresh(endpoint)
+		endpoint.APIKey = p.keyPool.Select(endpoint)
+
+		// 端点并发达到上限时排队等待，超时后换下一个端点
+		if !p.acquireRequestSlot(r.Context(), endpoint.Name, endpoint.MaxConcurrency, priority) {
+			if r.Context().Err() != nil {
+				logger.WarnKey(endpoint.Name, "[%s:%s] Client disconnected while waiting for a concurrency slot", clientType, endpoint.Name)
+				return
+			}
+			lastError = fmt.Sprintf("[%s] Concurrency limit (%d) reached, no slot freed within %v", endpoint.Name, endpoint.MaxConcurrency, concurrencyWaitTimeout)
+			lastUpstreamErr = nil
+			logger.WarnKey(endpoint.Name, "[%s:%s] Concurrency limit %d reached, switching endpoint", clientType, endpoint.Name, endpoint.MaxConcurrency)
+			if fixedEndpoint == nil && fallbackStart < 0 {
+				p.rotateEndpointForClient(clientType)
+			}
+			endpointAttempts = 0
+			continue
+		}
+
 		endpointAttempts++
-		p.markRequestActive(endpoint.Name)
-		p.stats.RecordRequest(endpoint.Name, string(clientType))
+		p.circuitBreaker.Acquire(string(epClientType), endpoint.Name)
+		p.stats.RecordRequest(endpoint.Name, string(epClientType))
 
 		// Start monitoring this request attempt
 		monitorReqID := generateMonitorRequestID()
 		messagePreview := ExtractMessagePreview(bodyBytes, 300)
-		p.monitor.StartRequest(monitorReqID, endpoint.Name, string(clientType), streamReq.Model, messagePreview)
+		p.monitor.StartRequest(monitorReqID, endpoint.Name, string(epClientType), streamReq.Model, messagePreview)
+
+		// 链路追踪 ID：转发给上游，并写回客户端响应头
+		requestID := resolveRequestID(r, monitorReqID)
+		w.Header().Set(requestIDHeader, requestID)
 
 		// Log request attempt with test indication if applicable
 		if fixedEndpoint != nil {
-			logger.Debug("[TEST:%s][%s] Testing endpoint (attempt %d/%d)", clientType, endpoint.Name, endpointAttempts, maxRetries)
+			logger.DebugKey(endpoint.Name, "[TEST:%s][%s] Testing endpoint (attempt %d/%d)", clientType, endpoint.Name, endpointAttempts, maxRetries)
 		}
 
-		trans, err := prepareTransformerForClient(clientFormat, endpoint)
+		// 请求模型在端点的多模型配置中时，按请求模型转发
+		trans, err := prepareTransformerForClient(clientFormat, endpoint.ForModel(streamReq.Model))
 		if err != nil {
 			lastError = fmt.Sprintf("[%s] %v", endpoint.Name, err)
-			logger.Error("[%s:%s] %v", clientType, endpoint.Name, err)
-			p.stats.RecordError(endpoint.Name, string(clientType))
+			lastUpstreamErr = nil
+			logger.ErrorKey(endpoint.Name, "[%s:%s] %v", clientType, endpoint.Name, err)
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, "", requestStartTime, 0, fixedEndpoint != nil, err.Error(), ErrorCategoryOther)
+			p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 			p.monitor.CompleteRequest(monitorReqID, false, err.Error())
 			p.markRequestInactive(endpoint.Name)
-			if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+			if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 				endpointAttempts = 0
 			}
 			continue
@@ -906,14 +1312,18 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 
 		transformerName := trans.Name()
 
-		transformedBody, err := trans.TransformRequest(bodyBytes)
+		// 先按端点的模型名重写规则替换请求体中的 model，再交给 transformer 转换
+		transformedBody, err := trans.TransformRequest(rewriteRequestModel(bodyBytes, endpoint))
 		if err != nil {
 			lastError = fmt.Sprintf("[%s] Failed to transform request: %v", endpoint.Name, err)
-			logger.Error("[%s:%s] Failed to transform request: %v", clientType, endpoint.Name, err)
-			p.stats.RecordError(endpoint.Name, string(clientType))
+			lastUpstreamErr = nil
+			logger.ErrorKey(endpoint.Name, "[%s:%s] Failed to transform request: %v", clientType, endpoint.Name, err)
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, "", requestStartTime, 0, fixedEndpoint != nil, lastError, ErrorCategoryOther)
+			p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 			p.monitor.CompleteRequest(monitorReqID, false, err.Error())
 			p.markRequestInactive(endpoint.Name)
-			if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+			if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 				endpointAttempts = 0
 			}
 			continue
@@ -933,9 +1343,15 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		// 记录实际发往上游的模型，多端点时同一请求模型可能映射到不同的实际模型
+		upstreamModel := extractUpstreamModel(transformedBody, endpoint.ForModel(streamReq.Model))
+		if upstreamModel != streamReq.Model {
+			logger.DebugKey(endpoint.Name, "[%s:%s] Model mapped: %s -> %s (attempt %d)", clientType, endpoint.Name, streamReq.Model, upstreamModel, retry+1)
+		}
+
 		cleanedBody, err := cleanIncompleteToolCalls(transformedBody)
 		if err != nil {
-			logger.Warn("[%s] Failed to clean tool calls: %v", endpoint.Name, err)
+			logger.WarnKey(endpoint.Name, "[%s] Failed to clean tool calls: %v", endpoint.Name, err)
 			cleanedBody = transformedBody
 		}
 		transformedBody = cleanedBody
@@ -950,14 +1366,23 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		proxyReq, err := buildProxyRequest(r, endpoint, transformedBody, transformerName)
+		// 测试请求不自动续写
+		var continuer *autoContinuer
+		if fixedEndpoint == nil {
+			continuer = p.newAutoContinuer(r, clientFormat, endpoint, trans, transformerName, bodyBytes, requestID, priority)
+		}
+
+		proxyReq, err := buildProxyRequest(r, endpoint, transformedBody, transformerName, requestID, streamReq.Stream)
 		if err != nil {
 			lastError = fmt.Sprintf("[%s] Failed to create request: %v", endpoint.Name, err)
-			logger.Error("[%s:%s] Failed to create request: %v (URL: %s)", clientType, endpoint.Name, err, endpoint.APIUrl)
-			p.stats.RecordError(endpoint.Name, string(clientType))
+			lastUpstreamErr = nil
+			logger.ErrorKey(endpoint.Name, "[%s:%s] Failed to create request: %v (URL: %s)", clientType, endpoint.Name, err, endpoint.APIUrl)
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, lastError, ErrorCategoryOther)
+			p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 			p.monitor.CompleteRequest(monitorReqID, false, err.Error())
 			p.markRequestInactive(endpoint.Name)
-			if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+			if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 				endpointAttempts = 0
 			}
 			continue
@@ -966,20 +1391,57 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		// Update monitor phase to sending
 		p.monitor.UpdatePhase(monitorReqID, PhaseSending)
 
-		ctx := p.getEndpointContext(endpoint.Name)
-		resp, err := sendRequest(ctx, proxyReq, p.config)
+		// 客户端断开时主动中止上游请求
+		var ctx context.Context
+		ctx, stopUpstream = p.upstreamContext(r.Context(), endpoint.Name)
+		sentAt := time.Now()
+		resp, err := sendRequest(ctx, proxyReq, p.config, endpoint)
+		if err != nil && isClientGone(ctx) {
+			// 客户端已断开：不是端点的错误，不计入熔断也不再重试
+			logger.InfoKey(endpoint.Name, "[%s:%s] Client disconnected, upstream request aborted", clientType, endpoint.Name)
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, errClientGone.Error(), ErrorCategoryClientGone)
+			p.circuitBreaker.Release(string(epClientType), endpoint.Name)
+			p.monitor.CompleteRequest(monitorReqID, false, errClientGone.Error())
+			p.markRequestInactive(endpoint.Name)
+			return
+		}
 		if err != nil {
 			lastError = fmt.Sprintf("[%s] Request failed: %v", endpoint.Name, err)
-			logger.Error("[%s:%s] Request failed: %v (URL: %s, Model: %s)", clientType, endpoint.Name, err, endpoint.APIUrl, streamReq.Model)
-			p.stats.RecordError(endpoint.Name, string(clientType))
+			lastUpstreamErr = nil
+			logger.ErrorKey(endpoint.Name, "[%s:%s] Request failed: %v (URL: %s, Model: %s)", clientType, endpoint.Name, err, endpoint.APIUrl, streamReq.Model)
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, lastError, classifyError(err))
+			p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 			p.monitor.CompleteRequest(monitorReqID, false, err.Error())
 			p.markRequestInactive(endpoint.Name)
-			if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+			if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 				endpointAttempts = 0
 			}
 			continue
 		}
 
+		// 统计从上游实际读取的响应字节数（压缩响应按压缩后大小计）
+		respCounter := newCountingReadCloser(resp.Body)
+		resp.Body = respCounter
+
+		// 纠正上游声明与实际内容不符的 Content-Type（如 SSE 被标成 JSON）
+		if resp.StatusCode == http.StatusOK {
+			if err := correctResponseContentType(resp, streamReq.Stream, endpoint.Name, p.firstByteDeadline(sentAt)); err != nil {
+				lastError = fmt.Sprintf("[%s] Request failed: %v", endpoint.Name, err)
+				lastUpstreamErr = nil
+				p.stats.RecordError(endpoint.Name, string(epClientType))
+				p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, lastError, classifyError(err))
+				p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
+				p.monitor.CompleteRequest(monitorReqID, false, err.Error())
+				p.markRequestInactive(endpoint.Name)
+				if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
+					endpointAttempts = 0
+				}
+				continue
+			}
+		}
+
 		contentType := resp.Header.Get("Content-Type")
 		isStreaming := contentType == "text/event-stream" || (streamReq.Stream && strings.Contains(contentType, "text/event-stream"))
 
@@ -987,16 +1449,42 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			// Update monitor phase to streaming
 			p.monitor.UpdatePhase(monitorReqID, PhaseStreaming)
 
-			usage, outputText, rawEvents, transformedEvents, streamErr := p.handleStreamingResponse(w, resp, endpoint, trans, transformerName, thinkingEnabled, streamReq.Model, bodyBytes, clientType)
+			// 启用自动续写时由 continuationWriter 暂存因 max_tokens 截断的结尾事件
+			var streamWriter http.ResponseWriter = w
+			var streamRec *streamRecorder
+			if dedupKeyStr != "" || cacheable {
+				streamRec = newStreamRecorder(w)
+				streamWriter = streamRec
+			}
+			var cw *continuationWriter
+			if continuer != nil {
+				cw = newContinuationWriter(streamWriter)
+				streamWriter = cw
+			}
+
+			usage, outputText, rawEvents, transformedEvents, streamErr := p.handleStreamingResponse(streamWriter, resp, endpoint, trans, transformerName, thinkingEnabled, streamReq.Model, bodyBytes, clientType, sentAt)
+
+			// 响应头发出前客户端已断开：不计入端点失败，也不再重试
+			if errors.Is(streamErr, ErrStreamRetryable) && isClientGone(ctx) {
+				logger.InfoKey(endpoint.Name, "[%s:%s] Client disconnected, upstream stream aborted", clientType, endpoint.Name)
+				p.stats.RecordError(endpoint.Name, string(epClientType))
+				p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, errClientGone.Error(), ErrorCategoryClientGone)
+				p.circuitBreaker.Release(string(epClientType), endpoint.Name)
+				p.monitor.CompleteRequest(monitorReqID, false, errClientGone.Error())
+				p.markRequestInactive(endpoint.Name)
+				return
+			}
 
 			// Handle retryable streaming errors (before response headers sent)
 			if errors.Is(streamErr, ErrStreamRetryable) {
-				logger.Warn("[%s:%s] Streaming failed before response sent, will retry: %v", clientType, endpoint.Name, streamErr)
-				p.stats.RecordError(endpoint.Name, string(clientType))
+				logger.WarnKey(endpoint.Name, "[%s:%s] Streaming failed before response sent, will retry: %v", clientType, endpoint.Name, streamErr)
+				p.stats.RecordError(endpoint.Name, string(epClientType))
+				p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, streamErr.Error(), classifyError(streamErr))
+				p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 				p.monitor.CompleteRequest(monitorReqID, false, streamErr.Error())
 				p.markRequestInactive(endpoint.Name)
 				// endpointAttempts already incremented at loop start (line 487)
-				if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+				if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 					endpointAttempts = 0
 				}
 				continue // Retry with same or different endpoint
@@ -1012,24 +1500,44 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 
+			// 上游流已结束，归还本次请求的并发名额；续传/续写请求各自占用名额并单独记录统计
+			p.markRequestInactive(endpoint.Name)
+
+			// 上游流中途断开时换其他端点续传，客户端收到拼接后的完整响应；中断仍记为本端点的失败
+			var resumedOn string
+			if cw != nil && streamErr != nil && r.Context().Err() == nil {
+				resumedOn = continuer.resumeStream(cw, usage.OutputTokens, clientType, group, thinkingEnabled, streamReq.Model)
+			}
+
+			// 响应因 max_tokens 截断时自动续写
+			if cw != nil && (streamErr == nil || resumedOn != "") {
+				continuer.continueStream(cw, clientType, thinkingEnabled, streamReq.Model)
+			}
+
 			// Record daily aggregated stats
-			p.stats.RecordTokens(endpoint.Name, string(clientType), usage)
+			p.stats.RecordTokens(endpoint.Name, string(epClientType), usage)
+			p.tokenRate.Record(endpoint.Name, usage.TotalInputTokens()+usage.OutputTokens)
 
 			// Handle non-retryable streaming errors (after response headers sent)
 			if streamErr != nil {
-				logger.Warn("[%s] 流式传输异常结束: %v", endpoint.Name, streamErr)
-				p.stats.RecordError(endpoint.Name, string(clientType))
+				logger.WarnKey(endpoint.Name, "[%s] 流式传输异常结束: %v", endpoint.Name, streamErr)
+				p.stats.RecordError(endpoint.Name, string(epClientType))
+				p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 				durationMs := time.Since(requestStartTime).Milliseconds()
 
 				// Limit error message to 500 characters
 				errorMsg := streamErr.Error()
+				if resumedOn != "" {
+					logger.InfoKey(endpoint.Name, "[%s] Interrupted stream resumed on %s", endpoint.Name, resumedOn)
+					errorMsg = fmt.Sprintf("%s (resumed on %s)", errorMsg, resumedOn)
+				}
 				if len(errorMsg) > 500 {
 					errorMsg = errorMsg[:500]
 				}
 
 				p.stats.RecordRequestStat(&RequestStatRecord{
 					EndpointName:        endpoint.Name,
-					ClientType:          string(clientType),
+					ClientType:          string(epClientType),
 					ClientIP:            clientIP,
 					Timestamp:           time.Now(),
 					InputTokens:         usage.InputTokens,
@@ -1037,10 +1545,15 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 					CacheReadTokens:     usage.CacheReadInputTokens,
 					OutputTokens:        usage.OutputTokens,
 					Model:               streamReq.Model,
+					UpstreamModel:       upstreamModel,
 					IsStreaming:         true,
 					Success:             false,
 					DurationMs:          durationMs,
 					ErrorMessage:        errorMsg,
+					ErrorCategory:       classifyError(streamErr),
+					RequestBytes:        int64(len(transformedBody)),
+					ResponseBytes:       respCounter.Count(),
+					IsTest:              fixedEndpoint != nil,
 				})
 
 				// Save interaction record (with error)
@@ -1066,16 +1579,27 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				}
 
 				p.monitor.CompleteRequest(monitorReqID, false, streamErr.Error())
-				p.markRequestInactive(endpoint.Name)
 				// Cannot retry: HTTP headers already sent to client
 				return
 			}
 
+			// 保存完整的流供窗口内的重复请求回放，可缓存的请求同时按事件写入响应缓存
+			if streamRec != nil {
+				if recorded, ok := streamRec.Recorded(); ok {
+					if dedupKeyStr != "" {
+						p.dedup.Complete(dedupKeyStr, recorded, true)
+					}
+					if cacheable {
+						p.cache.SetStream(cache.GenerateKey(bodyBytes), splitSSEEvents(recorded))
+					}
+				}
+			}
+
 			// Record request-level stats
 			durationMs := time.Since(requestStartTime).Milliseconds()
 			p.stats.RecordRequestStat(&RequestStatRecord{
 				EndpointName:        endpoint.Name,
-				ClientType:          string(clientType),
+				ClientType:          string(epClientType),
 				ClientIP:            clientIP,
 				Timestamp:           time.Now(),
 				InputTokens:         usage.InputTokens,
@@ -1083,9 +1607,13 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				CacheReadTokens:     usage.CacheReadInputTokens,
 				OutputTokens:        usage.OutputTokens,
 				Model:               streamReq.Model,
+				UpstreamModel:       upstreamModel,
 				IsStreaming:         true,
 				Success:             true,
 				DurationMs:          durationMs,
+				RequestBytes:        int64(len(transformedBody)),
+				ResponseBytes:       respCounter.Count(),
+				IsTest:              fixedEndpoint != nil,
 			})
 
 			// Save interaction record (success)
@@ -1110,39 +1638,51 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			}
 
 			p.monitor.CompleteRequest(monitorReqID, true, "")
-			p.markRequestInactive(endpoint.Name)
 			// 记录配额使用量（智能路由）
-			p.recordQuotaUsage(endpoint.Name, string(clientType), usage)
+			p.recordQuotaUsage(endpoint, string(epClientType), streamReq.Model, usage)
 
-			// 实际请求成功时，将端点状态设置为可用
-			if endpoint.Status != config.EndpointStatusAvailable {
-				p.config.SetEndpointStatus(endpoint.Name, string(clientType), config.EndpointStatusAvailable)
-				logger.Info("Endpoint %s (client: %s) is now AVAILABLE (via successful request)", endpoint.Name, clientType)
+			// 实际请求成功时，将端点状态设置为可用（维护状态只能由用户手动退出，降级状态由健康检查按延迟判定）
+			if endpoint.Status != config.EndpointStatusAvailable && endpoint.Status != config.EndpointStatusMaintenance && endpoint.Status != config.EndpointStatusDegraded {
+				if changed, _ := p.config.SetEndpointStatusAuto(endpoint.Name, string(epClientType), config.EndpointStatusAvailable); changed {
+					logger.Info("Endpoint %s (client: %s) is now AVAILABLE (via successful request)", endpoint.Name, epClientType)
+				}
 			}
 
+			p.circuitBreaker.RecordSuccess(string(epClientType), endpoint.Name)
 			if p.onEndpointSuccess != nil {
-				p.onEndpointSuccess(endpoint.Name, string(clientType))
+				p.onEndpointSuccess(endpoint.Name, string(epClientType))
 			}
-			logger.Debug("[%s] Request completed successfully (streaming)", endpoint.Name)
+			logger.DebugKey(endpoint.Name, "[%s] Request completed successfully (streaming)", endpoint.Name)
 			return
 		}
 
 		if resp.StatusCode == http.StatusOK {
-			usage, rawResp, transformedResp, respBytes, err := p.handleNonStreamingResponse(w, resp, endpoint, trans)
+			usage, rawResp, transformedResp, respBytes, err := p.handleNonStreamingResponse(w, r, resp, endpoint, trans, continuer)
 			if err == nil {
 				// 缓存成功的非流式响应
-				if !streamReq.Stream && p.cache.IsEnabled() {
+				if cacheable && !streamReq.Stream {
 					cacheKey := cache.GenerateKey(bodyBytes)
 					p.cache.Set(cacheKey, respBytes, nil, false)
 				}
 
+				// 保存幂等结果，供相同 key 的重试回放
+				if idempotencyKey != "" {
+					p.idempotency.Complete(idempotencyKey, resp.StatusCode, w.Header().Get("Content-Type"), respBytes)
+				}
+
+				// 保存响应供窗口内的重复请求回放
+				if dedupKeyStr != "" {
+					p.dedup.Complete(dedupKeyStr, respBytes, false)
+				}
+
 				// Fallback: estimate tokens when usage is 0
 				if usage.TotalInputTokens() == 0 {
 					usage.InputTokens = p.estimateInputTokens(bodyBytes)
 				}
 
 				// Record daily aggregated stats
-				p.stats.RecordTokens(endpoint.Name, string(clientType), usage)
+				p.stats.RecordTokens(endpoint.Name, string(epClientType), usage)
+				p.tokenRate.Record(endpoint.Name, usage.TotalInputTokens()+usage.OutputTokens)
 
 				// Record request-level stats
 				// Extract model name from request body
@@ -1153,7 +1693,7 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				durationMs := time.Since(requestStartTime).Milliseconds()
 				p.stats.RecordRequestStat(&RequestStatRecord{
 					EndpointName:        endpoint.Name,
-					ClientType:          string(clientType),
+					ClientType:          string(epClientType),
 					ClientIP:            clientIP,
 					Timestamp:           time.Now(),
 					InputTokens:         usage.InputTokens,
@@ -1161,9 +1701,13 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 					CacheReadTokens:     usage.CacheReadInputTokens,
 					OutputTokens:        usage.OutputTokens,
 					Model:               modelName,
+					UpstreamModel:       upstreamModel,
 					IsStreaming:         false,
 					Success:             true,
 					DurationMs:          durationMs,
+					RequestBytes:        int64(len(transformedBody)),
+					ResponseBytes:       respCounter.Count(),
+					IsTest:              fixedEndpoint != nil,
 				})
 
 				// Save interaction record (success)
@@ -1188,25 +1732,28 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				}
 
 				p.monitor.CompleteRequest(monitorReqID, true, "")
-				p.markRequestInactive(endpoint.Name)
 				// 记录配额使用量（智能路由）
-				p.recordQuotaUsage(endpoint.Name, string(clientType), usage)
+				p.recordQuotaUsage(endpoint, string(epClientType), streamReq.Model, usage)
 
-				// 实际请求成功时，将端点状态设置为可用
-				if endpoint.Status != config.EndpointStatusAvailable {
-					p.config.SetEndpointStatus(endpoint.Name, string(clientType), config.EndpointStatusAvailable)
-					logger.Info("Endpoint %s (client: %s) is now AVAILABLE (via successful request)", endpoint.Name, clientType)
+				// 实际请求成功时，将端点状态设置为可用（维护状态只能由用户手动退出，降级状态由健康检查按延迟判定）
+				if endpoint.Status != config.EndpointStatusAvailable && endpoint.Status != config.EndpointStatusMaintenance && endpoint.Status != config.EndpointStatusDegraded {
+					if changed, _ := p.config.SetEndpointStatusAuto(endpoint.Name, string(epClientType), config.EndpointStatusAvailable); changed {
+						logger.Info("Endpoint %s (client: %s) is now AVAILABLE (via successful request)", endpoint.Name, epClientType)
+					}
 				}
 
+				p.circuitBreaker.RecordSuccess(string(epClientType), endpoint.Name)
 				if p.onEndpointSuccess != nil {
-					p.onEndpointSuccess(endpoint.Name, string(clientType))
+					p.onEndpointSuccess(endpoint.Name, string(epClientType))
 				}
-				logger.Debug("[%s] Request completed successfully", endpoint.Name)
+				logger.DebugKey(endpoint.Name, "[%s] Request completed successfully", endpoint.Name)
 				return
 			}
 		}
 
-		if shouldRetry(resp.StatusCode) {
+		// 多 key 端点的 key 返回 401/429 时暂停该 key，重试时换下一把（401 此时也可重试）
+		keyRejected := p.keyPool.MarkUnavailable(endpoint, endpoint.APIKey, resp)
+		if shouldRetry(resp.StatusCode) || keyRejected {
 			var errBody []byte
 			if resp.Header.Get("Content-Encoding") == "gzip" {
 				errBody, _ = decompressGzip(resp.Body)
@@ -1221,12 +1768,20 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				errMsg = errMsg[:200] + "..."
 			}
 			lastError = fmt.Sprintf("[%s] HTTP %d: %s", endpoint.Name, resp.StatusCode, errMsg)
-			logger.Warn("[%s:%s] Request failed %d: %s (URL: %s, Model: %s)", clientType, endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
+			lastUpstreamErr = &upstreamError{
+				endpoint:   endpoint.Name,
+				statusCode: resp.StatusCode,
+				header:     resp.Header.Clone(),
+				body:       errBody,
+			}
+			logger.WarnKey(endpoint.Name, "[%s:%s] Request failed %d: %s (URL: %s, Model: %s)", clientType, endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
 			logger.DebugLog("[%s:%s] Request failed %d: %s (URL: %s, Model: %s)", clientType, endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
-			p.stats.RecordError(endpoint.Name, string(clientType))
+			p.stats.RecordError(endpoint.Name, string(epClientType))
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, errMsg), classifyStatusCode(resp.StatusCode))
+			p.circuitBreaker.RecordFailure(string(epClientType), endpoint.Name)
 			p.monitor.CompleteRequest(monitorReqID, false, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, errMsg))
 			p.markRequestInactive(endpoint.Name)
-			if p.handleEndpointRotation(fixedEndpoint, clientType, endpoint, endpointAttempts) {
+			if p.handleEndpointRotation(fixedEndpoint, epClientType, endpoint, endpointAttempts) {
 				endpointAttempts = 0
 			}
 			continue
@@ -1246,6 +1801,8 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			p.monitor.CompleteRequest(monitorReqID, false, fmt.Sprintf("HTTP %d", resp.StatusCode))
 		}
 		p.markRequestInactive(endpoint.Name)
+		// 不可重试的响应（如 4xx）说明端点本身可达，对熔断器视为成功
+		p.circuitBreaker.RecordSuccess(string(epClientType), endpoint.Name)
 		// Log non-200 responses for debugging
 		if resp.StatusCode != http.StatusOK {
 			errMsg := string(respBody)
@@ -1254,8 +1811,9 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 			} else if len(errMsg) > 500 {
 				errMsg = errMsg[:500] + "..."
 			}
-			logger.Warn("[%s] Response %d: %s (URL: %s, Model: %s)", endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
+			logger.WarnKey(endpoint.Name, "[%s] Response %d: %s (URL: %s, Model: %s)", endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
 			logger.DebugLog("[%s] Response %d: %s (URL: %s, Model: %s)", endpoint.Name, resp.StatusCode, errMsg, endpoint.APIUrl, streamReq.Model)
+			p.recordAttemptError(endpoint, clientIP, streamReq.Model, upstreamModel, requestStartTime, int64(len(transformedBody)), fixedEndpoint != nil, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, errMsg), classifyStatusCode(resp.StatusCode))
 		}
 		// Remove Content-Encoding header since we've decompressed
 		for key, values := range resp.Header {
@@ -1266,8 +1824,11 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 				w.Header().Add(key, value)
 			}
 		}
-		w.WriteHeader(resp.StatusCode)
-		w.Write(respBody)
+		// 不可重试的错误直接透传，并标明来源端点
+		if resp.StatusCode != http.StatusOK {
+			w.Header().Set("X-CCNexus-Upstream-Endpoint", endpoint.Name)
+		}
+		writeResponseBody(w, r, resp.StatusCode, respBody)
 		return
 	}
 
@@ -1283,7 +1844,13 @@ func (p *Proxy) handleProxy(w http.ResponseWriter, r *http.Request) {
 		go p.interactionStorage.Save(interactionRecord)
 	}
 
-	// Return detailed error message
+	// 可重试错误全部耗尽：透传最后一个端点的原始状态码和错误体，便于客户端定位问题
+	if lastUpstreamErr != nil {
+		writeUpstreamError(w, lastUpstreamErr, true)
+		return
+	}
+
+	// 没有上游 HTTP 响应（网络错误、请求构造失败等）时返回 503
 	errorMsg := "All endpoints failed"
 	if lastError != "" {
 		errorMsg = lastError
@@ -1300,8 +1867,9 @@ func (p *Proxy) handleEndpointRotation(fixedEndpoint *config.Endpoint, clientTyp
 
 	// 请求失败时，将 untested 状态的端点标记为 unavailable
 	if endpoint.Status == config.EndpointStatusUntested {
-		p.config.SetEndpointStatus(endpoint.Name, string(clientType), config.EndpointStatusUnavailable)
-		logger.Info("Endpoint %s (client: %s) marked as UNAVAILABLE after failed attempt", endpoint.Name, clientType)
+		if changed, _ := p.config.SetEndpointStatusAuto(endpoint.Name, string(clientType), config.EndpointStatusUnavailable); changed {
+			logger.Info("Endpoint %s (client: %s) marked as UNAVAILABLE after failed attempt", endpoint.Name, clientType)
+		}
 	}
 
 	if fixedEndpoint == nil {
@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"math"
 	"sync"
 	"time"
 
@@ -22,10 +23,32 @@ type QuotaRecord struct {
 	PeriodStart  time.Time
 	PeriodEnd    time.Time
 	TokensUsed   int64
-	QuotaLimit   int64
+	CostUsed     float64 // 已累计成本（美元）
+	QuotaLimit   int64   // token 模式为 Token 数，cost 模式为美元
+	QuotaMode    string  // 配额模式：token / cost
 	LastUpdated  time.Time
 }
 
+// Used 返回按配额模式计算的已用量：cost 模式为美元，否则为 Token 数
+func (r *QuotaRecord) Used() float64 {
+	if r.QuotaMode == config.QuotaModeCost {
+		return r.CostUsed
+	}
+	return float64(r.TokensUsed)
+}
+
+// UsagePercent 返回已使用百分比（最高 100），无限配额时为 0
+func (r *QuotaRecord) UsagePercent() float64 {
+	if r.QuotaLimit <= 0 {
+		return 0
+	}
+	percent := r.Used() / float64(r.QuotaLimit) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
+
 // NewQuotaTracker 创建配额跟踪器
 func NewQuotaTracker(cfg *config.Config, store storage.Storage) *QuotaTracker {
 	qt := &QuotaTracker{
@@ -41,49 +64,79 @@ func NewQuotaTracker(cfg *config.Config, store storage.Storage) *QuotaTracker {
 func (q *QuotaTracker) loadExistingQuotas() {
 	endpoints := q.config.GetEndpoints()
 	for _, ep := range endpoints {
+		clientType := ep.ClientType
+		if clientType == "" {
+			clientType = "claude"
+		}
 		if ep.QuotaLimit > 0 {
-			clientType := ep.ClientType
-			if clientType == "" {
-				clientType = "claude"
-			}
-			quota, err := q.storage.GetEndpointQuota(ep.Name, clientType)
-			if err == nil && quota != nil {
-				key := clientType + ":" + ep.Name
-				q.cache.Store(key, &QuotaRecord{
-					EndpointName: quota.EndpointName,
-					ClientType:   quota.ClientType,
-					PeriodStart:  quota.PeriodStart,
-					PeriodEnd:    quota.PeriodEnd,
-					TokensUsed:   quota.TokensUsed,
-					QuotaLimit:   quota.QuotaLimit,
-					LastUpdated:  quota.LastUpdated,
-				})
+			q.loadQuotaIntoCache(ep.Name, clientType, ep.QuotaMode)
+		}
+		// 模型级配额（与端点使用相同的配额模式）
+		for _, m := range ep.Models {
+			if m.QuotaLimit > 0 {
+				q.loadQuotaIntoCache(config.ModelQuotaName(ep.Name, m.Name), clientType, ep.QuotaMode)
 			}
 		}
 	}
 }
 
-// RecordUsage 记录 Token 使用
-func (q *QuotaTracker) RecordUsage(endpointName, clientType string, tokens int64) {
-	if clientType == "" {
-		clientType = "claude"
+// loadQuotaIntoCache 从存储加载单条配额记录到内存
+func (q *QuotaTracker) loadQuotaIntoCache(name, clientType, quotaMode string) {
+	quota, err := q.storage.GetEndpointQuota(name, clientType)
+	if err == nil && quota != nil {
+		key := clientType + ":" + name
+		q.cache.Store(key, &QuotaRecord{
+			EndpointName: quota.EndpointName,
+			ClientType:   quota.ClientType,
+			PeriodStart:  quota.PeriodStart,
+			PeriodEnd:    quota.PeriodEnd,
+			TokensUsed:   quota.TokensUsed,
+			CostUsed:     quota.CostUsed,
+			QuotaLimit:   quota.QuotaLimit,
+			QuotaMode:    quotaMode,
+			LastUpdated:  quota.LastUpdated,
+		})
 	}
+}
 
-	// 获取端点配置
-	var endpoint *config.Endpoint
+// findQuotaEndpoint 查找配额对应的端点配置
+// name 为模型级配额名称（端点名#模型）时，返回以该模型配额替换 QuotaLimit 的端点副本
+func (q *QuotaTracker) findQuotaEndpoint(name, clientType string) *config.Endpoint {
+	endpointName, model := config.SplitModelQuotaName(name)
 	for _, ep := range q.config.GetEndpoints() {
-		if ep.Name == endpointName {
-			epClientType := ep.ClientType
-			if epClientType == "" {
-				epClientType = "claude"
-			}
-			if epClientType == clientType {
-				endpoint = &ep
-				break
+		if ep.Name != endpointName {
+			continue
+		}
+		epClientType := ep.ClientType
+		if epClientType == "" {
+			epClientType = "claude"
+		}
+		if epClientType != clientType {
+			continue
+		}
+		if model == "" {
+			return &ep
+		}
+		for _, m := range ep.Models {
+			if m.Name == model {
+				ep.QuotaLimit = m.QuotaLimit
+				return &ep
 			}
 		}
+		return nil
+	}
+	return nil
+}
+
+// RecordUsage 记录 Token 使用量和按端点单价换算的成本（美元），两者都会累计，按配额模式决定用哪个限额
+func (q *QuotaTracker) RecordUsage(endpointName, clientType string, tokens int64, cost float64) {
+	if clientType == "" {
+		clientType = "claude"
 	}
 
+	// 获取端点配置（模型级配额时为按模型配额调整后的副本）
+	endpoint := q.findQuotaEndpoint(endpointName, clientType)
+
 	// 没有配额限制的端点不需要跟踪
 	if endpoint == nil || endpoint.QuotaLimit == 0 {
 		return
@@ -106,8 +159,13 @@ func (q *QuotaTracker) RecordUsage(endpointName, clientType string, tokens int64
 		record = q.resetQuota(endpointName, clientType, endpoint)
 	}
 
+	// 配额模式和上限可能在周期中被修改，以当前端点配置为准
+	record.QuotaMode = endpoint.QuotaMode
+	record.QuotaLimit = endpoint.QuotaLimit
+
 	// 更新使用量
 	record.TokensUsed += tokens
+	record.CostUsed += cost
 	record.LastUpdated = now
 	q.cache.Store(key, record)
 
@@ -126,7 +184,9 @@ func (q *QuotaTracker) loadOrCreateQuota(endpointName, clientType string, endpoi
 			PeriodStart:  quota.PeriodStart,
 			PeriodEnd:    quota.PeriodEnd,
 			TokensUsed:   quota.TokensUsed,
+			CostUsed:     quota.CostUsed,
 			QuotaLimit:   quota.QuotaLimit,
+			QuotaMode:    endpoint.QuotaMode,
 			LastUpdated:  quota.LastUpdated,
 		}
 	}
@@ -147,6 +207,7 @@ func (q *QuotaTracker) createNewQuotaRecord(endpointName, clientType string, end
 		PeriodEnd:    periodEnd,
 		TokensUsed:   0,
 		QuotaLimit:   endpoint.QuotaLimit,
+		QuotaMode:    endpoint.QuotaMode,
 		LastUpdated:  now,
 	}
 }
@@ -163,6 +224,7 @@ func (q *QuotaTracker) resetQuota(endpointName, clientType string, endpoint *con
 		PeriodEnd:    periodEnd,
 		TokensUsed:   0,
 		QuotaLimit:   endpoint.QuotaLimit,
+		QuotaMode:    endpoint.QuotaMode,
 		LastUpdated:  now,
 	}
 
@@ -208,12 +270,22 @@ func (q *QuotaTracker) persistQuota(record *QuotaRecord) {
 		PeriodStart:  record.PeriodStart,
 		PeriodEnd:    record.PeriodEnd,
 		TokensUsed:   record.TokensUsed,
+		CostUsed:     record.CostUsed,
 		QuotaLimit:   record.QuotaLimit,
 		LastUpdated:  record.LastUpdated,
 	}
 	_ = q.storage.UpdateEndpointQuota(quota)
 }
 
+// IsModelExhausted 检查端点上请求模型对应的模型级配额是否用尽（未配置模型级配额时返回 false）
+func (q *QuotaTracker) IsModelExhausted(endpoint config.Endpoint, clientType, model string) bool {
+	m := endpoint.MatchModel(model)
+	if m == nil || m.QuotaLimit == 0 {
+		return false
+	}
+	return q.IsExhausted(config.ModelQuotaName(endpoint.Name, m.Name), clientType)
+}
+
 // IsExhausted 检查配额是否用尽
 func (q *QuotaTracker) IsExhausted(endpointName, clientType string) bool {
 	if clientType == "" {
@@ -238,10 +310,10 @@ func (q *QuotaTracker) IsExhausted(endpointName, clientType string) bool {
 		return false
 	}
 
-	return record.TokensUsed >= record.QuotaLimit
+	return record.Used() >= float64(record.QuotaLimit)
 }
 
-// GetRemainingQuota 获取剩余配额
+// GetRemainingQuota 获取剩余配额（cost 模式下为向下取整的美元数）
 func (q *QuotaTracker) GetRemainingQuota(endpointName, clientType string) (remaining int64, percentage float64) {
 	if clientType == "" {
 		clientType = "claude"
@@ -265,11 +337,12 @@ func (q *QuotaTracker) GetRemainingQuota(endpointName, clientType string) (remai
 		return record.QuotaLimit, 100.0 // 周期已过期，视为满配额
 	}
 
-	remaining = record.QuotaLimit - record.TokensUsed
-	if remaining < 0 {
-		remaining = 0
+	left := float64(record.QuotaLimit) - record.Used()
+	if left < 0 {
+		left = 0
 	}
-	percentage = float64(remaining) / float64(record.QuotaLimit) * 100
+	remaining = int64(math.Floor(left))
+	percentage = left / float64(record.QuotaLimit) * 100
 
 	return remaining, percentage
 }
@@ -313,20 +386,8 @@ func (q *QuotaTracker) ResetQuota(endpointName, clientType string) error {
 		clientType = "claude"
 	}
 
-	// 获取端点配置
-	var endpoint *config.Endpoint
-	for _, ep := range q.config.GetEndpoints() {
-		if ep.Name == endpointName {
-			epClientType := ep.ClientType
-			if epClientType == "" {
-				epClientType = "claude"
-			}
-			if epClientType == clientType {
-				endpoint = &ep
-				break
-			}
-		}
-	}
+	// 获取端点配置（模型级配额时为按模型配额调整后的副本）
+	endpoint := q.findQuotaEndpoint(endpointName, clientType)
 
 	if endpoint == nil {
 		return nil
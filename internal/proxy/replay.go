@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+)
+
+// ReplayResult 重放请求的结果
+type ReplayResult struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	DurationMs  int64
+}
+
+// Replay sends a recorded request body through the proxy pipeline to the named endpoint
+// 等同于带 X-CCNexus-Endpoint 头的测试请求：不受速率限制和去重影响，可指定已禁用的端点，
+// 统计中计为测试请求；流式请求返回完整的 SSE 文本
+func (p *Proxy) Replay(ctx context.Context, path string, body []byte, endpointName string) (*ReplayResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CCNexus-Endpoint", endpointName)
+	req.RemoteAddr = "127.0.0.1:0"
+
+	w := &replayResponseWriter{header: make(http.Header)}
+	start := time.Now()
+	p.handleProxy(w, req)
+
+	statusCode := w.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return &ReplayResult{
+		StatusCode:  statusCode,
+		ContentType: w.header.Get("Content-Type"),
+		Body:        w.body.Bytes(),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// replayResponseWriter 在内存中收集重放请求的响应
+type replayResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *replayResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *replayResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *replayResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+// Flush 实现 http.Flusher，流式响应直接写入缓冲区
+func (w *replayResponseWriter) Flush() {}
@@ -1,9 +1,16 @@
 package proxy
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/logger"
@@ -11,25 +18,29 @@ import (
 )
 
 // handleNonStreamingResponse processes non-streaming responses
-// Returns: usage, rawResponse, transformedResponse, transformedBytes, error
-func (p *Proxy) handleNonStreamingResponse(w http.ResponseWriter, resp *http.Response, endpoint config.Endpoint, trans transformer.Transformer) (transformer.TokenUsageDetail, interface{}, interface{}, []byte, error) {
+// continuer is optional; when set, responses truncated by max_tokens are continued and merged
+// On success the endpoint's concurrency slot has been released; on error the caller still holds it
+// Returns: usage (of this upstream response only), rawResponse, transformedResponse, transformedBytes, error
+func (p *Proxy) handleNonStreamingResponse(w http.ResponseWriter, r *http.Request, resp *http.Response, endpoint config.Endpoint, trans transformer.Transformer, continuer *autoContinuer) (transformer.TokenUsageDetail, interface{}, interface{}, []byte, error) {
+	// 所有返回路径（包括读取失败）都要释放上游连接
+	defer resp.Body.Close()
+
 	var bodyBytes []byte
 	var err error
 
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		bodyBytes, err = decompressGzip(resp.Body)
 		if err != nil {
-			logger.Error("[%s] Failed to decompress gzip response: %v", endpoint.Name, err)
+			logger.ErrorKey(endpoint.Name, "[%s] Failed to decompress gzip response: %v", endpoint.Name, err)
 			return transformer.TokenUsageDetail{}, nil, nil, nil, err
 		}
 	} else {
 		bodyBytes, err = io.ReadAll(resp.Body)
 		if err != nil {
-			logger.Error("[%s] Failed to read response body: %v", endpoint.Name, err)
+			logger.ErrorKey(endpoint.Name, "[%s] Failed to read response body: %v", endpoint.Name, err)
 			return transformer.TokenUsageDetail{}, nil, nil, nil, err
 		}
 	}
-	resp.Body.Close()
 
 	logger.DebugLog("[%s] Response Body: %s", endpoint.Name, string(bodyBytes))
 
@@ -40,19 +51,27 @@ func (p *Proxy) handleNonStreamingResponse(w http.ResponseWriter, resp *http.Res
 	// Transform response back to Claude format
 	transformedResp, err := trans.TransformResponse(bodyBytes, false)
 	if err != nil {
-		logger.Error("[%s] Failed to transform response: %v", endpoint.Name, err)
+		logger.ErrorKey(endpoint.Name, "[%s] Failed to transform response: %v", endpoint.Name, err)
 		return transformer.TokenUsageDetail{}, rawResponse, nil, nil, err
 	}
 
 	logger.DebugLog("[%s] Transformed Response: %s", endpoint.Name, string(transformedResp))
 
+	// 本次上游响应的 token 用量，续写请求的 token 由续写器按请求单独记录
+	usage := extractTokenUsage(transformedResp)
+
+	// 上游响应已处理完，归还本次请求的并发名额；续写请求各自占用名额
+	p.markRequestInactive(endpoint.Name)
+
+	// 响应因 max_tokens 截断时自动续写并拼接
+	if continuer != nil {
+		transformedResp = continuer.continueResponse(transformedResp)
+	}
+
 	// Parse transformed response as JSON for interaction recording
 	var transformedResponse interface{}
 	json.Unmarshal(transformedResp, &transformedResponse)
 
-	// Extract token usage
-	usage := extractTokenUsage(transformedResp)
-
 	// Copy response headers
 	for key, values := range resp.Header {
 		if key == "Content-Length" || key == "Content-Encoding" {
@@ -63,12 +82,123 @@ func (p *Proxy) handleNonStreamingResponse(w http.ResponseWriter, resp *http.Res
 		}
 	}
 
-	w.WriteHeader(resp.StatusCode)
-	w.Write(transformedResp)
+	writeResponseBody(w, r, resp.StatusCode, transformedResp)
 
 	return usage, rawResponse, transformedResponse, transformedResp, nil
 }
 
+// minGzipSize 小于该大小的响应体压缩收益有限，原样写回
+const minGzipSize = 1024
+
+// acceptsGzip reports whether the client's Accept-Encoding allows gzip (q=0 means refused)
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// writeResponseBody writes a complete non-streaming body to the client.
+// 上游的 gzip 响应已被解压，客户端声明接受 gzip 时重新压缩后写回，并设置 Content-Encoding；
+// 调用方需事先设置好其余响应头（不含 Content-Encoding/Content-Length）
+func writeResponseBody(w http.ResponseWriter, r *http.Request, statusCode int, body []byte) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if len(body) < minGzipSize || !acceptsGzip(r) {
+		w.WriteHeader(statusCode)
+		w.Write(body)
+		return
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil || gz.Close() != nil {
+		w.WriteHeader(statusCode)
+		w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(statusCode)
+	w.Write(buf.Bytes())
+}
+
+// correctResponseContentType fixes an upstream Content-Type that does not match the body,
+// e.g. an SSE stream labeled as application/json. The body is sniffed only when the declared
+// type disagrees with what the request asked for, and only for uncompressed bodies.
+// Sniffing waits for the first byte, so it is bounded by firstByteDeadline (zero means no limit);
+// errStreamFirstByteTimeout is returned when the deadline passes and the body has been closed.
+func correctResponseContentType(resp *http.Response, expectStream bool, endpointName string, firstByteDeadline time.Time) error {
+	if resp.Header.Get("Content-Encoding") != "" {
+		return nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	declaredSSE := strings.Contains(contentType, "text/event-stream")
+	if expectStream == declaredSSE {
+		return nil
+	}
+
+	// 首字节到达前心跳和流式首字节计时尚未启动，这里单独按首字节超时关闭上游连接
+	var timedOut atomic.Bool
+	var timer *time.Timer
+	if !firstByteDeadline.IsZero() {
+		timer = time.AfterFunc(time.Until(firstByteDeadline), func() {
+			timedOut.Store(true)
+			resp.Body.Close()
+		})
+	}
+
+	// Peek at the first chunk without consuming it (blocks until upstream sends data)
+	br := bufio.NewReader(resp.Body)
+	_, err := br.Peek(1)
+	if timer != nil {
+		timer.Stop()
+	}
+	if timedOut.Load() {
+		logger.WarnKey(endpointName, "[%s] 等待上游首字节超时，无法识别响应类型", endpointName)
+		return errStreamFirstByteTimeout
+	}
+	if err != nil {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{br, resp.Body}
+		return nil
+	}
+	head, _ := br.Peek(br.Buffered())
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{br, resp.Body}
+
+	var actual string
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("event:")), bytes.HasPrefix(trimmed, []byte("data:")), bytes.HasPrefix(trimmed, []byte(":")):
+		actual = "text/event-stream"
+	case bytes.HasPrefix(trimmed, []byte("{")), bytes.HasPrefix(trimmed, []byte("[")):
+		actual = "application/json"
+	default:
+		return nil
+	}
+
+	if (actual == "text/event-stream") != declaredSSE {
+		logger.WarnKey(endpointName, "[%s] Upstream Content-Type %q does not match body, correcting to %s", endpointName, contentType, actual)
+		resp.Header.Set("Content-Type", actual)
+	}
+	return nil
+}
+
 // extractTokenUsage extracts detailed token usage from response
 func extractTokenUsage(responseBody []byte) transformer.TokenUsageDetail {
 	var resp map[string]interface{}
@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// maxRetryBudgetWindowSeconds 重试预算统计窗口上限（秒），决定环形桶数量
+const maxRetryBudgetWindowSeconds = 600
+
+// retryBudgetBucket 一秒内的请求数和重试数
+type retryBudgetBucket struct {
+	second   int64
+	requests int
+	retries  int
+}
+
+// RetryBudgetStats 重试预算统计
+type RetryBudgetStats struct {
+	Enabled       bool    `json:"enabled"`
+	WindowSeconds int     `json:"windowSeconds"`
+	Requests      int     `json:"requests"` // 窗口内的正常请求数
+	Retries       int     `json:"retries"`  // 窗口内已放行的重试数
+	Budget        int     `json:"budget"`   // 窗口内允许的重试数上限
+	Ratio         float64 `json:"ratio"`    // 配置的重试比例
+	Rejected      int64   `json:"rejected"` // 因预算耗尽被拒绝的重试总数
+}
+
+// RetryBudget 全局重试预算：按秒分桶统计滑动窗口内的请求数与重试数，
+// 重试数超过 max(MinRetries, Ratio*请求数) 时拒绝继续重试，避免上游故障时重试放大
+type RetryBudget struct {
+	config *config.Config
+
+	mu       sync.Mutex
+	buckets  [maxRetryBudgetWindowSeconds]retryBudgetBucket
+	rejected int64
+}
+
+// NewRetryBudget creates a new RetryBudget reading its settings from cfg on every call
+func NewRetryBudget(cfg *config.Config) *RetryBudget {
+	return &RetryBudget{config: cfg}
+}
+
+// bucket 返回当前秒对应的桶，桶已过期时先清零（调用方需持有 mu）
+func (b *RetryBudget) bucket(now int64) *retryBudgetBucket {
+	bk := &b.buckets[now%maxRetryBudgetWindowSeconds]
+	if bk.second != now {
+		*bk = retryBudgetBucket{second: now}
+	}
+	return bk
+}
+
+// window 汇总窗口内的请求数和重试数（调用方需持有 mu）
+func (b *RetryBudget) window(now int64, windowSeconds int) (requests, retries int) {
+	for i := range b.buckets {
+		bk := &b.buckets[i]
+		if bk.second > now-int64(windowSeconds) && bk.second <= now {
+			requests += bk.requests
+			retries += bk.retries
+		}
+	}
+	return requests, retries
+}
+
+// RecordRequest 记录一次正常（首次）请求
+func (b *RetryBudget) RecordRequest() {
+	if !b.config.GetRetryBudget().Enabled {
+		return
+	}
+	b.mu.Lock()
+	b.bucket(time.Now().Unix()).requests++
+	b.mu.Unlock()
+}
+
+// AllowRetry 判断预算内是否还允许重试，允许时计入一次重试
+func (b *RetryBudget) AllowRetry() bool {
+	cfg := b.config.GetRetryBudget()
+	if !cfg.Enabled {
+		return true
+	}
+	windowSeconds := clampRetryBudgetWindow(cfg.WindowSeconds)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now().Unix()
+	requests, retries := b.window(now, windowSeconds)
+	if retries >= retryBudgetLimit(cfg, requests) {
+		b.rejected++
+		return false
+	}
+	b.bucket(now).retries++
+	return true
+}
+
+// GetStats 返回当前窗口的重试预算统计
+func (b *RetryBudget) GetStats() RetryBudgetStats {
+	cfg := b.config.GetRetryBudget()
+	windowSeconds := clampRetryBudgetWindow(cfg.WindowSeconds)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	requests, retries := b.window(time.Now().Unix(), windowSeconds)
+	return RetryBudgetStats{
+		Enabled:       cfg.Enabled,
+		WindowSeconds: windowSeconds,
+		Requests:      requests,
+		Retries:       retries,
+		Budget:        retryBudgetLimit(cfg, requests),
+		Ratio:         cfg.Ratio,
+		Rejected:      b.rejected,
+	}
+}
+
+// Reset 清空窗口和拒绝计数
+func (b *RetryBudget) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buckets = [maxRetryBudgetWindowSeconds]retryBudgetBucket{}
+	b.rejected = 0
+}
+
+// retryBudgetLimit 计算窗口内允许的重试数上限
+func retryBudgetLimit(cfg *config.RetryBudgetConfig, requests int) int {
+	limit := int(float64(requests) * cfg.Ratio)
+	if limit < cfg.MinRetries {
+		limit = cfg.MinRetries
+	}
+	return limit
+}
+
+// clampRetryBudgetWindow 将窗口限制在 [1, maxRetryBudgetWindowSeconds] 之间
+func clampRetryBudgetWindow(seconds int) int {
+	if seconds <= 0 {
+		return 60
+	}
+	if seconds > maxRetryBudgetWindowSeconds {
+		return maxRetryBudgetWindowSeconds
+	}
+	return seconds
+}
@@ -23,27 +23,53 @@ type Router struct {
 	// 轮询索引（每个客户端类型独立）
 	roundRobinIndex map[ClientType]int
 
+	// 加权轮询的当前权重（每个客户端类型独立，key 为端点名称）
+	weightedRoundRobin map[ClientType]map[string]int
+
 	// 线程安全的随机数生成器
 	rng *rand.Rand
+
+	// 端点当前的在途请求数，用于最少连接算法（由 Proxy 设置）
+	activeRequests func(endpointName string) int
 }
 
+const (
+	// latencySpikeRecentSamples 判断延迟突增时参与计算的最近请求数
+	latencySpikeRecentSamples = 5
+	// minLatencyWeightFactor 延迟突增时的最低权重系数，保留少量流量以便观察端点是否恢复
+	minLatencyWeightFactor = 0.1
+)
+
 // NewRouter 创建路由器
 func NewRouter(cfg *config.Config, monitor *Monitor) *Router {
 	return &Router{
-		config:          cfg,
-		monitor:         monitor,
-		roundRobinIndex: make(map[ClientType]int),
-		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		config:             cfg,
+		monitor:            monitor,
+		roundRobinIndex:    make(map[ClientType]int),
+		weightedRoundRobin: make(map[ClientType]map[string]int),
+		rng:                rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetActiveRequestCounter 设置端点在途请求数的来源，最少连接算法据此选择端点
+func (r *Router) SetActiveRequestCounter(counter func(endpointName string) int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activeRequests = counter
+}
+
 // SelectEndpoint 选择端点（组合策略）
 // 1. 模型匹配过滤 → 2. 配额过滤 → 3. 按成本/负载/优先级排序选择
 // 注意：从所有非禁用状态的端点中选择，包括 available、untested 和 unavailable
 // 这样即使端点未经健康检查验证，也可以尝试使用
 func (r *Router) SelectEndpoint(clientType ClientType, requestModel string, quotaTracker *QuotaTracker) (config.Endpoint, error) {
-	routingCfg := r.config.GetRoutingConfig()
-	endpoints := r.config.GetEnabledEndpointsByClient(string(clientType))
+	return r.SelectEndpointFrom(r.config.GetEnabledEndpointsByClient(string(clientType)), clientType, requestModel, quotaTracker)
+}
+
+// SelectEndpointFrom 在给定的候选端点（如某个分组内的端点）中按路由策略选择
+// 使用该 client type 的独立路由配置，没有时使用全局配置
+func (r *Router) SelectEndpointFrom(endpoints []config.Endpoint, clientType ClientType, requestModel string, quotaTracker *QuotaTracker) (config.Endpoint, error) {
+	routingCfg := r.config.GetRoutingConfigForClient(string(clientType))
 
 	if len(endpoints) == 0 {
 		return config.Endpoint{}, fmt.Errorf("没有可用的 %s 类型端点，请检查端点配置", clientType)
@@ -62,7 +88,7 @@ func (r *Router) SelectEndpoint(clientType ClientType, requestModel string, quot
 	// 步骤2: 配额过滤（排除已用尽的）
 	if routingCfg.EnableQuotaRouting && quotaTracker != nil {
 		beforeCount := len(endpoints)
-		endpoints = r.filterByQuota(endpoints, clientType, quotaTracker)
+		endpoints = r.filterByQuota(endpoints, clientType, requestModel, quotaTracker)
 		r.logFilterStep("配额过滤", "", beforeCount, endpoints)
 	}
 
@@ -81,9 +107,9 @@ func (r *Router) SelectEndpoint(clientType ClientType, requestModel string, quot
 
 	if routingCfg.EnableCostPriority {
 		selectionMethod = "成本优先"
-		selectedEndpoint, err = r.selectByCost(endpoints)
+		selectedEndpoint, err = r.selectByCost(endpoints, requestModel)
 	} else if routingCfg.EnableLoadBalance {
-		algorithm := r.config.GetLoadBalanceAlgorithm()
+		algorithm := r.config.GetLoadBalanceAlgorithmForClient(string(clientType))
 		selectionMethod = "负载均衡-" + algorithm
 		selectedEndpoint, err = r.selectByLoad(endpoints, clientType)
 	} else {
@@ -99,9 +125,16 @@ func (r *Router) SelectEndpoint(clientType ClientType, requestModel string, quot
 }
 
 // filterByModel 按模型模式过滤端点
+// 配置了多模型的端点按模型列表匹配（ModelPatterns 非空时也可匹配），否则按 ModelPatterns 匹配
 func (r *Router) filterByModel(endpoints []config.Endpoint, model string) []config.Endpoint {
 	var matched []config.Endpoint
 	for _, ep := range endpoints {
+		if len(ep.Models) > 0 {
+			if ep.MatchModel(model) != nil || (ep.ModelPatterns != "" && r.matchesModelPattern(model, ep.ModelPatterns)) {
+				matched = append(matched, ep)
+			}
+			continue
+		}
 		if r.matchesModelPattern(model, ep.ModelPatterns) {
 			matched = append(matched, ep)
 		}
@@ -149,10 +182,14 @@ func (r *Router) matchesModelPattern(model, patterns string) bool {
 	return false
 }
 
-// filterByQuota 过滤掉配额用尽的端点
-func (r *Router) filterByQuota(endpoints []config.Endpoint, clientType ClientType, quotaTracker *QuotaTracker) []config.Endpoint {
+// filterByQuota 过滤掉配额用尽的端点（包括请求模型的模型级配额）
+func (r *Router) filterByQuota(endpoints []config.Endpoint, clientType ClientType, requestModel string, quotaTracker *QuotaTracker) []config.Endpoint {
 	var available []config.Endpoint
 	for _, ep := range endpoints {
+		// 请求模型的模型级配额已用尽
+		if quotaTracker.IsModelExhausted(ep, string(clientType), requestModel) {
+			continue
+		}
 		// 没有配额限制的端点始终可用
 		if ep.QuotaLimit == 0 {
 			available = append(available, ep)
@@ -170,17 +207,20 @@ func (r *Router) filterByQuota(endpoints []config.Endpoint, clientType ClientTyp
 }
 
 // filterByStatusPriority 按状态优先级过滤端点
-// 优先级：available > untested > unavailable
+// 优先级：available > degraded > untested > unavailable
 // 如果有 available 状态的端点，只返回 available 的
-// 如果没有 available 但有 untested，只返回 untested 的
+// 如果没有 available 但有 degraded（可用但延迟超过阈值），只返回 degraded 的
+// 如果没有 available 和 degraded 但有 untested，只返回 untested 的
 // 如果都没有，返回 unavailable 的（作为最后备选）
 func (r *Router) filterByStatusPriority(endpoints []config.Endpoint) []config.Endpoint {
-	var available, untested, unavailable []config.Endpoint
+	var available, degraded, untested, unavailable []config.Endpoint
 
 	for _, ep := range endpoints {
 		switch ep.Status {
 		case config.EndpointStatusAvailable:
 			available = append(available, ep)
+		case config.EndpointStatusDegraded:
+			degraded = append(degraded, ep)
 		case config.EndpointStatusUntested:
 			untested = append(untested, ep)
 		case config.EndpointStatusUnavailable:
@@ -193,6 +233,10 @@ func (r *Router) filterByStatusPriority(endpoints []config.Endpoint) []config.En
 		logger.Debug("[路由选择] 状态过滤: 使用 %d 个 available 状态端点", len(available))
 		return available
 	}
+	if len(degraded) > 0 {
+		logger.Debug("[路由选择] 状态过滤: 使用 %d 个 degraded 状态端点", len(degraded))
+		return degraded
+	}
 	if len(untested) > 0 {
 		logger.Debug("[路由选择] 状态过滤: 使用 %d 个 untested 状态端点", len(untested))
 		return untested
@@ -206,8 +250,8 @@ func (r *Router) filterByStatusPriority(endpoints []config.Endpoint) []config.En
 	return endpoints
 }
 
-// selectByCost 按成本排序选择（成本越低越优先）
-func (r *Router) selectByCost(endpoints []config.Endpoint) (config.Endpoint, error) {
+// selectByCost 按成本排序选择（成本越低越优先），端点配置了请求模型的单价时使用模型单价
+func (r *Router) selectByCost(endpoints []config.Endpoint, requestModel string) (config.Endpoint, error) {
 	if len(endpoints) == 0 {
 		return config.Endpoint{}, errors.New("no endpoints")
 	}
@@ -217,8 +261,10 @@ func (r *Router) selectByCost(endpoints []config.Endpoint) (config.Endpoint, err
 	copy(sorted, endpoints)
 
 	sort.Slice(sorted, func(i, j int) bool {
-		costI := sorted[i].CostPerInputToken + sorted[i].CostPerOutputToken
-		costJ := sorted[j].CostPerInputToken + sorted[j].CostPerOutputToken
+		inI, outI := sorted[i].CostForModel(requestModel)
+		inJ, outJ := sorted[j].CostForModel(requestModel)
+		costI := inI + outI
+		costJ := inJ + outJ
 		if costI == costJ {
 			return sorted[i].Priority < sorted[j].Priority
 		}
@@ -234,18 +280,51 @@ func (r *Router) selectByLoad(endpoints []config.Endpoint, clientType ClientType
 		return config.Endpoint{}, errors.New("no endpoints")
 	}
 
-	algorithm := r.config.GetLoadBalanceAlgorithm()
+	algorithm := r.config.GetLoadBalanceAlgorithmForClient(string(clientType))
 
 	switch algorithm {
 	case "fastest":
 		return r.selectFastest(endpoints)
 	case "weighted":
 		return r.selectWeightedRandom(endpoints)
+	case "weighted_round_robin":
+		return r.selectWeightedRoundRobin(endpoints, clientType)
+	case "least_connections":
+		return r.selectLeastConnections(endpoints)
 	default: // "round_robin"
 		return r.selectRoundRobin(endpoints, clientType)
 	}
 }
 
+// latencyWeightFactor 计算端点的动态权重系数（0.1~1）
+// 最近几次请求的平均延迟比历史均值高出 AlertConfig.LatencyIncreasePercent 以上时，
+// 按 历史均值/近期均值 降低权重；延迟回落后系数自动回到 1
+func (r *Router) latencyWeightFactor(endpointName string) float64 {
+	if r.monitor == nil {
+		return 1
+	}
+
+	recentAvg, baselineAvg, ok := r.monitor.GetLatencyTrend(endpointName, latencySpikeRecentSamples)
+	if !ok || baselineAvg <= 0 || recentAvg <= baselineAvg {
+		return 1
+	}
+
+	increasePercent := float64(r.config.GetAlert().LatencyIncreasePercent)
+	if increasePercent <= 0 {
+		increasePercent = 200 // 默认200%
+	}
+	if (recentAvg-baselineAvg)/baselineAvg*100 <= increasePercent {
+		return 1
+	}
+
+	factor := baselineAvg / recentAvg
+	if factor < minLatencyWeightFactor {
+		factor = minLatencyWeightFactor
+	}
+	logger.Debug("[路由选择] %s 延迟突增: 近期=%.2fs, 均值=%.2fs, 权重系数=%.2f", endpointName, recentAvg, baselineAvg, factor)
+	return factor
+}
+
 // selectFastest 选择响应最快的端点
 func (r *Router) selectFastest(endpoints []config.Endpoint) (config.Endpoint, error) {
 	if r.monitor == nil {
@@ -293,6 +372,7 @@ func (r *Router) selectWeightedRandom(endpoints []config.Endpoint) (config.Endpo
 		} else {
 			weights[i] = 1.0 / metric.AvgResponseTime
 		}
+		weights[i] *= r.latencyWeightFactor(ep.Name)
 		totalWeight += weights[i]
 	}
 
@@ -313,25 +393,123 @@ func (r *Router) selectWeightedRandom(endpoints []config.Endpoint) (config.Endpo
 }
 
 // selectRoundRobin 轮询选择
+// 轮到延迟突增的端点时按权重系数概率跳过，改选下一个端点
 func (r *Router) selectRoundRobin(endpoints []config.Endpoint, clientType ClientType) (config.Endpoint, error) {
+	factors := make([]float64, len(endpoints))
+	for i, ep := range endpoints {
+		factors[i] = r.latencyWeightFactor(ep.Name)
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	index := r.roundRobinIndex[clientType] % len(endpoints)
+	if len(endpoints) > 1 && factors[index] < 1 && r.rng.Float64() > factors[index] {
+		logger.Debug("[路由选择] 轮询: 跳过延迟突增的端点 %s", endpoints[index].Name)
+		index = (index + 1) % len(endpoints)
+	}
 	r.roundRobinIndex[clientType] = (index + 1) % len(endpoints)
 
 	return endpoints[index], nil
 }
 
+// selectWeightedRoundRobin 加权轮询选择（平滑加权轮询，同 nginx）
+// 每次选择时所有端点的当前权重加上自身权重，选出当前权重最大者后减去总权重，
+// 这样权重高的端点被选中次数更多且分布均匀；权重全部相同时等同于普通轮询
+func (r *Router) selectWeightedRoundRobin(endpoints []config.Endpoint, clientType ClientType) (config.Endpoint, error) {
+	if len(endpoints) == 1 {
+		return endpoints[0], nil
+	}
+
+	// 配置权重放大 10 倍后乘以动态系数，使延迟突增的端点按比例少分流量
+	weights := make([]int, len(endpoints))
+	for i := range endpoints {
+		weights[i] = int(math.Round(float64(endpoints[i].EffectiveWeight()*10) * r.latencyWeightFactor(endpoints[i].Name)))
+		if weights[i] < 1 {
+			weights[i] = 1
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.weightedRoundRobin[clientType]
+	if current == nil {
+		current = make(map[string]int)
+		r.weightedRoundRobin[clientType] = current
+	}
+
+	// 清理已不在候选列表中的端点，避免残留权重影响后续选择
+	candidates := make(map[string]bool, len(endpoints))
+	for _, ep := range endpoints {
+		candidates[ep.Name] = true
+	}
+	for name := range current {
+		if !candidates[name] {
+			delete(current, name)
+		}
+	}
+
+	totalWeight := 0
+	best := -1
+	for i := range endpoints {
+		weight := weights[i]
+		totalWeight += weight
+		current[endpoints[i].Name] += weight
+		if best < 0 || current[endpoints[i].Name] > current[endpoints[best].Name] {
+			best = i
+		}
+	}
+	current[endpoints[best].Name] -= totalWeight
+
+	logger.Debug("[路由选择] 加权轮询: 选择 %s (权重=%d, 总权重=%d)", endpoints[best].Name, weights[best], totalWeight)
+	return endpoints[best], nil
+}
+
+// selectLeastConnections 选择在途请求数最少的端点（在途请求数相同时随机选择）
+// 适合请求耗时差异大的场景：长请求占用的端点不会继续被分配新请求
+func (r *Router) selectLeastConnections(endpoints []config.Endpoint) (config.Endpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.activeRequests == nil || len(endpoints) == 1 {
+		return endpoints[0], nil
+	}
+
+	var tied []config.Endpoint
+	least := math.MaxInt
+	for _, ep := range endpoints {
+		active := r.activeRequests(ep.Name)
+		if active < least {
+			least = active
+			tied = tied[:0]
+		}
+		if active == least {
+			tied = append(tied, ep)
+		}
+	}
+
+	selected := tied[r.rng.Intn(len(tied))]
+	logger.Debug("[路由选择] 最少连接: 选择 %s (在途请求=%d, 同等候选=%d)", selected.Name, least, len(tied))
+	return selected, nil
+}
+
 // selectByPriority 按优先级选择端点（相同优先级随机选择）
 func (r *Router) selectByPriority(endpoints []config.Endpoint) (config.Endpoint, error) {
 	if len(endpoints) == 0 {
 		return config.Endpoint{}, errors.New("no endpoints")
 	}
 
-	// 复制切片以避免修改原数组
-	sorted := make([]config.Endpoint, len(endpoints))
-	copy(sorted, endpoints)
+	// 复制切片以避免修改原数组，降级端点只在没有其他端点时参与
+	sorted := make([]config.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Status != config.EndpointStatusDegraded {
+			sorted = append(sorted, ep)
+		}
+	}
+	if len(sorted) == 0 {
+		sorted = append(sorted, endpoints...)
+	}
 
 	// 按优先级排序（数字越小优先级越高）
 	sort.Slice(sorted, func(i, j int) bool {
@@ -376,6 +554,7 @@ func (r *Router) ResetRoundRobinIndex(clientType ClientType) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.roundRobinIndex[clientType] = 0
+	delete(r.weightedRoundRobin, clientType)
 }
 
 // UpdateConfig 更新配置引用
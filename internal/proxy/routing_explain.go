@@ -0,0 +1,285 @@
+package proxy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// 路由决策原因
+const (
+	RoutingReasonPinned          = "pinned"           // 手动钉选的端点
+	RoutingReasonSessionAffinity = "session_affinity" // 会话亲和命中
+	RoutingReasonCostPriority    = "cost_priority"    // 成本优先
+	RoutingReasonLoadBalance     = "load_balance"     // 负载均衡
+	RoutingReasonPriority        = "priority"         // 优先级
+	RoutingReasonRoundRobin      = "round_robin"      // 回退到传统轮询
+	RoutingReasonNoEndpoint      = "no_endpoint"      // 没有可选端点
+)
+
+// RoutingStep 路由决策中的一个步骤，记录该步骤前后的候选端点
+type RoutingStep struct {
+	Stage      string   `json:"stage"`
+	Detail     string   `json:"detail"`
+	Candidates []string `json:"candidates"`
+	Excluded   []string `json:"excluded,omitempty"`
+}
+
+// RoutingExplanation 一次假设请求的路由决策过程
+type RoutingExplanation struct {
+	ClientType string        `json:"clientType"`
+	Model      string        `json:"model"`
+	SessionID  string        `json:"sessionId"`
+	Selected   string        `json:"selected"`
+	Reason     string        `json:"reason"`
+	Steps      []RoutingStep `json:"steps"`
+}
+
+func (e *RoutingExplanation) addStep(stage, detail string, before, after []config.Endpoint) {
+	e.Steps = append(e.Steps, RoutingStep{
+		Stage:      stage,
+		Detail:     detail,
+		Candidates: endpointNames(after),
+		Excluded:   excludedNames(before, after),
+	})
+}
+
+func endpointNames(endpoints []config.Endpoint) []string {
+	names := make([]string, len(endpoints))
+	for i, ep := range endpoints {
+		names[i] = ep.Name
+	}
+	return names
+}
+
+// excludedNames 返回 before 中不在 after 里的端点名称
+func excludedNames(before, after []config.Endpoint) []string {
+	kept := make(map[string]bool, len(after))
+	for _, ep := range after {
+		kept[ep.Name] = true
+	}
+	var excluded []string
+	for _, ep := range before {
+		if !kept[ep.Name] {
+			excluded = append(excluded, ep.Name)
+		}
+	}
+	return excluded
+}
+
+// ExplainRouting 按 selectEndpointForRequest 的顺序推演一次假设请求会选中哪个端点及原因
+// 只读推演：不绑定会话、不推进轮询索引；随机类算法（同优先级随机、加权随机）的结果仅代表一次可能的选择
+func (p *Proxy) ExplainRouting(clientType ClientType, requestModel, sessionID string) *RoutingExplanation {
+	explain := &RoutingExplanation{
+		ClientType: string(clientType),
+		Model:      requestModel,
+		SessionID:  sessionID,
+	}
+
+	// 0. 手动钉选
+	if endpointName, pinned := p.pins.Get(string(clientType)); pinned {
+		endpoint := p.config.GetEndpointByName(endpointName, string(clientType))
+		if endpoint != nil && endpoint.IsRoutable() {
+			explain.Steps = append(explain.Steps, RoutingStep{
+				Stage:      "pin",
+				Detail:     fmt.Sprintf("端点 %s 已被手动钉选", endpointName),
+				Candidates: []string{endpointName},
+			})
+			explain.Selected = endpointName
+			explain.Reason = RoutingReasonPinned
+			return explain
+		}
+		explain.Steps = append(explain.Steps, RoutingStep{
+			Stage:  "pin",
+			Detail: fmt.Sprintf("钉选的端点 %s 已禁用或不存在，忽略", endpointName),
+		})
+	}
+
+	// 1. 会话亲和性
+	if p.sessionAffinity != nil && sessionID != "" {
+		if endpointName, exists := p.sessionAffinity.GetEndpointForSession(sessionID, string(clientType)); exists {
+			endpoint := p.config.GetEndpointByName(endpointName, string(clientType))
+			if endpoint != nil && endpoint.IsRoutable() && p.circuitBreaker.Allow(string(clientType), endpointName) {
+				explain.Steps = append(explain.Steps, RoutingStep{
+					Stage:      "session_affinity",
+					Detail:     fmt.Sprintf("会话已绑定到端点 %s", endpointName),
+					Candidates: []string{endpointName},
+				})
+				explain.Selected = endpointName
+				explain.Reason = RoutingReasonSessionAffinity
+				return explain
+			}
+			explain.Steps = append(explain.Steps, RoutingStep{
+				Stage:  "session_affinity",
+				Detail: fmt.Sprintf("会话绑定的端点 %s 已禁用或处于熔断冷却中，实际请求时会解除绑定", endpointName),
+			})
+		} else {
+			explain.Steps = append(explain.Steps, RoutingStep{
+				Stage:  "session_affinity",
+				Detail: "会话没有绑定端点",
+			})
+		}
+	}
+
+	// 2. 候选端点：非禁用端点，排除熔断冷却中和达到并发上限的端点
+	all := p.getEnabledEndpointsForClient(clientType)
+	explain.addStep("candidates", fmt.Sprintf("%d 个非禁用的 %s 端点", len(all), clientType), all, all)
+	breakerFiltered := p.circuitBreaker.Filter(clientType, all)
+	explain.addStep("circuit_breaker", "排除熔断冷却中的端点", all, breakerFiltered)
+	endpoints := p.filterSaturated(breakerFiltered)
+	explain.addStep("concurrency", "排除达到并发上限的端点", breakerFiltered, endpoints)
+
+	if len(endpoints) == 0 {
+		explain.Reason = RoutingReasonNoEndpoint
+		return explain
+	}
+	if p.router == nil {
+		// 没有路由器时按传统轮询选择
+		explain.Selected = p.getCurrentEndpointForClient(clientType).Name
+		explain.Reason = RoutingReasonRoundRobin
+		return explain
+	}
+
+	p.router.explainSelection(explain, endpoints, clientType, requestModel, p.quotaTracker)
+	return explain
+}
+
+// explainSelection 按 SelectEndpointFrom 的步骤推演选择结果；未启用任何高级路由策略时按优先级选择
+func (r *Router) explainSelection(explain *RoutingExplanation, endpoints []config.Endpoint, clientType ClientType, requestModel string, quotaTracker *QuotaTracker) {
+	routingCfg := r.config.GetRoutingConfigForClient(string(clientType))
+	advanced := routingCfg.EnableModelRouting || routingCfg.EnableLoadBalance ||
+		routingCfg.EnableCostPriority || routingCfg.EnableQuotaRouting
+
+	if !advanced {
+		r.explainPriority(explain, endpoints)
+		return
+	}
+
+	if routingCfg.EnableModelRouting && requestModel != "" {
+		filtered := r.filterByModel(endpoints, requestModel)
+		detail := fmt.Sprintf("按模型 %s 匹配端点", requestModel)
+		if len(filtered) == len(endpoints) {
+			detail += "（全部匹配或没有端点匹配，保留全部）"
+		}
+		explain.addStep("model_routing", detail, endpoints, filtered)
+		endpoints = filtered
+	}
+
+	if routingCfg.EnableQuotaRouting && quotaTracker != nil {
+		filtered := r.filterByQuota(endpoints, clientType, requestModel, quotaTracker)
+		explain.addStep("quota", "排除配额已用尽的端点（全部用尽时保留全部）", endpoints, filtered)
+		endpoints = filtered
+	}
+
+	filtered := r.filterByStatusPriority(endpoints)
+	explain.addStep("status", "优先使用 available，其次 degraded、untested，最后 unavailable", endpoints, filtered)
+	endpoints = filtered
+
+	switch {
+	case routingCfg.EnableCostPriority:
+		selected, err := r.selectByCost(endpoints, requestModel)
+		if err != nil {
+			explain.Reason = RoutingReasonNoEndpoint
+			return
+		}
+		input, output := selected.CostForModel(requestModel)
+		explain.Steps = append(explain.Steps, RoutingStep{
+			Stage:      "cost_priority",
+			Detail:     fmt.Sprintf("选择单价最低的端点 %s（输入+输出=%.6f），同价按优先级", selected.Name, input+output),
+			Candidates: endpointNames(endpoints),
+		})
+		explain.Selected = selected.Name
+		explain.Reason = RoutingReasonCostPriority
+	case routingCfg.EnableLoadBalance:
+		algorithm := r.config.GetLoadBalanceAlgorithmForClient(string(clientType))
+		selected := r.peekByLoad(endpoints, clientType, algorithm)
+		explain.Steps = append(explain.Steps, RoutingStep{
+			Stage:      "load_balance",
+			Detail:     fmt.Sprintf("负载均衡算法 %s 选择端点 %s", algorithm, selected.Name),
+			Candidates: endpointNames(endpoints),
+		})
+		explain.Selected = selected.Name
+		explain.Reason = RoutingReasonLoadBalance
+	default:
+		r.explainPriority(explain, endpoints)
+	}
+}
+
+// explainPriority 推演按优先级选择的结果
+func (r *Router) explainPriority(explain *RoutingExplanation, endpoints []config.Endpoint) {
+	selected, err := r.selectByPriority(endpoints)
+	if err != nil {
+		explain.Reason = RoutingReasonNoEndpoint
+		return
+	}
+
+	var tied []config.Endpoint
+	for _, ep := range endpoints {
+		if ep.Priority == selected.Priority {
+			tied = append(tied, ep)
+		}
+	}
+	detail := fmt.Sprintf("选择最高优先级（%d）的端点 %s", selected.Priority, selected.Name)
+	if len(tied) > 1 {
+		detail += fmt.Sprintf("，该优先级有 %d 个端点，实际请求时随机选择", len(tied))
+	}
+	explain.Steps = append(explain.Steps, RoutingStep{
+		Stage:      "priority",
+		Detail:     detail,
+		Candidates: endpointNames(tied),
+	})
+	explain.Selected = selected.Name
+	explain.Reason = RoutingReasonPriority
+}
+
+// peekByLoad 推演负载均衡算法的下一次选择，轮询类算法不推进索引和当前权重
+func (r *Router) peekByLoad(endpoints []config.Endpoint, clientType ClientType, algorithm string) config.Endpoint {
+	switch algorithm {
+	case "fastest":
+		selected, _ := r.selectFastest(endpoints)
+		return selected
+	case "weighted":
+		selected, _ := r.selectWeightedRandom(endpoints)
+		return selected
+	case "weighted_round_robin":
+		return r.peekWeightedRoundRobin(endpoints, clientType)
+	case "least_connections":
+		selected, _ := r.selectLeastConnections(endpoints)
+		return selected
+	default: // "round_robin"
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return endpoints[r.roundRobinIndex[clientType]%len(endpoints)]
+	}
+}
+
+// peekWeightedRoundRobin 在当前权重的副本上推演一次平滑加权轮询
+func (r *Router) peekWeightedRoundRobin(endpoints []config.Endpoint, clientType ClientType) config.Endpoint {
+	if len(endpoints) == 1 {
+		return endpoints[0]
+	}
+
+	weights := make([]int, len(endpoints))
+	for i := range endpoints {
+		weights[i] = int(math.Round(float64(endpoints[i].EffectiveWeight()*10) * r.latencyWeightFactor(endpoints[i].Name)))
+		if weights[i] < 1 {
+			weights[i] = 1
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	current := r.weightedRoundRobin[clientType]
+	best := -1
+	bestWeight := 0
+	for i, ep := range endpoints {
+		weight := current[ep.Name] + weights[i]
+		if best < 0 || weight > bestWeight {
+			best = i
+			bestWeight = weight
+		}
+	}
+	return endpoints[best]
+}
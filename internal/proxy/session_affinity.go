@@ -3,7 +3,9 @@ package proxy
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -79,7 +81,13 @@ func (s *SessionAffinityManager) cleanupLoop() {
 }
 
 // ExtractSessionID 从请求中提取会话ID
-func (s *SessionAffinityManager) ExtractSessionID(r *http.Request) string {
+// 配置了自定义来源（header 或请求体字段）且取到值时使用该值，否则按默认规则提取
+func (s *SessionAffinityManager) ExtractSessionID(r *http.Request, body []byte) string {
+	// 0. 自定义会话 key，如 X-Session-Id 或 metadata.user_id，使同一用户的多轮对话落到同一端点
+	if sessionID := s.extractCustomSessionKey(r, body); sessionID != "" {
+		return sessionID
+	}
+
 	// 1. 优先使用客户端提供的会话ID
 	if sessionID := r.Header.Get("X-CCNexus-Session-ID"); sessionID != "" {
 		return sessionID
@@ -96,6 +104,40 @@ func (s *SessionAffinityManager) ExtractSessionID(r *http.Request) string {
 	return generateSessionHash(clientIP, userAgent)
 }
 
+// extractCustomSessionKey 按配置的来源提取会话 key，未配置或取不到时返回空
+func (s *SessionAffinityManager) extractCustomSessionKey(r *http.Request, body []byte) string {
+	cfg := s.config.GetSessionAffinity()
+	name := strings.TrimSpace(cfg.SessionKeyName)
+	if name == "" {
+		return ""
+	}
+
+	switch cfg.SessionKeySource {
+	case config.SessionKeySourceHeader:
+		return strings.TrimSpace(r.Header.Get(name))
+	case config.SessionKeySourceBody:
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return ""
+		}
+		current := data
+		for _, key := range strings.Split(name, ".") {
+			node, ok := current.(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			current = node[key]
+		}
+		switch v := current.(type) {
+		case string:
+			return strings.TrimSpace(v)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
 // getClientIPFromRequest 获取客户端IP（内部方法）
 func (s *SessionAffinityManager) getClientIPFromRequest(r *http.Request) string {
 	// 优先从 X-Forwarded-For 获取
@@ -37,8 +37,9 @@ type EndpointStats struct {
 type StatsStorage interface {
 	RecordDailyStat(stat interface{}) error
 	RecordRequestStat(stat interface{}) error // 新增
-	GetTotalStats() (int, map[string]interface{}, error)
+	GetTotalStats(excludeTest bool) (int, map[string]interface{}, error)
 	GetDailyStats(endpointName, clientType, startDate, endDate string) ([]interface{}, error)
+	GetTestStats(startDate, endDate string) (map[string]interface{}, error) // 测试请求的用量，用于从统计中扣除
 }
 
 // StatRecord represents a stat record for storage
@@ -68,11 +69,16 @@ type RequestStatRecord struct {
 	CacheReadTokens     int
 	OutputTokens        int
 	Model               string
+	UpstreamModel       string // 转换后实际发往上游的模型
 	IsStreaming         bool
 	Success             bool
 	DeviceID            string
 	DurationMs          int64 // 请求时长（毫秒）
 	ErrorMessage        string // 错误消息
+	ErrorCategory       string // 错误分类（失败时记录，见 ErrorCategories）
+	RequestBytes        int64  // 请求体字节数（发往上游）
+	ResponseBytes       int64  // 响应体字节数（从上游读取）
+	IsTest              bool   // 测试请求（X-CCNexus-Endpoint 指定端点）
 }
 
 // StatsData represents aggregated stats data
@@ -101,6 +107,7 @@ type Stats struct {
 	storage       StatsStorage
 	deviceID      string
 	mu            sync.RWMutex
+	location      func() *time.Location // 统计日期使用的时区，nil 时为本机时区
 
 	// Save optimization
 	savePending   bool
@@ -119,9 +126,27 @@ func NewStats(storage StatsStorage, deviceID string) *Stats {
 	}
 }
 
+// SetDateLocation sets the function returning the time zone used for the date column of recorded stats
+func (s *Stats) SetDateLocation(location func() *time.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.location = location
+}
+
+// dateOf returns the stats date of t in the configured time zone
+func (s *Stats) dateOf(t time.Time) string {
+	s.mu.RLock()
+	location := s.location
+	s.mu.RUnlock()
+	if location != nil {
+		t = t.In(location())
+	}
+	return t.Format("2006-01-02")
+}
+
 // RecordRequest records a request for an endpoint
 func (s *Stats) RecordRequest(endpointName string, clientType string) {
-	date := time.Now().Format("2006-01-02")
+	date := s.dateOf(time.Now())
 
 	stat := &StatRecord{
 		EndpointName: endpointName,
@@ -141,7 +166,7 @@ func (s *Stats) RecordRequest(endpointName string, clientType string) {
 
 // RecordError records an error for an endpoint
 func (s *Stats) RecordError(endpointName string, clientType string) {
-	date := time.Now().Format("2006-01-02")
+	date := s.dateOf(time.Now())
 
 	stat := &StatRecord{
 		EndpointName: endpointName,
@@ -161,7 +186,7 @@ func (s *Stats) RecordError(endpointName string, clientType string) {
 
 // RecordTokens records token usage for an endpoint
 func (s *Stats) RecordTokens(endpointName string, clientType string, usage transformer.TokenUsageDetail) {
-	date := time.Now().Format("2006-01-02")
+	date := s.dateOf(time.Now())
 
 	stat := &StatRecord{
 		EndpointName:        endpointName,
@@ -184,7 +209,7 @@ func (s *Stats) RecordTokens(endpointName string, clientType string, usage trans
 // RecordRequestStat records a request-level statistic (新增)
 func (s *Stats) RecordRequestStat(record *RequestStatRecord) {
 	record.DeviceID = s.deviceID
-	record.Date = record.Timestamp.Format("2006-01-02")
+	record.Date = s.dateOf(record.Timestamp)
 
 	if err := s.storage.RecordRequestStat(record); err != nil {
 		logger.Error("Failed to record request stat: %v", err)
@@ -224,8 +249,9 @@ func (s *Stats) scheduleSave() {
 }
 
 // GetStats returns a copy of current statistics (thread-safe)
-func (s *Stats) GetStats() (int, map[string]*EndpointStats) {
-	totalRequests, statsData, err := s.storage.GetTotalStats()
+// excludeTest 为 true 时不计入测试请求
+func (s *Stats) GetStats(excludeTest bool) (int, map[string]*EndpointStats) {
+	totalRequests, statsData, err := s.storage.GetTotalStats(excludeTest)
 	if err != nil {
 		logger.Error("Failed to get stats: %v", err)
 		return 0, make(map[string]*EndpointStats)
@@ -276,9 +302,10 @@ func (s *Stats) Load() error {
 
 
 // GetPeriodStats returns aggregated statistics for a time period
-func (s *Stats) GetPeriodStats(startDate, endDate string) map[string]*DailyStats {
+// excludeTest 为 true 时扣除该时间段内测试请求的用量
+func (s *Stats) GetPeriodStats(startDate, endDate string, excludeTest bool) map[string]*DailyStats {
 	// Get all endpoints from storage
-	totalRequests, statsData, err := s.storage.GetTotalStats()
+	totalRequests, statsData, err := s.storage.GetTotalStats(false)
 	if err != nil {
 		logger.Error("Failed to get stats: %v", err)
 		return make(map[string]*DailyStats)
@@ -323,13 +350,17 @@ func (s *Stats) GetPeriodStats(startDate, endDate string) map[string]*DailyStats
 		result[key] = aggregated
 	}
 
+	if excludeTest {
+		s.subtractTestStats(result, startDate, endDate)
+	}
 	return result
 }
 
 // GetDailyStats returns statistics for a specific date
-func (s *Stats) GetDailyStats(date string) map[string]*DailyStats {
+// excludeTest 为 true 时扣除当天测试请求的用量
+func (s *Stats) GetDailyStats(date string, excludeTest bool) map[string]*DailyStats {
 	// Get all endpoints from storage
-	totalRequests, statsData, err := s.storage.GetTotalStats()
+	totalRequests, statsData, err := s.storage.GetTotalStats(false)
 	if err != nil {
 		logger.Error("Failed to get stats: %v", err)
 		return make(map[string]*DailyStats)
@@ -366,9 +397,39 @@ func (s *Stats) GetDailyStats(date string) map[string]*DailyStats {
 		}
 	}
 
+	if excludeTest {
+		s.subtractTestStats(result, date, date)
+	}
 	return result
 }
 
+// subtractTestStats 从按端点汇总的统计中扣除测试请求的用量（不低于 0）
+// 测试请求同样计入 daily_stats，只有 request_stats 带测试标记，因此按 request_stats 扣除
+func (s *Stats) subtractTestStats(result map[string]*DailyStats, startDate, endDate string) {
+	testStats, err := s.storage.GetTestStats(startDate, endDate)
+	if err != nil {
+		logger.Error("Failed to get test request stats: %v", err)
+		return
+	}
+
+	for key, data := range testStats {
+		st, ok := result[key]
+		if !ok {
+			continue
+		}
+		v := reflect.ValueOf(data)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		st.Requests = max(st.Requests-int(v.FieldByName("Requests").Int()), 0)
+		st.Errors = max(st.Errors-int(v.FieldByName("Errors").Int()), 0)
+		st.InputTokens = max(st.InputTokens-int(v.FieldByName("InputTokens").Int()), 0)
+		st.CacheCreationTokens = max(st.CacheCreationTokens-int(v.FieldByName("CacheCreationTokens").Int()), 0)
+		st.CacheReadTokens = max(st.CacheReadTokens-int(v.FieldByName("CacheReadTokens").Int()), 0)
+		st.OutputTokens = max(st.OutputTokens-int(v.FieldByName("OutputTokens").Int()), 0)
+	}
+}
+
 // splitClientEndpointKey separates the stored key into client type and endpoint name
 func splitClientEndpointKey(key string) (clientType string, endpointName string) {
 	parts := strings.SplitN(key, ":", 2)
@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// streamCacheHeader 客户端通过该请求头显式允许缓存非确定性（temperature 不为 0）的流式响应
+const streamCacheHeader = "X-CCNexus-Cache-Stream"
+
+// isStreamCacheable 判断流式请求是否可以缓存：temperature=0 时输出是确定的，其他情况需要客户端显式允许
+func isStreamCacheable(r *http.Request, temperature *float64) bool {
+	if temperature != nil && *temperature == 0 {
+		return true
+	}
+	switch strings.ToLower(r.Header.Get(streamCacheHeader)) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// splitSSEEvents 按空行将完整的 SSE 流拆分为事件，每个事件保留结尾的空行
+func splitSSEEvents(data []byte) [][]byte {
+	var events [][]byte
+	for len(data) > 0 {
+		idx := bytes.Index(data, []byte("\n\n"))
+		if idx < 0 {
+			events = append(events, append([]byte(nil), data...))
+			break
+		}
+		events = append(events, append([]byte(nil), data[:idx+2]...))
+		data = data[idx+2:]
+	}
+	return events
+}
+
+// replayStreamEvents 将缓存的 SSE 事件逐个写出并 flush，模拟流式响应
+func replayStreamEvents(w http.ResponseWriter, events [][]byte) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	for _, event := range events {
+		if _, err := w.Write(event); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/logger"
@@ -22,6 +25,41 @@ import (
 // so the request can be retried with a different endpoint
 var ErrStreamRetryable = errors.New("stream failed before response sent, retryable")
 
+// sseHeartbeat is an SSE comment line, ignored by clients but keeps idle connections alive
+var sseHeartbeat = []byte(": ping\n\n")
+
+// heartbeatWriter 由录制/过滤客户端流的 ResponseWriter 包装实现，心跳直接写给底层连接，
+// 不进入去重和响应缓存回放的缓冲
+type heartbeatWriter interface {
+	WriteHeartbeat() error
+}
+
+// writeHeartbeat 向客户端写一次 SSE 心跳，绕过包装层的缓冲
+func writeHeartbeat(w http.ResponseWriter) error {
+	if hw, ok := w.(heartbeatWriter); ok {
+		return hw.WriteHeartbeat()
+	}
+	_, err := w.Write(sseHeartbeat)
+	return err
+}
+
+// errStreamFirstByteTimeout indicates no SSE data arrived within StreamFirstByteTimeout
+// after heartbeats had already sent response headers, so the request cannot be retried;
+// also returned when the timeout fires while sniffing the response Content-Type
+var errStreamFirstByteTimeout = errors.New("stream first byte timeout")
+
+// errStreamNoData indicates upstream closed the stream without any event after heartbeats were sent
+var errStreamNoData = errors.New("upstream stream ended without data")
+
+// firstByteDeadline 返回从 sentAt 起算的首字节超时截止时间，未配置 StreamFirstByteTimeout 时返回零值
+func (p *Proxy) firstByteDeadline(sentAt time.Time) time.Time {
+	timeout := p.config.GetStreamFirstByteTimeout()
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return sentAt.Add(time.Duration(timeout) * time.Second)
+}
+
 // isClientDisconnectError checks if the error indicates client disconnection
 // This is normal behavior when user cancels request or client times out
 func isClientDisconnectError(err error) bool {
@@ -41,11 +79,16 @@ func isClientDisconnectError(err error) bool {
 // handleStreamingResponse processes streaming SSE responses
 // Returns error for upstream/server-side errors (not client disconnection)
 // Returns ErrStreamRetryable if stream fails before response headers are sent (can retry with different endpoint)
+// sentAt is when the upstream request was sent, used for the first-byte (TTFT) timeout
+// The stream is terminated when endpoint stops being the current endpoint of clientType; empty clientType skips the check
 // Returns: usage, outputText, rawEvents, transformedEvents, error
-func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Response, endpoint config.Endpoint, trans transformer.Transformer, transformerName string, thinkingEnabled bool, modelName string, bodyBytes []byte, clientType ClientType) (transformer.TokenUsageDetail, string, []interface{}, []interface{}, error) {
+func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Response, endpoint config.Endpoint, trans transformer.Transformer, transformerName string, thinkingEnabled bool, modelName string, bodyBytes []byte, clientType ClientType, sentAt time.Time) (transformer.TokenUsageDetail, string, []interface{}, []interface{}, error) {
+	// 任何返回路径都释放上游连接（重复 Close 无副作用）
+	defer resp.Body.Close()
+
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		logger.Error("[%s] ResponseWriter does not support flushing", endpoint.Name)
+		logger.ErrorKey(endpoint.Name, "[%s] ResponseWriter does not support flushing", endpoint.Name)
 		resp.Body.Close()
 		return transformer.TokenUsageDetail{}, "", nil, nil, ErrStreamRetryable
 	}
@@ -55,7 +98,7 @@ func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Respon
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		gzipReader, err := gzip.NewReader(resp.Body)
 		if err != nil {
-			logger.Error("[%s] Failed to create gzip reader: %v", endpoint.Name, err)
+			logger.ErrorKey(endpoint.Name, "[%s] Failed to create gzip reader: %v", endpoint.Name, err)
 			resp.Body.Close()
 			return transformer.TokenUsageDetail{}, "", nil, nil, ErrStreamRetryable
 		}
@@ -110,11 +153,78 @@ func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Respon
 		headersSent = true
 	}
 
+	// 等待上游首字节期间定期向客户端发送 SSE 注释行保活，
+	// 避免长时间思考的请求被中间代理/负载均衡器因空闲而断开。
+	// 收到首字节后心跳停止，之后只有当前 goroutine 写入 w。
+	var writeMu sync.Mutex
+	var stopOnce sync.Once
+	heartbeatStopped := false
+	heartbeatCount := 0
+	stopHeartbeat := func() {
+		stopOnce.Do(func() {
+			writeMu.Lock()
+			heartbeatStopped = true
+			writeMu.Unlock()
+		})
+	}
+	defer stopHeartbeat()
+
+	if interval := p.config.GetStreamHeartbeatInterval(); interval > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Duration(interval) * time.Second)
+			defer ticker.Stop()
+			for range ticker.C {
+				writeMu.Lock()
+				if heartbeatStopped {
+					writeMu.Unlock()
+					return
+				}
+				sendHeaders()
+				err := writeHeartbeat(w)
+				if err == nil {
+					flusher.Flush()
+					heartbeatCount++
+				}
+				writeMu.Unlock()
+
+				if err != nil {
+					if isClientDisconnectError(err) {
+						logger.InfoKey(endpoint.Name, "[%s] 客户端已断开连接（可能是用户取消或超时）", endpoint.Name)
+					} else {
+						logger.WarnKey(endpoint.Name, "[%s] 发送心跳失败: %v", endpoint.Name, err)
+					}
+					return
+				}
+				logger.DebugKey(endpoint.Name, "[%s] Sent SSE heartbeat while waiting for upstream", endpoint.Name)
+			}
+		}()
+	}
+
+	// 首字节超时（TTFT）：从发出请求起超过 StreamFirstByteTimeout 仍未收到数据则关闭上游连接，
+	// 响应头未发出时返回 ErrStreamRetryable 切换端点；后续数据仍受整体 RequestTimeout 限制
+	var firstByteTimedOut atomic.Bool
+	var firstByteTimer *time.Timer
+	if deadline := p.firstByteDeadline(sentAt); !deadline.IsZero() {
+		firstByteTimer = time.AfterFunc(time.Until(deadline), func() {
+			firstByteTimedOut.Store(true)
+			resp.Body.Close()
+		})
+	}
+	stopFirstByteTimer := func() {
+		if firstByteTimer != nil {
+			firstByteTimer.Stop()
+		}
+	}
+	defer stopFirstByteTimer()
+
 	for scanner.Scan() && !streamDone {
+		stopHeartbeat()
+		stopFirstByteTimer()
 		line := scanner.Text()
 
-		if !p.isCurrentEndpointForClient(endpoint.Name, clientType) {
-			logger.Warn("[%s] Endpoint switched during streaming, terminating stream gracefully", endpoint.Name)
+		// 跨 client type 兜底的端点不属于本 client type 的轮换，不做切换检测
+		if endpointClientType(endpoint) == clientType && !p.isCurrentEndpointForClient(endpoint.Name, clientType) {
+			logger.WarnKey(endpoint.Name, "[%s] Endpoint switched during streaming, terminating stream gracefully", endpoint.Name)
 			streamDone = true
 			break
 		}
@@ -156,7 +266,7 @@ func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Respon
 
 			transformedEvent, err := p.transformStreamEvent(eventData, trans, transformerName, streamCtx)
 			if err != nil {
-				logger.Error("[%s] Failed to transform SSE event: %v", endpoint.Name, err)
+				logger.ErrorKey(endpoint.Name, "[%s] Failed to transform SSE event: %v", endpoint.Name, err)
 			} else if len(transformedEvent) > 0 {
 				logger.DebugLog("[%s] SSE Event #%d (Transformed): %s", endpoint.Name, eventCount, string(transformedEvent))
 
@@ -175,9 +285,9 @@ func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Respon
 					// Client disconnected - this is normal, not an endpoint error
 					// Common patterns: broken pipe (Linux/Mac), connection reset, wsasend abort (Windows)
 					if isClientDisconnectError(writeErr) {
-						logger.Info("[%s] 客户端已断开连接（可能是用户取消或超时）", endpoint.Name)
+						logger.InfoKey(endpoint.Name, "[%s] 客户端已断开连接（可能是用户取消或超时）", endpoint.Name)
 					} else {
-						logger.Warn("[%s] 写入响应失败: %v", endpoint.Name, writeErr)
+						logger.WarnKey(endpoint.Name, "[%s] 写入响应失败: %v", endpoint.Name, writeErr)
 					}
 					streamDone = true
 					break
@@ -188,28 +298,47 @@ func (p *Proxy) handleStreamingResponse(w http.ResponseWriter, resp *http.Respon
 		}
 	}
 
+	stopHeartbeat()
+
+	if firstByteTimedOut.Load() {
+		timeout := p.config.GetStreamFirstByteTimeout()
+		resp.Body.Close()
+		if !headersSent {
+			logger.WarnKey(endpoint.Name, "[%s] 流式首字节超时（%d 秒），切换端点", endpoint.Name, timeout)
+			return transformer.TokenUsageDetail{}, "", nil, nil, ErrStreamRetryable
+		}
+		logger.WarnKey(endpoint.Name, "[%s] 流式首字节超时（%d 秒），心跳已发出响应头，无法重试", endpoint.Name, timeout)
+		return transformer.TokenUsageDetail{}, "", nil, nil, errStreamFirstByteTimeout
+	}
+
 	if err := scanner.Err(); err != nil {
 		// If headers not sent yet, this error is retryable
 		if !headersSent {
-			logger.Warn("[%s] 读取上游响应失败: %v", endpoint.Name, err)
+			logger.WarnKey(endpoint.Name, "[%s] 读取上游响应失败: %v", endpoint.Name, err)
 			resp.Body.Close()
 			return transformer.TokenUsageDetail{}, "", nil, nil, ErrStreamRetryable
 		}
-		// After headers sent, check if it's client disconnect
-		if isClientDisconnectError(err) {
-			logger.Info("[%s] 客户端已断开连接（可能是用户取消或超时）", endpoint.Name)
+		// After headers sent, check if it's client disconnect (including the upstream read being aborted for it)
+		if isClientDisconnectError(err) || (resp.Request != nil && isClientGone(resp.Request.Context())) {
+			logger.InfoKey(endpoint.Name, "[%s] 客户端已断开连接（可能是用户取消或超时）", endpoint.Name)
 		} else {
-			logger.Warn("[%s] 流式传输读取错误: %v", endpoint.Name, err)
+			logger.WarnKey(endpoint.Name, "[%s] 流式传输读取错误: %v", endpoint.Name, err)
 			streamErr = err
 		}
 	}
 
 	resp.Body.Close()
 
+	// Only heartbeats were sent: headers are already out so we cannot retry, report as upstream error
+	if heartbeatCount > 0 && eventCount == 0 && !streamDone && streamErr == nil {
+		logger.WarnKey(endpoint.Name, "[%s] Stream ended without data after %d heartbeats", endpoint.Name, heartbeatCount)
+		streamErr = errStreamNoData
+	}
+
 	// If we never sent headers (empty response or all events failed to transform),
 	// and no other error occurred, this is a retryable situation
 	if !headersSent && streamErr == nil {
-		logger.Warn("[%s] Stream ended without sending any data to client", endpoint.Name)
+		logger.WarnKey(endpoint.Name, "[%s] Stream ended without sending any data to client", endpoint.Name)
 		return transformer.TokenUsageDetail{}, "", nil, nil, ErrStreamRetryable
 	}
 
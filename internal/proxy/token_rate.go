@@ -0,0 +1,99 @@
+package proxy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxTokenRateWindowSeconds token 消耗速率统计窗口上限（秒），决定环形桶数量
+const maxTokenRateWindowSeconds = 600
+
+// tokenRateBucket 一秒内的 token 消耗
+type tokenRateBucket struct {
+	second     int64
+	tokens     int64
+	byEndpoint map[string]int64
+}
+
+// EndpointTokenUsage 窗口内单个端点的 token 消耗
+type EndpointTokenUsage struct {
+	EndpointName string `json:"endpointName"`
+	Tokens       int64  `json:"tokens"`
+}
+
+// TokenRateSnapshot 滑动窗口内的 token 消耗速率
+type TokenRateSnapshot struct {
+	WindowSeconds   int                  `json:"windowSeconds"`
+	Tokens          int64                `json:"tokens"`          // 窗口内消耗的 token 总数（含缓存 token）
+	TokensPerMinute float64              `json:"tokensPerMinute"` // 折算为每分钟的消耗速率
+	TopEndpoints    []EndpointTokenUsage `json:"topEndpoints"`    // 窗口内消耗最多的端点（降序）
+}
+
+// TokenRateTracker 按秒分桶记录 token 消耗，用于计算实时消耗速率
+type TokenRateTracker struct {
+	mu      sync.Mutex
+	buckets [maxTokenRateWindowSeconds]tokenRateBucket
+}
+
+// NewTokenRateTracker creates a new TokenRateTracker
+func NewTokenRateTracker() *TokenRateTracker {
+	return &TokenRateTracker{}
+}
+
+// Record 记录一次请求消耗的 token
+func (t *TokenRateTracker) Record(endpointName string, tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	now := time.Now().Unix()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bk := &t.buckets[now%maxTokenRateWindowSeconds]
+	if bk.second != now {
+		*bk = tokenRateBucket{second: now, byEndpoint: make(map[string]int64)}
+	}
+	bk.tokens += int64(tokens)
+	bk.byEndpoint[endpointName] += int64(tokens)
+}
+
+// Snapshot 计算最近 windowSeconds 秒内的 token 消耗速率
+func (t *TokenRateTracker) Snapshot(windowSeconds int) TokenRateSnapshot {
+	if windowSeconds <= 0 {
+		windowSeconds = 60
+	}
+	if windowSeconds > maxTokenRateWindowSeconds {
+		windowSeconds = maxTokenRateWindowSeconds
+	}
+	now := time.Now().Unix()
+
+	t.mu.Lock()
+	var total int64
+	byEndpoint := make(map[string]int64)
+	for i := range t.buckets {
+		bk := &t.buckets[i]
+		if bk.second <= now-int64(windowSeconds) || bk.second > now {
+			continue
+		}
+		total += bk.tokens
+		for name, tokens := range bk.byEndpoint {
+			byEndpoint[name] += tokens
+		}
+	}
+	t.mu.Unlock()
+
+	top := make([]EndpointTokenUsage, 0, len(byEndpoint))
+	for name, tokens := range byEndpoint {
+		top = append(top, EndpointTokenUsage{EndpointName: name, Tokens: tokens})
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].Tokens > top[j].Tokens })
+
+	return TokenRateSnapshot{
+		WindowSeconds:   windowSeconds,
+		Tokens:          total,
+		TokensPerMinute: float64(total) * 60 / float64(windowSeconds),
+		TopEndpoints:    top,
+	}
+}
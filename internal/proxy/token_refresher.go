@@ -0,0 +1,198 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// Anthropic 官方 OAuth 刷新接口
+const (
+	oauthTokenURL     = "https://console.anthropic.com/v1/oauth/token"
+	oauthClientID     = "9d1c250a-e61b-44d9-88ed-5944d1962f5e"
+	oauthRefreshAhead = 5 * time.Minute  // 距过期不足该时间即提前刷新
+	oauthRetryBackoff = 1 * time.Minute  // 刷新失败后的重试间隔，期间直接使用旧 token
+	oauthTimeout      = 30 * time.Second // 刷新请求超时
+)
+
+// oauthTokenResponse 刷新接口返回
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// TokenRefresher 在请求发往上游前检查端点的 OAuth access token 是否临近过期，
+// 临近过期时用 refresh token 换取新 token 并更新配置和存储；刷新失败时降级为使用旧 token 并告警
+type TokenRefresher struct {
+	config  *config.Config
+	storage storage.Storage
+
+	mu          sync.Mutex
+	locks       map[string]*sync.Mutex // clientType:endpointName -> 刷新锁，同一端点同时只刷新一次
+	lastFailure map[string]time.Time   // clientType:endpointName -> 最近一次刷新失败时间
+}
+
+// NewTokenRefresher creates a new TokenRefresher
+func NewTokenRefresher(cfg *config.Config) *TokenRefresher {
+	return &TokenRefresher{
+		config:      cfg,
+		locks:       make(map[string]*sync.Mutex),
+		lastFailure: make(map[string]time.Time),
+	}
+}
+
+// SetStorage sets the storage used to persist refreshed tokens
+func (t *TokenRefresher) SetStorage(store storage.Storage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.storage = store
+}
+
+// needsRefresh 端点配置了 refresh token 且 access token 临近过期
+func needsRefresh(endpoint config.Endpoint, now time.Time) bool {
+	if endpoint.RefreshToken == "" || endpoint.TokenExpiry <= 0 {
+		return false
+	}
+	return time.Unix(endpoint.TokenExpiry, 0).Sub(now) < oauthRefreshAhead
+}
+
+// EnsureFresh returns the endpoint with a valid access token, refreshing it first if it is about to expire.
+// 刷新失败时返回原端点（使用旧 token）
+func (t *TokenRefresher) EnsureFresh(endpoint config.Endpoint) config.Endpoint {
+	if t == nil || !needsRefresh(endpoint, time.Now()) {
+		return endpoint
+	}
+
+	clientType := endpoint.ClientType
+	if clientType == "" {
+		clientType = "claude"
+	}
+	key := clientType + ":" + endpoint.Name
+
+	t.mu.Lock()
+	lock, exists := t.locks[key]
+	if !exists {
+		lock = &sync.Mutex{}
+		t.locks[key] = lock
+	}
+	lastFailure := t.lastFailure[key]
+	store := t.storage
+	t.mu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// 等待锁期间可能已被其他请求刷新
+	if current, ok := t.findEndpoint(endpoint.Name, clientType); ok {
+		endpoint = current
+		if !needsRefresh(endpoint, time.Now()) {
+			return endpoint
+		}
+	}
+
+	if time.Since(lastFailure) < oauthRetryBackoff {
+		return endpoint
+	}
+
+	token, err := t.refresh(endpoint.RefreshToken)
+	if err != nil {
+		t.mu.Lock()
+		t.lastFailure[key] = time.Now()
+		t.mu.Unlock()
+		logger.Warn("[OAUTH:%s] Failed to refresh token for endpoint %s, using the old token: %v", clientType, endpoint.Name, err)
+		return endpoint
+	}
+
+	refreshToken := token.RefreshToken
+	if refreshToken == "" {
+		refreshToken = endpoint.RefreshToken
+	}
+	expiry := int64(0)
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second).Unix()
+	}
+
+	if err := t.config.UpdateEndpointToken(endpoint.Name, clientType, token.AccessToken, refreshToken, expiry); err != nil {
+		logger.Warn("[OAUTH:%s] Failed to update token for endpoint %s: %v", clientType, endpoint.Name, err)
+	}
+	if store != nil {
+		if err := store.UpdateEndpointToken(endpoint.Name, clientType, token.AccessToken, refreshToken, expiry); err != nil {
+			logger.Warn("[OAUTH:%s] Failed to save refreshed token for endpoint %s: %v", clientType, endpoint.Name, err)
+		}
+	}
+
+	t.mu.Lock()
+	delete(t.lastFailure, key)
+	t.mu.Unlock()
+
+	endpoint.APIKey = token.AccessToken
+	endpoint.RefreshToken = refreshToken
+	endpoint.TokenExpiry = expiry
+	logger.Info("[OAUTH:%s] Token refreshed for endpoint %s, expires at %s", clientType, endpoint.Name, time.Unix(expiry, 0).Format(time.RFC3339))
+	return endpoint
+}
+
+// findEndpoint 从当前配置中查找端点
+func (t *TokenRefresher) findEndpoint(name, clientType string) (config.Endpoint, bool) {
+	for _, ep := range t.config.GetEndpointsByClient(clientType) {
+		if ep.Name == name {
+			return ep, true
+		}
+	}
+	return config.Endpoint{}, false
+}
+
+// refresh 调用 OAuth 刷新接口换取新 token
+func (t *TokenRefresher) refresh(refreshToken string) (*oauthTokenResponse, error) {
+	body, _ := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     oauthClientID,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, oauthTokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	proxyURL := ""
+	if proxyCfg := t.config.GetProxy(); proxyCfg != nil {
+		proxyURL = proxyCfg.URL
+	}
+	resp, err := NewProxyHTTPClient(proxyURL, oauthTimeout).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(respBody) > 200 {
+			respBody = respBody[:200]
+		}
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var token oauthTokenResponse
+	if err := json.Unmarshal(respBody, &token); err != nil {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	return &token, nil
+}
@@ -2,21 +2,16 @@ package proxy
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/lich0821/ccNexus/internal/logger"
 	"github.com/lich0821/ccNexus/internal/tokencount"
 )
 
-// normalizeAPIUrl ensures the API URL has a protocol prefix
-func normalizeAPIUrl(apiUrl string) string {
-	if !strings.HasPrefix(apiUrl, "http://") && !strings.HasPrefix(apiUrl, "https://") {
-		return "https://" + apiUrl
-	}
-	return apiUrl
-}
-
 // shouldRetry determines if a response should trigger a retry
 func shouldRetry(statusCode int) bool {
 	return statusCode != http.StatusOK &&
@@ -24,6 +19,67 @@ func shouldRetry(statusCode int) bool {
 		statusCode != http.StatusUnauthorized
 }
 
+// upstreamError 上游返回的可重试错误响应，所有重试耗尽后原样返回给客户端
+type upstreamError struct {
+	endpoint   string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// writeUpstreamError 透传上游错误的原始状态码和响应体（响应体已解压）
+func writeUpstreamError(w http.ResponseWriter, upErr *upstreamError, retriesExhausted bool) {
+	for key, values := range upErr.header {
+		if key == "Content-Encoding" || key == "Content-Length" {
+			continue
+		}
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.Header().Set("X-CCNexus-Upstream-Endpoint", upErr.endpoint)
+	if retriesExhausted {
+		w.Header().Set("X-CCNexus-Retries-Exhausted", "true")
+	}
+	w.WriteHeader(upErr.statusCode)
+	w.Write(upErr.body)
+}
+
+// countingReadCloser wraps a response body and counts the bytes actually read from upstream
+type countingReadCloser struct {
+	io.ReadCloser
+	n int64
+}
+
+// newCountingReadCloser creates a byte-counting wrapper around rc
+func newCountingReadCloser(rc io.ReadCloser) *countingReadCloser {
+	return &countingReadCloser{ReadCloser: rc}
+}
+
+func (c *countingReadCloser) Read(b []byte) (int, error) {
+	n, err := c.ReadCloser.Read(b)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+// Count returns the number of bytes read so far
+func (c *countingReadCloser) Count() int64 {
+	return atomic.LoadInt64(&c.n)
+}
+
+// releaseOnCloseReadCloser wraps a response body and runs release once when it is closed
+type releaseOnCloseReadCloser struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (c *releaseOnCloseReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.once.Do(c.release)
+	return err
+}
+
 // cleanIncompleteToolCalls removes incomplete tool_use blocks from request
 func cleanIncompleteToolCalls(bodyBytes []byte) ([]byte, error) {
 	var req map[string]interface{}
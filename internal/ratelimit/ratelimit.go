@@ -1,6 +1,7 @@
 package ratelimit
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -9,13 +10,15 @@ import (
 
 // RateLimiter 速率限制器
 type RateLimiter struct {
-	enabled         bool
-	globalLimit     int           // 全局每分钟最大请求数
-	perEndpointLimit int          // 每端点每分钟最大请求数
-	windowSize      time.Duration // 时间窗口大小
+	enabled          bool
+	globalLimit      int           // 全局每分钟最大请求数
+	perEndpointLimit int           // 每端点每分钟最大请求数
+	windowSize       time.Duration // 时间窗口大小
 
-	globalRequests    []time.Time            // 全局请求时间戳
-	endpointRequests  map[string][]time.Time // 每端点请求时间戳
+	globalRequests   []time.Time               // 全局请求时间戳
+	endpointRequests map[string][]time.Time    // 每端点请求时间戳
+	keyRequests      map[string][]time.Time    // 按任意 key（如客户端 IP）的请求时间戳
+	keyRejected      map[string]*KeyRejectStat // 按 key 统计的被拒绝次数
 
 	mu sync.RWMutex
 
@@ -26,12 +29,20 @@ type RateLimiter struct {
 
 // RateLimitStats 速率限制统计
 type RateLimitStats struct {
-	Enabled          bool  `json:"enabled"`
-	GlobalLimit      int   `json:"globalLimit"`
-	PerEndpointLimit int   `json:"perEndpointLimit"`
-	TotalAllowed     int64 `json:"totalAllowed"`
-	TotalRejected    int64 `json:"totalRejected"`
-	CurrentGlobalRPM int   `json:"currentGlobalRpm"` // 当前全局每分钟请求数
+	Enabled          bool            `json:"enabled"`
+	GlobalLimit      int             `json:"globalLimit"`
+	PerEndpointLimit int             `json:"perEndpointLimit"`
+	TotalAllowed     int64           `json:"totalAllowed"`
+	TotalRejected    int64           `json:"totalRejected"`
+	CurrentGlobalRPM int             `json:"currentGlobalRpm"` // 当前全局每分钟请求数
+	RejectedKeys     []KeyRejectStat `json:"rejectedKeys"`     // 按 key 限流被拒绝的统计（按拒绝次数降序）
+}
+
+// KeyRejectStat 单个 key 被限流的统计
+type KeyRejectStat struct {
+	Key          string    `json:"key"`
+	Rejected     int64     `json:"rejected"`
+	LastRejected time.Time `json:"lastRejected"`
 }
 
 // New 创建新的速率限制器
@@ -50,6 +61,8 @@ func New(enabled bool, globalLimit, perEndpointLimit int) *RateLimiter {
 		windowSize:       time.Minute,
 		globalRequests:   make([]time.Time, 0),
 		endpointRequests: make(map[string][]time.Time),
+		keyRequests:      make(map[string][]time.Time),
+		keyRejected:      make(map[string]*KeyRejectStat),
 	}
 
 	// 启动清理协程
@@ -122,6 +135,39 @@ func (rl *RateLimiter) Allow(endpointName string) (bool, time.Duration) {
 	return true, 0
 }
 
+// AllowKey 按任意 key 检查是否允许请求，limit 为每个 key 每分钟最大请求数（<=0 表示不限制）
+// 与 Allow 相互独立，只统计该 key 自身的请求；返回 (是否允许, 等待时间建议)
+func (rl *RateLimiter) AllowKey(key string, limit int) (bool, time.Duration) {
+	if !rl.enabled || limit <= 0 || key == "" {
+		return true, 0
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	windowStart := now.Add(-rl.windowSize)
+
+	requests := filterRecent(rl.keyRequests[key], windowStart)
+	if len(requests) >= limit {
+		rl.keyRequests[key] = requests
+		rl.totalRejected++
+		stat := rl.keyRejected[key]
+		if stat == nil {
+			stat = &KeyRejectStat{Key: key}
+			rl.keyRejected[key] = stat
+		}
+		stat.Rejected++
+		stat.LastRejected = now
+		waitTime := requests[0].Add(rl.windowSize).Sub(now)
+		logger.Debug("[RATELIMIT] Key %s limit reached (%d/%d), wait: %v", key, len(requests), limit, waitTime)
+		return false, waitTime
+	}
+
+	rl.keyRequests[key] = append(requests, now)
+	return true, 0
+}
+
 // filterRecent 过滤出时间窗口内的记录
 func filterRecent(times []time.Time, windowStart time.Time) []time.Time {
 	result := make([]time.Time, 0, len(times))
@@ -161,6 +207,14 @@ func (rl *RateLimiter) cleanup() {
 			delete(rl.endpointRequests, name)
 		}
 	}
+
+	// 清理 key 记录
+	for key, times := range rl.keyRequests {
+		rl.keyRequests[key] = filterRecent(times, windowStart)
+		if len(rl.keyRequests[key]) == 0 {
+			delete(rl.keyRequests, key)
+		}
+	}
 }
 
 // GetStats 获取统计信息
@@ -177,6 +231,17 @@ func (rl *RateLimiter) GetStats() RateLimitStats {
 		}
 	}
 
+	rejectedKeys := make([]KeyRejectStat, 0, len(rl.keyRejected))
+	for _, stat := range rl.keyRejected {
+		rejectedKeys = append(rejectedKeys, *stat)
+	}
+	sort.Slice(rejectedKeys, func(i, j int) bool {
+		if rejectedKeys[i].Rejected != rejectedKeys[j].Rejected {
+			return rejectedKeys[i].Rejected > rejectedKeys[j].Rejected
+		}
+		return rejectedKeys[i].Key < rejectedKeys[j].Key
+	})
+
 	return RateLimitStats{
 		Enabled:          rl.enabled,
 		GlobalLimit:      rl.globalLimit,
@@ -184,6 +249,7 @@ func (rl *RateLimiter) GetStats() RateLimitStats {
 		TotalAllowed:     rl.totalAllowed,
 		TotalRejected:    rl.totalRejected,
 		CurrentGlobalRPM: currentRPM,
+		RejectedKeys:     rejectedKeys,
 	}
 }
 
@@ -213,6 +279,8 @@ func (rl *RateLimiter) Reset() {
 	rl.totalRejected = 0
 	rl.globalRequests = make([]time.Time, 0)
 	rl.endpointRequests = make(map[string][]time.Time)
+	rl.keyRequests = make(map[string][]time.Time)
+	rl.keyRejected = make(map[string]*KeyRejectStat)
 
 	logger.Info("[RATELIMIT] Stats reset")
 }
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// webhook 请求超时与重试
+const (
+	alertWebhookTimeout     = 10 * time.Second
+	alertWebhookMaxAttempts = 3
+	alertWebhookRetryDelay  = 2 * time.Second
+)
+
+// webhookAlertTypes 发送 webhook 的告警类型（与系统通知一致，不推送健康检查完成等内部事件）
+var webhookAlertTypes = map[string]bool{
+	"failure":      true,
+	"recovery":     true,
+	"failover":     true,
+	"failback":     true,
+	"sla":          true,
+	"token_rate":   true,
+	"key_expiring": true,
+	"key_expired":  true,
+}
+
+// alertWebhookPayload 未配置模板时 POST 的默认 JSON body
+type alertWebhookPayload struct {
+	EndpointName string `json:"endpointName"`
+	ClientType   string `json:"clientType"`
+	AlertType    string `json:"alertType"`
+	Timestamp    string `json:"timestamp"`
+	Message      string `json:"message"`
+}
+
+// alertWebhookTemplateData 模板变量，字符串已做 JSON 转义（不含两侧引号），可直接放在模板的 JSON 字符串中
+type alertWebhookTemplateData struct {
+	EndpointName string
+	ClientType   string
+	AlertType    string
+	Timestamp    string
+	Message      string
+}
+
+// IsWebhookAlertType reports whether an alert type is pushed to the alert webhook
+func IsWebhookAlertType(alertType string) bool {
+	return webhookAlertTypes[alertType]
+}
+
+// ValidateAlertWebhook validates the webhook URL and template
+func ValidateAlertWebhook(webhookURL, webhookTemplate string) error {
+	if webhookURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook URL: %s", webhookURL)
+	}
+	if strings.TrimSpace(webhookTemplate) != "" {
+		body, err := renderAlertWebhookBody(webhookTemplate, AlertEvent{
+			EndpointName: "test",
+			ClientType:   "claude",
+			AlertType:    "failure",
+			Message:      "test",
+			Timestamp:    time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if !json.Valid(body) {
+			return fmt.Errorf("webhook template does not render valid JSON")
+		}
+	}
+	return nil
+}
+
+// SendAlertWebhook posts an alert event to the configured webhook in the background
+// 未配置 URL 或告警类型不需要推送时直接返回
+func SendAlertWebhook(alertConfig *config.AlertConfig, event AlertEvent) {
+	if alertConfig == nil || alertConfig.WebhookURL == "" || !IsWebhookAlertType(event.AlertType) {
+		return
+	}
+	webhookURL := alertConfig.WebhookURL
+	webhookTemplate := alertConfig.WebhookTemplate
+
+	go func() {
+		if err := postAlertWebhook(webhookURL, webhookTemplate, event); err != nil {
+			logger.Warn("[WEBHOOK] Failed to send %s alert for %s: %v", event.AlertType, event.EndpointName, err)
+		}
+	}()
+}
+
+// TestAlertWebhook sends a test alert to the webhook synchronously and returns the result as JSON
+func TestAlertWebhook(webhookURL, webhookTemplate string) string {
+	if webhookURL == "" {
+		return errorJSON("Webhook URL is empty")
+	}
+	if err := ValidateAlertWebhook(webhookURL, webhookTemplate); err != nil {
+		return errorJSON(err.Error())
+	}
+	err := postAlertWebhook(webhookURL, webhookTemplate, AlertEvent{
+		EndpointName: "ccNexus",
+		ClientType:   "claude",
+		AlertType:    "failure",
+		Message:      "ccNexus webhook test",
+		Timestamp:    time.Now(),
+	})
+	if err != nil {
+		return errorJSON(err.Error())
+	}
+	return successJSON(nil)
+}
+
+// postAlertWebhook POSTs the event, retrying on network errors and non-2xx responses
+func postAlertWebhook(webhookURL, webhookTemplate string, event AlertEvent) error {
+	var body []byte
+	var err error
+	if strings.TrimSpace(webhookTemplate) != "" {
+		body, err = renderAlertWebhookBody(webhookTemplate, event)
+	} else {
+		body, err = json.Marshal(alertWebhookPayload{
+			EndpointName: event.EndpointName,
+			ClientType:   event.ClientType,
+			AlertType:    event.AlertType,
+			Timestamp:    event.Timestamp.Format(time.RFC3339),
+			Message:      event.Message,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: alertWebhookTimeout}
+	var lastErr error
+	for attempt := 1; attempt <= alertWebhookMaxAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(alertWebhookRetryDelay * time.Duration(attempt-1))
+		}
+		lastErr = doAlertWebhookRequest(client, webhookURL, body)
+		if lastErr == nil {
+			logger.Debug("[WEBHOOK] %s alert for %s sent", event.AlertType, event.EndpointName)
+			return nil
+		}
+		logger.Debug("[WEBHOOK] Attempt %d/%d failed: %v", attempt, alertWebhookMaxAttempts, lastErr)
+	}
+	return fmt.Errorf("failed after %d attempts: %w", alertWebhookMaxAttempts, lastErr)
+}
+
+func doAlertWebhookRequest(client *http.Client, webhookURL string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// renderAlertWebhookBody renders the user template (Go text/template) with JSON-escaped event fields
+// 例如飞书：{"msg_type":"text","content":{"text":"[{{.AlertType}}] {{.Message}}"}}
+func renderAlertWebhookBody(webhookTemplate string, event AlertEvent) ([]byte, error) {
+	tmpl, err := template.New("webhook").Parse(webhookTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	data := alertWebhookTemplateData{
+		EndpointName: jsonEscape(event.EndpointName),
+		ClientType:   jsonEscape(event.ClientType),
+		AlertType:    jsonEscape(event.AlertType),
+		Timestamp:    jsonEscape(event.Timestamp.Format(time.RFC3339)),
+		Message:      jsonEscape(event.Message),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonEscape returns s escaped as the content of a JSON string (without surrounding quotes)
+func jsonEscape(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// AutoOptimizeService 按 AutoOptimizeInterval 定期对每个 client type 执行 TestAllEndpointsAndOptimize：
+// 启用恢复的端点、将失效的端点标记为不可用、把最快的端点设为当前端点。
+// 与定时健康检查互斥执行，避免两者同时检测端点、修改状态
+type AutoOptimizeService struct {
+	config      *config.Config
+	endpoint    *EndpointService
+	healthCheck *HealthCheckService
+
+	mu       sync.Mutex
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+}
+
+// NewAutoOptimizeService creates a new AutoOptimizeService
+func NewAutoOptimizeService(cfg *config.Config, endpoint *EndpointService, healthCheck *HealthCheckService) *AutoOptimizeService {
+	return &AutoOptimizeService{
+		config:      cfg,
+		endpoint:    endpoint,
+		healthCheck: healthCheck,
+	}
+}
+
+// Start starts scheduled optimization if the interval is configured
+func (s *AutoOptimizeService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	interval := s.config.GetAutoOptimizeInterval()
+	if s.running || interval <= 0 {
+		return
+	}
+
+	s.stopChan = make(chan struct{})
+	s.ticker = time.NewTicker(time.Duration(interval) * time.Minute)
+	s.running = true
+
+	logger.Info("Auto optimize started with interval %d minutes", interval)
+
+	go s.run(s.ticker, s.stopChan)
+}
+
+// Stop stops scheduled optimization
+func (s *AutoOptimizeService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+
+	logger.Info("Auto optimize stopped")
+}
+
+// Restart restarts scheduled optimization with the latest interval
+func (s *AutoOptimizeService) Restart() {
+	s.Stop()
+	s.Start()
+}
+
+// run is the main loop for scheduled optimization; the first run happens after one interval
+func (s *AutoOptimizeService) run(ticker *time.Ticker, stop <-chan struct{}) {
+	for {
+		select {
+		case <-ticker.C:
+			s.Optimize()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Optimize 对所有有端点的 client type 执行一次检测和优化
+func (s *AutoOptimizeService) Optimize() {
+	optimize := func() {
+		for _, clientType := range config.ClientPortTypes {
+			if len(s.config.GetEndpointsByClient(clientType)) == 0 {
+				continue
+			}
+			logger.Info("Auto optimize: testing %s endpoints", clientType)
+			s.endpoint.TestAllEndpointsAndOptimize(clientType)
+		}
+	}
+
+	if s.healthCheck != nil {
+		s.healthCheck.RunExclusive(optimize)
+		return
+	}
+	optimize()
+}
@@ -46,7 +46,30 @@ func (b *BackupService) UpdateLocalBackupDir(dir string) error {
 		backup = &config.BackupConfig{}
 	}
 	backup.Provider = string(BackupProviderLocal)
-	backup.Local = &config.LocalBackupConfig{Dir: dir}
+	keepVersions := 0
+	if backup.Local != nil {
+		keepVersions = backup.Local.KeepVersions
+	}
+	backup.Local = &config.LocalBackupConfig{Dir: dir, KeepVersions: keepVersions}
+	b.config.UpdateBackup(backup)
+
+	return b.saveConfig()
+}
+
+// UpdateLocalBackupKeepVersions sets how many timestamped local backups to keep, 0 disables versioning
+func (b *BackupService) UpdateLocalBackupKeepVersions(keepVersions int) error {
+	if keepVersions < 0 {
+		return fmt.Errorf("backup_keep_versions_invalid")
+	}
+
+	backup := cloneBackupConfig(b.config.GetBackup())
+	if backup == nil {
+		backup = &config.BackupConfig{}
+	}
+	if backup.Local == nil {
+		backup.Local = &config.LocalBackupConfig{}
+	}
+	backup.Local.KeepVersions = keepVersions
 	b.config.UpdateBackup(backup)
 
 	return b.saveConfig()
@@ -224,7 +247,7 @@ func cloneBackupConfig(src *config.BackupConfig) *config.BackupConfig {
 
 	dst := &config.BackupConfig{Provider: src.Provider}
 	if src.Local != nil {
-		dst.Local = &config.LocalBackupConfig{Dir: src.Local.Dir}
+		dst.Local = &config.LocalBackupConfig{Dir: src.Local.Dir, KeepVersions: src.Local.KeepVersions}
 	}
 	if src.S3 != nil {
 		dst.S3 = &config.S3BackupConfig{
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/logger"
@@ -82,6 +84,15 @@ func (b *BackupService) backupToLocal(filename string) error {
 		return fmt.Errorf("filename_required")
 	}
 
+	keepVersions := 0
+	if backup := b.config.GetBackup(); backup != nil && backup.Local != nil {
+		keepVersions = backup.Local.KeepVersions
+	}
+	baseName := filename
+	if keepVersions > 0 {
+		filename = versionedBackupFilename(baseName, time.Now())
+	}
+
 	finalPath := filepath.Join(dir, filename)
 	tmpPath := finalPath + ".tmp"
 	_ = os.Remove(tmpPath)
@@ -98,9 +109,60 @@ func (b *BackupService) backupToLocal(filename string) error {
 	}
 
 	_ = os.WriteFile(finalPath+".meta.json", nowMeta(b.version), 0644)
+
+	if keepVersions > 0 {
+		pruneLocalBackupVersions(dir, baseName, keepVersions)
+	}
 	return nil
 }
 
+// localBackupVersionLayout 本地备份历史版本文件名中的时间戳格式，按字典序即按时间排序
+const localBackupVersionLayout = "20060102-150405"
+
+// versionedBackupFilename 在备份文件名后追加时间戳，如 ccnexus.db → ccnexus-20240101-120000.db
+func versionedBackupFilename(filename string, t time.Time) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "-" + t.Format(localBackupVersionLayout) + ext
+}
+
+// pruneLocalBackupVersions 只保留同一备份名最近的 keep 个历史版本，删除更旧的版本及其元数据
+func pruneLocalBackupVersions(dir, filename string, keep int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logger.Warn("Failed to read backup dir for pruning: %v", err)
+		return
+	}
+
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, ext) + "-"
+	var versions []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if _, err := time.Parse(localBackupVersionLayout, stamp); err != nil {
+			continue
+		}
+		versions = append(versions, name)
+	}
+	if len(versions) <= keep {
+		return
+	}
+
+	// 时间戳格式固定，按文件名倒序即最新在前
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+	for _, name := range versions[keep:] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			logger.Warn("Failed to remove old backup %s: %v", name, err)
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, name+".meta.json"))
+		logger.Info("Removed old local backup: %s", name)
+	}
+}
+
 func (b *BackupService) detectLocalConflict(filename string) string {
 	if b.storage == nil {
 		return marshalConflictResult(false, "存储未初始化", nil)
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// 端点配置变更审计的操作类型
+const (
+	ConfigAuditActionAdd    = "add"
+	ConfigAuditActionUpdate = "update"
+	ConfigAuditActionRemove = "remove"
+)
+
+// 端点配置变更来源
+const (
+	ConfigAuditSourceUI     = "ui"     // 界面上手动增删改
+	ConfigAuditSourceImport = "import" // 导入端点配置
+)
+
+// maskSecret 审计记录中只保留密钥的首尾几位，足以判断 key 是否被更换
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return secret[:4] + "****" + secret[len(secret)-4:]
+}
+
+// endpointAuditJSON 将端点配置序列化为审计用 JSON，API key 和 refresh token 脱敏
+func endpointAuditJSON(ep *config.Endpoint) string {
+	if ep == nil {
+		return ""
+	}
+	masked := *ep
+	masked.APIKey = maskSecret(ep.APIKey)
+	masked.RefreshToken = maskSecret(ep.RefreshToken)
+	if len(ep.APIKeys) > 0 {
+		masked.APIKeys = make([]string, len(ep.APIKeys))
+		for i, key := range ep.APIKeys {
+			masked.APIKeys[i] = maskSecret(key)
+		}
+	}
+	data, err := json.Marshal(masked)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// SetDeviceID sets the device ID written to config audit records
+func (e *EndpointService) SetDeviceID(deviceID string) {
+	e.deviceID = deviceID
+}
+
+// recordConfigAudit 写入一条端点配置变更审计记录，新增时 before 为 nil，删除时 after 为 nil
+// 审计写入失败只记日志，不影响配置变更本身
+func (e *EndpointService) recordConfigAudit(action, source, clientType string, before, after *config.Endpoint) {
+	if e.storage == nil {
+		return
+	}
+
+	name := ""
+	if after != nil {
+		name = after.Name
+	} else if before != nil {
+		name = before.Name
+	}
+
+	deviceID := e.deviceID
+	if deviceID == "" {
+		deviceID = "default"
+	}
+
+	record := &storage.ConfigAuditRecord{
+		Action:       action,
+		EndpointName: name,
+		ClientType:   clientType,
+		Before:       endpointAuditJSON(before),
+		After:        endpointAuditJSON(after),
+		Source:       source,
+		DeviceID:     deviceID,
+		Timestamp:    time.Now(),
+	}
+	if err := e.storage.RecordConfigAudit(record); err != nil {
+		logger.Warn("Failed to record config audit for %s: %v", name, err)
+	}
+}
+
+// GetConfigAuditLog returns endpoint config change records, newest first, with pagination
+func (e *EndpointService) GetConfigAuditLog(limit, offset int) string {
+	if e.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	records, total, err := e.storage.GetConfigAuditLog(limit, offset)
+	if err != nil {
+		return jsonError("Failed to get config audit log: " + err.Error())
+	}
+
+	return successJSON(map[string]interface{}{
+		"records": records,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// fullConfigVersion 完整配置导出格式的版本
+const fullConfigVersion = "1.0"
+
+// FullConfigExport 完整配置导出：全部端点 + 与设备无关的应用设置，用于迁移到新机器
+// Settings 为 app_config 中可迁移的配置项（见 storage.IsPortableConfigKey），
+// 设备 ID、本地备份目录、代理地址、窗口大小等设备特定的配置项不会导出
+type FullConfigExport struct {
+	Version     string            `json:"version"`
+	ExportTime  string            `json:"exportTime"`
+	IncludeKeys bool              `json:"includeKeys"`
+	Endpoints   []ExportEndpoint  `json:"endpoints"`
+	Settings    map[string]string `json:"settings"`
+}
+
+// FullConfigImportResult 完整配置导入结果
+type FullConfigImportResult struct {
+	Success          bool     `json:"success"`
+	Message          string   `json:"message"`
+	Imported         int      `json:"imported"`         // 导入的端点数
+	Skipped          int      `json:"skipped"`          // 跳过的端点数
+	SettingsImported int      `json:"settingsImported"` // 写入的设置项数
+	SettingsIgnored  int      `json:"settingsIgnored"`  // 不可迁移而被忽略的设置项数
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// ExportFullConfig exports all endpoints and portable app settings as one JSON document
+// includeKeys 为 false 时端点 API Key 脱敏，WebDAV 密码和 S3 密钥不导出
+func (e *EndpointService) ExportFullConfig(includeKeys bool) string {
+	if e.storage == nil {
+		return errorJSON("Storage not available")
+	}
+
+	var endpoints ExportData
+	if err := json.Unmarshal([]byte(e.ExportAllEndpoints(includeKeys)), &endpoints); err != nil {
+		return errorJSON(fmt.Sprintf("Failed to export endpoints: %v", err))
+	}
+
+	settings, err := e.storage.ExportPortableConfig(includeKeys)
+	if err != nil {
+		return errorJSON(fmt.Sprintf("Failed to export settings: %v", err))
+	}
+
+	exportData := FullConfigExport{
+		Version:     fullConfigVersion,
+		ExportTime:  time.Now().Format(time.RFC3339),
+		IncludeKeys: includeKeys,
+		Endpoints:   endpoints.Endpoints,
+		Settings:    settings,
+	}
+
+	jsonData, err := json.MarshalIndent(exportData, "", "  ")
+	if err != nil {
+		return errorJSON(fmt.Sprintf("Failed to export: %v", err))
+	}
+
+	logger.Info("Exported full config: %d endpoints, %d settings (includeKeys=%v)", len(exportData.Endpoints), len(settings), includeKeys)
+	return string(jsonData)
+}
+
+// ImportFullConfig imports a document produced by ExportFullConfig and reloads the running config
+// mode 同 ImportEndpoints 的端点冲突处理："skip"、"overwrite"、"rename"；
+// 设置项仅在 "overwrite" 时覆盖本地值，其他模式只补充本地没有的设置项
+func (e *EndpointService) ImportFullConfig(jsonData string, mode string) string {
+	if e.storage == nil {
+		return toJSON(FullConfigImportResult{Success: false, Message: "Storage not available"})
+	}
+
+	var importData FullConfigExport
+	if err := json.Unmarshal([]byte(jsonData), &importData); err != nil {
+		return toJSON(FullConfigImportResult{Success: false, Message: fmt.Sprintf("Invalid JSON format: %v", err)})
+	}
+	if importData.Version == "" || importData.Settings == nil {
+		return toJSON(FullConfigImportResult{Success: false, Message: "Not a full config export"})
+	}
+	if importData.Version != fullConfigVersion {
+		return toJSON(FullConfigImportResult{Success: false, Message: fmt.Sprintf("Unsupported config version: %s", importData.Version)})
+	}
+
+	result := FullConfigImportResult{}
+
+	// 端点：复用端点导入的校验和冲突处理
+	if len(importData.Endpoints) > 0 {
+		endpointData, _ := json.Marshal(ExportData{
+			Version:     importData.Version,
+			ExportTime:  importData.ExportTime,
+			Endpoints:   importData.Endpoints,
+			IncludeKeys: importData.IncludeKeys,
+		})
+		var endpointResult ImportResult
+		if err := json.Unmarshal([]byte(e.ImportEndpoints(string(endpointData), mode, false)), &endpointResult); err != nil {
+			return toJSON(FullConfigImportResult{Success: false, Message: fmt.Sprintf("Failed to import endpoints: %v", err)})
+		}
+		result.Imported = endpointResult.Imported
+		result.Skipped = endpointResult.Skipped
+		result.Errors = append(result.Errors, endpointResult.Errors...)
+	}
+
+	// 设置：只接受可迁移的配置项
+	settings := make(map[string]string, len(importData.Settings))
+	for key, value := range importData.Settings {
+		if !storage.IsPortableConfigKey(key) {
+			result.SettingsIgnored++
+			continue
+		}
+		settings[key] = value
+	}
+	strategy := storage.MergeStrategyKeepLocal
+	if mode == "overwrite" {
+		strategy = storage.MergeStrategyOverwriteLocal
+	}
+	written, err := e.storage.ImportPortableConfig(settings, strategy)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to import settings: %v", err))
+	}
+	result.SettingsImported = written
+
+	// 从存储重新加载配置，使导入的设置立即生效
+	if written > 0 {
+		newConfig, err := config.LoadFromStorage(storage.NewConfigStorageAdapter(e.storage))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to reload config: %v", err))
+		} else {
+			e.config.CopyFrom(newConfig)
+			if e.proxy != nil {
+				if err := e.proxy.UpdateConfig(newConfig); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("Failed to apply config: %v", err))
+				}
+			}
+		}
+	}
+
+	result.Success = result.Imported > 0 || result.SettingsImported > 0 || len(result.Errors) == 0
+	result.Message = fmt.Sprintf("Imported %d endpoints (skipped %d) and %d settings", result.Imported, result.Skipped, result.SettingsImported)
+	if !result.Success {
+		result.Message = "Import failed"
+	}
+
+	logger.Info("Full config import completed: %d endpoints, %d settings, %d ignored settings, %d errors",
+		result.Imported, result.SettingsImported, result.SettingsIgnored, len(result.Errors))
+	return toJSON(result)
+}
@@ -66,19 +66,19 @@ type TransformerCost struct {
 
 // GetCostDaily 获取今日成本统计
 func (s *CostService) GetCostDaily() string {
-	today := time.Now().Format("2006-01-02")
+	today := s.config.StatsNow().Format("2006-01-02")
 	return s.getCostByDateRange(today, today, "daily")
 }
 
 // GetCostYesterday 获取昨日成本统计
 func (s *CostService) GetCostYesterday() string {
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	yesterday := s.config.StatsNow().AddDate(0, 0, -1).Format("2006-01-02")
 	return s.getCostByDateRange(yesterday, yesterday, "yesterday")
 }
 
 // GetCostWeekly 获取本周成本统计
 func (s *CostService) GetCostWeekly() string {
-	now := time.Now()
+	now := s.config.StatsNow()
 	weekday := int(now.Weekday())
 	if weekday == 0 {
 		weekday = 7
@@ -89,7 +89,7 @@ func (s *CostService) GetCostWeekly() string {
 
 // GetCostMonthly 获取本月成本统计
 func (s *CostService) GetCostMonthly() string {
-	now := time.Now()
+	now := s.config.StatsNow()
 	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
 	return s.getCostByDateRange(startDate, now.Format("2006-01-02"), "monthly")
 }
@@ -113,9 +113,9 @@ func (s *CostService) getCostByDateRange(startDate, endDate, period string) stri
 	// 获取统计数据
 	var stats map[string]*proxy.DailyStats
 	if startDate == endDate {
-		stats = s.proxy.GetStats().GetDailyStats(startDate)
+		stats = s.proxy.GetStats().GetDailyStats(startDate, false)
 	} else {
-		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate)
+		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate, false)
 	}
 
 	// 获取端点配置以确定转换器类型
@@ -240,7 +240,7 @@ func (s *CostService) getCostByDateRange(startDate, endDate, period string) stri
 
 // GetCostTrend 获取成本趋势对比
 func (s *CostService) GetCostTrend(period string) string {
-	now := time.Now()
+	now := s.config.StatsNow()
 	var currentStart, currentEnd, prevStart, prevEnd string
 
 	switch period {
@@ -301,9 +301,9 @@ func (s *CostService) GetCostTrend(period string) string {
 func (s *CostService) calculateTotalCost(startDate, endDate string) float64 {
 	var stats map[string]*proxy.DailyStats
 	if startDate == endDate {
-		stats = s.proxy.GetStats().GetDailyStats(startDate)
+		stats = s.proxy.GetStats().GetDailyStats(startDate, false)
 	} else {
-		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate)
+		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate, false)
 	}
 
 	endpoints := s.config.GetEndpoints()
@@ -6,11 +6,14 @@ import (
     "fmt"
     "io"
     "net/http"
+    "sort"
     "strings"
     "sync"
+    "sync/atomic"
     "time"
 
     "github.com/lich0821/ccNexus/internal/config"
+    "github.com/lich0821/ccNexus/internal/interaction"
     "github.com/lich0821/ccNexus/internal/logger"
     "github.com/lich0821/ccNexus/internal/proxy"
     "github.com/lich0821/ccNexus/internal/storage"
@@ -61,21 +64,23 @@ func (e *EndpointService) getHTTPClient(timeout time.Duration) *http.Client {
 
 // createHTTPClient creates an HTTP client with optional proxy support
 func (e *EndpointService) createHTTPClient(timeout time.Duration) *http.Client {
-    client := &http.Client{Timeout: timeout}
-    if proxyCfg := e.config.GetProxy(); proxyCfg != nil && proxyCfg.URL != "" {
-        if transport, err := proxy.CreateProxyTransport(proxyCfg.URL); err == nil {
-            client.Transport = transport
-        }
+    proxyURL := ""
+    if proxyCfg := e.config.GetProxy(); proxyCfg != nil {
+        proxyURL = proxyCfg.URL
     }
-    return client
+    return proxy.NewProxyHTTPClient(proxyURL, timeout)
 }
 
-// Test endpoint constants
+// Test endpoint constants (defaults when TestEndpoint is called without a custom prompt / max_tokens)
 const (
     testMessage   = "你是什么模型?"
     testMaxTokens = 16
 )
 
+// testSystemPrompt Gemini 测试请求携带的 systemInstruction，
+// Claude Code 请求总是带 system，经代理转换后会以 systemInstruction 发给 Gemini，测试时一并验证端点支持该字段
+const testSystemPrompt = "You are a helpful assistant. Answer briefly."
+
 // httpClientCache holds cached HTTP clients by timeout duration
 type httpClientCache struct {
     clients  map[time.Duration]*http.Client
@@ -85,10 +90,12 @@ type httpClientCache struct {
 
 // EndpointService handles endpoint management operations
 type EndpointService struct {
-    config      *config.Config
-    proxy       *proxy.Proxy
-    storage     *storage.SQLiteStorage
-    clientCache *httpClientCache
+    config       *config.Config
+    proxy        *proxy.Proxy
+    storage      *storage.SQLiteStorage
+    clientCache  *httpClientCache
+    deviceID     string               // 写入配置审计记录的设备 ID
+    interactions *interaction.Storage // 请求重放时查找归档的原始请求
 }
 
 // NewEndpointService creates a new EndpointService
@@ -104,49 +111,232 @@ func NewEndpointService(cfg *config.Config, p *proxy.Proxy, s *storage.SQLiteSto
 }
 
 // normalizeAPIUrl ensures the API URL has the correct format
+// 只去掉首尾空白和尾部斜杠，保留用户输入的协议和路径前缀
 func normalizeAPIUrl(apiUrl string) string {
-    return strings.TrimSuffix(apiUrl, "/")
+    return strings.TrimRight(strings.TrimSpace(apiUrl), "/")
+}
+
+// parseEndpointModels parses and validates the multi-model config JSON sent by the UI
+func parseEndpointModels(models string) ([]config.EndpointModel, error) {
+    models = strings.TrimSpace(models)
+    if models == "" {
+        return nil, nil
+    }
+
+    var parsed []config.EndpointModel
+    if err := json.Unmarshal([]byte(models), &parsed); err != nil {
+        return nil, fmt.Errorf("invalid models config: %w", err)
+    }
+
+    result := make([]config.EndpointModel, 0, len(parsed))
+    seen := make(map[string]bool)
+    for _, m := range parsed {
+        m.Name = strings.TrimSpace(m.Name)
+        if m.Name == "" {
+            continue
+        }
+        if seen[m.Name] {
+            return nil, fmt.Errorf("duplicate model '%s' in models config", m.Name)
+        }
+        if m.CostPerInputToken < 0 || m.CostPerOutputToken < 0 || m.QuotaLimit < 0 {
+            return nil, fmt.Errorf("model '%s': cost and quota must not be negative", m.Name)
+        }
+        seen[m.Name] = true
+        result = append(result, m)
+    }
+    if len(result) == 0 {
+        return nil, nil
+    }
+    return result, nil
+}
+
+// parseModelRewrite parses and validates the model rewrite rules JSON sent by the UI
+func parseModelRewrite(rules string) (map[string]string, error) {
+    rules = strings.TrimSpace(rules)
+    if rules == "" {
+        return nil, nil
+    }
+
+    var parsed map[string]string
+    if err := json.Unmarshal([]byte(rules), &parsed); err != nil {
+        return nil, fmt.Errorf("invalid model rewrite config: %w", err)
+    }
+
+    result := make(map[string]string, len(parsed))
+    for from, to := range parsed {
+        from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+        if from == "" {
+            continue
+        }
+        if to == "" {
+            return nil, fmt.Errorf("model rewrite rule '%s': target model must not be empty", from)
+        }
+        result[from] = to
+    }
+    if len(result) == 0 {
+        return nil, nil
+    }
+    return result, nil
+}
+
+// parseAPIKeys parses the extra API keys input (comma or newline separated)
+func parseAPIKeys(input string) []string {
+    input = strings.NewReplacer("\r\n", ",", "\n", ",").Replace(input)
+    return config.ParseAPIKeys(input)
+}
+
+// EndpointInput 端点新增/编辑表单提交的字段
+type EndpointInput struct {
+    Name                  string  `json:"name"`
+    APIUrl                string  `json:"apiUrl"`
+    APIKey                string  `json:"apiKey"`
+    Transformer           string  `json:"transformer"`
+    Model                 string  `json:"model"`
+    Remark                string  `json:"remark"`
+    Tags                  string  `json:"tags"`
+    ModelPatterns         string  `json:"modelPatterns"`
+    CostPerInputToken     float64 `json:"costPerInputToken"`
+    CostPerOutputToken    float64 `json:"costPerOutputToken"`
+    CostPerCacheReadToken float64 `json:"costPerCacheReadToken"`
+    QuotaLimit            int64   `json:"quotaLimit"`
+    QuotaResetCycle       string  `json:"quotaResetCycle"`
+    QuotaMode             string  `json:"quotaMode"`
+    Priority              int     `json:"priority"`
+    UserAgent             string  `json:"userAgent"`
+    SLAP95Ms              int     `json:"slaP95Ms"`
+    Weight                int     `json:"weight"`
+    Models                string  `json:"models"` // JSON 数组文本
+    Group                 string  `json:"group"`
+    HeaderMode            string  `json:"headerMode"`
+    HeaderWhitelist       string  `json:"headerWhitelist"`
+    HealthFields          string  `json:"healthFields"`
+    HealthErrorWords      string  `json:"healthErrorWords"`
+    HealthCheckPath       string  `json:"healthCheckPath"`
+    HealthCheckMethod     string  `json:"healthCheckMethod"`
+    ActiveHours           string  `json:"activeHours"`
+    KeyExpiryDate         string  `json:"keyExpiryDate"`
+    RefreshToken          string  `json:"refreshToken"`
+    TokenExpiry           int64   `json:"tokenExpiry"`
+    APIKeys               string  `json:"apiKeys"` // 逗号或换行分隔
+    HideThinking          bool    `json:"hideThinking"`
+    DisableStreamUsage    bool    `json:"disableStreamUsage"`
+    PinStatus             bool    `json:"pinStatus"`
+    MaxConcurrency        int     `json:"maxConcurrency"`
+    TimeoutSeconds        int     `json:"timeoutSeconds"`
+    ModelRewrite          string  `json:"modelRewrite"` // JSON 对象文本
+}
+
+// buildEndpoint validates and normalizes the input into an endpoint (Status/Enabled are left to the caller)
+func buildEndpoint(clientType string, input EndpointInput) (config.Endpoint, error) {
+    // 默认优先级
+    if input.Priority <= 0 {
+        input.Priority = 100
+    }
+
+    // 默认权重
+    if input.Weight <= 0 {
+        input.Weight = 1
+    }
+
+    endpointModels, err := parseEndpointModels(input.Models)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    modelRewriteRules, err := parseModelRewrite(input.ModelRewrite)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    quotaMode, err := normalizeQuotaMode(input.QuotaMode)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    headerMode, err := normalizeHeaderMode(input.HeaderMode)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    healthCheckPath, healthCheckMethod, err := normalizeHealthCheckProbe(input.HealthCheckPath, input.HealthCheckMethod)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    activeHours, err := normalizeActiveHours(input.ActiveHours)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    keyExpiryDate, err := normalizeKeyExpiryDate(input.KeyExpiryDate)
+    if err != nil {
+        return config.Endpoint{}, err
+    }
+
+    return config.Endpoint{
+        Name:                  input.Name,
+        ClientType:            clientType,
+        APIUrl:                normalizeAPIUrl(input.APIUrl),
+        APIKey:                input.APIKey,
+        Transformer:           normalizeTransformer(input.Transformer),
+        Model:                 input.Model,
+        Remark:                input.Remark,
+        Tags:                  input.Tags,
+        ModelPatterns:         input.ModelPatterns,
+        CostPerInputToken:     input.CostPerInputToken,
+        CostPerOutputToken:    input.CostPerOutputToken,
+        CostPerCacheReadToken: input.CostPerCacheReadToken,
+        QuotaLimit:            input.QuotaLimit,
+        QuotaResetCycle:       input.QuotaResetCycle,
+        QuotaMode:             quotaMode,
+        Priority:              input.Priority,
+        UserAgent:             strings.TrimSpace(input.UserAgent),
+        SLAP95Ms:              input.SLAP95Ms,
+        Weight:                input.Weight,
+        Group:                 strings.TrimSpace(input.Group),
+        HeaderMode:            headerMode,
+        HeaderWhitelist:       strings.TrimSpace(input.HeaderWhitelist),
+        HealthFields:          strings.TrimSpace(input.HealthFields),
+        HealthErrorWords:      strings.TrimSpace(input.HealthErrorWords),
+        HideThinking:          input.HideThinking,
+        DisableStreamUsage:    input.DisableStreamUsage,
+        PinStatus:             input.PinStatus,
+        HealthCheckPath:       healthCheckPath,
+        HealthCheckMethod:     healthCheckMethod,
+        ActiveHours:           activeHours,
+        KeyExpiryDate:         keyExpiryDate,
+        MaxConcurrency:        input.MaxConcurrency,
+        TimeoutSeconds:        input.TimeoutSeconds,
+        RefreshToken:          strings.TrimSpace(input.RefreshToken),
+        TokenExpiry:           input.TokenExpiry,
+        APIKeys:               parseAPIKeys(input.APIKeys),
+        Models:                endpointModels,
+        ModelRewrite:          modelRewriteRules,
+    }, nil
 }
 
 // AddEndpoint adds a new endpoint for a specific client type
-func (e *EndpointService) AddEndpoint(clientType, name, apiUrl, apiKey, transformer, model, remark, tags string,
-    modelPatterns string, costPerInputToken, costPerOutputToken float64, quotaLimit int64, quotaResetCycle string, priority int) error {
+func (e *EndpointService) AddEndpoint(clientType string, input EndpointInput) error {
+    return e.addEndpoint(ConfigAuditSourceUI, clientType, input)
+}
+
+// addEndpoint adds a new endpoint and records the change with the given audit source
+func (e *EndpointService) addEndpoint(source, clientType string, input EndpointInput) error {
     clientType = normalizeClientType(clientType)
 
     endpoints := e.config.GetEndpointsByClient(clientType)
     for _, ep := range endpoints {
-        if ep.Name == name {
-            return fmt.Errorf("endpoint name '%s' already exists for client type '%s'", name, clientType)
+        if ep.Name == input.Name {
+            return fmt.Errorf("endpoint name '%s' already exists for client type '%s'", input.Name, clientType)
         }
     }
 
-    transformer = normalizeTransformer(transformer)
-
-    apiUrl = normalizeAPIUrl(apiUrl)
-
-    // 默认优先级
-    if priority <= 0 {
-        priority = 100
-    }
-
-    newEndpoint := config.Endpoint{
-        Name:               name,
-        ClientType:         clientType,
-        APIUrl:             apiUrl,
-        APIKey:             apiKey,
-        Status:             config.EndpointStatusUntested, // 新端点默认为未检测状态
-        Enabled:            true,
-        Transformer:        transformer,
-        Model:              model,
-        Remark:             remark,
-        Tags:               tags,
-        ModelPatterns:      modelPatterns,
-        CostPerInputToken:  costPerInputToken,
-        CostPerOutputToken: costPerOutputToken,
-        QuotaLimit:         quotaLimit,
-        QuotaResetCycle:    quotaResetCycle,
-        Priority:           priority,
+    newEndpoint, err := buildEndpoint(clientType, input)
+    if err != nil {
+        return err
     }
+    newEndpoint.Status = config.EndpointStatusUntested // 新端点默认为未检测状态
+    newEndpoint.Enabled = true
 
     // Get all endpoints and add the new one
     allEndpoints := e.config.GetEndpoints()
@@ -168,12 +358,96 @@ func (e *EndpointService) AddEndpoint(clientType, name, apiUrl, apiKey, transfor
         }
     }
 
-    if model != "" {
-        logger.Info("Endpoint added: %s (%s) [%s/%s] for client %s", name, apiUrl, transformer, model, clientType)
+    e.recordConfigAudit(ConfigAuditActionAdd, source, clientType, nil, &newEndpoint)
+
+    if newEndpoint.Model != "" {
+        logger.Info("Endpoint added: %s (%s) [%s/%s] for client %s", newEndpoint.Name, newEndpoint.APIUrl, newEndpoint.Transformer, newEndpoint.Model, clientType)
     } else {
-        logger.Info("Endpoint added: %s (%s) [%s] for client %s", name, apiUrl, transformer, clientType)
+        logger.Info("Endpoint added: %s (%s) [%s] for client %s", newEndpoint.Name, newEndpoint.APIUrl, newEndpoint.Transformer, clientType)
+    }
+
+    return nil
+}
+
+// CloneEndpoint copies all settings of an endpoint (except its name) into a new endpoint for the same client type.
+// newName 为空时自动生成不冲突的名称（<原名>_copy、<原名>_copy_2 ...）；指定的名称已存在时返回错误。
+// 新端点默认禁用，等待用户修改 API Key 后再启用
+func (e *EndpointService) CloneEndpoint(clientType string, index int, newName string) error {
+    clientType = normalizeClientType(clientType)
+
+    endpoints := e.config.GetEndpointsByClient(clientType)
+
+    if index < 0 || index >= len(endpoints) {
+        return fmt.Errorf("invalid endpoint index: %d", index)
+    }
+
+    source := endpoints[index]
+
+    nameTaken := func(name string) bool {
+        for _, ep := range endpoints {
+            if ep.Name == name {
+                return true
+            }
+        }
+        return false
+    }
+
+    newName = strings.TrimSpace(newName)
+    if newName == "" {
+        for suffix := 1; suffix <= 100; suffix++ {
+            candidate := source.Name + "_copy"
+            if suffix > 1 {
+                candidate = fmt.Sprintf("%s_copy_%d", source.Name, suffix)
+            }
+            if !nameTaken(candidate) {
+                newName = candidate
+                break
+            }
+        }
+        if newName == "" {
+            return fmt.Errorf("could not find unique name for a copy of '%s'", source.Name)
+        }
+    } else if nameTaken(newName) {
+        return fmt.Errorf("endpoint name '%s' already exists for client type '%s'", newName, clientType)
+    }
+
+    // 切片和 map 单独复制，避免与原端点共享
+    clone := source
+    clone.Name = newName
+    clone.ClientType = clientType
+    clone.Status = config.EndpointStatusDisabled
+    clone.Enabled = false
+    clone.APIKeys = append([]string(nil), source.APIKeys...)
+    clone.Models = append([]config.EndpointModel(nil), source.Models...)
+    if source.ModelRewrite != nil {
+        clone.ModelRewrite = make(map[string]string, len(source.ModelRewrite))
+        for from, to := range source.ModelRewrite {
+            clone.ModelRewrite[from] = to
+        }
+    }
+
+    allEndpoints := e.config.GetEndpoints()
+    allEndpoints = append(allEndpoints, clone)
+    e.config.UpdateEndpoints(allEndpoints)
+
+    if err := e.config.Validate(); err != nil {
+        return err
+    }
+
+    if err := e.proxy.UpdateConfig(e.config); err != nil {
+        return err
     }
 
+    if e.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(e.storage)
+        if err := e.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save config: %w", err)
+        }
+    }
+
+    e.recordConfigAudit(ConfigAuditActionAdd, ConfigAuditSourceUI, clientType, nil, &clone)
+
+    logger.Info("Endpoint cloned: %s -> %s (client: %s), disabled until enabled manually", source.Name, newName, clientType)
     return nil
 }
 
@@ -187,7 +461,8 @@ func (e *EndpointService) RemoveEndpoint(clientType string, index int) error {
         return fmt.Errorf("invalid endpoint index: %d", index)
     }
 
-    removedName := endpoints[index].Name
+    removed := endpoints[index]
+    removedName := removed.Name
 
     // Remove from all endpoints
     allEndpoints := e.config.GetEndpoints()
@@ -216,13 +491,19 @@ func (e *EndpointService) RemoveEndpoint(clientType string, index int) error {
         }
     }
 
+    e.recordConfigAudit(ConfigAuditActionRemove, ConfigAuditSourceUI, clientType, &removed, nil)
+
     logger.Info("Endpoint removed: %s (client: %s)", removedName, clientType)
     return nil
 }
 
 // UpdateEndpoint updates an endpoint by index for a specific client type
-func (e *EndpointService) UpdateEndpoint(clientType string, index int, name, apiUrl, apiKey, transformer, model, remark, tags string,
-    modelPatterns string, costPerInputToken, costPerOutputToken float64, quotaLimit int64, quotaResetCycle string, priority int) error {
+func (e *EndpointService) UpdateEndpoint(clientType string, index int, input EndpointInput) error {
+    return e.updateEndpoint(ConfigAuditSourceUI, clientType, index, input)
+}
+
+// updateEndpoint updates an endpoint by index and records the change with the given audit source
+func (e *EndpointService) updateEndpoint(source, clientType string, index int, input EndpointInput) error {
     clientType = normalizeClientType(clientType)
 
     endpoints := e.config.GetEndpointsByClient(clientType)
@@ -231,7 +512,9 @@ func (e *EndpointService) UpdateEndpoint(clientType string, index int, name, api
         return fmt.Errorf("invalid endpoint index: %d", index)
     }
 
-    oldName := endpoints[index].Name
+    previous := endpoints[index]
+    oldName := previous.Name
+    name := input.Name
 
     if oldName != name {
         for i, ep := range endpoints {
@@ -241,34 +524,12 @@ func (e *EndpointService) UpdateEndpoint(clientType string, index int, name, api
         }
     }
 
-    enabled := endpoints[index].Enabled
-
-    transformer = normalizeTransformer(transformer)
-
-    apiUrl = normalizeAPIUrl(apiUrl)
-
-    // 默认优先级
-    if priority <= 0 {
-        priority = 100
-    }
-
-    updatedEndpoint := config.Endpoint{
-        Name:               name,
-        ClientType:         clientType,
-        APIUrl:             apiUrl,
-        APIKey:             apiKey,
-        Enabled:            enabled,
-        Transformer:        transformer,
-        Model:              model,
-        Remark:             remark,
-        Tags:               tags,
-        ModelPatterns:      modelPatterns,
-        CostPerInputToken:  costPerInputToken,
-        CostPerOutputToken: costPerOutputToken,
-        QuotaLimit:         quotaLimit,
-        QuotaResetCycle:    quotaResetCycle,
-        Priority:           priority,
+    updatedEndpoint, err := buildEndpoint(clientType, input)
+    if err != nil {
+        return err
     }
+    updatedEndpoint.Enabled = endpoints[index].Enabled
+    apiUrl, transformer, model := updatedEndpoint.APIUrl, updatedEndpoint.Transformer, updatedEndpoint.Model
 
     // Update in all endpoints
     allEndpoints := e.config.GetEndpoints()
@@ -295,6 +556,8 @@ func (e *EndpointService) UpdateEndpoint(clientType string, index int, name, api
         }
     }
 
+    e.recordConfigAudit(ConfigAuditActionUpdate, source, clientType, &previous, &updatedEndpoint)
+
     if oldName != name {
         if model != "" {
             logger.Info("Endpoint updated: %s → %s (%s) [%s/%s] for client %s", oldName, name, apiUrl, transformer, model, clientType)
@@ -362,6 +625,100 @@ func (e *EndpointService) ToggleEndpoint(clientType string, index int, enabled b
     return nil
 }
 
+// ToggleEndpointsByTag enables or disables all endpoints of a client type that carry the given tag,
+// saving the configuration once. Returns the number of endpoints whose state changed
+// 启用时与 ToggleEndpoint 一致设置为 untested；已处于目标状态的端点不计入
+func (e *EndpointService) ToggleEndpointsByTag(clientType, tag string, enabled bool) (int, error) {
+    clientType = normalizeClientType(clientType)
+    tag = strings.TrimSpace(tag)
+    if tag == "" {
+        return 0, fmt.Errorf("tag cannot be empty")
+    }
+
+    affected := 0
+    for _, ep := range e.config.GetEndpointsByClient(clientType) {
+        if !ep.HasTag(tag) || ep.IsEnabled() == enabled {
+            continue
+        }
+
+        newStatus := config.EndpointStatusDisabled
+        if enabled {
+            newStatus = config.EndpointStatusUntested
+        }
+        if err := e.config.SetEndpointStatus(ep.Name, clientType, newStatus); err != nil {
+            return affected, err
+        }
+        affected++
+    }
+
+    if affected == 0 {
+        return 0, nil
+    }
+
+    // 更新代理配置
+    if err := e.proxy.UpdateConfig(e.config); err != nil {
+        return affected, err
+    }
+
+    // 保存到数据库
+    if e.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(e.storage)
+        if err := e.config.SaveToStorage(configAdapter); err != nil {
+            return affected, fmt.Errorf("failed to save config: %w", err)
+        }
+    }
+
+    if enabled {
+        logger.Info("Enabled %d endpoint(s) with tag %q (client: %s)", affected, tag, clientType)
+    } else {
+        logger.Info("Disabled %d endpoint(s) with tag %q (client: %s)", affected, tag, clientType)
+    }
+
+    return affected, nil
+}
+
+// SetEndpointMaintenance puts an endpoint into or out of maintenance mode
+// 维护中的端点不参与路由，但健康检查和统计照常进行；退出维护后恢复为 available
+func (e *EndpointService) SetEndpointMaintenance(clientType string, index int, maintenance bool) error {
+    clientType = normalizeClientType(clientType)
+
+    endpoints := e.config.GetEndpointsByClient(clientType)
+
+    if index < 0 || index >= len(endpoints) {
+        return fmt.Errorf("invalid endpoint index: %d", index)
+    }
+
+    endpointName := endpoints[index].Name
+    if !maintenance && endpoints[index].Status != config.EndpointStatusMaintenance {
+        return fmt.Errorf("endpoint %s is not in maintenance", endpointName)
+    }
+
+    if err := e.config.SetEndpointMaintenance(endpointName, clientType, maintenance); err != nil {
+        return err
+    }
+
+    // 更新代理配置
+    if err := e.proxy.UpdateConfig(e.config); err != nil {
+        return err
+    }
+
+    // 保存到数据库
+    if e.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(e.storage)
+        if err := e.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save config: %w", err)
+        }
+    }
+
+    if maintenance {
+        logger.Info("Endpoint entered maintenance: %s (client: %s)", endpointName, clientType)
+    } else {
+        logger.Info("Endpoint left maintenance: %s (client: %s), status set to available", endpointName, clientType)
+    }
+
+    return nil
+}
+
 // ReorderEndpoints reorders endpoints based on the provided name array for a specific client type
 func (e *EndpointService) ReorderEndpoints(clientType string, names []string) error {
     clientType = normalizeClientType(clientType)
@@ -424,6 +781,41 @@ func (e *EndpointService) ReorderEndpoints(clientType string, names []string) er
     return nil
 }
 
+// AutoSortEndpointsByLatency reorders endpoints of a client type from fastest to slowest
+// based on the health check latencies recorded in the Monitor, and persists the new sort_order.
+// Endpoints without latency data keep their relative order and are moved to the end.
+func (e *EndpointService) AutoSortEndpointsByLatency(clientType string) error {
+    clientType = normalizeClientType(clientType)
+
+    if e.proxy == nil {
+        return fmt.Errorf("proxy not initialized")
+    }
+
+    endpoints := e.config.GetEndpointsByClient(clientType)
+    if len(endpoints) == 0 {
+        return fmt.Errorf("no endpoints for client type '%s'", clientType)
+    }
+
+    latencies := e.proxy.GetMonitor().GetHealthCheckLatencies()
+    sorted := make([]config.Endpoint, len(endpoints))
+    copy(sorted, endpoints)
+    sort.SliceStable(sorted, func(i, j int) bool {
+        li, iok := latencies[sorted[i].Name]
+        lj, jok := latencies[sorted[j].Name]
+        if iok != jok {
+            return iok
+        }
+        return iok && li < lj
+    })
+
+    names := make([]string, len(sorted))
+    for i, ep := range sorted {
+        names[i] = ep.Name
+    }
+
+    return e.ReorderEndpoints(clientType, names)
+}
+
 // GetCurrentEndpoint returns the current active endpoint name for a specific client type
 func (e *EndpointService) GetCurrentEndpoint(clientType string) string {
     if e.proxy == nil {
@@ -442,9 +834,55 @@ func (e *EndpointService) SwitchToEndpoint(clientType, endpointName string) erro
     return e.proxy.SetCurrentEndpointForClient(clientType, endpointName)
 }
 
+// PinEndpoint pins an endpoint for durationMinutes, all requests of the client type use it until the pin expires
+func (e *EndpointService) PinEndpoint(clientType, endpointName string, durationMinutes int) error {
+    if e.proxy == nil {
+        return fmt.Errorf("proxy not initialized")
+    }
+    if durationMinutes <= 0 {
+        return fmt.Errorf("pin duration must be positive")
+    }
+    clientType = normalizeClientType(clientType)
+
+    endpoint := e.config.GetEndpointByName(endpointName, clientType)
+    if endpoint == nil {
+        return fmt.Errorf("endpoint '%s' not found for client type: %s", endpointName, clientType)
+    }
+    if endpoint.Status == config.EndpointStatusDisabled {
+        return fmt.Errorf("endpoint '%s' is disabled", endpointName)
+    }
+
+    e.proxy.PinEndpoint(clientType, endpointName, time.Duration(durationMinutes)*time.Minute)
+    return nil
+}
+
+// UnpinEndpoint removes the endpoint pin of the client type
+func (e *EndpointService) UnpinEndpoint(clientType string) error {
+    if e.proxy == nil {
+        return fmt.Errorf("proxy not initialized")
+    }
+    e.proxy.UnpinEndpoint(normalizeClientType(clientType))
+    return nil
+}
+
+// GetPinnedEndpoints returns all active endpoint pins as JSON
+func (e *EndpointService) GetPinnedEndpoints() string {
+    if e.proxy == nil {
+        return toJSON(map[string]interface{}{"pins": []interface{}{}})
+    }
+    return toJSON(map[string]interface{}{"pins": e.proxy.GetPinnedEndpoints()})
+}
+
 // TestEndpoint tests an endpoint by sending a simple request for a specific client type
-func (e *EndpointService) TestEndpoint(clientType string, index int) string {
+// prompt and maxTokens are optional, empty/0 falls back to testMessage and testMaxTokens
+func (e *EndpointService) TestEndpoint(clientType string, index int, prompt string, maxTokens int) string {
     clientType = normalizeClientType(clientType)
+    if strings.TrimSpace(prompt) == "" {
+        prompt = testMessage
+    }
+    if maxTokens <= 0 {
+        maxTokens = testMaxTokens
+    }
 
     endpoints := e.config.GetEndpointsByClient(clientType)
 
@@ -471,9 +909,9 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
         }
         requestBody, err = json.Marshal(map[string]interface{}{
             "model":      model,
-            "max_tokens": testMaxTokens,
+            "max_tokens": maxTokens,
             "messages": []map[string]string{
-                {"role": "user", "content": testMessage},
+                {"role": "user", "content": prompt},
             },
         })
 
@@ -485,9 +923,9 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
         }
         requestBody, err = json.Marshal(map[string]interface{}{
             "model":      model,
-            "max_tokens": testMaxTokens,
+            "max_tokens": maxTokens,
             "messages": []map[string]interface{}{
-                {"role": "user", "content": testMessage},
+                {"role": "user", "content": prompt},
             },
         })
 
@@ -498,13 +936,14 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
             model = "gpt-5-codex"
         }
         requestBody, err = json.Marshal(map[string]interface{}{
-            "model": model,
+            "model":             model,
+            "max_output_tokens": maxTokens,
             "input": []map[string]interface{}{
                 {
                     "type": "message",
                     "role": "user",
                     "content": []map[string]interface{}{
-                        {"type": "input_text", "text": testMessage},
+                        {"type": "input_text", "text": prompt},
                     },
                 },
             },
@@ -518,15 +957,21 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
         }
         apiPath = fmt.Sprintf("/v1beta/models/%s:generateContent", model)
         requestBody, err = json.Marshal(map[string]interface{}{
+            "systemInstruction": map[string]interface{}{
+                "parts": []map[string]string{
+                    {"text": testSystemPrompt},
+                },
+            },
             "contents": []map[string]interface{}{
                 {
+                    "role": "user",
                     "parts": []map[string]string{
-                        {"text": testMessage},
+                        {"text": prompt},
                     },
                 },
             },
             "generationConfig": map[string]int{
-                "maxOutputTokens": testMaxTokens,
+                "maxOutputTokens": maxTokens,
             },
         })
 
@@ -542,9 +987,9 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
     normalizedURL := normalizeAPIUrlWithScheme(endpoint.APIUrl)
     var url string
     if transformer == "gemini" {
-        url = fmt.Sprintf("%s%s?key=%s", normalizedURL, apiPath, endpoint.APIKey)
+        url = config.JoinAPIUrl(normalizedURL, fmt.Sprintf("%s?key=%s", apiPath, endpoint.APIKey))
     } else {
-        url = fmt.Sprintf("%s%s", normalizedURL, apiPath)
+        url = config.JoinAPIUrl(normalizedURL, apiPath)
     }
 
     req, err := http.NewRequest("POST", url, bytes.NewReader(requestBody))
@@ -563,20 +1008,24 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
     }
 
     client := e.getHTTPClient(30 * time.Second)
+    start := time.Now()
     resp, err := client.Do(req)
     if err != nil {
         logger.Error("Test failed for %s: %v", endpoint.Name, err)
+        e.recordTestResult(clientType, endpoint.Name, start, false, 0, fmt.Sprintf("Request failed: %v", err))
         return errorJSON(fmt.Sprintf("Request failed: %v", err))
     }
     defer resp.Body.Close()
 
     respBody, err := io.ReadAll(resp.Body)
     if err != nil {
+        e.recordTestResult(clientType, endpoint.Name, start, false, resp.StatusCode, fmt.Sprintf("Failed to read response: %v", err))
         return errorJSON(fmt.Sprintf("Failed to read response: %v", err))
     }
 
     if resp.StatusCode != http.StatusOK {
         logger.Error("Test failed for %s: HTTP %d - %s", endpoint.Name, resp.StatusCode, string(respBody))
+        e.recordTestResult(clientType, endpoint.Name, start, false, resp.StatusCode, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(respBody)))
         return toJSON(map[string]interface{}{
             "success":    false,
             "statusCode": resp.StatusCode,
@@ -587,6 +1036,7 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
     var responseData map[string]interface{}
     if err := json.Unmarshal(respBody, &responseData); err != nil {
         logger.Info("Test successful for %s", endpoint.Name)
+        e.recordTestResult(clientType, endpoint.Name, start, true, resp.StatusCode, string(respBody))
         return successJSON(map[string]interface{}{
             "message": string(respBody),
         })
@@ -653,11 +1103,52 @@ func (e *EndpointService) TestEndpoint(clientType string, index int) string {
     }
 
     logger.Info("Test successful for %s", endpoint.Name)
+    e.recordTestResult(clientType, endpoint.Name, start, true, resp.StatusCode, message)
     return successJSON(map[string]interface{}{
         "message": message,
     })
 }
 
+// testResultMaxMessage 缓存的测试消息最大长度，避免保存完整的错误响应体
+const testResultMaxMessage = 2000
+
+// recordTestResult 缓存端点最近一次测试的结果，供 GetLastTestResult 查询
+func (e *EndpointService) recordTestResult(clientType, endpointName string, start time.Time, success bool, statusCode int, message string) {
+    if e.proxy == nil {
+        return
+    }
+    if len(message) > testResultMaxMessage {
+        message = message[:testResultMaxMessage] + "..."
+    }
+    e.proxy.GetMonitor().RecordTestResult(proxy.EndpointTestResult{
+        EndpointName: endpointName,
+        ClientType:   clientType,
+        TestedAt:     time.Now(),
+        Success:      success,
+        LatencyMs:    float64(time.Since(start).Microseconds()) / 1000,
+        StatusCode:   statusCode,
+        Message:      message,
+    })
+}
+
+// GetLastTestResult returns the cached result of the last TestEndpoint call for an endpoint,
+// result is null when the endpoint has not been tested since startup
+func (e *EndpointService) GetLastTestResult(clientType string, index int) string {
+    clientType = normalizeClientType(clientType)
+    endpoints := e.config.GetEndpointsByClient(clientType)
+    if index < 0 || index >= len(endpoints) {
+        return errorJSON(fmt.Sprintf("Invalid endpoint index: %d", index))
+    }
+
+    var result *proxy.EndpointTestResult
+    if e.proxy != nil {
+        result = e.proxy.GetMonitor().GetTestResult(clientType, endpoints[index].Name)
+    }
+    return successJSON(map[string]interface{}{
+        "result": result,
+    })
+}
+
 // TestEndpointLight tests endpoint availability with minimal token consumption for a specific client type
 func (e *EndpointService) TestEndpointLight(clientType string, index int) string {
     clientType = normalizeClientType(clientType)
@@ -772,116 +1263,15 @@ func (e *EndpointService) TestAllEndpointsZeroCost(clientType string) string {
 }
 
 func (e *EndpointService) testModelsAPI(apiUrl, apiKey, transformer string) (int, error) {
-    var url string
-    if transformer == "gemini" {
-        url = fmt.Sprintf("%s/v1beta/models?key=%s", apiUrl, apiKey)
-    } else {
-        url = fmt.Sprintf("%s/v1/models", apiUrl)
-    }
-
-    req, err := http.NewRequest("GET", url, nil)
-    if err != nil {
-        return 0, err
-    }
-
-    // Set authentication headers based on transformer type
-    switch transformer {
-    case "claude":
-        req.Header.Set("x-api-key", apiKey)
-        req.Header.Set("anthropic-version", "2023-06-01")
-    case "openai", "openai2":
-        req.Header.Set("Authorization", "Bearer "+apiKey)
-    // gemini uses query parameter, already set in URL
-    }
-
-    client := e.getHTTPClient(15 * time.Second)
-    resp, err := client.Do(req)
-    if err != nil {
-        return 0, err
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK {
-        return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
-    }
-
-    body, err := io.ReadAll(resp.Body)
-    if err != nil {
-        return resp.StatusCode, fmt.Errorf("failed to read response")
-    }
-
-    var result map[string]interface{}
-    if err := json.Unmarshal(body, &result); err != nil {
-        return resp.StatusCode, fmt.Errorf("failed to parse response")
-    }
-
-    if data, ok := result["data"].([]interface{}); ok {
-        if len(data) == 0 {
-            return resp.StatusCode, fmt.Errorf("no models found")
-        }
-        return resp.StatusCode, nil
-    }
-
-    if models, ok := result["models"].([]interface{}); ok {
-        if len(models) == 0 {
-            return resp.StatusCode, fmt.Errorf("no models found")
-        }
-        return resp.StatusCode, nil
-    }
-
-    return resp.StatusCode, fmt.Errorf("unexpected response format")
+    return testModelsAPI(e.getHTTPClient(15*time.Second), apiUrl, apiKey, transformer)
 }
 
 func (e *EndpointService) testTokenCountAPI(apiUrl, apiKey string) (int, error) {
-    url := fmt.Sprintf("%s/v1/messages/count_tokens", apiUrl)
-
-    body, _ := json.Marshal(map[string]interface{}{
-        "model": "claude-sonnet-4-5-20250929",
-        "messages": []map[string]string{
-            {"role": "user", "content": "Hi"},
-        },
-    })
-
-    req, err := http.NewRequest("POST", url, bytes.NewReader(body))
-    if err != nil {
-        return 0, err
-    }
-
-    req.Header.Set("Content-Type", "application/json")
-    req.Header.Set("x-api-key", apiKey)
-    req.Header.Set("anthropic-version", "2023-06-01")
-    req.Header.Set("anthropic-beta", "token-counting-2024-11-01")
-
-    client := e.getHTTPClient(15 * time.Second)
-    resp, err := client.Do(req)
-    if err != nil {
-        return 0, err
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != http.StatusOK {
-        return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
-    }
-
-    respBody, err := io.ReadAll(resp.Body)
-    if err != nil {
-        return resp.StatusCode, fmt.Errorf("failed to read response")
-    }
-
-    var result map[string]interface{}
-    if err := json.Unmarshal(respBody, &result); err != nil {
-        return resp.StatusCode, fmt.Errorf("failed to parse response")
-    }
-
-    if _, ok := result["input_tokens"]; !ok {
-        return resp.StatusCode, fmt.Errorf("invalid response: no input_tokens")
-    }
-
-    return resp.StatusCode, nil
+    return testTokenCountAPI(e.getHTTPClient(15*time.Second), apiUrl, apiKey)
 }
 
 func (e *EndpointService) testBillingAPI(apiUrl, apiKey string) (int, error) {
-    url := fmt.Sprintf("%s/v1/dashboard/billing/credit_grants", apiUrl)
+    url := config.JoinAPIUrl(apiUrl, "/v1/dashboard/billing/credit_grants")
 
     req, err := http.NewRequest("GET", url, nil)
     if err != nil {
@@ -920,7 +1310,7 @@ func (e *EndpointService) testMinimalRequest(apiUrl, apiKey, transformer, model
 
     switch transformer {
     case "claude":
-        url = fmt.Sprintf("%s/v1/messages", apiUrl)
+        url = config.JoinAPIUrl(apiUrl, "/v1/messages")
         if model == "" {
             model = "claude-sonnet-4-5-20250929"
         }
@@ -930,7 +1320,7 @@ func (e *EndpointService) testMinimalRequest(apiUrl, apiKey, transformer, model
             "messages":   []map[string]string{{"role": "user", "content": "Hi"}},
         })
     case "openai":
-        url = fmt.Sprintf("%s/v1/chat/completions", apiUrl)
+        url = config.JoinAPIUrl(apiUrl, "/v1/chat/completions")
         if model == "" {
             model = "gpt-4-turbo"
         }
@@ -940,7 +1330,7 @@ func (e *EndpointService) testMinimalRequest(apiUrl, apiKey, transformer, model
             "messages":   []map[string]interface{}{{"role": "user", "content": "Hi"}},
         })
     case "openai2":
-        url = fmt.Sprintf("%s/v1/responses", apiUrl)
+        url = config.JoinAPIUrl(apiUrl, "/v1/responses")
         if model == "" {
             model = "gpt-4-turbo"
         }
@@ -954,7 +1344,7 @@ func (e *EndpointService) testMinimalRequest(apiUrl, apiKey, transformer, model
         if model == "" {
             model = "gemini-2.0-flash"
         }
-        url = fmt.Sprintf("%s/v1beta/models/%s:generateContent?key=%s", apiUrl, model, apiKey)
+        url = config.JoinAPIUrl(apiUrl, fmt.Sprintf("/v1beta/models/%s:generateContent?key=%s", model, apiKey))
         body, _ = json.Marshal(map[string]interface{}{
             "contents":         []map[string]interface{}{{"parts": []map[string]string{{"text": "Hi"}}}},
             "generationConfig": map[string]int{"maxOutputTokens": 1},
@@ -1031,7 +1421,7 @@ func (e *EndpointService) FetchModels(apiUrl, apiKey, transformer string) string
 }
 
 func (e *EndpointService) fetchOpenAIModels(apiUrl, apiKey string) ([]string, error) {
-    url := fmt.Sprintf("%s/v1/models", apiUrl)
+    url := config.JoinAPIUrl(apiUrl, "/v1/models")
 
     req, err := http.NewRequest("GET", url, nil)
     if err != nil {
@@ -1075,7 +1465,7 @@ func (e *EndpointService) fetchOpenAIModels(apiUrl, apiKey string) ([]string, er
 }
 
 func (e *EndpointService) fetchGeminiModels(apiUrl, apiKey string) ([]string, error) {
-    url := fmt.Sprintf("%s/v1beta/models?key=%s", apiUrl, apiKey)
+    url := config.JoinAPIUrl(apiUrl, fmt.Sprintf("/v1beta/models?key=%s", apiKey))
 
     req, err := http.NewRequest("GET", url, nil)
     if err != nil {
@@ -1118,21 +1508,46 @@ func (e *EndpointService) fetchGeminiModels(apiUrl, apiKey string) ([]string, er
 
 // ExportEndpoint represents an endpoint for export (without sensitive data option)
 type ExportEndpoint struct {
-	Name               string  `json:"name"`
-	ClientType         string  `json:"clientType,omitempty"`
-	APIUrl             string  `json:"apiUrl"`
-	APIKey             string  `json:"apiKey,omitempty"`
-	Enabled            bool    `json:"enabled"`
-	Transformer        string  `json:"transformer,omitempty"`
-	Model              string  `json:"model,omitempty"`
-	Remark             string  `json:"remark,omitempty"`
-	Tags               string  `json:"tags,omitempty"`
-	ModelPatterns      string  `json:"modelPatterns,omitempty"`
-	CostPerInputToken  float64 `json:"costPerInputToken,omitempty"`
-	CostPerOutputToken float64 `json:"costPerOutputToken,omitempty"`
-	QuotaLimit         int64   `json:"quotaLimit,omitempty"`
-	QuotaResetCycle    string  `json:"quotaResetCycle,omitempty"`
-	Priority           int     `json:"priority,omitempty"`
+	Name                  string  `json:"name"`
+	ClientType            string  `json:"clientType,omitempty"`
+	APIUrl                string  `json:"apiUrl"`
+	APIKey                string  `json:"apiKey,omitempty"`
+	Enabled               bool    `json:"enabled"`
+	Transformer           string  `json:"transformer,omitempty"`
+	Model                 string  `json:"model,omitempty"`
+	Remark                string  `json:"remark,omitempty"`
+	Tags                  string  `json:"tags,omitempty"`
+	ModelPatterns         string  `json:"modelPatterns,omitempty"`
+	CostPerInputToken     float64 `json:"costPerInputToken,omitempty"`
+	CostPerOutputToken    float64 `json:"costPerOutputToken,omitempty"`
+	CostPerCacheReadToken float64 `json:"costPerCacheReadToken,omitempty"`
+	QuotaLimit            int64   `json:"quotaLimit,omitempty"`
+	QuotaResetCycle       string  `json:"quotaResetCycle,omitempty"`
+	QuotaMode             string  `json:"quotaMode,omitempty"`
+	Priority              int     `json:"priority,omitempty"`
+	UserAgent             string  `json:"userAgent,omitempty"`
+	SLAP95Ms              int     `json:"slaP95Ms,omitempty"`
+	Weight                int     `json:"weight,omitempty"`
+	Group                 string  `json:"group,omitempty"`
+	HeaderMode            string  `json:"headerMode,omitempty"`
+	HeaderWhitelist       string  `json:"headerWhitelist,omitempty"`
+	HealthFields          string  `json:"healthFields,omitempty"`
+	HealthErrorWords      string  `json:"healthErrorWords,omitempty"`
+	HideThinking          bool    `json:"hideThinking,omitempty"`
+	DisableStreamUsage    bool    `json:"disableStreamUsage,omitempty"`
+	PinStatus             bool    `json:"pinStatus,omitempty"`
+	HealthCheckPath       string  `json:"healthCheckPath,omitempty"`
+	HealthCheckMethod     string  `json:"healthCheckMethod,omitempty"`
+	ActiveHours           string  `json:"activeHours,omitempty"`
+	KeyExpiryDate         string  `json:"keyExpiryDate,omitempty"`
+	MaxConcurrency        int     `json:"maxConcurrency,omitempty"`
+	TimeoutSeconds        int     `json:"timeoutSeconds,omitempty"`
+	RefreshToken          string  `json:"refreshToken,omitempty"` // 仅在包含密钥导出时输出
+	TokenExpiry           int64   `json:"tokenExpiry,omitempty"`
+
+	APIKeys      []string               `json:"apiKeys,omitempty"` // 额外的 API key，仅在包含密钥导出时输出
+	Models       []config.EndpointModel `json:"models,omitempty"`
+	ModelRewrite map[string]string      `json:"modelRewrite,omitempty"`
 }
 
 // ExportData represents the exported data structure
@@ -1153,24 +1568,48 @@ func (e *EndpointService) ExportEndpoints(clientType string, includeKeys bool) s
 	exportEndpoints := make([]ExportEndpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
 		exportEp := ExportEndpoint{
-			Name:               ep.Name,
-			ClientType:         ep.ClientType,
-			APIUrl:             ep.APIUrl,
-			Enabled:            ep.Enabled,
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            ep.ClientType,
+			APIUrl:                ep.APIUrl,
+			Enabled:               ep.Enabled,
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			QuotaMode:             ep.QuotaMode,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			Models:                ep.Models,
+			ModelRewrite:          ep.ModelRewrite,
 		}
 
 		if includeKeys {
 			exportEp.APIKey = ep.APIKey
+			exportEp.RefreshToken = ep.RefreshToken
+			exportEp.TokenExpiry = ep.TokenExpiry
+			exportEp.APIKeys = ep.APIKeys
 		} else {
 			if len(ep.APIKey) > 8 {
 				exportEp.APIKey = ep.APIKey[:4] + "****" + ep.APIKey[len(ep.APIKey)-4:]
@@ -1206,24 +1645,48 @@ func (e *EndpointService) ExportAllEndpoints(includeKeys bool) string {
 	exportEndpoints := make([]ExportEndpoint, 0, len(endpoints))
 	for _, ep := range endpoints {
 		exportEp := ExportEndpoint{
-			Name:               ep.Name,
-			ClientType:         ep.ClientType,
-			APIUrl:             ep.APIUrl,
-			Enabled:            ep.Enabled,
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            ep.ClientType,
+			APIUrl:                ep.APIUrl,
+			Enabled:               ep.Enabled,
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			QuotaMode:             ep.QuotaMode,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			Models:                ep.Models,
+			ModelRewrite:          ep.ModelRewrite,
 		}
 
 		if includeKeys {
 			exportEp.APIKey = ep.APIKey
+			exportEp.RefreshToken = ep.RefreshToken
+			exportEp.TokenExpiry = ep.TokenExpiry
+			exportEp.APIKeys = ep.APIKeys
 		} else {
 			if len(ep.APIKey) > 8 {
 				exportEp.APIKey = ep.APIKey[:4] + "****" + ep.APIKey[len(ep.APIKey)-4:]
@@ -1260,108 +1723,324 @@ type ImportResult struct {
 	Errors   []string `json:"errors,omitempty"`
 }
 
-// ImportEndpoints imports endpoints from JSON data
-// mode: "skip" (skip existing), "overwrite" (overwrite existing), "rename" (add suffix to duplicates)
-func (e *EndpointService) ImportEndpoints(jsonData string, mode string) string {
+// Import plan actions
+const (
+	importActionAdd       = "add"       // 新增
+	importActionOverwrite = "overwrite" // 覆盖已有端点
+	importActionRename    = "rename"    // 重名，改名后新增
+	importActionSkip      = "skip"      // 已存在，跳过
+	importActionInvalid   = "invalid"   // 数据无效，跳过
+)
+
+// ImportPlanItem describes what importing a single endpoint would do
+type ImportPlanItem struct {
+	Action       string         `json:"action"`            // add, overwrite, rename, skip, invalid
+	Name         string         `json:"name"`              // 导入后的名称（rename 时为新名称）
+	OriginalName string         `json:"originalName"`      // 导入数据中的名称
+	ClientType   string         `json:"clientType"`
+	APIUrl       string         `json:"apiUrl"`
+	Reason       string         `json:"reason,omitempty"`      // skip/invalid 的原因
+	Unreachable  string         `json:"unreachable,omitempty"` // 可达性探测失败的原因（仅 validate 时填充）
+	Endpoint     ExportEndpoint `json:"-"`
+	index        int            // overwrite 时已有端点的索引
+}
+
+// ImportPreview represents the dry-run result of an import
+type ImportPreview struct {
+	Success     bool             `json:"success"`
+	Message     string           `json:"message"`
+	Mode        string           `json:"mode"`
+	Items       []ImportPlanItem `json:"items"`
+	Added       int              `json:"added"`
+	Overwrite   int              `json:"overwrite"`
+	Renamed     int              `json:"renamed"`
+	Skipped     int              `json:"skipped"`
+	Invalid     int              `json:"invalid"`
+	Unreachable int              `json:"unreachable"`
+}
+
+// parseImportData parses and validates import JSON, normalizing the mode
+func parseImportData(jsonData string, mode string) (*ExportData, string, error) {
 	var exportData ExportData
 	if err := json.Unmarshal([]byte(jsonData), &exportData); err != nil {
-		return toJSON(ImportResult{
-			Success: false,
-			Message: fmt.Sprintf("Invalid JSON format: %v", err),
-		})
+		return nil, mode, fmt.Errorf("Invalid JSON format: %v", err)
 	}
 
 	if len(exportData.Endpoints) == 0 {
-		return toJSON(ImportResult{
-			Success: false,
-			Message: "No endpoints found in import data",
-		})
+		return nil, mode, fmt.Errorf("No endpoints found in import data")
 	}
 
 	if mode != "skip" && mode != "overwrite" && mode != "rename" {
 		mode = "skip"
 	}
+	return &exportData, mode, nil
+}
 
-	imported := 0
-	skipped := 0
-	var errors []string
+// planImport decides what to do with each imported endpoint without changing the config.
+// Names planned to be added earlier in the same import are treated as existing,
+// so duplicates inside one file are resolved the same way as conflicts with the config.
+func (e *EndpointService) planImport(endpoints []ExportEndpoint, mode string) []ImportPlanItem {
+	plan := make([]ImportPlanItem, 0, len(endpoints))
+	planned := make(map[string]map[string]bool) // clientType -> names added by this import
+
+	for _, importEp := range endpoints {
+		clientType := normalizeClientType(importEp.ClientType)
+		item := ImportPlanItem{
+			Name:         importEp.Name,
+			OriginalName: importEp.Name,
+			ClientType:   clientType,
+			APIUrl:       importEp.APIUrl,
+			Endpoint:     importEp,
+			index:        -1,
+		}
 
-	for _, importEp := range exportData.Endpoints {
 		if importEp.Name == "" {
-			errors = append(errors, "Endpoint with empty name skipped")
-			skipped++
+			item.Action = importActionInvalid
+			item.Reason = "Endpoint with empty name skipped"
+			plan = append(plan, item)
 			continue
 		}
 		if importEp.APIUrl == "" {
-			errors = append(errors, fmt.Sprintf("Endpoint '%s': missing API URL", importEp.Name))
-			skipped++
+			item.Action = importActionInvalid
+			item.Reason = fmt.Sprintf("Endpoint '%s': missing API URL", importEp.Name)
+			plan = append(plan, item)
 			continue
 		}
 		if importEp.APIKey == "" || strings.Contains(importEp.APIKey, "****") {
-			errors = append(errors, fmt.Sprintf("Endpoint '%s': missing or masked API key", importEp.Name))
-			skipped++
+			item.Action = importActionInvalid
+			item.Reason = fmt.Sprintf("Endpoint '%s': missing or masked API key", importEp.Name)
+			plan = append(plan, item)
 			continue
 		}
 
-		clientType := normalizeClientType(importEp.ClientType)
-		transformer := normalizeTransformer(importEp.Transformer)
-
+		if planned[clientType] == nil {
+			planned[clientType] = make(map[string]bool)
+		}
 		existingEndpoints := e.config.GetEndpointsByClient(clientType)
-		exists := false
+		nameTaken := func(name string) bool {
+			if planned[clientType][name] {
+				return true
+			}
+			for _, ep := range existingEndpoints {
+				if ep.Name == name {
+					return true
+				}
+			}
+			return false
+		}
+
 		existingIndex := -1
 		for i, ep := range existingEndpoints {
 			if ep.Name == importEp.Name {
-				exists = true
 				existingIndex = i
 				break
 			}
 		}
 
-		if exists {
-			switch mode {
-			case "skip":
-				skipped++
-				continue
-			case "overwrite":
-				err := e.UpdateEndpoint(clientType, existingIndex, importEp.Name, importEp.APIUrl, importEp.APIKey, transformer, importEp.Model, importEp.Remark, importEp.Tags,
-					importEp.ModelPatterns, importEp.CostPerInputToken, importEp.CostPerOutputToken, importEp.QuotaLimit, importEp.QuotaResetCycle, importEp.Priority)
-				if err != nil {
-					errors = append(errors, fmt.Sprintf("Failed to update '%s': %v", importEp.Name, err))
-					skipped++
-				} else {
-					imported++
-				}
-				continue
-			case "rename":
-				baseName := importEp.Name
-				suffix := 1
-				for suffix <= 100 {
-					newName := fmt.Sprintf("%s_%d", baseName, suffix)
-					nameExists := false
-					for _, ep := range existingEndpoints {
-						if ep.Name == newName {
-							nameExists = true
-							break
-						}
-					}
-					if !nameExists {
-						importEp.Name = newName
-						break
-					}
-					suffix++
-				}
-				if suffix > 100 {
-					errors = append(errors, fmt.Sprintf("Could not find unique name for '%s'", baseName))
-					skipped++
-					continue
+		if existingIndex < 0 && !planned[clientType][importEp.Name] {
+			item.Action = importActionAdd
+			planned[clientType][importEp.Name] = true
+			plan = append(plan, item)
+			continue
+		}
+
+		switch mode {
+		case "overwrite":
+			if existingIndex >= 0 {
+				item.Action = importActionOverwrite
+				item.index = existingIndex
+			} else {
+				item.Action = importActionSkip
+				item.Reason = fmt.Sprintf("Duplicate endpoint '%s' in import data", importEp.Name)
+			}
+		case "rename":
+			item.Action = importActionInvalid
+			item.Reason = fmt.Sprintf("Could not find unique name for '%s'", importEp.Name)
+			for suffix := 1; suffix <= 100; suffix++ {
+				newName := fmt.Sprintf("%s_%d", importEp.Name, suffix)
+				if !nameTaken(newName) {
+					item.Action = importActionRename
+					item.Name = newName
+					item.Reason = ""
+					planned[clientType][newName] = true
+					break
 				}
 			}
+		default:
+			item.Action = importActionSkip
+			item.Reason = fmt.Sprintf("Endpoint '%s' already exists", importEp.Name)
+		}
+		plan = append(plan, item)
+	}
+
+	return plan
+}
+
+// probeImportPlan 并发对将要导入的端点做一次轻量 models API 探测，结果写入 Unreachable。
+// 仅网络错误和鉴权失败（401/403）视为不可达；其他 HTTP 状态说明服务可连通，不标记。
+func (e *EndpointService) probeImportPlan(plan []ImportPlanItem) {
+	var wg sync.WaitGroup
+	for i := range plan {
+		switch plan[i].Action {
+		case importActionAdd, importActionOverwrite, importActionRename:
+		default:
+			continue
+		}
+
+		wg.Add(1)
+		go func(item *ImportPlanItem) {
+			defer wg.Done()
+			ep := item.Endpoint
+			statusCode, err := e.testModelsAPI(normalizeAPIUrlWithScheme(ep.APIUrl), ep.APIKey, normalizeTransformer(ep.Transformer))
+			if err == nil {
+				return
+			}
+			if statusCode == 0 || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+				item.Unreachable = err.Error()
+			}
+		}(&plan[i])
+	}
+	wg.Wait()
+}
+
+// PreviewImport returns what ImportEndpoints would do with the same data and mode,
+// without modifying the configuration. validate 为 true 时同时探测端点可达性
+func (e *EndpointService) PreviewImport(jsonData string, mode string, validate bool) string {
+	exportData, mode, err := parseImportData(jsonData, mode)
+	if err != nil {
+		return toJSON(ImportPreview{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	preview := ImportPreview{
+		Success: true,
+		Mode:    mode,
+		Items:   e.planImport(exportData.Endpoints, mode),
+	}
+	if validate {
+		e.probeImportPlan(preview.Items)
+	}
+	for _, item := range preview.Items {
+		if item.Unreachable != "" {
+			preview.Unreachable++
+		}
+		switch item.Action {
+		case importActionAdd:
+			preview.Added++
+		case importActionOverwrite:
+			preview.Overwrite++
+		case importActionRename:
+			preview.Renamed++
+		case importActionSkip:
+			preview.Skipped++
+		case importActionInvalid:
+			preview.Invalid++
+		}
+	}
+	preview.Message = fmt.Sprintf("%d to add, %d to overwrite, %d to rename, %d to skip, %d invalid",
+		preview.Added, preview.Overwrite, preview.Renamed, preview.Skipped, preview.Invalid)
+	if validate {
+		preview.Message += fmt.Sprintf(", %d unreachable", preview.Unreachable)
+	}
+
+	return toJSON(preview)
+}
+
+// importEndpointInput converts an exported endpoint into the add/update input under the given name
+func importEndpointInput(name string, ep ExportEndpoint) EndpointInput {
+	return EndpointInput{
+		Name:                  name,
+		APIUrl:                ep.APIUrl,
+		APIKey:                ep.APIKey,
+		Transformer:           ep.Transformer,
+		Model:                 ep.Model,
+		Remark:                ep.Remark,
+		Tags:                  ep.Tags,
+		ModelPatterns:         ep.ModelPatterns,
+		CostPerInputToken:     ep.CostPerInputToken,
+		CostPerOutputToken:    ep.CostPerOutputToken,
+		CostPerCacheReadToken: ep.CostPerCacheReadToken,
+		QuotaLimit:            ep.QuotaLimit,
+		QuotaResetCycle:       ep.QuotaResetCycle,
+		QuotaMode:             ep.QuotaMode,
+		Priority:              ep.Priority,
+		UserAgent:             ep.UserAgent,
+		SLAP95Ms:              ep.SLAP95Ms,
+		Weight:                ep.Weight,
+		Models:                config.EncodeEndpointModels(ep.Models),
+		Group:                 ep.Group,
+		HeaderMode:            ep.HeaderMode,
+		HeaderWhitelist:       ep.HeaderWhitelist,
+		HealthFields:          ep.HealthFields,
+		HealthErrorWords:      ep.HealthErrorWords,
+		HealthCheckPath:       ep.HealthCheckPath,
+		HealthCheckMethod:     ep.HealthCheckMethod,
+		ActiveHours:           ep.ActiveHours,
+		KeyExpiryDate:         ep.KeyExpiryDate,
+		RefreshToken:          ep.RefreshToken,
+		TokenExpiry:           ep.TokenExpiry,
+		APIKeys:               config.EncodeAPIKeys(ep.APIKeys),
+		HideThinking:          ep.HideThinking,
+		DisableStreamUsage:    ep.DisableStreamUsage,
+		PinStatus:             ep.PinStatus,
+		MaxConcurrency:        ep.MaxConcurrency,
+		TimeoutSeconds:        ep.TimeoutSeconds,
+		ModelRewrite:          config.EncodeModelRewrite(ep.ModelRewrite),
+	}
+}
+
+// ImportEndpoints imports endpoints from JSON data
+// mode: "skip" (skip existing), "overwrite" (overwrite existing), "rename" (add suffix to duplicates)
+// validate: 导入前并发探测端点可达性，不可达的端点记录到 Errors，但仍然导入
+func (e *EndpointService) ImportEndpoints(jsonData string, mode string, validate bool) string {
+	exportData, mode, err := parseImportData(jsonData, mode)
+	if err != nil {
+		return toJSON(ImportResult{
+			Success: false,
+			Message: err.Error(),
+		})
+	}
+
+	imported := 0
+	skipped := 0
+	var errors []string
+
+	plan := e.planImport(exportData.Endpoints, mode)
+	if validate {
+		e.probeImportPlan(plan)
+	}
+
+	for _, item := range plan {
+		importEp := item.Endpoint
+		if item.Unreachable != "" {
+			errors = append(errors, fmt.Sprintf("Endpoint '%s' is unreachable: %s", item.Name, item.Unreachable))
+		}
+
+		switch item.Action {
+		case importActionInvalid:
+			errors = append(errors, item.Reason)
+			skipped++
+			continue
+		case importActionSkip:
+			skipped++
+			continue
+		case importActionOverwrite:
+			err := e.updateEndpoint(ConfigAuditSourceImport, item.ClientType, item.index, importEndpointInput(item.Name, importEp))
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("Failed to update '%s': %v", item.Name, err))
+				skipped++
+			} else {
+				imported++
+			}
+			continue
 		}
 
-		err := e.AddEndpoint(clientType, importEp.Name, importEp.APIUrl, importEp.APIKey, transformer, importEp.Model, importEp.Remark, importEp.Tags,
-			importEp.ModelPatterns, importEp.CostPerInputToken, importEp.CostPerOutputToken, importEp.QuotaLimit, importEp.QuotaResetCycle, importEp.Priority)
+		// add / rename
+		err := e.addEndpoint(ConfigAuditSourceImport, item.ClientType, importEndpointInput(item.Name, importEp))
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to add '%s': %v", importEp.Name, err))
+			errors = append(errors, fmt.Sprintf("Failed to add '%s': %v", item.Name, err))
 			skipped++
 		} else {
 			imported++
@@ -1405,6 +2084,87 @@ func (e *EndpointService) GetHealthHistory(endpointName, clientType string, hour
 	return e.storage.GetHealthHistory(endpointName, clientType, startTime, endTime, 1000)
 }
 
+// healthHistorySummaryLimit 聚合健康历史时最多读取的记录数
+const healthHistorySummaryLimit = 100000
+
+// maxHealthHistoryBuckets 聚合结果的最大桶数，超过时自动放大桶宽
+const maxHealthHistoryBuckets = 1000
+
+// HealthHistoryBucket 健康历史按时间桶聚合后的数据点
+// 桶内无记录时 SuccessRate 和 AvgLatencyMs 为 nil，序列化为 null，折线图在该处断开
+type HealthHistoryBucket struct {
+	StartTime    time.Time `json:"startTime"`
+	Total        int       `json:"total"`        // 桶内健康检查次数
+	SuccessRate  *float64  `json:"successRate"`  // 健康（healthy）记录占比（百分比）
+	AvgLatencyMs *float64  `json:"avgLatencyMs"` // 非错误记录的平均延迟
+}
+
+// GetHealthHistorySummary aggregates the health history of the last hours into buckets of bucketMinutes,
+// returning one point per bucket in chronological order
+func (e *EndpointService) GetHealthHistorySummary(endpointName, clientType string, hours, bucketMinutes int) ([]HealthHistoryBucket, error) {
+	if hours <= 0 {
+		hours = 24
+	}
+	if bucketMinutes <= 0 {
+		// 默认每小时 1 个桶
+		bucketMinutes = 60
+	}
+	if hours*60/bucketMinutes > maxHealthHistoryBuckets {
+		bucketMinutes = (hours*60 + maxHealthHistoryBuckets - 1) / maxHealthHistoryBuckets
+	}
+	bucket := time.Duration(bucketMinutes) * time.Minute
+
+	endTime := time.Now()
+	startTime := endTime.Add(-time.Duration(hours) * time.Hour).Truncate(bucket)
+	count := int(endTime.Sub(startTime)/bucket) + 1
+
+	buckets := make([]HealthHistoryBucket, count)
+	for i := range buckets {
+		buckets[i].StartTime = startTime.Add(time.Duration(i) * bucket)
+	}
+	if e.storage == nil {
+		return buckets, nil
+	}
+
+	records, err := e.storage.GetHealthHistory(endpointName, clientType, startTime, endTime, healthHistorySummaryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	healthy := make([]int, count)
+	latencyTotal := make([]float64, count)
+	latencyCount := make([]int, count)
+	for _, r := range records {
+		idx := int(r.Timestamp.Sub(startTime) / bucket)
+		if idx < 0 || idx >= count {
+			continue
+		}
+		buckets[idx].Total++
+		if r.Status == "healthy" {
+			healthy[idx]++
+		}
+		// 错误记录的耗时多为超时，不计入平均延迟
+		if r.Status != "error" && r.LatencyMs > 0 {
+			latencyTotal[idx] += r.LatencyMs
+			latencyCount[idx]++
+		}
+	}
+
+	for i := range buckets {
+		if buckets[i].Total == 0 {
+			continue
+		}
+		rate := float64(healthy[i]) / float64(buckets[i].Total) * 100.0
+		buckets[i].SuccessRate = &rate
+		if latencyCount[i] > 0 {
+			avg := latencyTotal[i] / float64(latencyCount[i])
+			buckets[i].AvgLatencyMs = &avg
+		}
+	}
+
+	return buckets, nil
+}
+
 // GetHealthHistoryRetentionDays returns the health history retention days
 func (e *EndpointService) GetHealthHistoryRetentionDays() int {
 	return e.config.GetHealthHistoryRetentionDays()
@@ -1490,6 +2250,7 @@ func (e *EndpointService) TestAllEndpointsAndOptimize(clientType string) string
 
 	results := make([]testResult, len(allEndpoints))
 	var wg sync.WaitGroup
+	var completed int32
 
 	for i, ep := range allEndpoints {
 		wg.Add(1)
@@ -1529,6 +2290,17 @@ func (e *EndpointService) TestAllEndpointsAndOptimize(clientType string) string
 				} else {
 					e.proxy.GetMonitor().ClearHealthCheckLatency(endpoint.Name)
 				}
+
+				// 每个端点检测完成后立即推送进度，前端据此显示进度条
+				e.proxy.GetMonitor().NotifyTestProgress(proxy.EndpointTestProgress{
+					ClientType:   clientType,
+					Completed:    int(atomic.AddInt32(&completed, 1)),
+					Total:        len(allEndpoints),
+					EndpointName: endpoint.Name,
+					Success:      success,
+					LatencyMs:    latencyMs,
+					ErrorMessage: errorMsg,
+				})
 			}
 		}(i, ep)
 	}
@@ -1538,7 +2310,7 @@ func (e *EndpointService) TestAllEndpointsAndOptimize(clientType string) string
 	// 找出检测成功且延迟最低的端点
 	var bestResult *testResult
 	for i := range results {
-		if results[i].success {
+		if results[i].success && results[i].endpoint.IsRoutable() {
 			if bestResult == nil || results[i].latencyMs < bestResult.latencyMs {
 				bestResult = &results[i]
 			}
@@ -1556,8 +2328,8 @@ func (e *EndpointService) TestAllEndpointsAndOptimize(clientType string) string
 		action := "unchanged"
 		wasEnabled := r.endpoint.IsEnabled()
 
-		// 跳过禁用状态的端点
-		if r.endpoint.Status == config.EndpointStatusDisabled {
+		// 跳过禁用、维护状态和锁定状态的端点
+		if r.endpoint.Status == config.EndpointStatusDisabled || r.endpoint.Status == config.EndpointStatusMaintenance || r.endpoint.PinStatus {
 			testResults[i] = EndpointTestResult{
 				Name:         r.endpoint.Name,
 				Success:      r.success,
@@ -1570,11 +2342,14 @@ func (e *EndpointService) TestAllEndpointsAndOptimize(clientType string) string
 		}
 
 		if r.success {
-			// 检测成功：设置为可用（包括 untested 和 unavailable）
-			if r.endpoint.Status != config.EndpointStatusAvailable {
-				e.config.SetEndpointStatus(r.endpoint.Name, clientType, config.EndpointStatusAvailable)
-				action = "enabled"
-				enabledCount++
+			// 检测成功：按延迟设置为可用或降级（包括 untested 和 unavailable）
+			newStatus := e.config.HealthyStatusForLatency(r.latencyMs)
+			if r.endpoint.Status != newStatus {
+				e.config.SetEndpointStatus(r.endpoint.Name, clientType, newStatus)
+				if r.endpoint.Status != config.EndpointStatusAvailable && r.endpoint.Status != config.EndpointStatusDegraded {
+					action = "enabled"
+					enabledCount++
+				}
 			}
 			if bestResult != nil && r.index == bestResult.index {
 				action = "set_current"
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/proxy"
+)
+
+// 主备切换通过端点标签标识
+const (
+	failoverPrimaryTag = "primary"
+	failoverBackupTag  = "backup"
+)
+
+// failoverRecoverySuccesses 主端点降级后连续健康检测成功该次数才切回，避免抖动
+const failoverRecoverySuccesses = 3
+
+// failoverState 单个 client type 的主备降级状态
+type failoverState struct {
+	primary   string    // 触发降级的主端点
+	backup    string    // 降级后使用的备用端点
+	since     time.Time // 降级时间
+	successes int       // 降级后主端点的连续成功次数
+}
+
+// FailoverStatus 主备降级状态（供前端展示）
+type FailoverStatus struct {
+	ClientType string    `json:"clientType"`
+	Primary    string    `json:"primary"`
+	Backup     string    `json:"backup"`
+	Since      time.Time `json:"since"`
+	Successes  int       `json:"successes"` // 主端点已连续成功次数
+	Required   int       `json:"required"`  // 切回所需的连续成功次数
+}
+
+// SetProxy sets the proxy used to switch between primary and backup endpoints
+func (h *HealthCheckService) SetProxy(p *proxy.Proxy) {
+	h.proxy = p
+}
+
+// processFailover 处理主备降级：tags 含 primary 的端点连续失败达到告警阈值时切换到 tags 含 backup 的端点，
+// 主端点连续成功若干次后切回
+func (h *HealthCheckService) processFailover(endpoint config.Endpoint, clientType string, isHealthy bool) {
+	if h.proxy == nil || !endpoint.HasTag(failoverPrimaryTag) {
+		return
+	}
+
+	threshold := 3
+	if alertConfig := h.config.GetAlert(); alertConfig != nil && alertConfig.ConsecutiveFailures > 0 {
+		threshold = alertConfig.ConsecutiveFailures
+	}
+
+	key := clientType + ":" + endpoint.Name
+
+	h.failoverMu.Lock()
+	defer h.failoverMu.Unlock()
+
+	state := h.failovers[clientType]
+
+	if !isHealthy {
+		h.primaryFailures[key]++
+		if state != nil {
+			if state.primary == endpoint.Name {
+				state.successes = 0
+			}
+			return
+		}
+		if h.primaryFailures[key] < threshold {
+			return
+		}
+
+		backup := h.selectBackupEndpoint(clientType)
+		if backup == nil {
+			logger.Warn("[FAILOVER:%s] Primary endpoint %s failed %d times, but no backup endpoint is available",
+				clientType, endpoint.Name, h.primaryFailures[key])
+			return
+		}
+		if err := h.proxy.SetCurrentEndpointForClient(clientType, backup.Name); err != nil {
+			logger.Warn("[FAILOVER:%s] Failed to switch to backup endpoint %s: %v", clientType, backup.Name, err)
+			return
+		}
+
+		h.failovers[clientType] = &failoverState{
+			primary: endpoint.Name,
+			backup:  backup.Name,
+			since:   time.Now(),
+		}
+		logger.Warn("[FAILOVER:%s] Primary endpoint %s failed %d times, switched to backup %s",
+			clientType, endpoint.Name, h.primaryFailures[key], backup.Name)
+		h.notifyFailover(endpoint.Name, clientType, "failover",
+			fmt.Sprintf("主端点 %s 连续 %d 次健康检测失败，已切换到备用端点 %s", endpoint.Name, h.primaryFailures[key], backup.Name))
+		return
+	}
+
+	delete(h.primaryFailures, key)
+	if state == nil || state.primary != endpoint.Name {
+		return
+	}
+
+	state.successes++
+	if state.successes < failoverRecoverySuccesses {
+		logger.Debug("[FAILOVER:%s] Primary endpoint %s healthy %d/%d", clientType, endpoint.Name, state.successes, failoverRecoverySuccesses)
+		return
+	}
+	if err := h.proxy.SetCurrentEndpointForClient(clientType, endpoint.Name); err != nil {
+		logger.Warn("[FAILOVER:%s] Failed to switch back to primary endpoint %s: %v", clientType, endpoint.Name, err)
+		return
+	}
+
+	delete(h.failovers, clientType)
+	logger.Info("[FAILOVER:%s] Primary endpoint %s recovered, switched back from %s", clientType, endpoint.Name, state.backup)
+	h.notifyFailover(endpoint.Name, clientType, "failback",
+		fmt.Sprintf("主端点 %s 已恢复（连续 %d 次检测成功），已从备用端点 %s 切回", endpoint.Name, state.successes, state.backup))
+}
+
+// selectBackupEndpoint 选择可用的备用端点（优先级数字最小者优先）
+func (h *HealthCheckService) selectBackupEndpoint(clientType string) *config.Endpoint {
+	var backups []config.Endpoint
+	for _, ep := range h.config.GetEnabledEndpointsByClient(clientType) {
+		if ep.HasTag(failoverBackupTag) && !ep.HasTag(failoverPrimaryTag) && ep.IsAvailable() {
+			backups = append(backups, ep)
+		}
+	}
+	if len(backups) == 0 {
+		return nil
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].Priority < backups[j].Priority })
+	return &backups[0]
+}
+
+// notifyFailover 发送主备切换通知
+func (h *HealthCheckService) notifyFailover(endpointName, clientType, alertType, message string) {
+	if h.alertCallback == nil {
+		return
+	}
+	h.alertCallback(AlertEvent{
+		EndpointName: endpointName,
+		ClientType:   clientType,
+		AlertType:    alertType,
+		Message:      message,
+		Timestamp:    time.Now(),
+	})
+}
+
+// GetFailoverStatus returns the active primary/backup failovers as JSON
+func (h *HealthCheckService) GetFailoverStatus() string {
+	h.failoverMu.Lock()
+	defer h.failoverMu.Unlock()
+
+	statuses := make([]FailoverStatus, 0, len(h.failovers))
+	for clientType, state := range h.failovers {
+		statuses = append(statuses, FailoverStatus{
+			ClientType: clientType,
+			Primary:    state.primary,
+			Backup:     state.backup,
+			Since:      state.since,
+			Successes:  state.successes,
+			Required:   failoverRecoverySuccesses,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ClientType < statuses[j].ClientType })
+
+	return successJSON(map[string]interface{}{
+		"failovers": statuses,
+	})
+}
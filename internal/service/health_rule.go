@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// matchHealthErrorWords 响应体包含任一错误关键词（不区分大小写）时返回错误，用于识别「假 200」
+// 如上游用 HTTP 200 包装的「余额不足」等错误
+func matchHealthErrorWords(body []byte, errorWords []string) error {
+	if len(errorWords) == 0 {
+		return nil
+	}
+	lowerBody := strings.ToLower(string(body))
+	for _, word := range errorWords {
+		if strings.Contains(lowerBody, word) {
+			return fmt.Errorf("response contains error keyword '%s'", word)
+		}
+	}
+	return nil
+}
+
+// checkHealthRequiredFields 检查响应 JSON 是否包含所有必需字段，字段路径以点号分隔，数组用下标（如 choices.0.message）
+func checkHealthRequiredFields(respData map[string]interface{}, fields []string) error {
+	for _, field := range fields {
+		if lookupJSONPath(respData, field) == nil {
+			return fmt.Errorf("invalid response: missing required field '%s'", field)
+		}
+	}
+	return nil
+}
+
+// lookupJSONPath 按点号路径查找 JSON 中的值，不存在时返回 nil
+func lookupJSONPath(data interface{}, path string) interface{} {
+	current := data
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
@@ -38,6 +38,9 @@ type endpointAlertState struct {
 	lastPerfAlertTime   time.Time   // 上次性能告警时间
 }
 
+// startupCheckConcurrency 启动时全量健康检查的最大并发数，避免端点较多时同时发起大量请求
+const startupCheckConcurrency = 8
+
 // HealthCheckService handles periodic health checks for all enabled endpoints
 type HealthCheckService struct {
 	config  *config.Config
@@ -49,6 +52,12 @@ type HealthCheckService struct {
 	stopChan chan struct{}
 	running  bool
 
+	// 启动前已执行过 RunOnceBlocking，定时循环首次不再立即检查
+	initialChecked bool
+
+	// 一轮健康检查与定时优化端点（RunExclusive）互斥，避免同时检测端点、修改状态
+	checkMu sync.Mutex
+
 	// HTTP client cache
 	clientCache *httpClientCache
 
@@ -59,6 +68,12 @@ type HealthCheckService struct {
 	alertStates   map[string]*endpointAlertState // key: endpointName
 	alertStatesMu sync.RWMutex
 	alertCallback AlertCallback
+
+	// 主备降级相关
+	proxy           *proxy.Proxy
+	primaryFailures map[string]int            // key: clientType:endpointName，主端点连续失败次数
+	failovers       map[string]*failoverState // key: clientType
+	failoverMu      sync.Mutex
 }
 
 // NewHealthCheckService creates a new HealthCheckService
@@ -69,8 +84,10 @@ func NewHealthCheckService(cfg *config.Config, monitor *proxy.Monitor) *HealthCh
 		clientCache: &httpClientCache{
 			clients: make(map[time.Duration]*http.Client),
 		},
-		deviceID:    "default",
-		alertStates: make(map[string]*endpointAlertState),
+		deviceID:        "default",
+		alertStates:     make(map[string]*endpointAlertState),
+		primaryFailures: make(map[string]int),
+		failovers:       make(map[string]*failoverState),
 	}
 }
 
@@ -101,6 +118,7 @@ func (h *HealthCheckService) Start() {
 
 	interval := h.config.GetHealthCheckInterval()
 	if interval <= 0 {
+		h.initialChecked = false
 		logger.Info("Health check disabled (interval=0)")
 		return
 	}
@@ -132,6 +150,27 @@ func (h *HealthCheckService) Stop() {
 	logger.Info("Health check service stopped")
 }
 
+// RunOnceBlocking checks all non-disabled endpoints once with limited concurrency and
+// returns when every check has finished, so endpoint status is up to date before serving
+func (h *HealthCheckService) RunOnceBlocking() {
+	start := time.Now()
+	count := h.checkEndpoints(startupCheckConcurrency)
+
+	h.mu.Lock()
+	h.initialChecked = true
+	h.mu.Unlock()
+
+	logger.Info("Startup health check finished for %d endpoints in %v", count, time.Since(start).Round(time.Millisecond))
+}
+
+// RunExclusive runs fn while no health check round is in progress; health checks
+// scheduled meanwhile wait until fn returns
+func (h *HealthCheckService) RunExclusive(fn func()) {
+	h.checkMu.Lock()
+	defer h.checkMu.Unlock()
+	fn()
+}
+
 // Restart restarts the health check service with the new interval
 func (h *HealthCheckService) Restart() {
 	h.Stop()
@@ -147,8 +186,14 @@ func (h *HealthCheckService) IsRunning() bool {
 
 // run is the main loop for health checks
 func (h *HealthCheckService) run() {
-	// Run immediately on start
-	h.checkAllEndpoints()
+	// Run immediately on start（启动前已同步检查过则跳过）
+	h.mu.Lock()
+	skipInitial := h.initialChecked
+	h.initialChecked = false
+	h.mu.Unlock()
+	if !skipInitial {
+		h.checkAllEndpoints()
+	}
 
 	for {
 		select {
@@ -162,8 +207,22 @@ func (h *HealthCheckService) run() {
 
 // checkAllEndpoints checks all non-disabled endpoints
 func (h *HealthCheckService) checkAllEndpoints() {
+	h.checkEndpoints(0)
+}
+
+// checkEndpoints 并发检查所有未禁用的端点，limit <= 0 表示不限制并发数，返回检查的端点数
+func (h *HealthCheckService) checkEndpoints(limit int) int {
+	h.checkMu.Lock()
+	defer h.checkMu.Unlock()
+
 	endpoints := h.config.GetEndpoints()
 
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	count := 0
 	var wg sync.WaitGroup
 	for _, ep := range endpoints {
 		// 跳过禁用的端点
@@ -171,13 +230,19 @@ func (h *HealthCheckService) checkAllEndpoints() {
 			continue
 		}
 
+		count++
 		wg.Add(1)
 		go func(endpoint config.Endpoint) {
 			defer wg.Done()
+			if sem != nil {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+			}
 			h.checkEndpoint(endpoint)
 		}(ep)
 	}
 	wg.Wait()
+	return count
 }
 
 // checkEndpoint checks a single endpoint and records the latency
@@ -195,7 +260,7 @@ func (h *HealthCheckService) checkEndpoint(endpoint config.Endpoint) {
 	normalizedURL := normalizeAPIUrlWithScheme(endpoint.APIUrl)
 
 	start := time.Now()
-	statusCode, err := h.testMinimalRequest(normalizedURL, endpoint.APIKey, transformer, endpoint.Model)
+	statusCode, err := h.probeEndpoint(endpoint, normalizedURL, transformer)
 	latencyMs := float64(time.Since(start).Milliseconds())
 
 	var status string
@@ -209,10 +274,20 @@ func (h *HealthCheckService) checkEndpoint(endpoint config.Endpoint) {
 		status = "healthy"
 		isHealthy = true
 
-		// 自动设置为可用状态（包括 untested 和 unavailable）
-		if endpoint.Status == config.EndpointStatusUntested ||
-			endpoint.Status == config.EndpointStatusUnavailable {
-			h.setEndpointAvailable(endpoint.Name, clientType)
+		// 按延迟自动设置为可用或降级状态（包括 untested、unavailable 和已有的 available/degraded 之间切换）
+		switch h.config.HealthyStatusForLatency(latencyMs) {
+		case config.EndpointStatusDegraded:
+			if endpoint.Status == config.EndpointStatusUntested ||
+				endpoint.Status == config.EndpointStatusUnavailable ||
+				endpoint.Status == config.EndpointStatusAvailable {
+				h.setEndpointDegraded(endpoint.Name, clientType, latencyMs)
+			}
+		default:
+			if endpoint.Status == config.EndpointStatusUntested ||
+				endpoint.Status == config.EndpointStatusUnavailable ||
+				endpoint.Status == config.EndpointStatusDegraded {
+				h.setEndpointAvailable(endpoint.Name, clientType)
+			}
 		}
 	} else if statusCode == 401 || statusCode == 403 {
 		// Auth error - still record latency but log warning
@@ -229,9 +304,10 @@ func (h *HealthCheckService) checkEndpoint(endpoint config.Endpoint) {
 			errorMsg = err.Error()
 		}
 
-		// 自动设置为不可用状态（包括 untested 和 available）
+		// 自动设置为不可用状态（包括 untested、available 和 degraded）
 		if endpoint.Status == config.EndpointStatusUntested ||
-			endpoint.Status == config.EndpointStatusAvailable {
+			endpoint.Status == config.EndpointStatusAvailable ||
+			endpoint.Status == config.EndpointStatusDegraded {
 			h.setEndpointUnavailable(endpoint.Name, clientType)
 		}
 	}
@@ -245,6 +321,9 @@ func (h *HealthCheckService) checkEndpoint(endpoint config.Endpoint) {
 	// 处理告警逻辑
 	h.processAlert(endpoint.Name, clientType, isHealthy, errorMsg)
 
+	// 处理主备降级
+	h.processFailover(endpoint, clientType, isHealthy)
+
 	// 处理性能告警逻辑（仅在健康时检查）
 	if isHealthy {
 		h.processPerformanceAlert(endpoint.Name, clientType, latencyMs)
@@ -269,15 +348,53 @@ func (h *HealthCheckService) checkEndpoint(endpoint config.Endpoint) {
 	}
 }
 
+// probeEndpoint 按配置的健康检查方式检测端点
+// 端点配置了自定义检查路径时只请求该路径，不回退；
+// models / token_count 为零成本方式，接口不支持（非鉴权失败的 HTTP 错误）时回退到 minimal request；
+// 连接失败直接返回，回退也不会成功。端点配置了自定义成功规则时规则针对对话响应，始终使用 minimal request
+func (h *HealthCheckService) probeEndpoint(endpoint config.Endpoint, normalizedURL, transformer string) (int, error) {
+	if endpoint.HealthCheckPath != "" {
+		return testCustomHealthPath(h.getHTTPClient(15*time.Second), normalizedURL, endpoint.APIKey, transformer, endpoint.HealthCheckPath, endpoint.HealthCheckMethod)
+	}
+
+	requiredFields := endpoint.HealthFieldList()
+	errorWords := endpoint.HealthErrorWordList()
+
+	method := h.config.GetHealthCheckMethod()
+	if len(requiredFields) > 0 || len(errorWords) > 0 {
+		method = config.HealthCheckMethodMinimal
+	}
+
+	var statusCode int
+	var err error
+	client := h.getHTTPClient(15 * time.Second)
+	switch {
+	case method == config.HealthCheckMethodTokenCount && transformer == "claude":
+		statusCode, err = testTokenCountAPI(client, normalizedURL, endpoint.APIKey)
+	case method == config.HealthCheckMethodModels, method == config.HealthCheckMethodTokenCount:
+		statusCode, err = testModelsAPI(client, normalizedURL, endpoint.APIKey, transformer)
+	default:
+		return h.testMinimalRequest(normalizedURL, endpoint.APIKey, transformer, endpoint.Model, requiredFields, errorWords)
+	}
+
+	if err == nil || statusCode == 0 || statusCode == 401 || statusCode == 403 {
+		return statusCode, err
+	}
+
+	logger.Debug("Health check %s unavailable for %s (%v), falling back to minimal request", method, endpoint.Name, err)
+	return h.testMinimalRequest(normalizedURL, endpoint.APIKey, transformer, endpoint.Model, requiredFields, errorWords)
+}
+
 // testMinimalRequest sends a minimal request to test if the LLM service is available
 // This consumes approximately 1-2 output tokens per check
-func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, model string) (int, error) {
+// requiredFields and errorWords are the endpoint's custom success rules applied to HTTP 200 responses
+func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, model string, requiredFields, errorWords []string) (int, error) {
 	var url string
 	var body []byte
 
 	switch transformer {
 	case "claude":
-		url = fmt.Sprintf("%s/v1/messages", apiUrl)
+		url = config.JoinAPIUrl(apiUrl, "/v1/messages")
 		if model == "" {
 			model = "claude-sonnet-4-5-20250929"
 		}
@@ -287,7 +404,7 @@ func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, mod
 			"messages":   []map[string]string{{"role": "user", "content": "Hi"}},
 		})
 	case "openai", "openai2":
-		url = fmt.Sprintf("%s/v1/chat/completions", apiUrl)
+		url = config.JoinAPIUrl(apiUrl, "/v1/chat/completions")
 		if model == "" {
 			model = "gpt-4o-mini"
 		}
@@ -300,7 +417,7 @@ func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, mod
 		if model == "" {
 			model = "gemini-2.0-flash"
 		}
-		url = fmt.Sprintf("%s/v1beta/models/%s:generateContent?key=%s", apiUrl, model, apiKey)
+		url = config.JoinAPIUrl(apiUrl, fmt.Sprintf("/v1beta/models/%s:generateContent?key=%s", model, apiKey))
 		body, _ = json.Marshal(map[string]interface{}{
 			"contents":         []map[string]interface{}{{"parts": []map[string]string{{"text": "Hi"}}}},
 			"generationConfig": map[string]int{"maxOutputTokens": 1},
@@ -359,6 +476,11 @@ func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, mod
 		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
+	// 自定义判定：响应体包含错误关键词视为失败（识别 200 包装的错误）
+	if err := matchHealthErrorWords(respBody, errorWords); err != nil {
+		return resp.StatusCode, err
+	}
+
 	// 验证响应内容是否有效（检查是否包含错误）
 	var respData map[string]interface{}
 	if err := json.Unmarshal(respBody, &respData); err != nil {
@@ -473,6 +595,11 @@ func (h *HealthCheckService) testMinimalRequest(apiUrl, apiKey, transformer, mod
 		}
 	}
 
+	// 自定义判定：响应必须包含端点配置的字段
+	if err := checkHealthRequiredFields(respData, requiredFields); err != nil {
+		return resp.StatusCode, err
+	}
+
 	return resp.StatusCode, nil
 }
 
@@ -517,13 +644,11 @@ func (h *HealthCheckService) getHTTPClient(timeout time.Duration) *http.Client {
 
 // createHTTPClient creates an HTTP client with optional proxy support
 func (h *HealthCheckService) createHTTPClient(timeout time.Duration) *http.Client {
-	client := &http.Client{Timeout: timeout}
-	if proxyCfg := h.config.GetProxy(); proxyCfg != nil && proxyCfg.URL != "" {
-		if transport, err := proxy.CreateProxyTransport(proxyCfg.URL); err == nil {
-			client.Transport = transport
-		}
+	proxyURL := ""
+	if proxyCfg := h.config.GetProxy(); proxyCfg != nil {
+		proxyURL = proxyCfg.URL
 	}
-	return client
+	return proxy.NewProxyHTTPClient(proxyURL, timeout)
 }
 
 // recordHealthHistory records a health check result to the history table
@@ -742,12 +867,17 @@ func (h *HealthCheckService) processPerformanceAlert(endpointName, clientType st
 	}
 }
 
-// setEndpointAvailable 设置端点为可用状态
+// setEndpointAvailable 设置端点为可用状态（锁定状态的端点不修改）
 func (h *HealthCheckService) setEndpointAvailable(endpointName, clientType string) {
-	if err := h.config.SetEndpointStatus(endpointName, clientType, config.EndpointStatusAvailable); err != nil {
+	changed, err := h.config.SetEndpointStatusAuto(endpointName, clientType, config.EndpointStatusAvailable)
+	if err != nil {
 		logger.Warn("Failed to set endpoint %s to available: %v", endpointName, err)
 		return
 	}
+	if !changed {
+		logger.Debug("Endpoint %s (client: %s) status is pinned, skip setting AVAILABLE", endpointName, clientType)
+		return
+	}
 
 	logger.Info("Endpoint %s (client: %s) is now AVAILABLE", endpointName, clientType)
 
@@ -765,16 +895,36 @@ func (h *HealthCheckService) setEndpointAvailable(endpointName, clientType strin
 	}
 }
 
-// setEndpointUnavailable 设置端点为不可用状态
+// setEndpointUnavailable 设置端点为不可用状态（锁定状态的端点不修改）
 func (h *HealthCheckService) setEndpointUnavailable(endpointName, clientType string) {
-	if err := h.config.SetEndpointStatus(endpointName, clientType, config.EndpointStatusUnavailable); err != nil {
+	changed, err := h.config.SetEndpointStatusAuto(endpointName, clientType, config.EndpointStatusUnavailable)
+	if err != nil {
 		logger.Warn("Failed to set endpoint %s to unavailable: %v", endpointName, err)
 		return
 	}
+	if !changed {
+		logger.Debug("Endpoint %s (client: %s) status is pinned, skip setting UNAVAILABLE", endpointName, clientType)
+		return
+	}
 
 	logger.Warn("Endpoint %s (client: %s) is now UNAVAILABLE", endpointName, clientType)
 }
 
+// setEndpointDegraded 健康检查延迟超过阈值时设置端点为降级状态（锁定状态的端点不修改）
+func (h *HealthCheckService) setEndpointDegraded(endpointName, clientType string, latencyMs float64) {
+	changed, err := h.config.SetEndpointStatusAuto(endpointName, clientType, config.EndpointStatusDegraded)
+	if err != nil {
+		logger.Warn("Failed to set endpoint %s to degraded: %v", endpointName, err)
+		return
+	}
+	if !changed {
+		logger.Debug("Endpoint %s (client: %s) status is pinned, skip setting DEGRADED", endpointName, clientType)
+		return
+	}
+
+	logger.Warn("Endpoint %s (client: %s) is now DEGRADED (latency %.0fms > %dms)", endpointName, clientType, latencyMs, h.config.GetAlert().LatencyThresholdMs)
+}
+
 // autoEnableEndpoint 自动启用端点（连续成功达到阈值后）
 // 已废弃：三状态系统中不再需要此功能
 func (h *HealthCheckService) autoEnableEndpoint(endpointName, clientType string) {
@@ -1,6 +1,12 @@
 package service
 
-import "strings"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
 
 // normalizeClientType ensures clientType has a default value
 func normalizeClientType(clientType string) string {
@@ -18,11 +24,65 @@ func normalizeTransformer(transformer string) string {
 	return transformer
 }
 
+// normalizeHeaderMode validates the header passthrough mode (empty means pass all headers)
+func normalizeHeaderMode(headerMode string) (string, error) {
+	headerMode = strings.ToLower(strings.TrimSpace(headerMode))
+	if !config.IsValidHeaderMode(headerMode) {
+		return "", fmt.Errorf("invalid header mode '%s', must be one of: all, whitelist, minimal", headerMode)
+	}
+	return headerMode, nil
+}
+
+// normalizeQuotaMode validates the quota mode; empty means token
+func normalizeQuotaMode(quotaMode string) (string, error) {
+	quotaMode = strings.ToLower(strings.TrimSpace(quotaMode))
+	if !config.IsValidQuotaMode(quotaMode) {
+		return "", fmt.Errorf("invalid quota mode '%s', must be one of: token, cost", quotaMode)
+	}
+	return quotaMode, nil
+}
+
+// normalizeHealthCheckProbe validates the custom health check path and HTTP method;
+// the method defaults to GET and is cleared when no path is set
+func normalizeHealthCheckProbe(path, method string) (string, string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", "", nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "", "", fmt.Errorf("invalid health check path '%s', must start with /", path)
+	}
+
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case "":
+		method = http.MethodGet
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodOptions:
+	default:
+		return "", "", fmt.Errorf("invalid health check method '%s', must be one of: GET, HEAD, POST, OPTIONS", method)
+	}
+	return path, method, nil
+}
+
+// normalizeActiveHours validates the endpoint active hours expression (e.g. 22:00-06:00 Asia/Shanghai)
+func normalizeActiveHours(activeHours string) (string, error) {
+	activeHours = strings.TrimSpace(activeHours)
+	if _, err := config.ParseActiveHours(activeHours); err != nil {
+		return "", err
+	}
+	return activeHours, nil
+}
+
+// normalizeKeyExpiryDate validates the endpoint API key expiry date (YYYY-MM-DD)
+func normalizeKeyExpiryDate(keyExpiryDate string) (string, error) {
+	keyExpiryDate = strings.TrimSpace(keyExpiryDate)
+	if _, err := config.ParseKeyExpiryDate(keyExpiryDate); err != nil {
+		return "", err
+	}
+	return keyExpiryDate, nil
+}
+
 // normalizeAPIUrlWithScheme ensures the API URL has the correct format with scheme
 func normalizeAPIUrlWithScheme(apiUrl string) string {
-	apiUrl = strings.TrimSuffix(apiUrl, "/")
-	if !strings.HasPrefix(apiUrl, "http://") && !strings.HasPrefix(apiUrl, "https://") {
-		apiUrl = "https://" + apiUrl
-	}
-	return apiUrl
+	return config.NormalizeAPIUrl(apiUrl)
 }
@@ -1,16 +1,27 @@
 package service
 
 import (
+	"encoding/json"
 	"strconv"
+	"sync"
+	"time"
 
+	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/interaction"
+	"github.com/lich0821/ccNexus/internal/logger"
 	"github.com/lich0821/ccNexus/internal/storage"
 )
 
+// interactionCleanupInterval 自动清理旧交互记录的周期
+const interactionCleanupInterval = 6 * time.Hour
+
 // InteractionService 交互记录服务
 type InteractionService struct {
 	storage    *interaction.Storage
 	sqlStorage *storage.SQLiteStorage
+
+	mu          sync.Mutex
+	cleanupStop chan struct{}
 }
 
 // NewInteractionService 创建交互服务实例
@@ -28,6 +39,16 @@ func NewInteractionService(interactionStorage *interaction.Storage, sqlStorage *
 	}
 	// 如果数据库中没有配置，使用 Storage 的默认值（true）
 
+	// 从数据库加载脱敏规则
+	if patternsStr, err := sqlStorage.GetConfig("interaction_redactPatterns"); err == nil && patternsStr != "" {
+		var patterns []string
+		if err := json.Unmarshal([]byte(patternsStr), &patterns); err == nil {
+			if err := interactionStorage.SetRedactPatterns(patterns); err != nil {
+				logger.Warn("Failed to load interaction redact patterns: %v", err)
+			}
+		}
+	}
+
 	return s
 }
 
@@ -53,6 +74,29 @@ func (s *InteractionService) SetEnabled(enabled bool) string {
 	})
 }
 
+// GetRedactPatterns 获取交互记录脱敏规则
+func (s *InteractionService) GetRedactPatterns() string {
+	return successJSON(map[string]interface{}{
+		"patterns": s.storage.GetRedactPatterns(),
+	})
+}
+
+// SetRedactPatterns 设置交互记录脱敏规则（正则列表），保存记录前将匹配内容替换为 ***
+func (s *InteractionService) SetRedactPatterns(patterns []string) string {
+	if err := s.storage.SetRedactPatterns(patterns); err != nil {
+		return errorJSON(err.Error())
+	}
+
+	data, _ := json.Marshal(s.storage.GetRedactPatterns())
+	if err := s.sqlStorage.SetConfig("interaction_redactPatterns", string(data)); err != nil {
+		return errorJSON(err.Error())
+	}
+
+	return successJSON(map[string]interface{}{
+		"patterns": s.storage.GetRedactPatterns(),
+	})
+}
+
 // GetDates 获取所有有记录的日期列表
 func (s *InteractionService) GetDates() string {
 	dates, err := s.storage.GetDates()
@@ -114,7 +158,7 @@ func (s *InteractionService) ExportInteractions(date string) string {
 
 // Cleanup 清理旧记录
 func (s *InteractionService) Cleanup(daysToKeep int) string {
-	deleted, err := s.storage.Cleanup(daysToKeep)
+	deleted, err := s.storage.CleanupOlderThan(daysToKeep)
 	if err != nil {
 		return errorJSON(err.Error())
 	}
@@ -124,6 +168,54 @@ func (s *InteractionService) Cleanup(daysToKeep int) string {
 	})
 }
 
+// StartAutoCleanup 启动后台清理：立即清理一次，之后每隔 interactionCleanupInterval
+// 按 cfg 中的 InteractionRetentionDays 删除旧记录（每次读取最新配置）
+func (s *InteractionService) StartAutoCleanup(cfg *config.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cleanupStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	s.cleanupStop = stop
+
+	go func() {
+		ticker := time.NewTicker(interactionCleanupInterval)
+		defer ticker.Stop()
+
+		s.cleanupExpired(cfg.GetInteractionRetentionDays())
+		for {
+			select {
+			case <-ticker.C:
+				s.cleanupExpired(cfg.GetInteractionRetentionDays())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopAutoCleanup 停止后台清理
+func (s *InteractionService) StopAutoCleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cleanupStop != nil {
+		close(s.cleanupStop)
+		s.cleanupStop = nil
+	}
+}
+
+func (s *InteractionService) cleanupExpired(days int) {
+	deleted, err := s.storage.CleanupOlderThan(days)
+	if err != nil {
+		logger.Warn("Failed to cleanup old interactions: %v", err)
+	} else if deleted > 0 {
+		logger.Info("Cleaned up %d old interaction folders (retention %d days)", deleted, days)
+	}
+}
+
 // GetStoragePath 获取存储路径
 func (s *InteractionService) GetStoragePath() string {
 	return successJSON(map[string]interface{}{
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/proxy"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+const (
+	// keyExpiryCheckInterval 扫描端点 API Key 到期日的间隔
+	keyExpiryCheckInterval = time.Hour
+	// keyExpiryWarnDays 距到期日不超过该天数时开始提醒
+	keyExpiryWarnDays = 7
+)
+
+// KeyExpiryService 定期扫描端点的 KeyExpiryDate：临近到期时每天提醒一次，
+// 过期后自动禁用端点（锁定状态的端点只提醒，不修改状态）
+type KeyExpiryService struct {
+	config  *config.Config
+	proxy   *proxy.Proxy
+	storage *storage.SQLiteStorage
+
+	mu       sync.Mutex
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+
+	stateMu       sync.Mutex
+	lastWarned    map[string]string // 端点（clientType:name:到期日）-> 上次提醒的日期，每天只提醒一次
+	alertCallback AlertCallback
+}
+
+// NewKeyExpiryService creates a new KeyExpiryService
+func NewKeyExpiryService(cfg *config.Config, p *proxy.Proxy, store *storage.SQLiteStorage) *KeyExpiryService {
+	return &KeyExpiryService{
+		config:     cfg,
+		proxy:      p,
+		storage:    store,
+		lastWarned: make(map[string]string),
+	}
+}
+
+// SetAlertCallback 设置告警回调
+func (s *KeyExpiryService) SetAlertCallback(callback AlertCallback) {
+	s.alertCallback = callback
+}
+
+// Start starts periodic key expiry scans; the first scan runs immediately
+func (s *KeyExpiryService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	s.stopChan = make(chan struct{})
+	s.ticker = time.NewTicker(keyExpiryCheckInterval)
+	s.running = true
+
+	logger.Info("Key expiry check started, warning %d days before expiry", keyExpiryWarnDays)
+
+	go s.run(s.ticker, s.stopChan)
+}
+
+// Stop stops periodic key expiry scans
+func (s *KeyExpiryService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+
+	logger.Info("Key expiry check stopped")
+}
+
+// run is the main loop for key expiry scans
+func (s *KeyExpiryService) run(ticker *time.Ticker, stop <-chan struct{}) {
+	s.Check()
+	for {
+		select {
+		case <-ticker.C:
+			s.Check()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Check 扫描所有未禁用的端点：已过期的自动禁用并告警，临近到期的提醒
+func (s *KeyExpiryService) Check() {
+	now := time.Now()
+	today := now.Format(config.KeyExpiryDateLayout)
+	startOfToday, _ := time.ParseInLocation(config.KeyExpiryDateLayout, today, time.Local)
+
+	var expired []config.Endpoint
+	var expiring []AlertEvent
+	for _, ep := range s.config.GetEndpoints() {
+		expiresAt, ok := ep.KeyExpiresAt()
+		if !ok || ep.Status == config.EndpointStatusDisabled {
+			continue
+		}
+		clientType := ep.ClientType
+		if clientType == "" {
+			clientType = "claude"
+		}
+
+		if !now.Before(expiresAt) {
+			changed, err := s.config.SetEndpointStatusAuto(ep.Name, clientType, config.EndpointStatusDisabled)
+			if err != nil {
+				logger.Warn("Failed to disable endpoint %s with expired key: %v", ep.Name, err)
+				continue
+			}
+			if !changed {
+				logger.Warn("Endpoint %s (client: %s) key expired on %s, status is pinned, skip disabling", ep.Name, clientType, ep.KeyExpiryDate)
+				continue
+			}
+			ep.ClientType = clientType
+			expired = append(expired, ep)
+			continue
+		}
+
+		// 剩余天数：0 表示今天是到期日
+		daysLeft := int(math.Round(expiresAt.Sub(startOfToday).Hours()/24)) - 1
+		if daysLeft > keyExpiryWarnDays {
+			continue
+		}
+		key := clientType + ":" + ep.Name + ":" + ep.KeyExpiryDate
+		s.stateMu.Lock()
+		alreadyWarned := s.lastWarned[key] == today
+		s.lastWarned[key] = today
+		s.stateMu.Unlock()
+		if alreadyWarned {
+			continue
+		}
+
+		message := fmt.Sprintf("端点 %s 的 API Key 将于 %s 到期（剩余 %d 天）", ep.Name, ep.KeyExpiryDate, daysLeft)
+		if daysLeft == 0 {
+			message = fmt.Sprintf("端点 %s 的 API Key 今天（%s）到期", ep.Name, ep.KeyExpiryDate)
+		}
+		logger.Warn("Endpoint %s (client: %s) key expires on %s (%d days left)", ep.Name, clientType, ep.KeyExpiryDate, daysLeft)
+		expiring = append(expiring, AlertEvent{
+			EndpointName: ep.Name,
+			ClientType:   clientType,
+			AlertType:    "key_expiring",
+			Message:      message,
+			Timestamp:    now,
+		})
+	}
+
+	if len(expired) > 0 {
+		s.saveDisabled(expired)
+	}
+
+	if s.alertCallback == nil {
+		return
+	}
+	for _, event := range expiring {
+		s.alertCallback(event)
+	}
+	for _, ep := range expired {
+		s.alertCallback(AlertEvent{
+			EndpointName: ep.Name,
+			ClientType:   ep.ClientType,
+			AlertType:    "key_expired",
+			Message:      fmt.Sprintf("端点 %s 的 API Key 已于 %s 到期，端点已自动禁用", ep.Name, ep.KeyExpiryDate),
+			Timestamp:    now,
+		})
+	}
+}
+
+// saveDisabled 应用并保存因 Key 过期而禁用的端点
+func (s *KeyExpiryService) saveDisabled(expired []config.Endpoint) {
+	for _, ep := range expired {
+		logger.Warn("Endpoint %s (client: %s) disabled: key expired on %s", ep.Name, ep.ClientType, ep.KeyExpiryDate)
+	}
+
+	if s.proxy != nil {
+		if err := s.proxy.UpdateConfig(s.config); err != nil {
+			logger.Warn("Failed to apply config after disabling expired endpoints: %v", err)
+		}
+	}
+	if s.storage != nil {
+		configAdapter := storage.NewConfigStorageAdapter(s.storage)
+		if err := s.config.SaveToStorage(configAdapter); err != nil {
+			logger.Warn("Failed to save config after disabling expired endpoints: %v", err)
+		}
+	}
+}
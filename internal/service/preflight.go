@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+const (
+	preflightDNSTimeout = 5 * time.Second
+	preflightTCPTimeout = 5 * time.Second
+)
+
+// PreflightAddress 单个解析地址的 TCP 建连结果
+type PreflightAddress struct {
+	IP        string `json:"ip"`
+	Reachable bool   `json:"reachable"`
+	ConnectMs int64  `json:"connectMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// PreflightCheck 只做 DNS 解析和 TCP 建连（不发 HTTP 请求），用于区分网络问题和鉴权问题。
+// 解析到多个 IP 时逐个建连并全部返回；直连目标主机，不经过配置的代理。
+func (e *EndpointService) PreflightCheck(clientType string, index int) string {
+	clientType = normalizeClientType(clientType)
+
+	endpoints := e.config.GetEndpointsByClient(clientType)
+	if index < 0 || index >= len(endpoints) {
+		return errorJSON(fmt.Sprintf("Invalid endpoint index: %d", index))
+	}
+
+	endpoint := endpoints[index]
+	u, err := url.Parse(normalizeAPIUrlWithScheme(endpoint.APIUrl))
+	if err != nil || u.Hostname() == "" {
+		return errorJSON(fmt.Sprintf("Invalid API URL: %s", endpoint.APIUrl))
+	}
+
+	host := u.Hostname()
+	port := u.Port()
+	if port == "" {
+		if u.Scheme == "http" {
+			port = "80"
+		} else {
+			port = "443"
+		}
+	}
+
+	logger.Info("Preflight check: %s (%s:%s)", endpoint.Name, host, port)
+
+	// DNS 解析（IP 直连时跳过）
+	var ips []string
+	var dnsMs int64
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []string{ip.String()}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), preflightDNSTimeout)
+		start := time.Now()
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		dnsMs = time.Since(start).Milliseconds()
+		cancel()
+		if err != nil {
+			return toJSON(map[string]interface{}{
+				"success":   false,
+				"stage":     "dns",
+				"host":      host,
+				"port":      port,
+				"dnsMs":     dnsMs,
+				"addresses": []PreflightAddress{},
+				"message":   fmt.Sprintf("DNS lookup failed: %v", err),
+			})
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP.String())
+		}
+	}
+
+	// 对每个 IP 并发建立 TCP 连接
+	addresses := make([]PreflightAddress, len(ips))
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		wg.Add(1)
+		go func(i int, ip string) {
+			defer wg.Done()
+			addresses[i] = dialPreflight(ip, port)
+		}(i, ip)
+	}
+	wg.Wait()
+
+	reachable := 0
+	for _, addr := range addresses {
+		if addr.Reachable {
+			reachable++
+		}
+	}
+
+	result := map[string]interface{}{
+		"success":   reachable > 0,
+		"stage":     "tcp",
+		"host":      host,
+		"port":      port,
+		"dnsMs":     dnsMs,
+		"addresses": addresses,
+		"message":   fmt.Sprintf("%d/%d addresses reachable", reachable, len(addresses)),
+	}
+	if reachable > 0 {
+		result["stage"] = "ok"
+	}
+	if proxyCfg := e.config.GetProxy(); proxyCfg != nil && proxyCfg.URL != "" {
+		// 实际请求走代理，直连结果仅供参考
+		result["proxy"] = proxyCfg.URL
+	}
+	return toJSON(result)
+}
+
+// dialPreflight 对单个 IP 建立 TCP 连接并记录耗时
+func dialPreflight(ip, port string) PreflightAddress {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip, port), preflightTCPTimeout)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		return PreflightAddress{IP: ip, ConnectMs: elapsed, Error: err.Error()}
+	}
+	conn.Close()
+	return PreflightAddress{IP: ip, Reachable: true, ConnectMs: elapsed}
+}
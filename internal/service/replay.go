@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/interaction"
+	"github.com/lich0821/ccNexus/internal/logger"
+)
+
+// replayWarning 重放会向端点发送真实请求，返回给前端提示用户
+const replayWarning = "Replay sends a real request to the endpoint and consumes tokens"
+
+// SetInteractionStorage sets the interaction storage used to look up archived requests for replay
+func (e *EndpointService) SetInteractionStorage(s *interaction.Storage) {
+	e.interactions = s
+}
+
+// ReplayRequest re-sends the archived raw request body of requestID to endpointName and returns
+// the new response alongside the original one for comparison
+// endpointName 为空时重放到原端点；请求经代理完整处理（格式转换、Key 选择等），统计中计为测试请求。
+// 交互记录启用脱敏时，归档的请求体可能已被替换为 ***，重放结果会与原请求不同
+func (e *EndpointService) ReplayRequest(requestID, endpointName string) string {
+	if e.interactions == nil || e.proxy == nil {
+		return errorJSON("Interaction storage not available")
+	}
+
+	record, err := e.interactions.GetInteractionByID(requestID)
+	if err != nil {
+		return errorJSON(err.Error())
+	}
+	if record.Request.Raw == nil || record.Request.Path == "" {
+		return errorJSON(fmt.Sprintf("Interaction %s has no archived request body", requestID))
+	}
+
+	if strings.TrimSpace(endpointName) == "" {
+		endpointName = record.Endpoint.Name
+	}
+	if e.config.GetEndpointByName(endpointName, record.Client.Type) == nil {
+		return errorJSON(fmt.Sprintf("Endpoint '%s' not found for client type: %s", endpointName, record.Client.Type))
+	}
+
+	body, err := json.Marshal(record.Request.Raw)
+	if err != nil {
+		return errorJSON(fmt.Sprintf("Failed to encode archived request: %v", err))
+	}
+
+	logger.Warn("[REPLAY] Replaying request %s to endpoint %s (consumes tokens)", requestID, endpointName)
+	result, err := e.proxy.Replay(context.Background(), record.Request.Path, body, endpointName)
+	if err != nil {
+		return errorJSON(fmt.Sprintf("Replay failed: %v", err))
+	}
+
+	// JSON 响应原样嵌入，流式响应等非 JSON 内容按文本返回
+	var response interface{} = string(result.Body)
+	if json.Valid(result.Body) {
+		response = json.RawMessage(result.Body)
+	}
+
+	return successJSON(map[string]interface{}{
+		"warning":   replayWarning,
+		"requestId": record.RequestID,
+		"path":      record.Request.Path,
+		"model":     record.Request.Model,
+		"original": map[string]interface{}{
+			"endpoint":   record.Endpoint.Name,
+			"status":     record.Response.Status,
+			"response":   record.Response.Raw,
+			"durationMs": record.Stats.DurationMs,
+		},
+		"replay": map[string]interface{}{
+			"endpoint":   endpointName,
+			"status":     result.StatusCode,
+			"response":   response,
+			"durationMs": result.DurationMs,
+		},
+	})
+}
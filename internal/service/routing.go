@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+
 	"github.com/lich0821/ccNexus/internal/config"
 	"github.com/lich0821/ccNexus/internal/proxy"
 	"github.com/lich0821/ccNexus/internal/storage"
@@ -11,6 +13,8 @@ type QuotaStatus struct {
 	EndpointName   string  `json:"endpointName"`
 	ClientType     string  `json:"clientType"`
 	TokensUsed     int64   `json:"tokensUsed"`
+	CostUsed       float64 `json:"costUsed"`  // 已累计成本（美元）
+	QuotaMode      string  `json:"quotaMode"` // token / cost，cost 模式下 QuotaLimit 为美元上限
 	QuotaLimit     int64   `json:"quotaLimit"`
 	RemainingQuota int64   `json:"remainingQuota"`
 	UsagePercent   float64 `json:"usagePercent"` // 已使用百分比
@@ -60,6 +64,68 @@ func (s *RoutingService) UpdateRoutingConfig(cfg *config.RoutingConfig) error {
 	return nil
 }
 
+// GetClientRoutingConfigs 获取各 client type 的独立路由配置
+func (s *RoutingService) GetClientRoutingConfigs() map[string]*config.RoutingConfig {
+	return s.config.GetRoutingByClient()
+}
+
+// UpdateClientRoutingConfig 设置 client type 的独立路由配置，cfg 为 nil 时恢复使用全局配置
+func (s *RoutingService) UpdateClientRoutingConfig(clientType string, cfg *config.RoutingConfig) error {
+	switch clientType {
+	case "claude", "gemini", "codex":
+	default:
+		return fmt.Errorf("invalid client type: %s", clientType)
+	}
+
+	s.config.UpdateClientRoutingConfig(clientType, cfg)
+
+	// 持久化到存储
+	if s.storage != nil {
+		configAdapter := storage.NewConfigStorageAdapter(s.storage)
+		if err := s.config.SaveToStorage(configAdapter); err != nil {
+			return err
+		}
+	}
+
+	// 更新代理的路由器配置
+	if s.proxy != nil {
+		s.proxy.UpdateRouterConfig(s.config)
+	}
+
+	return nil
+}
+
+// GetCrossClientFallback 获取跨 client type 回退配置
+func (s *RoutingService) GetCrossClientFallback() *config.CrossClientFallbackConfig {
+	return s.config.GetCrossClientFallback()
+}
+
+// UpdateCrossClientFallback 更新跨 client type 回退配置
+func (s *RoutingService) UpdateCrossClientFallback(enabled bool, clientTypes []string) error {
+	for _, t := range clientTypes {
+		switch t {
+		case "claude", "gemini", "codex":
+		default:
+			return fmt.Errorf("invalid client type: %s", t)
+		}
+	}
+
+	s.config.UpdateCrossClientFallback(&config.CrossClientFallbackConfig{
+		Enabled:     enabled,
+		ClientTypes: clientTypes,
+	})
+
+	// 持久化到存储
+	if s.storage != nil {
+		configAdapter := storage.NewConfigStorageAdapter(s.storage)
+		if err := s.config.SaveToStorage(configAdapter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetQuotaStatuses 获取所有端点的配额状态
 func (s *RoutingService) GetQuotaStatuses(clientType string) []QuotaStatus {
 	quotaTracker := s.proxy.GetQuotaTracker()
@@ -77,21 +143,15 @@ func (s *RoutingService) GetQuotaStatuses(clientType string) []QuotaStatus {
 	for _, record := range records {
 		remaining, _ := quotaTracker.GetRemainingQuota(record.EndpointName, record.ClientType)
 
-		var usagePercent float64
-		if record.QuotaLimit > 0 {
-			usagePercent = float64(record.TokensUsed) / float64(record.QuotaLimit) * 100
-			if usagePercent > 100 {
-				usagePercent = 100
-			}
-		}
-
 		statuses = append(statuses, QuotaStatus{
 			EndpointName:   record.EndpointName,
 			ClientType:     record.ClientType,
 			TokensUsed:     record.TokensUsed,
+			CostUsed:       record.CostUsed,
+			QuotaMode:      record.QuotaMode,
 			QuotaLimit:     record.QuotaLimit,
 			RemainingQuota: remaining,
-			UsagePercent:   usagePercent,
+			UsagePercent:   record.UsagePercent(),
 			PeriodStart:    record.PeriodStart.Format("2006-01-02 15:04:05"),
 			PeriodEnd:      record.PeriodEnd.Format("2006-01-02 15:04:05"),
 			IsExhausted:    quotaTracker.IsExhausted(record.EndpointName, record.ClientType),
@@ -119,21 +179,15 @@ func (s *RoutingService) GetQuotaStatus(endpointName, clientType string) *QuotaS
 
 	remaining, _ := quotaTracker.GetRemainingQuota(endpointName, clientType)
 
-	var usagePercent float64
-	if record.QuotaLimit > 0 {
-		usagePercent = float64(record.TokensUsed) / float64(record.QuotaLimit) * 100
-		if usagePercent > 100 {
-			usagePercent = 100
-		}
-	}
-
 	return &QuotaStatus{
 		EndpointName:   record.EndpointName,
 		ClientType:     record.ClientType,
 		TokensUsed:     record.TokensUsed,
+		CostUsed:       record.CostUsed,
+		QuotaMode:      record.QuotaMode,
 		QuotaLimit:     record.QuotaLimit,
 		RemainingQuota: remaining,
-		UsagePercent:   usagePercent,
+		UsagePercent:   record.UsagePercent(),
 		PeriodStart:    record.PeriodStart.Format("2006-01-02 15:04:05"),
 		PeriodEnd:      record.PeriodEnd.Format("2006-01-02 15:04:05"),
 		IsExhausted:    quotaTracker.IsExhausted(endpointName, clientType),
@@ -149,3 +203,18 @@ func (s *RoutingService) ResetQuota(endpointName, clientType string) error {
 
 	return quotaTracker.ResetQuota(endpointName, clientType)
 }
+
+// ExplainRouting 推演一次假设请求会被路由到哪个端点及原因
+func (s *RoutingService) ExplainRouting(clientType, model, sessionID string) string {
+	if s.proxy == nil {
+		return jsonError("Proxy not initialized")
+	}
+	if clientType == "" {
+		clientType = "claude"
+	}
+
+	explain := s.proxy.ExplainRouting(proxy.ClientType(clientType), model, sessionID)
+	return successJSON(map[string]interface{}{
+		"explanation": explain,
+	})
+}
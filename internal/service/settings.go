@@ -3,11 +3,14 @@ package service
 import (
     "encoding/json"
     "fmt"
+    "net/url"
     "os"
     "strings"
+    "time"
 
     "github.com/lich0821/ccNexus/internal/config"
     "github.com/lich0821/ccNexus/internal/logger"
+    "github.com/lich0821/ccNexus/internal/proxy"
     "github.com/lich0821/ccNexus/internal/storage"
     "github.com/lich0821/ccNexus/internal/tray"
 )
@@ -72,6 +75,24 @@ func (s *SettingsService) UpdatePort(port int) error {
     return nil
 }
 
+// UpdateClientPorts updates the dedicated listening ports of client types (takes effect after restart)
+func (s *SettingsService) UpdateClientPorts(ports map[string]int) error {
+    if err := config.ValidateClientPorts(s.config.GetPort(), ports); err != nil {
+        return err
+    }
+
+    s.config.UpdateClientPorts(ports)
+
+    if s.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(s.storage)
+        if err := s.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save config: %w", err)
+        }
+    }
+
+    return nil
+}
+
 // GetSystemLanguage detects the system language
 func (s *SettingsService) GetSystemLanguage() string {
     locale := os.Getenv("LANG")
@@ -265,6 +286,22 @@ func (s *SettingsService) GetLogLevel() int {
     return s.config.GetLogLevel()
 }
 
+// SetPerEndpointLogging enables or disables per-endpoint request log files
+func (s *SettingsService) SetPerEndpointLogging(enabled bool) error {
+    if err := logger.GetLogger().SetKeyedEnabled(enabled); err != nil {
+        return fmt.Errorf("failed to enable per-endpoint logging: %w", err)
+    }
+    s.config.UpdatePerEndpointLogging(enabled)
+
+    if s.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(s.storage)
+        if err := s.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save per-endpoint logging: %w", err)
+        }
+    }
+    return nil
+}
+
 // SetCloseWindowBehavior sets the user's preference for close window behavior
 func (s *SettingsService) SetCloseWindowBehavior(behavior string) error {
     if behavior != "quit" && behavior != "minimize" && behavior != "ask" {
@@ -312,6 +349,9 @@ func (s *SettingsService) GetProxyURL() string {
 func (s *SettingsService) SetProxyURL(proxyURL string) error {
     var proxyCfg *config.ProxyConfig
     if proxyURL != "" {
+        if _, err := proxy.CreateProxyTransport(proxyURL); err != nil {
+            return err
+        }
         proxyCfg = &config.ProxyConfig{URL: proxyURL}
     }
     s.config.UpdateProxy(proxyCfg)
@@ -323,6 +363,101 @@ func (s *SettingsService) SetProxyURL(proxyURL string) error {
         }
     }
 
-    logger.Info("Proxy URL changed to: %s", proxyURL)
+    logger.Info("Proxy URL changed to: %s", redactProxyURL(proxyURL))
+    return nil
+}
+
+// proxyTestURL 测试代理连通性时请求的地址，收到任意 HTTP 响应即视为代理可用
+const proxyTestURL = "https://api.anthropic.com"
+
+// TestProxyURL tests whether requests can go through the proxy (http, https or socks5)
+func (s *SettingsService) TestProxyURL(proxyURL string) string {
+    if proxyURL == "" {
+        return errorJSON("Proxy URL is empty")
+    }
+    if _, err := proxy.CreateProxyTransport(proxyURL); err != nil {
+        return errorJSON(err.Error())
+    }
+
+    client := proxy.NewProxyHTTPClient(proxyURL, 15*time.Second)
+    start := time.Now()
+    resp, err := client.Get(proxyTestURL)
+    if err != nil {
+        logger.Warn("Proxy test failed for %s: %v", redactProxyURL(proxyURL), err)
+        return errorJSON(err.Error())
+    }
+    resp.Body.Close()
+
+    return successJSON(map[string]interface{}{
+        "statusCode": resp.StatusCode,
+        "latencyMs":  time.Since(start).Milliseconds(),
+    })
+}
+
+// redactProxyURL 隐藏代理 URL 中的密码，用于日志输出
+func redactProxyURL(proxyURL string) string {
+    parsed, err := url.Parse(proxyURL)
+    if err != nil {
+        return proxyURL
+    }
+    return parsed.Redacted()
+}
+
+// GetStatsTimezone returns the time zone used for stats dates, empty for the local time zone
+func (s *SettingsService) GetStatsTimezone() string {
+    return s.config.GetStatsTimezone()
+}
+
+// SetStatsTimezone sets the time zone used for stats dates (IANA name, UTC or UTC offset, empty for local)
+// 已有的请求明细按时间戳在新时区下重新计算日期；按天汇总的统计无法重新划分，保留原日期
+func (s *SettingsService) SetStatsTimezone(tz string) error {
+    if strings.TrimSpace(tz) == s.config.GetStatsTimezone() {
+        return nil
+    }
+    if err := s.config.UpdateStatsTimezone(tz); err != nil {
+        return err
+    }
+
+    if s.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(s.storage)
+        if err := s.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save stats time zone: %w", err)
+        }
+        updated, err := s.storage.RebuildRequestStatsDates(s.config.StatsLocation())
+        if err != nil {
+            return fmt.Errorf("failed to rebuild request stats dates: %w", err)
+        }
+        logger.Info("Rebuilt dates of %d request stats for the new time zone", updated)
+    }
+
+    logger.Info("Stats time zone changed to: %s", s.config.StatsLocation())
+    return nil
+}
+
+// GetDashboardConfig returns the statistics dashboard configuration as JSON
+func (s *SettingsService) GetDashboardConfig() string {
+    return toJSON(s.config.GetDashboard())
+}
+
+// UpdateDashboardConfig updates the visible stat cards (in display order) and default time range
+func (s *SettingsService) UpdateDashboardConfig(cards []string, timeRange string) error {
+    dashboard := &config.DashboardConfig{
+        Cards:     cards,
+        TimeRange: timeRange,
+    }
+    if err := dashboard.Validate(); err != nil {
+        return err
+    }
+
+    s.config.UpdateDashboard(dashboard)
+
+    if s.storage != nil {
+        configAdapter := storage.NewConfigStorageAdapter(s.storage)
+        if err := s.config.SaveToStorage(configAdapter); err != nil {
+            return fmt.Errorf("failed to save dashboard config: %w", err)
+        }
+    }
+
+    logger.Info("Dashboard config changed: cards=%s, timeRange=%s", strings.Join(cards, ","), timeRange)
     return nil
 }
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// SLA 达成状态（前端以红绿灯展示）
+const (
+	SLAStatusMet      = "met"      // 绿灯：p95 未超过阈值的 80%
+	SLAStatusWarning  = "warning"  // 黄灯：p95 接近阈值（80%~100%）
+	SLAStatusViolated = "violated" // 红灯：p95 超过阈值
+	SLAStatusNoData   = "no_data"  // 灰色：窗口内请求数不足，无法判定
+)
+
+// slaWarningRatio p95 达到阈值的该比例时标记为黄灯
+const slaWarningRatio = 0.8
+
+// maxSLARequestSamples 每个端点计算 p95 时最多读取的请求数
+const maxSLARequestSamples = 1000
+
+// maxSLAViolations 内存中保留的违约记录数
+const maxSLAViolations = 200
+
+// SLAEndpointStatus 端点 SLA 达成情况
+type SLAEndpointStatus struct {
+	EndpointName string    `json:"endpointName"`
+	ClientType   string    `json:"clientType"`
+	Status       string    `json:"status"`      // met, warning, violated, no_data
+	P95Ms        int64     `json:"p95Ms"`       // 窗口内 p95 响应时间
+	ThresholdMs  int       `json:"thresholdMs"` // SLA 阈值
+	Samples      int       `json:"samples"`     // 窗口内参与计算的请求数
+	CheckedAt    time.Time `json:"checkedAt"`
+}
+
+// SLAViolation SLA 违约记录
+type SLAViolation struct {
+	EndpointName string    `json:"endpointName"`
+	ClientType   string    `json:"clientType"`
+	P95Ms        int64     `json:"p95Ms"`
+	ThresholdMs  int       `json:"thresholdMs"`
+	Samples      int       `json:"samples"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// SLAService 周期性地基于 request_stats 计算各端点 p95 响应时间并与 SLA 阈值比较
+type SLAService struct {
+	config  *config.Config
+	storage *storage.SQLiteStorage
+
+	mu       sync.Mutex
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+
+	statusMu      sync.RWMutex
+	statuses      map[string]*SLAEndpointStatus // key: clientType:endpointName
+	violations    []SLAViolation
+	alertCallback AlertCallback
+}
+
+// NewSLAService creates a new SLAService
+func NewSLAService(cfg *config.Config, store *storage.SQLiteStorage) *SLAService {
+	return &SLAService{
+		config:   cfg,
+		storage:  store,
+		statuses: make(map[string]*SLAEndpointStatus),
+	}
+}
+
+// SetAlertCallback 设置违约告警回调
+func (s *SLAService) SetAlertCallback(callback AlertCallback) {
+	s.alertCallback = callback
+}
+
+// Start starts periodic SLA checks if SLA monitoring is enabled
+func (s *SLAService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	slaConfig := s.config.GetSLA()
+	if !slaConfig.Enabled {
+		return
+	}
+
+	interval := slaConfig.CheckIntervalMinutes
+	if interval <= 0 {
+		interval = 5
+	}
+
+	s.stopChan = make(chan struct{})
+	s.ticker = time.NewTicker(time.Duration(interval) * time.Minute)
+	s.running = true
+
+	logger.Info("SLA monitor started with interval %d minutes", interval)
+
+	go s.run(s.ticker, s.stopChan)
+}
+
+// Stop stops periodic SLA checks
+func (s *SLAService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+
+	logger.Info("SLA monitor stopped")
+}
+
+// Restart restarts the SLA monitor with the latest config
+func (s *SLAService) Restart() {
+	s.Stop()
+	s.Start()
+}
+
+// run is the main loop for SLA checks
+func (s *SLAService) run(ticker *time.Ticker, stop <-chan struct{}) {
+	s.CheckAll()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.CheckAll()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// CheckAll evaluates the SLA of all non-disabled endpoints
+func (s *SLAService) CheckAll() {
+	if s.storage == nil {
+		return
+	}
+
+	slaConfig := s.config.GetSLA()
+	now := time.Now()
+	windowStart := now.Add(-time.Duration(slaConfig.WindowMinutes) * time.Minute)
+
+	for _, ep := range s.config.GetEndpoints() {
+		if ep.Status == config.EndpointStatusDisabled {
+			continue
+		}
+
+		clientType := ep.ClientType
+		if clientType == "" {
+			clientType = "claude"
+		}
+
+		requests, err := s.storage.GetRecentRequestsByEndpoint(ep.Name, clientType, maxSLARequestSamples)
+		if err != nil {
+			logger.Warn("SLA: failed to get requests for %s: %v", ep.Name, err)
+			continue
+		}
+
+		status := evaluateSLA(requests, windowStart, s.config.GetEndpointSLAThreshold(ep), slaConfig.MinSamples)
+		status.EndpointName = ep.Name
+		status.ClientType = clientType
+		status.CheckedAt = now
+
+		s.updateStatus(status)
+	}
+}
+
+// evaluateSLA 计算窗口内成功请求的 p95 并与阈值比较
+func evaluateSLA(requests []storage.RequestStat, windowStart time.Time, thresholdMs, minSamples int) *SLAEndpointStatus {
+	durations := make([]int64, 0, len(requests))
+	for _, req := range requests {
+		if !req.Success || req.DurationMs <= 0 || req.Timestamp.Before(windowStart) {
+			continue
+		}
+		durations = append(durations, req.DurationMs)
+	}
+
+	status := &SLAEndpointStatus{
+		ThresholdMs: thresholdMs,
+		Samples:     len(durations),
+		Status:      SLAStatusNoData,
+	}
+	if len(durations) == 0 || len(durations) < minSamples || thresholdMs <= 0 {
+		return status
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	status.P95Ms = percentile(durations, 95)
+
+	switch {
+	case status.P95Ms > int64(thresholdMs):
+		status.Status = SLAStatusViolated
+	case float64(status.P95Ms) >= float64(thresholdMs)*slaWarningRatio:
+		status.Status = SLAStatusWarning
+	default:
+		status.Status = SLAStatusMet
+	}
+	return status
+}
+
+// percentile 计算已升序排序数据的百分位数（nearest-rank 法）
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// updateStatus 保存最新状态，状态由非违约变为违约时记录并告警
+func (s *SLAService) updateStatus(status *SLAEndpointStatus) {
+	key := status.ClientType + ":" + status.EndpointName
+
+	s.statusMu.Lock()
+	previous := s.statuses[key]
+	s.statuses[key] = status
+
+	newlyViolated := status.Status == SLAStatusViolated &&
+		(previous == nil || previous.Status != SLAStatusViolated)
+	if newlyViolated {
+		s.violations = append(s.violations, SLAViolation{
+			EndpointName: status.EndpointName,
+			ClientType:   status.ClientType,
+			P95Ms:        status.P95Ms,
+			ThresholdMs:  status.ThresholdMs,
+			Samples:      status.Samples,
+			Timestamp:    status.CheckedAt,
+		})
+		if len(s.violations) > maxSLAViolations {
+			s.violations = s.violations[len(s.violations)-maxSLAViolations:]
+		}
+	}
+	s.statusMu.Unlock()
+
+	if newlyViolated {
+		logger.Warn("SLA violated: endpoint %s p95 %dms > %dms (%d samples)",
+			status.EndpointName, status.P95Ms, status.ThresholdMs, status.Samples)
+		if s.alertCallback != nil {
+			s.alertCallback(AlertEvent{
+				EndpointName: status.EndpointName,
+				ClientType:   status.ClientType,
+				AlertType:    "sla",
+				Message: fmt.Sprintf("端点 %s 未达成 SLA: p95 响应时间 %dms 超过阈值 %dms",
+					status.EndpointName, status.P95Ms, status.ThresholdMs),
+				Timestamp: status.CheckedAt,
+			})
+		}
+	} else if previous != nil && previous.Status == SLAStatusViolated && status.Status != SLAStatusViolated {
+		logger.Info("SLA restored: endpoint %s p95 %dms <= %dms", status.EndpointName, status.P95Ms, status.ThresholdMs)
+	}
+}
+
+// GetSLAConfig returns the SLA config as JSON
+func (s *SLAService) GetSLAConfig() string {
+	return toJSON(s.config.GetSLA())
+}
+
+// UpdateSLAConfig updates the SLA config, persists it and restarts the monitor
+func (s *SLAService) UpdateSLAConfig(enabled bool, defaultP95Ms, windowMinutes, checkIntervalMinutes, minSamples int) error {
+	if defaultP95Ms <= 0 {
+		return fmt.Errorf("default p95 threshold must be positive")
+	}
+	if windowMinutes <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	if checkIntervalMinutes <= 0 {
+		return fmt.Errorf("check interval must be positive")
+	}
+	if minSamples < 1 {
+		minSamples = 1
+	}
+
+	s.config.UpdateSLA(&config.SLAConfig{
+		Enabled:              enabled,
+		DefaultP95Ms:         defaultP95Ms,
+		WindowMinutes:        windowMinutes,
+		CheckIntervalMinutes: checkIntervalMinutes,
+		MinSamples:           minSamples,
+	})
+
+	if s.storage != nil {
+		configAdapter := storage.NewConfigStorageAdapter(s.storage)
+		if err := s.config.SaveToStorage(configAdapter); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	if !enabled {
+		s.statusMu.Lock()
+		s.statuses = make(map[string]*SLAEndpointStatus)
+		s.statusMu.Unlock()
+	}
+
+	s.Restart()
+	return nil
+}
+
+// GetSLAStatus returns the latest SLA status of all checked endpoints as JSON
+func (s *SLAService) GetSLAStatus() string {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	statuses := make([]SLAEndpointStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ClientType != statuses[j].ClientType {
+			return statuses[i].ClientType < statuses[j].ClientType
+		}
+		return statuses[i].EndpointName < statuses[j].EndpointName
+	})
+
+	return successJSON(map[string]interface{}{
+		"enabled":  s.config.GetSLA().Enabled,
+		"statuses": statuses,
+	})
+}
+
+// GetSLAViolations returns the most recent SLA violations (newest first) as JSON
+func (s *SLAService) GetSLAViolations(limit int) string {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	if limit <= 0 || limit > len(s.violations) {
+		limit = len(s.violations)
+	}
+	violations := make([]SLAViolation, 0, limit)
+	for i := len(s.violations) - 1; i >= 0 && len(violations) < limit; i-- {
+		violations = append(violations, s.violations[i])
+	}
+
+	return successJSON(map[string]interface{}{
+		"violations": violations,
+	})
+}
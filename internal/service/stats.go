@@ -1,18 +1,22 @@
 package service
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/pricing"
 	"github.com/lich0821/ccNexus/internal/proxy"
 	"github.com/lich0821/ccNexus/internal/storage"
 )
 
 // StatsService handles statistics operations
 type StatsService struct {
-	proxy   *proxy.Proxy
-	config  *config.Config
-	storage storage.Storage
+	proxy    *proxy.Proxy
+	config   *config.Config
+	storage  storage.Storage
+	deviceID string // 本机 device_id，按设备统计时标记本机
 }
 
 // NewStatsService creates a new stats service
@@ -25,9 +29,23 @@ func (s *StatsService) SetStorage(st storage.Storage) {
 	s.storage = st
 }
 
+// SetDeviceID sets the local device ID for per-device stats
+func (s *StatsService) SetDeviceID(deviceID string) {
+	s.deviceID = deviceID
+}
+
+// now returns the current time in the stats time zone, so "today" matches the date column of recorded stats
+func (s *StatsService) now() time.Time {
+	return s.config.StatsNow()
+}
+
 // GetStats returns current statistics
 func (s *StatsService) GetStats() string {
-	totalRequests, endpointStats := s.proxy.GetStats().GetStats()
+	return s.getTotalStats(false)
+}
+
+func (s *StatsService) getTotalStats(excludeTest bool) string {
+	totalRequests, endpointStats := s.proxy.GetStats().GetStats(excludeTest)
 	return toJSON(map[string]interface{}{
 		"totalRequests": totalRequests,
 		"endpoints":     endpointStats,
@@ -36,39 +54,69 @@ func (s *StatsService) GetStats() string {
 
 // GetStatsDaily returns statistics for today
 func (s *StatsService) GetStatsDaily() string {
-	return s.getPeriodStats("daily", time.Now().Format("2006-01-02"), time.Now().Format("2006-01-02"))
+	return s.getPeriodStats("daily", s.now().Format("2006-01-02"), s.now().Format("2006-01-02"), false)
 }
 
 // GetStatsYesterday returns statistics for yesterday
 func (s *StatsService) GetStatsYesterday() string {
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-	return s.getPeriodStats("yesterday", yesterday, yesterday)
+	yesterday := s.now().AddDate(0, 0, -1).Format("2006-01-02")
+	return s.getPeriodStats("yesterday", yesterday, yesterday, false)
 }
 
 // GetStatsWeekly returns statistics for this week
 func (s *StatsService) GetStatsWeekly() string {
-	now := time.Now()
+	now := s.now()
+	return s.getPeriodStats("weekly", weekStartDate(now), now.Format("2006-01-02"), false)
+}
+
+// GetStatsMonthly returns statistics for this month
+func (s *StatsService) GetStatsMonthly() string {
+	now := s.now()
+	return s.getPeriodStats("monthly", monthStartDate(now), now.Format("2006-01-02"), false)
+}
+
+// GetStatsByPeriod returns statistics for total/daily/yesterday/weekly/monthly,
+// optionally excluding test requests (requests sent with X-CCNexus-Endpoint)
+func (s *StatsService) GetStatsByPeriod(period string, excludeTest bool) string {
+	now := s.now()
+	today := now.Format("2006-01-02")
+	switch period {
+	case "total":
+		return s.getTotalStats(excludeTest)
+	case "daily":
+		return s.getPeriodStats("daily", today, today, excludeTest)
+	case "yesterday":
+		yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+		return s.getPeriodStats("yesterday", yesterday, yesterday, excludeTest)
+	case "weekly":
+		return s.getPeriodStats("weekly", weekStartDate(now), today, excludeTest)
+	case "monthly":
+		return s.getPeriodStats("monthly", monthStartDate(now), today, excludeTest)
+	default:
+		return jsonError("Invalid period: " + period)
+	}
+}
+
+// weekStartDate returns the Monday of the week containing now
+func weekStartDate(now time.Time) string {
 	weekday := int(now.Weekday())
 	if weekday == 0 {
 		weekday = 7
 	}
-	startDate := now.AddDate(0, 0, -(weekday - 1)).Format("2006-01-02")
-	return s.getPeriodStats("weekly", startDate, now.Format("2006-01-02"))
+	return now.AddDate(0, 0, -(weekday - 1)).Format("2006-01-02")
 }
 
-// GetStatsMonthly returns statistics for this month
-func (s *StatsService) GetStatsMonthly() string {
-	now := time.Now()
-	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
-	return s.getPeriodStats("monthly", startDate, now.Format("2006-01-02"))
+// monthStartDate returns the first day of the month containing now
+func monthStartDate(now time.Time) string {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).Format("2006-01-02")
 }
 
-func (s *StatsService) getPeriodStats(period, startDate, endDate string) string {
+func (s *StatsService) getPeriodStats(period, startDate, endDate string, excludeTest bool) string {
 	var stats map[string]*proxy.DailyStats
 	if startDate == endDate {
-		stats = s.proxy.GetStats().GetDailyStats(startDate)
+		stats = s.proxy.GetStats().GetDailyStats(startDate, excludeTest)
 	} else {
-		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate)
+		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate, excludeTest)
 	}
 
 	var totalRequests, totalErrors int
@@ -83,6 +131,7 @@ func (s *StatsService) getPeriodStats(period, startDate, endDate string) string
 	}
 
 	activeEndpoints, totalEndpoints := s.countEndpoints()
+	estimatedCost, endpointCosts := s.estimateCosts(stats)
 
 	result := map[string]interface{}{
 		"period":                   period,
@@ -96,6 +145,8 @@ func (s *StatsService) getPeriodStats(period, startDate, endDate string) string
 		"activeEndpoints":          activeEndpoints,
 		"totalEndpoints":           totalEndpoints,
 		"endpoints":                stats,
+		"estimatedCost":            estimatedCost,
+		"endpointCosts":            endpointCosts,
 	}
 	if startDate == endDate {
 		result["date"] = startDate
@@ -107,6 +158,32 @@ func (s *StatsService) getPeriodStats(period, startDate, endDate string) string
 	return toJSON(result)
 }
 
+// estimateCosts 按端点配置的单价估算花费，返回总计和按端点（clientType:name）的明细
+// 未配置单价或已删除的端点花费记为 0
+func (s *StatsService) estimateCosts(stats map[string]*proxy.DailyStats) (pricing.CostBreakdown, map[string]pricing.CostBreakdown) {
+	endpointPricing := make(map[string]pricing.ModelPricing)
+	for _, ep := range s.config.GetEndpoints() {
+		clientType := ep.ClientType
+		if clientType == "" {
+			clientType = "claude"
+		}
+		endpointPricing[clientType+":"+ep.Name] = ep.Pricing()
+	}
+
+	var total pricing.CostBreakdown
+	endpointCosts := make(map[string]pricing.CostBreakdown, len(stats))
+	for key, st := range stats {
+		cost := pricing.CalculateCostBreakdown(st.InputTokens, st.OutputTokens, st.CacheCreationTokens, st.CacheReadTokens, endpointPricing[key])
+		endpointCosts[key] = cost
+		total.InputCost += cost.InputCost
+		total.OutputCost += cost.OutputCost
+		total.CacheWriteCost += cost.CacheWriteCost
+		total.CacheReadCost += cost.CacheReadCost
+		total.TotalCost += cost.TotalCost
+	}
+	return total, endpointCosts
+}
+
 func (s *StatsService) countEndpoints() (active, total int) {
 	endpoints := s.config.GetEndpoints()
 	total = len(endpoints)
@@ -125,7 +202,7 @@ func (s *StatsService) GetStatsTrend() string {
 
 // GetStatsTrendByPeriod returns trend comparison data for specified period
 func (s *StatsService) GetStatsTrendByPeriod(period string) string {
-	now := time.Now()
+	now := s.now()
 	var currentStart, currentEnd, prevStart, prevEnd string
 
 	switch period {
@@ -174,6 +251,47 @@ func (s *StatsService) GetStatsTrendByPeriod(period string) string {
 	})
 }
 
+// GetStatsTrendByRange returns trend comparison data for an arbitrary date range (inclusive, YYYY-MM-DD)
+// 上一周期取紧邻的等长区间，例如 14 天范围对比前 14 天
+func (s *StatsService) GetStatsTrendByRange(startDate, endDate string) string {
+	start, err := time.ParseInLocation("2006-01-02", startDate, s.config.StatsLocation())
+	if err != nil {
+		return errorJSON("Invalid start date, expected YYYY-MM-DD: " + startDate)
+	}
+	end, err := time.ParseInLocation("2006-01-02", endDate, s.config.StatsLocation())
+	if err != nil {
+		return errorJSON("Invalid end date, expected YYYY-MM-DD: " + endDate)
+	}
+	if end.Before(start) {
+		return errorJSON("End date must not be before start date")
+	}
+
+	days := int(end.Sub(start).Hours()/24+0.5) + 1
+	prevStart := start.AddDate(0, 0, -days).Format("2006-01-02")
+	prevEnd := start.AddDate(0, 0, -1).Format("2006-01-02")
+
+	current := s.sumStats(startDate, endDate)
+	prev := s.sumStats(prevStart, prevEnd)
+
+	return toJSON(map[string]interface{}{
+		"success":           true,
+		"startDate":         startDate,
+		"endDate":           endDate,
+		"previousStartDate": prevStart,
+		"previousEndDate":   prevEnd,
+		"days":              days,
+		"current":           current.requests,
+		"previous":          prev.requests,
+		"trend":             calculateTrend(current.requests, prev.requests),
+		"currentErrors":     current.errors,
+		"previousErrors":    prev.errors,
+		"errorsTrend":       calculateTrend(current.errors, prev.errors),
+		"currentTokens":     current.tokens,
+		"previousTokens":    prev.tokens,
+		"tokensTrend":       calculateTrend(current.tokens, prev.tokens),
+	})
+}
+
 type statsSummary struct {
 	requests, errors, tokens int
 }
@@ -181,9 +299,9 @@ type statsSummary struct {
 func (s *StatsService) sumStats(startDate, endDate string) statsSummary {
 	var stats map[string]*proxy.DailyStats
 	if startDate == endDate {
-		stats = s.proxy.GetStats().GetDailyStats(startDate)
+		stats = s.proxy.GetStats().GetDailyStats(startDate, false)
 	} else {
-		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate)
+		stats = s.proxy.GetStats().GetPeriodStats(startDate, endDate, false)
 	}
 
 	var sum statsSummary
@@ -215,7 +333,7 @@ func calculateTrend(current, previous int) float64 {
 
 // GetDailyRequestDetails returns detailed request-level statistics for today with pagination
 func (s *StatsService) GetDailyRequestDetails(limit, offset int) string {
-	today := time.Now().Format("2006-01-02")
+	today := s.now().Format("2006-01-02")
 	return s.getRequestDetailsByDate(today, limit, offset)
 }
 
@@ -265,6 +383,43 @@ func (s *StatsService) getRequestDetailsByDate(date string, limit, offset int) s
 	})
 }
 
+// SearchRequestStats searches request details by keyword in error message, model and endpoint name
+// startDate/endDate 为空时默认今天，clientType 为空时搜索所有客户端类型
+func (s *StatsService) SearchRequestStats(keyword, clientType, startDate, endDate string, limit, offset int) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+	if strings.TrimSpace(keyword) == "" {
+		return jsonError("Keyword is empty")
+	}
+
+	today := s.now().Format("2006-01-02")
+	if startDate == "" {
+		startDate = today
+	}
+	if endDate == "" {
+		endDate = today
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	requests, total, err := s.storage.SearchRequestStats(keyword, clientType, startDate, endDate, limit, offset)
+	if err != nil {
+		return jsonError("Failed to search request details: " + err.Error())
+	}
+
+	return successJSON(map[string]interface{}{
+		"keyword":   keyword,
+		"startDate": startDate,
+		"endDate":   endDate,
+		"requests":  requests,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
 // GetTokenTrendData returns token usage trend data for charting
 // startTime and endTime are optional time filters in "HH:MM" format (empty string means auto)
 func (s *StatsService) GetTokenTrendData(granularity, period, startTime, endTime string) string {
@@ -274,7 +429,7 @@ func (s *StatsService) GetTokenTrendData(granularity, period, startTime, endTime
 
 	// Calculate date range based on period
 	var startDate, endDate string
-	now := time.Now()
+	now := s.now()
 
 	switch period {
 	case "yesterday":
@@ -327,14 +482,17 @@ func (s *StatsService) GetTokenTrendData(granularity, period, startTime, endTime
 // intervalMinutes: 5 or 30
 // startTime/endTime: optional "HH:MM" format, empty means auto-calculate
 func (s *StatsService) aggregateByMinutes(requests []storage.RequestStat, startDate, endDate, period string, intervalMinutes int, startTime, endTime string) map[string]interface{} {
+	// 时间槽按统计时区划分，与 date 列一致
+	loc := s.config.StatsLocation()
+
 	// Find first and last request times for auto range calculation
 	var firstRequestTime, lastRequestTime string
 	if len(requests) > 0 {
 		// Requests are in DESC order, so last element is earliest
 		firstReq := requests[len(requests)-1]
 		lastReq := requests[0]
-		firstRequestTime = firstReq.Timestamp.Format("15:04")
-		lastRequestTime = lastReq.Timestamp.Format("15:04")
+		firstRequestTime = firstReq.Timestamp.In(loc).Format("15:04")
+		lastRequestTime = lastReq.Timestamp.In(loc).Format("15:04")
 	}
 
 	// Calculate effective time range
@@ -351,7 +509,7 @@ func (s *StatsService) aggregateByMinutes(requests []storage.RequestStat, startD
 	totalOutput := make([]int, len(timeSlots))
 
 	for _, req := range requests {
-		absoluteSlotIndex := getTimeSlotIndex(req.Timestamp, intervalMinutes)
+		absoluteSlotIndex := getTimeSlotIndex(req.Timestamp.In(loc), intervalMinutes)
 		// Convert to relative index within our range
 		relativeSlotIndex := absoluteSlotIndex - startSlotIndex
 		if relativeSlotIndex < 0 || relativeSlotIndex >= len(timeSlots) {
@@ -527,6 +685,7 @@ func (s *StatsService) aggregateByRequest(requests []storage.RequestStat, period
 
 	numRequests := len(requests)
 	timestamps := make([]string, numRequests)
+	loc := s.config.StatsLocation()
 
 	// First pass: collect all unique endpoint names
 	endpointNames := make(map[string]bool)
@@ -548,7 +707,7 @@ func (s *StatsService) aggregateByRequest(requests []storage.RequestStat, period
 
 	// Second pass: fill in the data
 	for i, req := range requests {
-		timestamps[i] = req.Timestamp.Format("15:04:05")
+		timestamps[i] = req.Timestamp.In(loc).Format("15:04:05")
 
 		// Merge cache tokens into input
 		inputTotal := req.InputTokens + req.CacheCreationTokens + req.CacheReadTokens
@@ -612,6 +771,9 @@ func calculateTokensPerSecond(tokens int, durationMs int64) float64 {
 	return float64(tokens) / durationSec
 }
 
+// minPercentileSamples 样本数少于该值时百分位统计仍返回，但标注数据不足
+const minPercentileSamples = 20
+
 // calculatePerformanceMetrics calculates performance metrics from request stats
 // Includes all requests (both successful and failed) with non-zero duration
 func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]interface{} {
@@ -619,6 +781,7 @@ func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]inte
 	var totalDurationMs int64
 	var minDurationMs, maxDurationMs int64
 	var streamingCount, nonStreamingCount int
+	var durations []int64
 	validCount := 0
 
 	for _, req := range requests {
@@ -628,6 +791,7 @@ func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]inte
 			totalOutputTokens += req.OutputTokens
 			totalTokens += inputTotal + req.OutputTokens
 			totalDurationMs += req.DurationMs
+			durations = append(durations, req.DurationMs)
 
 			// Track min/max duration
 			if validCount == 0 || req.DurationMs < minDurationMs {
@@ -649,7 +813,7 @@ func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]inte
 	}
 
 	if validCount == 0 || totalDurationMs == 0 {
-		return map[string]interface{}{
+		return addTransferMetrics(map[string]interface{}{
 			"outputTokensPerSec":  0.0,
 			"inputTokensPerSec":   0.0,
 			"totalTokensPerSec":   0.0,
@@ -660,14 +824,21 @@ func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]inte
 			"nonStreamingCount":   0,
 			"streamingPercentage": 0.0,
 			"validRequests":       0,
-		}
+			"p50DurationMs":       0,
+			"p95DurationMs":       0,
+			"p99DurationMs":       0,
+			"insufficientSamples": true,
+		}, requests)
 	}
 
 	durationSec := float64(totalDurationMs) / 1000.0
 	avgDurationMs := float64(totalDurationMs) / float64(validCount)
 	streamingPercentage := float64(streamingCount) / float64(validCount) * 100.0
 
-	return map[string]interface{}{
+	// 百分位用于发现长尾慢请求
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return addTransferMetrics(map[string]interface{}{
 		"outputTokensPerSec":  float64(totalOutputTokens) / durationSec,
 		"inputTokensPerSec":   float64(totalInputTokens) / durationSec,
 		"totalTokensPerSec":   float64(totalTokens) / durationSec,
@@ -678,18 +849,45 @@ func calculatePerformanceMetrics(requests []storage.RequestStat) map[string]inte
 		"nonStreamingCount":   nonStreamingCount,
 		"streamingPercentage": streamingPercentage,
 		"validRequests":       validCount,
-	}
+		"p50DurationMs":       percentile(durations, 50),
+		"p95DurationMs":       percentile(durations, 95),
+		"p99DurationMs":       percentile(durations, 99),
+		"insufficientSamples": validCount < minPercentileSamples,
+	}, requests)
 }
 
-// GetPerformanceStats returns performance metrics for a time period
-func (s *StatsService) GetPerformanceStats(period string) string {
-	if s.storage == nil {
-		return jsonError("Storage not initialized")
+// addTransferMetrics adds request/response byte size metrics to the given metrics map
+// Only records with byte sizes are counted (old records from before migration have 0)
+func addTransferMetrics(metrics map[string]interface{}, requests []storage.RequestStat) map[string]interface{} {
+	var totalRequestBytes, totalResponseBytes int64
+	transferCount := 0
+
+	for _, req := range requests {
+		if req.RequestBytes == 0 && req.ResponseBytes == 0 {
+			continue
+		}
+		totalRequestBytes += req.RequestBytes
+		totalResponseBytes += req.ResponseBytes
+		transferCount++
 	}
 
-	// Calculate date range based on period
-	var startDate, endDate string
-	now := time.Now()
+	var avgRequestBytes, avgResponseBytes float64
+	if transferCount > 0 {
+		avgRequestBytes = float64(totalRequestBytes) / float64(transferCount)
+		avgResponseBytes = float64(totalResponseBytes) / float64(transferCount)
+	}
+
+	metrics["totalRequestBytes"] = totalRequestBytes
+	metrics["totalResponseBytes"] = totalResponseBytes
+	metrics["totalTransferBytes"] = totalRequestBytes + totalResponseBytes
+	metrics["avgRequestBytes"] = avgRequestBytes
+	metrics["avgResponseBytes"] = avgResponseBytes
+	metrics["transferRequests"] = transferCount
+	return metrics
+}
+
+// periodDateRange returns the date range (inclusive) of a statistics period
+func periodDateRange(period string, now time.Time) (startDate, endDate string) {
 
 	switch period {
 	case "yesterday":
@@ -709,6 +907,16 @@ func (s *StatsService) GetPerformanceStats(period string) string {
 		startDate = now.Format("2006-01-02")
 		endDate = startDate
 	}
+	return startDate, endDate
+}
+
+// GetPerformanceStats returns performance metrics for a time period
+func (s *StatsService) GetPerformanceStats(period string) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+
+	startDate, endDate := periodDateRange(period, s.now())
 
 	// Fetch all requests for the period
 	requests, err := s.storage.GetRequestStats("", "", startDate, endDate, 10000, 0)
@@ -719,6 +927,10 @@ func (s *StatsService) GetPerformanceStats(period string) string {
 	// Calculate overall metrics
 	overallMetrics := calculatePerformanceMetrics(requests)
 
+	// Streaming durations include the whole generation, so report streaming and
+	// non-streaming requests separately to keep them from skewing each other
+	streamingRequests, nonStreamingRequests := splitByStreaming(requests)
+
 	// Group requests by endpoint
 	endpointRequests := make(map[string][]storage.RequestStat)
 	for _, req := range requests {
@@ -726,20 +938,44 @@ func (s *StatsService) GetPerformanceStats(period string) string {
 		endpointRequests[key] = append(endpointRequests[key], req)
 	}
 
-	// Calculate per-endpoint metrics
+	// Calculate per-endpoint metrics, overall and per request type
 	endpointMetrics := make(map[string]map[string]interface{})
+	endpointRequestTypeMetrics := make(map[string]map[string]interface{})
 	for key, reqs := range endpointRequests {
 		endpointMetrics[key] = calculatePerformanceMetrics(reqs)
+		endpointRequestTypeMetrics[key] = requestTypeMetrics(splitByStreaming(reqs))
 	}
 
 	return successJSON(map[string]interface{}{
-		"period":          period,
-		"dateRange":       map[string]string{"start": startDate, "end": endDate},
-		"overallMetrics":  overallMetrics,
-		"endpointMetrics": endpointMetrics,
+		"period":                     period,
+		"dateRange":                  map[string]string{"start": startDate, "end": endDate},
+		"overallMetrics":             overallMetrics,
+		"endpointMetrics":            endpointMetrics,
+		"requestTypeMetrics":         requestTypeMetrics(streamingRequests, nonStreamingRequests),
+		"endpointRequestTypeMetrics": endpointRequestTypeMetrics,
 	})
 }
 
+// requestTypeMetrics calculates performance metrics for streaming and non-streaming requests
+func requestTypeMetrics(streaming, nonStreaming []storage.RequestStat) map[string]interface{} {
+	return map[string]interface{}{
+		"streaming":    calculatePerformanceMetrics(streaming),
+		"nonStreaming": calculatePerformanceMetrics(nonStreaming),
+	}
+}
+
+// splitByStreaming splits request stats into streaming and non-streaming requests
+func splitByStreaming(requests []storage.RequestStat) (streaming, nonStreaming []storage.RequestStat) {
+	for _, req := range requests {
+		if req.IsStreaming {
+			streaming = append(streaming, req)
+		} else {
+			nonStreaming = append(nonStreaming, req)
+		}
+	}
+	return streaming, nonStreaming
+}
+
 // GetRecentRequestStats returns the most recent request records for monitoring display
 func (s *StatsService) GetRecentRequestStats(limit int) string {
 	if s.storage == nil {
@@ -747,8 +983,8 @@ func (s *StatsService) GetRecentRequestStats(limit int) string {
 	}
 
 	// Get requests from the last 7 days to ensure we have data
-	endDate := time.Now().Format("2006-01-02")
-	startDate := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	endDate := s.now().Format("2006-01-02")
+	startDate := s.now().AddDate(0, 0, -7).Format("2006-01-02")
 	requests, err := s.storage.GetRequestStats("", "", startDate, endDate, limit, 0)
 	if err != nil {
 		return toJSON(map[string]interface{}{"requests": []interface{}{}})
@@ -756,3 +992,99 @@ func (s *StatsService) GetRecentRequestStats(limit int) string {
 
 	return toJSON(map[string]interface{}{"requests": requests})
 }
+
+// minReliabilitySamples 请求数少于该值的端点标注样本不足，仍列出但不参与成功率排名
+const minReliabilitySamples = 10
+
+// EndpointReliability 端点在统计周期内的成功率和平均延迟
+type EndpointReliability struct {
+	EndpointName        string  `json:"endpointName"`
+	ClientType          string  `json:"clientType"`
+	Requests            int     `json:"requests"`
+	Success             int     `json:"success"`
+	Errors              int     `json:"errors"`
+	SuccessRate         float64 `json:"successRate"`  // 成功率（百分比）
+	AvgLatencyMs        float64 `json:"avgLatencyMs"` // 成功请求的平均耗时
+	Rank                int     `json:"rank"`         // 排名，样本不足时为 0
+	InsufficientSamples bool    `json:"insufficientSamples"`
+}
+
+// GetEndpointReliabilityRanking returns endpoints of a period ranked by success rate (desc), then average latency (asc)
+// Endpoints with fewer than minReliabilitySamples requests are listed after the ranked ones without a rank
+func (s *StatsService) GetEndpointReliabilityRanking(period string) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+
+	startDate, endDate := periodDateRange(period, s.now())
+	requests, err := s.storage.GetRequestStats("", "", startDate, endDate, 10000, 0)
+	if err != nil {
+		return jsonError("Failed to get request stats: " + err.Error())
+	}
+
+	byEndpoint := make(map[string]*EndpointReliability)
+	latencyTotal := make(map[string]int64)
+	latencyCount := make(map[string]int)
+	for _, req := range requests {
+		key := req.ClientType + ":" + req.EndpointName
+		item, ok := byEndpoint[key]
+		if !ok {
+			item = &EndpointReliability{EndpointName: req.EndpointName, ClientType: req.ClientType}
+			byEndpoint[key] = item
+		}
+		item.Requests++
+		if !req.Success {
+			item.Errors++
+			continue
+		}
+		item.Success++
+		// 失败请求的耗时多为超时或立即报错，不计入平均延迟
+		if req.DurationMs > 0 {
+			latencyTotal[key] += req.DurationMs
+			latencyCount[key]++
+		}
+	}
+
+	ranking := make([]*EndpointReliability, 0, len(byEndpoint))
+	for key, item := range byEndpoint {
+		item.SuccessRate = float64(item.Success) / float64(item.Requests) * 100.0
+		if latencyCount[key] > 0 {
+			item.AvgLatencyMs = float64(latencyTotal[key]) / float64(latencyCount[key])
+		}
+		item.InsufficientSamples = item.Requests < minReliabilitySamples
+		ranking = append(ranking, item)
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.InsufficientSamples != b.InsufficientSamples {
+			return !a.InsufficientSamples
+		}
+		if a.InsufficientSamples {
+			if a.Requests != b.Requests {
+				return a.Requests > b.Requests
+			}
+			return a.EndpointName < b.EndpointName
+		}
+		if a.SuccessRate != b.SuccessRate {
+			return a.SuccessRate > b.SuccessRate
+		}
+		if a.AvgLatencyMs != b.AvgLatencyMs {
+			return a.AvgLatencyMs < b.AvgLatencyMs
+		}
+		return a.EndpointName < b.EndpointName
+	})
+	for i, item := range ranking {
+		if item.InsufficientSamples {
+			break
+		}
+		item.Rank = i + 1
+	}
+
+	return successJSON(map[string]interface{}{
+		"period":     period,
+		"dateRange":  map[string]string{"start": startDate, "end": endDate},
+		"minSamples": minReliabilitySamples,
+		"ranking":    ranking,
+	})
+}
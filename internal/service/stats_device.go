@@ -0,0 +1,84 @@
+package service
+
+import (
+	"sort"
+	"time"
+)
+
+// DeviceStats 按 device_id 聚合的用量统计
+type DeviceStats struct {
+	DeviceID            string `json:"deviceId"`
+	IsLocal             bool   `json:"isLocal"` // 是否为本机
+	Requests            int    `json:"requests"`
+	Errors              int    `json:"errors"`
+	InputTokens         int64  `json:"inputTokens"`
+	CacheCreationTokens int64  `json:"cacheCreationTokens"`
+	CacheReadTokens     int64  `json:"cacheReadTokens"`
+	OutputTokens        int64  `json:"outputTokens"`
+	TotalTokens         int64  `json:"totalTokens"`
+}
+
+func (d *DeviceStats) add(other DeviceStats) {
+	d.Requests += other.Requests
+	d.Errors += other.Errors
+	d.InputTokens += other.InputTokens
+	d.CacheCreationTokens += other.CacheCreationTokens
+	d.CacheReadTokens += other.CacheReadTokens
+	d.OutputTokens += other.OutputTokens
+	d.TotalTokens += other.TotalTokens
+}
+
+// GetDeviceStats returns usage of each device within a date range (inclusive, YYYY-MM-DD; empty means no limit),
+// sorted by total tokens. merged 为 true 时只返回合并所有设备的汇总，不列出各设备
+func (s *StatsService) GetDeviceStats(startDate, endDate string, merged bool) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+	for _, date := range []string{startDate, endDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+			return errorJSON("Invalid date, expected YYYY-MM-DD: " + date)
+		}
+	}
+
+	byDevice, err := s.storage.GetStatsByDevice(startDate, endDate)
+	if err != nil {
+		return jsonError("Failed to get device stats: " + err.Error())
+	}
+
+	total := DeviceStats{DeviceID: "all"}
+	devices := make([]DeviceStats, 0, len(byDevice))
+	for deviceID, stat := range byDevice {
+		item := DeviceStats{
+			DeviceID:            deviceID,
+			IsLocal:             deviceID == s.deviceID,
+			Requests:            stat.Requests,
+			Errors:              stat.Errors,
+			InputTokens:         stat.InputTokens,
+			CacheCreationTokens: stat.CacheCreationTokens,
+			CacheReadTokens:     stat.CacheReadTokens,
+			OutputTokens:        stat.OutputTokens,
+			TotalTokens:         stat.InputTokens + stat.CacheCreationTokens + stat.CacheReadTokens + stat.OutputTokens,
+		}
+		total.add(item)
+		devices = append(devices, item)
+	}
+
+	result := map[string]interface{}{
+		"dateRange": map[string]string{"start": startDate, "end": endDate},
+		"merged":    merged,
+		"total":     total,
+	}
+	if !merged {
+		sort.Slice(devices, func(i, j int) bool {
+			if devices[i].TotalTokens != devices[j].TotalTokens {
+				return devices[i].TotalTokens > devices[j].TotalTokens
+			}
+			return devices[i].DeviceID < devices[j].DeviceID
+		})
+		result["devices"] = devices
+	}
+	return successJSON(result)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/lich0821/ccNexus/internal/proxy"
+)
+
+// ErrorCategoryStats 一个错误分类在统计周期内的失败次数和占比
+type ErrorCategoryStats struct {
+	Category string  `json:"category"`
+	Count    int     `json:"count"`
+	Percent  float64 `json:"percent"` // 占全部失败请求的百分比
+}
+
+// GetErrorBreakdown returns failed requests of a period (daily, yesterday, weekly, monthly)
+// grouped by error category, sorted by count. 测试请求不计入
+func (s *StatsService) GetErrorBreakdown(period string) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+
+	startDate, endDate := periodDateRange(period, s.now())
+
+	counts, totalRequests, err := s.storage.GetErrorCategoryCounts(startDate, endDate)
+	if err != nil {
+		return jsonError("Failed to get error breakdown: " + err.Error())
+	}
+
+	totalErrors := 0
+	for _, count := range counts {
+		totalErrors += count
+	}
+
+	// 列出所有已知分类（没有失败的分类计数为 0），便于前端固定展示顺序
+	categories := make([]ErrorCategoryStats, 0, len(proxy.ErrorCategories))
+	known := make(map[string]bool, len(proxy.ErrorCategories))
+	for _, category := range proxy.ErrorCategories {
+		known[category] = true
+		categories = append(categories, ErrorCategoryStats{Category: category, Count: counts[category]})
+	}
+	for category, count := range counts {
+		if !known[category] {
+			categories = append(categories, ErrorCategoryStats{Category: category, Count: count})
+		}
+	}
+	for i := range categories {
+		if totalErrors > 0 {
+			categories[i].Percent = float64(categories[i].Count) / float64(totalErrors) * 100.0
+		}
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].Count > categories[j].Count
+	})
+
+	var errorRate float64
+	if totalRequests > 0 {
+		errorRate = float64(totalErrors) / float64(totalRequests) * 100.0
+	}
+
+	return successJSON(map[string]interface{}{
+		"period":        period,
+		"dateRange":     map[string]string{"start": startDate, "end": endDate},
+		"totalRequests": totalRequests,
+		"totalErrors":   totalErrors,
+		"errorRate":     errorRate,
+		"categories":    categories,
+	})
+}
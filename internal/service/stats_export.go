@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// utf8BOM 写在 CSV 开头，让 Excel 以 UTF-8 识别中文端点名
+const utf8BOM = "\ufeff"
+
+// statsCSVHeader CSV 表头
+var statsCSVHeader = []string{
+	"date", "endpoint", "client_type", "requests", "errors",
+	"input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens",
+}
+
+// ExportStatsCSV exports the daily stats of a period (daily, yesterday, weekly, monthly)
+// as CSV text, one row per date/endpoint/client_type
+func (s *StatsService) ExportStatsCSV(period string) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+
+	startDate, endDate := periodDateRange(period, s.now())
+
+	allStats, err := s.storage.GetAllStats()
+	if err != nil {
+		return jsonError("Failed to get daily stats: " + err.Error())
+	}
+
+	var rows []storage.DailyStat
+	for _, stats := range allStats {
+		for _, stat := range stats {
+			if stat.Date < startDate || stat.Date > endDate {
+				continue
+			}
+			rows = append(rows, stat)
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Date != rows[j].Date {
+			return rows[i].Date < rows[j].Date
+		}
+		if rows[i].ClientType != rows[j].ClientType {
+			return rows[i].ClientType < rows[j].ClientType
+		}
+		return rows[i].EndpointName < rows[j].EndpointName
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	// encoding/csv 负责对包含逗号、引号、换行的字段加引号转义
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+	w.Write(statsCSVHeader)
+	for _, row := range rows {
+		w.Write([]string{
+			row.Date,
+			row.EndpointName,
+			row.ClientType,
+			strconv.Itoa(row.Requests),
+			strconv.Itoa(row.Errors),
+			strconv.Itoa(row.InputTokens),
+			strconv.Itoa(row.OutputTokens),
+			strconv.Itoa(row.CacheCreationTokens),
+			strconv.Itoa(row.CacheReadTokens),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return jsonError("Failed to write CSV: " + err.Error())
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// statsImportTimeout 拉取远程统计数据的超时时间
+const statsImportTimeout = 30 * time.Second
+
+// statsImportMaxBytes 远程统计数据最大体积，防止误填地址时读取超大响应
+const statsImportMaxBytes = 64 << 20
+
+// ImportStatsFromURL pulls daily stats from another ccNexus instance's /stats/export endpoint
+// and merges them into the local daily_stats according to strategy.
+// rawURL 可以是完整的导出地址，也可以是实例根地址（如 http://192.168.1.10:3000）
+func (s *StatsService) ImportStatsFromURL(rawURL string, strategy storage.MergeStrategy) error {
+	if s.storage == nil {
+		return fmt.Errorf("storage not initialized")
+	}
+
+	exportURL, err := statsExportURL(rawURL)
+	if err != nil {
+		return err
+	}
+
+	export, err := fetchStatsExport(exportURL)
+	if err != nil {
+		return err
+	}
+
+	if localDeviceID, _ := s.storage.GetConfig("device_id"); localDeviceID != "" && localDeviceID == export.DeviceID {
+		return fmt.Errorf("cannot import stats from this instance itself")
+	}
+
+	result, err := s.storage.ImportDailyStats(export, strategy)
+	if err != nil {
+		return fmt.Errorf("failed to import stats: %w", err)
+	}
+
+	logger.Info("Imported stats from %s (device %s): %d rows imported, %d skipped",
+		exportURL, export.DeviceID, result.Imported, result.Skipped)
+	return nil
+}
+
+// statsExportURL normalizes the user input to the /stats/export URL of the remote instance
+func statsExportURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", fmt.Errorf("URL is empty")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid URL: %s", rawURL)
+	}
+	if path := strings.TrimRight(parsed.Path, "/"); !strings.HasSuffix(path, "/stats/export") {
+		parsed.Path = path + "/stats/export"
+	}
+	return parsed.String(), nil
+}
+
+// fetchStatsExport downloads and validates the stats export of a remote instance
+func fetchStatsExport(exportURL string) (*storage.StatsExport, error) {
+	client := &http.Client{Timeout: statsImportTimeout}
+	resp, err := client.Get(exportURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var export storage.StatsExport
+	if err := json.NewDecoder(io.LimitReader(resp.Body, statsImportMaxBytes)).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid stats export: %w", err)
+	}
+	if export.Version <= 0 || export.Version > storage.StatsExportVersion {
+		return nil, fmt.Errorf("unsupported stats export version: %d", export.Version)
+	}
+	if export.DeviceID == "" {
+		return nil, fmt.Errorf("invalid stats export: missing deviceId")
+	}
+	return &export, nil
+}
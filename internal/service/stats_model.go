@@ -0,0 +1,88 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/pricing"
+)
+
+// ModelStats 按 model 聚合的请求统计
+type ModelStats struct {
+	Model               string  `json:"model"`
+	Requests            int     `json:"requests"`
+	Errors              int     `json:"errors"`
+	InputTokens         int64   `json:"inputTokens"`
+	CacheCreationTokens int64   `json:"cacheCreationTokens"`
+	CacheReadTokens     int64   `json:"cacheReadTokens"`
+	OutputTokens        int64   `json:"outputTokens"`
+	TotalTokens         int64   `json:"totalTokens"`
+	EstimatedCost       float64 `json:"estimatedCost"` // 按模型名匹配定价表估算（美元）
+}
+
+// pricingTransformerForModel 根据模型名推断定价表，统计中只有模型名，没有端点的转换器信息
+func pricingTransformerForModel(model string) string {
+	m := strings.ToLower(model)
+	switch {
+	case strings.HasPrefix(m, "gemini"):
+		return "gemini"
+	case strings.HasPrefix(m, "gpt"), strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return "openai"
+	default:
+		return "claude"
+	}
+}
+
+// GetModelStats returns request stats of a period (daily, yesterday, weekly, monthly)
+// aggregated by model, sorted by total tokens
+func (s *StatsService) GetModelStats(period string) string {
+	if s.storage == nil {
+		return jsonError("Storage not initialized")
+	}
+
+	startDate, endDate := periodDateRange(period, s.now())
+
+	byModel, err := s.storage.GetStatsByModel(startDate, endDate)
+	if err != nil {
+		return jsonError("Failed to get model stats: " + err.Error())
+	}
+
+	models := make([]ModelStats, 0, len(byModel))
+	var totalCost float64
+	for model, stat := range byModel {
+		item := ModelStats{
+			Model:               model,
+			Requests:            stat.Requests,
+			Errors:              stat.Errors,
+			InputTokens:         stat.InputTokens,
+			CacheCreationTokens: stat.CacheCreationTokens,
+			CacheReadTokens:     stat.CacheReadTokens,
+			OutputTokens:        stat.OutputTokens,
+			TotalTokens:         stat.InputTokens + stat.CacheCreationTokens + stat.CacheReadTokens + stat.OutputTokens,
+		}
+		pricingInfo := pricing.GetPricing(pricingTransformerForModel(model), model)
+		item.EstimatedCost = pricing.CalculateCost(
+			int(stat.InputTokens),
+			int(stat.OutputTokens),
+			int(stat.CacheCreationTokens),
+			int(stat.CacheReadTokens),
+			pricingInfo,
+		)
+		totalCost += item.EstimatedCost
+		models = append(models, item)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].TotalTokens != models[j].TotalTokens {
+			return models[i].TotalTokens > models[j].TotalTokens
+		}
+		return models[i].Model < models[j].Model
+	})
+
+	return successJSON(map[string]interface{}{
+		"period":    period,
+		"dateRange": map[string]string{"start": startDate, "end": endDate},
+		"models":    models,
+		"totalCost": totalCost,
+	})
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/logger"
+	"github.com/lich0821/ccNexus/internal/proxy"
+	"github.com/lich0821/ccNexus/internal/storage"
+)
+
+// tokenRateCheckInterval token 消耗速率的检查间隔
+const tokenRateCheckInterval = 10 * time.Second
+
+// TokenRateAlertService 基于滑动窗口定期计算实时 token 消耗速率，超过阈值时告警，
+// 用于及时发现异常高频调用或死循环导致的失控消耗
+type TokenRateAlertService struct {
+	config  *config.Config
+	proxy   *proxy.Proxy
+	storage *storage.SQLiteStorage
+
+	mu       sync.Mutex
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+
+	stateMu       sync.RWMutex
+	exceeded      bool      // 上次检查时是否超过阈值
+	lastAlertTime time.Time // 上次告警时间
+	alertCallback AlertCallback
+}
+
+// NewTokenRateAlertService creates a new TokenRateAlertService
+func NewTokenRateAlertService(cfg *config.Config, p *proxy.Proxy, store *storage.SQLiteStorage) *TokenRateAlertService {
+	return &TokenRateAlertService{
+		config:  cfg,
+		proxy:   p,
+		storage: store,
+	}
+}
+
+// SetAlertCallback 设置告警回调
+func (s *TokenRateAlertService) SetAlertCallback(callback AlertCallback) {
+	s.alertCallback = callback
+}
+
+// Start starts periodic token rate checks if the alert is enabled
+func (s *TokenRateAlertService) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running || !s.config.GetTokenRateAlert().Enabled {
+		return
+	}
+
+	s.stopChan = make(chan struct{})
+	s.ticker = time.NewTicker(tokenRateCheckInterval)
+	s.running = true
+
+	logger.Info("Token rate alert started, threshold %d tokens/min", s.config.GetTokenRateAlert().ThresholdPerMinute)
+
+	go s.run(s.ticker, s.stopChan)
+}
+
+// Stop stops periodic token rate checks
+func (s *TokenRateAlertService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.ticker.Stop()
+	close(s.stopChan)
+	s.running = false
+
+	logger.Info("Token rate alert stopped")
+}
+
+// Restart restarts the checks with the latest config
+func (s *TokenRateAlertService) Restart() {
+	s.Stop()
+	s.Start()
+}
+
+// run is the main loop for token rate checks
+func (s *TokenRateAlertService) run(ticker *time.Ticker, stop <-chan struct{}) {
+	for {
+		select {
+		case <-ticker.C:
+			s.Check()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Check 计算当前消耗速率，超过阈值且不在冷却期内时触发告警
+func (s *TokenRateAlertService) Check() {
+	alertConfig := s.config.GetTokenRateAlert()
+	if alertConfig.ThresholdPerMinute <= 0 {
+		return
+	}
+
+	snapshot := s.proxy.GetTokenRate(alertConfig.WindowSeconds)
+	now := time.Now()
+	exceeded := snapshot.TokensPerMinute > float64(alertConfig.ThresholdPerMinute)
+	cooldown := time.Duration(alertConfig.CooldownMinutes) * time.Minute
+
+	s.stateMu.Lock()
+	wasExceeded := s.exceeded
+	s.exceeded = exceeded
+	shouldAlert := exceeded && (s.lastAlertTime.IsZero() || now.Sub(s.lastAlertTime) >= cooldown)
+	if shouldAlert {
+		s.lastAlertTime = now
+	}
+	s.stateMu.Unlock()
+
+	if !exceeded {
+		if wasExceeded {
+			logger.Info("Token rate back to normal: %.0f tokens/min <= %d", snapshot.TokensPerMinute, alertConfig.ThresholdPerMinute)
+		}
+		return
+	}
+	if !shouldAlert {
+		return
+	}
+
+	topEndpoint := ""
+	if len(snapshot.TopEndpoints) > 0 {
+		topEndpoint = snapshot.TopEndpoints[0].EndpointName
+	}
+	logger.Warn("Token rate exceeded: %.0f tokens/min > %d (top endpoint: %s)",
+		snapshot.TokensPerMinute, alertConfig.ThresholdPerMinute, topEndpoint)
+
+	if s.alertCallback != nil {
+		message := fmt.Sprintf("token 消耗速率 %.0f/分钟 超过阈值 %d/分钟", snapshot.TokensPerMinute, alertConfig.ThresholdPerMinute)
+		if topEndpoint != "" {
+			message += fmt.Sprintf("，消耗最多的端点: %s", topEndpoint)
+		}
+		s.alertCallback(AlertEvent{
+			EndpointName: topEndpoint,
+			AlertType:    "token_rate",
+			Message:      message,
+			Timestamp:    now,
+		})
+	}
+}
+
+// GetTokenRateAlertConfig returns the token rate alert config as JSON
+func (s *TokenRateAlertService) GetTokenRateAlertConfig() string {
+	return toJSON(s.config.GetTokenRateAlert())
+}
+
+// UpdateTokenRateAlertConfig updates the token rate alert config, persists it and restarts the checks
+func (s *TokenRateAlertService) UpdateTokenRateAlertConfig(enabled bool, thresholdPerMinute, windowSeconds, cooldownMinutes int) error {
+	if thresholdPerMinute <= 0 {
+		return fmt.Errorf("threshold must be positive")
+	}
+	if windowSeconds <= 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	if cooldownMinutes < 0 {
+		cooldownMinutes = 0
+	}
+
+	s.config.UpdateTokenRateAlert(&config.TokenRateAlertConfig{
+		Enabled:            enabled,
+		ThresholdPerMinute: thresholdPerMinute,
+		WindowSeconds:      windowSeconds,
+		CooldownMinutes:    cooldownMinutes,
+	})
+
+	if s.storage != nil {
+		configAdapter := storage.NewConfigStorageAdapter(s.storage)
+		if err := s.config.SaveToStorage(configAdapter); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	s.stateMu.Lock()
+	s.exceeded = false
+	s.lastAlertTime = time.Time{}
+	s.stateMu.Unlock()
+
+	s.Restart()
+	return nil
+}
+
+// GetTokenRateStatus returns the current token consumption rate as JSON
+func (s *TokenRateAlertService) GetTokenRateStatus() string {
+	alertConfig := s.config.GetTokenRateAlert()
+	snapshot := s.proxy.GetTokenRate(alertConfig.WindowSeconds)
+
+	s.stateMu.RLock()
+	exceeded := s.exceeded
+	lastAlertTime := s.lastAlertTime
+	s.stateMu.RUnlock()
+
+	result := map[string]interface{}{
+		"enabled":            alertConfig.Enabled,
+		"thresholdPerMinute": alertConfig.ThresholdPerMinute,
+		"windowSeconds":      snapshot.WindowSeconds,
+		"tokens":             snapshot.Tokens,
+		"tokensPerMinute":    snapshot.TokensPerMinute,
+		"topEndpoints":       snapshot.TopEndpoints,
+		"exceeded":           exceeded,
+	}
+	if !lastAlertTime.IsZero() {
+		result["lastAlertAt"] = lastAlertTime
+	}
+	return successJSON(result)
+}
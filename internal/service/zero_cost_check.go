@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/lich0821/ccNexus/internal/config"
+)
+
+// 零成本的端点检测：端点测试和定期健康检查共用
+
+// testModelsAPI 请求 /v1/models 列出模型，不消耗 token
+func testModelsAPI(client *http.Client, apiUrl, apiKey, transformer string) (int, error) {
+	var url string
+	if transformer == "gemini" {
+		url = config.JoinAPIUrl(apiUrl, fmt.Sprintf("/v1beta/models?key=%s", apiKey))
+	} else {
+		url = config.JoinAPIUrl(apiUrl, "/v1/models")
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Set authentication headers based on transformer type
+	switch transformer {
+	case "claude":
+		req.Header.Set("x-api-key", apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+	case "openai", "openai2":
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		// gemini uses query parameter, already set in URL
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response")
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to parse response")
+	}
+
+	if data, ok := result["data"].([]interface{}); ok {
+		if len(data) == 0 {
+			return resp.StatusCode, fmt.Errorf("no models found")
+		}
+		return resp.StatusCode, nil
+	}
+
+	if models, ok := result["models"].([]interface{}); ok {
+		if len(models) == 0 {
+			return resp.StatusCode, fmt.Errorf("no models found")
+		}
+		return resp.StatusCode, nil
+	}
+
+	return resp.StatusCode, fmt.Errorf("unexpected response format")
+}
+
+// testTokenCountAPI 调用 Claude count_tokens 接口，不消耗 token
+func testTokenCountAPI(client *http.Client, apiUrl, apiKey string) (int, error) {
+	url := config.JoinAPIUrl(apiUrl, "/v1/messages/count_tokens")
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"model": "claude-sonnet-4-5-20250929",
+		"messages": []map[string]string{
+			{"role": "user", "content": "Hi"},
+		},
+	})
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("anthropic-beta", "token-counting-2024-11-01")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response")
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to parse response")
+	}
+
+	if _, ok := result["input_tokens"]; !ok {
+		return resp.StatusCode, fmt.Errorf("invalid response: no input_tokens")
+	}
+
+	return resp.StatusCode, nil
+}
+
+// testCustomHealthPath 请求端点配置的自定义健康检查路径，2xx 即视为健康，不消耗 token
+func testCustomHealthPath(client *http.Client, apiUrl, apiKey, transformer, path, method string) (int, error) {
+	if method == "" {
+		method = http.MethodGet
+	}
+	if transformer == "gemini" {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + "key=" + apiKey
+	}
+
+	req, err := http.NewRequest(method, config.JoinAPIUrl(apiUrl, path), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	switch transformer {
+	case "claude":
+		req.Header.Set("x-api-key", apiKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+	case "openai", "openai2":
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		// gemini uses query parameter, already set in URL
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
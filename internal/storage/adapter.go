@@ -22,23 +22,47 @@ func (a *ConfigStorageAdapter) GetEndpoints() ([]config.StorageEndpoint, error)
 	result := make([]config.StorageEndpoint, len(endpoints))
 	for i, ep := range endpoints {
 		result[i] = config.StorageEndpoint{
-			Name:               ep.Name,
-			ClientType:         ep.ClientType,
-			APIUrl:             ep.APIUrl,
-			APIKey:             ep.APIKey,
-			Status:             config.EndpointStatus(ep.Status),
-			Enabled:            ep.Enabled,
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			SortOrder:          ep.SortOrder,
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            ep.ClientType,
+			APIUrl:                ep.APIUrl,
+			APIKey:                ep.APIKey,
+			Status:                config.EndpointStatus(ep.Status),
+			Enabled:               ep.Enabled,
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			SortOrder:             ep.SortOrder,
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			RefreshToken:          ep.RefreshToken,
+			TokenExpiry:           ep.TokenExpiry,
+			APIKeys:               ep.APIKeys,
+			Models:                ep.Models,
+			ModelRewrite:          ep.ModelRewrite,
+			QuotaMode:             ep.QuotaMode,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
 		}
 	}
 	return result, nil
@@ -54,23 +78,47 @@ func (a *ConfigStorageAdapter) GetEndpointsByClient(clientType string) ([]config
 	result := make([]config.StorageEndpoint, len(endpoints))
 	for i, ep := range endpoints {
 		result[i] = config.StorageEndpoint{
-			Name:               ep.Name,
-			ClientType:         ep.ClientType,
-			APIUrl:             ep.APIUrl,
-			APIKey:             ep.APIKey,
-			Status:             config.EndpointStatus(ep.Status),
-			Enabled:            ep.Enabled,
-			Transformer:        ep.Transformer,
-			Model:              ep.Model,
-			Remark:             ep.Remark,
-			Tags:               ep.Tags,
-			SortOrder:          ep.SortOrder,
-			ModelPatterns:      ep.ModelPatterns,
-			CostPerInputToken:  ep.CostPerInputToken,
-			CostPerOutputToken: ep.CostPerOutputToken,
-			QuotaLimit:         ep.QuotaLimit,
-			QuotaResetCycle:    ep.QuotaResetCycle,
-			Priority:           ep.Priority,
+			Name:                  ep.Name,
+			ClientType:            ep.ClientType,
+			APIUrl:                ep.APIUrl,
+			APIKey:                ep.APIKey,
+			Status:                config.EndpointStatus(ep.Status),
+			Enabled:               ep.Enabled,
+			Transformer:           ep.Transformer,
+			Model:                 ep.Model,
+			Remark:                ep.Remark,
+			Tags:                  ep.Tags,
+			SortOrder:             ep.SortOrder,
+			ModelPatterns:         ep.ModelPatterns,
+			CostPerInputToken:     ep.CostPerInputToken,
+			CostPerOutputToken:    ep.CostPerOutputToken,
+			CostPerCacheReadToken: ep.CostPerCacheReadToken,
+			QuotaLimit:            ep.QuotaLimit,
+			QuotaResetCycle:       ep.QuotaResetCycle,
+			Priority:              ep.Priority,
+			UserAgent:             ep.UserAgent,
+			SLAP95Ms:              ep.SLAP95Ms,
+			Weight:                ep.Weight,
+			Group:                 ep.Group,
+			HeaderMode:            ep.HeaderMode,
+			HeaderWhitelist:       ep.HeaderWhitelist,
+			HealthFields:          ep.HealthFields,
+			HealthErrorWords:      ep.HealthErrorWords,
+			HideThinking:          ep.HideThinking,
+			MaxConcurrency:        ep.MaxConcurrency,
+			TimeoutSeconds:        ep.TimeoutSeconds,
+			RefreshToken:          ep.RefreshToken,
+			TokenExpiry:           ep.TokenExpiry,
+			APIKeys:               ep.APIKeys,
+			Models:                ep.Models,
+			ModelRewrite:          ep.ModelRewrite,
+			QuotaMode:             ep.QuotaMode,
+			DisableStreamUsage:    ep.DisableStreamUsage,
+			PinStatus:             ep.PinStatus,
+			HealthCheckPath:       ep.HealthCheckPath,
+			HealthCheckMethod:     ep.HealthCheckMethod,
+			ActiveHours:           ep.ActiveHours,
+			KeyExpiryDate:         ep.KeyExpiryDate,
 		}
 	}
 	return result, nil
@@ -79,23 +127,47 @@ func (a *ConfigStorageAdapter) GetEndpointsByClient(clientType string) ([]config
 // SaveEndpoint saves an endpoint
 func (a *ConfigStorageAdapter) SaveEndpoint(ep *config.StorageEndpoint) error {
 	endpoint := &Endpoint{
-		Name:               ep.Name,
-		ClientType:         ep.ClientType,
-		APIUrl:             ep.APIUrl,
-		APIKey:             ep.APIKey,
-		Status:             string(ep.Status),
-		Enabled:            ep.Enabled,
-		Transformer:        ep.Transformer,
-		Model:              ep.Model,
-		Remark:             ep.Remark,
-		Tags:               ep.Tags,
-		SortOrder:          ep.SortOrder,
-		ModelPatterns:      ep.ModelPatterns,
-		CostPerInputToken:  ep.CostPerInputToken,
-		CostPerOutputToken: ep.CostPerOutputToken,
-		QuotaLimit:         ep.QuotaLimit,
-		QuotaResetCycle:    ep.QuotaResetCycle,
-		Priority:           ep.Priority,
+		Name:                  ep.Name,
+		ClientType:            ep.ClientType,
+		APIUrl:                ep.APIUrl,
+		APIKey:                ep.APIKey,
+		Status:                string(ep.Status),
+		Enabled:               ep.Enabled,
+		Transformer:           ep.Transformer,
+		Model:                 ep.Model,
+		Remark:                ep.Remark,
+		Tags:                  ep.Tags,
+		SortOrder:             ep.SortOrder,
+		ModelPatterns:         ep.ModelPatterns,
+		CostPerInputToken:     ep.CostPerInputToken,
+		CostPerOutputToken:    ep.CostPerOutputToken,
+		CostPerCacheReadToken: ep.CostPerCacheReadToken,
+		QuotaLimit:            ep.QuotaLimit,
+		QuotaResetCycle:       ep.QuotaResetCycle,
+		Priority:              ep.Priority,
+		UserAgent:             ep.UserAgent,
+		SLAP95Ms:              ep.SLAP95Ms,
+		Weight:                ep.Weight,
+		Group:                 ep.Group,
+		HeaderMode:            ep.HeaderMode,
+		HeaderWhitelist:       ep.HeaderWhitelist,
+		HealthFields:          ep.HealthFields,
+		HealthErrorWords:      ep.HealthErrorWords,
+		HideThinking:          ep.HideThinking,
+		MaxConcurrency:        ep.MaxConcurrency,
+		TimeoutSeconds:        ep.TimeoutSeconds,
+		RefreshToken:          ep.RefreshToken,
+		TokenExpiry:           ep.TokenExpiry,
+		APIKeys:               ep.APIKeys,
+		Models:                ep.Models,
+		ModelRewrite:          ep.ModelRewrite,
+		QuotaMode:             ep.QuotaMode,
+		DisableStreamUsage:    ep.DisableStreamUsage,
+		PinStatus:             ep.PinStatus,
+		HealthCheckPath:       ep.HealthCheckPath,
+		HealthCheckMethod:     ep.HealthCheckMethod,
+		ActiveHours:           ep.ActiveHours,
+		KeyExpiryDate:         ep.KeyExpiryDate,
 	}
 	return a.storage.SaveEndpoint(endpoint)
 }
@@ -103,23 +175,47 @@ func (a *ConfigStorageAdapter) SaveEndpoint(ep *config.StorageEndpoint) error {
 // UpdateEndpoint updates an endpoint
 func (a *ConfigStorageAdapter) UpdateEndpoint(ep *config.StorageEndpoint) error {
 	endpoint := &Endpoint{
-		Name:               ep.Name,
-		ClientType:         ep.ClientType,
-		APIUrl:             ep.APIUrl,
-		APIKey:             ep.APIKey,
-		Status:             string(ep.Status),
-		Enabled:            ep.Enabled,
-		Transformer:        ep.Transformer,
-		Model:              ep.Model,
-		Remark:             ep.Remark,
-		Tags:               ep.Tags,
-		SortOrder:          ep.SortOrder,
-		ModelPatterns:      ep.ModelPatterns,
-		CostPerInputToken:  ep.CostPerInputToken,
-		CostPerOutputToken: ep.CostPerOutputToken,
-		QuotaLimit:         ep.QuotaLimit,
-		QuotaResetCycle:    ep.QuotaResetCycle,
-		Priority:           ep.Priority,
+		Name:                  ep.Name,
+		ClientType:            ep.ClientType,
+		APIUrl:                ep.APIUrl,
+		APIKey:                ep.APIKey,
+		Status:                string(ep.Status),
+		Enabled:               ep.Enabled,
+		Transformer:           ep.Transformer,
+		Model:                 ep.Model,
+		Remark:                ep.Remark,
+		Tags:                  ep.Tags,
+		SortOrder:             ep.SortOrder,
+		ModelPatterns:         ep.ModelPatterns,
+		CostPerInputToken:     ep.CostPerInputToken,
+		CostPerOutputToken:    ep.CostPerOutputToken,
+		CostPerCacheReadToken: ep.CostPerCacheReadToken,
+		QuotaLimit:            ep.QuotaLimit,
+		QuotaResetCycle:       ep.QuotaResetCycle,
+		Priority:              ep.Priority,
+		UserAgent:             ep.UserAgent,
+		SLAP95Ms:              ep.SLAP95Ms,
+		Weight:                ep.Weight,
+		Group:                 ep.Group,
+		HeaderMode:            ep.HeaderMode,
+		HeaderWhitelist:       ep.HeaderWhitelist,
+		HealthFields:          ep.HealthFields,
+		HealthErrorWords:      ep.HealthErrorWords,
+		HideThinking:          ep.HideThinking,
+		MaxConcurrency:        ep.MaxConcurrency,
+		TimeoutSeconds:        ep.TimeoutSeconds,
+		RefreshToken:          ep.RefreshToken,
+		TokenExpiry:           ep.TokenExpiry,
+		APIKeys:               ep.APIKeys,
+		Models:                ep.Models,
+		ModelRewrite:          ep.ModelRewrite,
+		QuotaMode:             ep.QuotaMode,
+		DisableStreamUsage:    ep.DisableStreamUsage,
+		PinStatus:             ep.PinStatus,
+		HealthCheckPath:       ep.HealthCheckPath,
+		HealthCheckMethod:     ep.HealthCheckMethod,
+		ActiveHours:           ep.ActiveHours,
+		KeyExpiryDate:         ep.KeyExpiryDate,
 	}
 	return a.storage.UpdateEndpoint(endpoint)
 }
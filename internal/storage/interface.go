@@ -1,6 +1,10 @@
 package storage
 
-import "time"
+import (
+	"time"
+
+	"github.com/lich0821/ccNexus/internal/pricing"
+)
 
 type Endpoint struct {
 	ID          int64     `json:"id"`
@@ -19,12 +23,36 @@ type Endpoint struct {
 	UpdatedAt   time.Time `json:"updatedAt"`
 
 	// 智能路由相关字段
-	ModelPatterns      string  `json:"modelPatterns"`      // 模型匹配模式，逗号分隔
-	CostPerInputToken  float64 `json:"costPerInputToken"`  // 每百万输入 Token 成本
-	CostPerOutputToken float64 `json:"costPerOutputToken"` // 每百万输出 Token 成本
-	QuotaLimit         int64   `json:"quotaLimit"`         // Token 配额限制
-	QuotaResetCycle    string  `json:"quotaResetCycle"`    // 配额重置周期
-	Priority           int     `json:"priority"`           // 优先级
+	ModelPatterns         string  `json:"modelPatterns"`         // 模型匹配模式，逗号分隔
+	CostPerInputToken     float64 `json:"costPerInputToken"`     // 每百万输入 Token 成本
+	CostPerOutputToken    float64 `json:"costPerOutputToken"`    // 每百万输出 Token 成本
+	CostPerCacheReadToken float64 `json:"costPerCacheReadToken"` // 每百万缓存读取 Token 成本
+	QuotaLimit            int64   `json:"quotaLimit"`            // Token 配额限制
+	QuotaResetCycle       string  `json:"quotaResetCycle"`       // 配额重置周期
+	Priority              int     `json:"priority"`              // 优先级
+	UserAgent             string  `json:"userAgent"`             // 自定义 User-Agent
+	SLAP95Ms              int     `json:"slaP95Ms"`              // SLA p95 阈值（毫秒）
+	Weight                int     `json:"weight"`                // 加权轮询权重
+	Group                 string  `json:"group"`                 // 端点分组
+	HeaderMode            string  `json:"headerMode"`            // 请求头透传模式
+	HeaderWhitelist       string  `json:"headerWhitelist"`       // 请求头白名单
+	HealthFields          string  `json:"healthFields"`          // 健康检查必需字段
+	HealthErrorWords      string  `json:"healthErrorWords"`      // 健康检查错误关键词
+	HideThinking          bool    `json:"hideThinking"`          // 不转发推理内容
+	MaxConcurrency        int     `json:"maxConcurrency"`        // 最大并发数
+	TimeoutSeconds        int     `json:"timeoutSeconds"`        // 请求超时（秒）
+	RefreshToken          string  `json:"refreshToken"`          // OAuth refresh token
+	TokenExpiry           int64   `json:"tokenExpiry"`           // OAuth access token 过期时间（Unix 秒）
+	APIKeys               string  `json:"apiKeys"`               // 额外的 API key，逗号分隔
+	Models                string  `json:"models"`                // 支持的模型列表（JSON）
+	ModelRewrite          string  `json:"modelRewrite"`          // 模型名重写规则（JSON）
+	QuotaMode             string  `json:"quotaMode"`             // 配额模式：token / cost
+	DisableStreamUsage    bool    `json:"disableStreamUsage"`    // 流式请求不注入 stream_options.include_usage
+	PinStatus             bool    `json:"pinStatus"`             // 锁定状态，代理和健康检查不自动修改
+	HealthCheckPath       string  `json:"healthCheckPath"`       // 自定义健康检查路径，为空时使用默认检测
+	HealthCheckMethod     string  `json:"healthCheckMethod"`     // 自定义健康检查的 HTTP 方法，默认 GET
+	ActiveHours           string  `json:"activeHours"`           // 活跃时段（如 22:00-06:00），为空时一直可用
+	KeyExpiryDate         string  `json:"keyExpiryDate"`         // API Key 到期日（YYYY-MM-DD），为空时不过期
 }
 
 type DailyStat struct {
@@ -42,6 +70,37 @@ type DailyStat struct {
 	CreatedAt           time.Time
 }
 
+// StatsExportVersion 统计数据导出格式版本
+const StatsExportVersion = 1
+
+// StatsExport 统计数据导出格式（/stats/export 返回，供其他 ccNexus 实例导入）
+type StatsExport struct {
+	Version    int               `json:"version"`
+	DeviceID   string            `json:"deviceId"`   // 导出实例的 device_id
+	ExportedAt time.Time         `json:"exportedAt"`
+	DailyStats []DailyStatExport `json:"dailyStats"` // 按设备保留的原始每日统计行
+}
+
+// DailyStatExport 导出的单行每日统计，保留 device_id 以便导入时区分来源设备
+type DailyStatExport struct {
+	EndpointName        string `json:"endpointName"`
+	ClientType          string `json:"clientType"`
+	Date                string `json:"date"`
+	Requests            int    `json:"requests"`
+	Errors              int    `json:"errors"`
+	InputTokens         int    `json:"inputTokens"`
+	CacheCreationTokens int    `json:"cacheCreationTokens"`
+	CacheReadTokens     int    `json:"cacheReadTokens"`
+	OutputTokens        int    `json:"outputTokens"`
+	DeviceID            string `json:"deviceId"`
+}
+
+// StatsImportResult 统计数据导入结果
+type StatsImportResult struct {
+	Imported int `json:"imported"` // 写入（新增或覆盖）的行数
+	Skipped  int `json:"skipped"`  // 跳过的行数（本机数据、本地已存在或无效行）
+}
+
 type EndpointStats struct {
 	Requests            int
 	Errors              int
@@ -49,6 +108,7 @@ type EndpointStats struct {
 	CacheCreationTokens int64 // 新增：缓存创建 token
 	CacheReadTokens     int64 // 新增：缓存读取 token
 	OutputTokens        int64
+	EstimatedCost       pricing.CostBreakdown // 按端点配置的单价估算的花费（美元）
 }
 
 // RequestStat 请求级别统计（新增）
@@ -65,11 +125,16 @@ type RequestStat struct {
 	CacheReadTokens     int       `json:"cacheReadTokens"`
 	OutputTokens        int       `json:"outputTokens"`
 	Model               string    `json:"model"`
+	UpstreamModel       string    `json:"upstreamModel"` // 转换后实际发往上游的模型
 	IsStreaming         bool      `json:"isStreaming"`
 	Success             bool      `json:"success"`
 	DeviceID            string    `json:"deviceId"`
 	DurationMs          int64     `json:"durationMs"` // 请求时长（毫秒）
 	ErrorMessage        string    `json:"errorMessage"` // 错误消息（失败时记录）
+	ErrorCategory       string    `json:"errorCategory"` // 错误分类（失败时记录）：network、timeout、auth、rate_limit、server_error、upstream_error、other
+	RequestBytes        int64     `json:"requestBytes"`  // 发往上游的请求体字节数
+	ResponseBytes       int64     `json:"responseBytes"` // 从上游读取的响应体字节数（按实际传输计，压缩响应为压缩后大小）
+	IsTest              bool      `json:"isTest"`        // 测试请求（X-CCNexus-Endpoint 指定端点）
 }
 
 // ClientStats 连接客户端统计信息
@@ -96,6 +161,19 @@ type HealthHistoryRecord struct {
 	DeviceID     string    `json:"deviceId"`
 }
 
+// ConfigAuditRecord 端点配置变更审计记录
+type ConfigAuditRecord struct {
+	ID           int64     `json:"id"`
+	Action       string    `json:"action"` // add, update, remove
+	EndpointName string    `json:"endpointName"`
+	ClientType   string    `json:"clientType"`
+	Before       string    `json:"before,omitempty"` // 变更前的端点配置 JSON（密钥已脱敏）
+	After        string    `json:"after,omitempty"`  // 变更后的端点配置 JSON（密钥已脱敏）
+	Source       string    `json:"source"`           // 变更来源：ui, import
+	DeviceID     string    `json:"deviceId"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
 // EndpointQuota 端点配额跟踪记录
 type EndpointQuota struct {
 	ID           int64     `json:"id"`
@@ -105,6 +183,7 @@ type EndpointQuota struct {
 	PeriodEnd    time.Time `json:"periodEnd"`    // 当前周期结束时间
 	TokensUsed   int64     `json:"tokensUsed"`   // 已使用 Token
 	QuotaLimit   int64     `json:"quotaLimit"`   // 配额限制
+	CostUsed     float64   `json:"costUsed"`     // 已累计成本（美元），cost 模式下与 QuotaLimit 比较
 	LastUpdated  time.Time `json:"lastUpdated"`
 }
 
@@ -115,20 +194,27 @@ type Storage interface {
 	SaveEndpoint(ep *Endpoint) error
 	UpdateEndpoint(ep *Endpoint) error
 	DeleteEndpoint(name string, clientType string) error // 按名称和客户端类型删除
+	UpdateEndpointToken(name, clientType, apiKey, refreshToken string, tokenExpiry int64) error // OAuth token 刷新后更新
 
 	// Stats
 	RecordDailyStat(stat *DailyStat) error
 	GetDailyStats(endpointName, clientType, startDate, endDate string) ([]DailyStat, error) // 添加 clientType 参数
 	GetAllStats() (map[string][]DailyStat, error)
-	GetTotalStats() (int, map[string]*EndpointStats, error)
+	GetTotalStats(excludeTest bool) (int, map[string]*EndpointStats, error) // excludeTest 为 true 时扣除测试请求的用量
 	GetTotalStatsByClient(clientType string) (int, map[string]*EndpointStats, error) // 按客户端类型获取统计
 	GetEndpointTotalStats(endpointName string, clientType string) (*EndpointStats, error)
+	GetStatsByDevice(startDate, endDate string) (map[string]*EndpointStats, error) // 按 device_id 聚合，日期为空表示不限
+	ExportDailyStats() (*StatsExport, error)
+	ImportDailyStats(export *StatsExport, strategy MergeStrategy) (*StatsImportResult, error)
 
 	// Request Stats（新增）
 	RecordRequestStat(stat *RequestStat) error
 	GetRequestStats(endpointName string, clientType string, startDate, endDate string, limit, offset int) ([]RequestStat, error)
 	GetRequestStatsCount(endpointName string, clientType string, startDate, endDate string) (int, error)
+	SearchRequestStats(keyword, clientType, startDate, endDate string, limit, offset int) ([]RequestStat, int, error) // 按关键词搜索 error_message/model/endpoint_name
 	GetRecentRequestsByEndpoint(endpointName string, clientType string, limit int) ([]RequestStat, error)
+	GetStatsByModel(startDate, endDate string) (map[string]*EndpointStats, error) // 按 model 聚合
+	GetErrorCategoryCounts(startDate, endDate string) (map[string]int, int, error) // 失败请求按错误分类计数，并返回请求总数
 	CleanupOldRequestStats(daysToKeep int) error
 	GetConnectedClients(hoursAgo int) ([]ClientStats, error)
 
@@ -138,6 +224,10 @@ type Storage interface {
 	CleanupOldHealthHistory(daysToKeep int) error
 	GetAllEndpointTags() ([]string, error)
 
+	// Config Audit（端点配置变更审计）
+	RecordConfigAudit(record *ConfigAuditRecord) error
+	GetConfigAuditLog(limit, offset int) ([]ConfigAuditRecord, int, error)
+
 	// Quota（配额跟踪）
 	GetEndpointQuota(endpointName, clientType string) (*EndpointQuota, error)
 	UpdateEndpointQuota(quota *EndpointQuota) error
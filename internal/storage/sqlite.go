@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lich0821/ccNexus/internal/config"
+	"github.com/lich0821/ccNexus/internal/pricing"
 	_ "modernc.org/sqlite"
 )
 
@@ -37,6 +39,52 @@ var safeConfigKeys = []string{
 	"routing_loadBalanceAlgorithm",
 }
 
+// portableConfigKeys 完整配置导出/导入在 safeConfigKeys 之外额外包含的 app_config 配置项，
+// 都是与设备无关的功能设置（超时、健康检查、日志保留等）
+var portableConfigKeys = []string{
+	"healthCheckInterval", "autoOptimizeInterval", "healthCheckMethod", "statsTimezone",
+	"healthHistoryRetentionDays", "interactionRetentionDays",
+	"requestTimeout", "enableHTTP2", "streamHeartbeatInterval", "streamFirstByteTimeout", "maxRequestBodyBytes",
+	"backup_local_keepVersions",
+}
+
+// portableConfigPrefixes 完整配置导出/导入包含的 app_config 配置前缀：
+// 路由、缓存、限流、告警、熔断等功能设置，以及各 client type 的端口
+var portableConfigPrefixes = []string{
+	"client_port_", "routing_", "sessionAffinity_", "crossClientFallback_",
+	"cache_", "idempotency_", "dedup_", "rateLimit_", "circuitBreaker_", "retryBudget_",
+	"alert_", "tokenRateAlert_", "sla_", "autoContinue_",
+}
+
+// secretConfigKeys 包含凭证的配置项，不包含密钥导出时跳过
+var secretConfigKeys = map[string]bool{
+	"webdav_password":        true,
+	"backup_s3_accessKey":    true,
+	"backup_s3_secretKey":    true,
+	"backup_s3_sessionToken": true,
+}
+
+// IsPortableConfigKey 判断配置项能否随完整配置迁移到其他设备：
+// safeConfigKeys、portableConfigKeys 中的配置项以及 portableConfigPrefixes 开头的配置项
+func IsPortableConfigKey(key string) bool {
+	for _, k := range safeConfigKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, k := range portableConfigKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range portableConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 type SQLiteStorage struct {
 	db     *sql.DB
 	dbPath string
@@ -152,6 +200,121 @@ func (s *SQLiteStorage) initSchema() error {
 		return err
 	}
 
+	// 迁移请求/响应字节数字段
+	if err := s.migrateTransferBytes(); err != nil {
+		return err
+	}
+
+	// 迁移端点 User-Agent 字段
+	if err := s.migrateEndpointUserAgent(); err != nil {
+		return err
+	}
+
+	// 迁移端点 SLA 阈值字段
+	if err := s.migrateEndpointSLA(); err != nil {
+		return err
+	}
+
+	// 迁移：添加 weight 字段
+	if err := s.migrateEndpointWeight(); err != nil {
+		return err
+	}
+
+	// 迁移：添加 models 字段
+	if err := s.migrateEndpointModels(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点分组字段
+	if err := s.migrateEndpointGroup(); err != nil {
+		return err
+	}
+
+	// 迁移：添加请求头透传模式字段
+	if err := s.migrateEndpointHeaderMode(); err != nil {
+		return err
+	}
+
+	// 迁移：添加请求头白名单字段
+	if err := s.migrateEndpointHeaderWhitelist(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点健康检查必需字段
+	if err := s.migrateEndpointHealthFields(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点健康检查错误关键词
+	if err := s.migrateEndpointHealthErrorWords(); err != nil {
+		return err
+	}
+
+	// 迁移：添加请求实际上游模型字段
+	if err := s.migrateUpstreamModel(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点 OAuth token 刷新字段
+	if err := s.migrateEndpointOAuthToken(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点额外 API key 字段
+	if err := s.migrateEndpointAPIKeys(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点缓存读取单价字段
+	if err := s.migrateEndpointCacheReadCost(); err != nil {
+		return err
+	}
+
+	// 迁移：创建端点配置变更审计表
+	if err := s.migrateConfigAudit(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点配额模式字段和配额累计成本字段（需在 endpoint_quotas 表创建之后）
+	if err := s.migrateEndpointQuotaMode(); err != nil {
+		return err
+	}
+
+	// 迁移：添加请求统计的测试请求标记
+	if err := s.migrateRequestStatsIsTest(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点关闭流式 usage 注入的开关
+	if err := s.migrateEndpointDisableStreamUsage(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点锁定状态的开关
+	if err := s.migrateEndpointPinStatus(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点自定义健康检查路径和方法
+	if err := s.migrateEndpointHealthCheckProbe(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点活跃时段
+	if err := s.migrateEndpointActiveHours(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点 API Key 到期日
+	if err := s.migrateEndpointKeyExpiryDate(); err != nil {
+		return err
+	}
+
+	// 迁移：添加请求统计的错误分类
+	if err := s.migrateRequestStatsErrorCategory(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -329,6 +492,26 @@ func (s *SQLiteStorage) migrateClientType() error {
 		return err
 	}
 
+	// 迁移：添加端点推理内容转发开关字段
+	if err := s.migrateEndpointHideThinking(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点最大并发数字段
+	if err := s.migrateEndpointMaxConcurrency(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点级请求超时字段
+	if err := s.migrateEndpointTimeoutSeconds(); err != nil {
+		return err
+	}
+
+	// 迁移：添加端点模型名重写规则字段
+	if err := s.migrateEndpointModelRewrite(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -361,6 +544,290 @@ func (s *SQLiteStorage) migrateClientIP() error {
 	return nil
 }
 
+// migrateTransferBytes adds request_bytes and response_bytes columns to request_stats table
+func (s *SQLiteStorage) migrateTransferBytes() error {
+	for _, column := range []string{"request_bytes", "response_bytes"} {
+		var count int
+		err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('request_stats') WHERE name=?`, column).Scan(&count)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			// Existing records default to 0 (unknown)
+			if _, err := s.db.Exec(`ALTER TABLE request_stats ADD COLUMN ` + column + ` INTEGER DEFAULT 0`); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointUserAgent adds the user_agent column to endpoints table
+func (s *SQLiteStorage) migrateEndpointUserAgent() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='user_agent'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN user_agent TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointSLA adds the sla_p95_ms column to endpoints table
+func (s *SQLiteStorage) migrateEndpointSLA() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='sla_p95_ms'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN sla_p95_ms INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointWeight adds the weight column to endpoints table
+func (s *SQLiteStorage) migrateEndpointWeight() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='weight'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN weight INTEGER DEFAULT 1`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointModels adds the models column to endpoints table
+func (s *SQLiteStorage) migrateEndpointModels() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='models'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN models TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointGroup adds the group_name column to endpoints table
+func (s *SQLiteStorage) migrateEndpointGroup() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='group_name'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN group_name TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointHeaderMode adds the header_mode column to endpoints table
+func (s *SQLiteStorage) migrateEndpointHeaderMode() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='header_mode'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN header_mode TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointHeaderWhitelist adds the header_whitelist column to endpoints table
+func (s *SQLiteStorage) migrateEndpointHeaderWhitelist() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='header_whitelist'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN header_whitelist TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointHealthFields adds the health_fields column to endpoints table
+func (s *SQLiteStorage) migrateEndpointHealthFields() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='health_fields'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN health_fields TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateUpstreamModel adds the upstream_model column to request_stats table
+func (s *SQLiteStorage) migrateUpstreamModel() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('request_stats') WHERE name='upstream_model'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE request_stats ADD COLUMN upstream_model TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateRequestStatsIsTest adds the is_test column to request_stats table
+func (s *SQLiteStorage) migrateRequestStatsIsTest() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('request_stats') WHERE name='is_test'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE request_stats ADD COLUMN is_test INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateRequestStatsErrorCategory adds the error_category column to request_stats table
+func (s *SQLiteStorage) migrateRequestStatsErrorCategory() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('request_stats') WHERE name='error_category'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE request_stats ADD COLUMN error_category TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointHealthErrorWords adds the health_error_words column to endpoints table
+func (s *SQLiteStorage) migrateEndpointHealthErrorWords() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='health_error_words'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN health_error_words TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointOAuthToken adds the refresh_token and token_expiry columns to endpoints table
+func (s *SQLiteStorage) migrateEndpointOAuthToken() error {
+	columns := []struct {
+		name string
+		ddl  string
+	}{
+		{"refresh_token", `ALTER TABLE endpoints ADD COLUMN refresh_token TEXT DEFAULT ''`},
+		{"token_expiry", `ALTER TABLE endpoints ADD COLUMN token_expiry INTEGER DEFAULT 0`},
+	}
+
+	for _, col := range columns {
+		var count int
+		err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name=?`, col.name).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			if _, err := s.db.Exec(col.ddl); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointAPIKeys adds the api_keys column to endpoints table
+func (s *SQLiteStorage) migrateEndpointAPIKeys() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='api_keys'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN api_keys TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointCacheReadCost adds the cost_per_cache_read_token column to endpoints table
+func (s *SQLiteStorage) migrateEndpointCacheReadCost() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='cost_per_cache_read_token'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN cost_per_cache_read_token REAL DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // migrateErrorMessage adds error_message column to request_stats table
 func (s *SQLiteStorage) migrateErrorMessage() error {
 	// Check if error_message column exists in request_stats
@@ -582,11 +1049,195 @@ func (s *SQLiteStorage) migrateDurationTracking() error {
 	return nil
 }
 
+// migrateEndpointHideThinking adds the hide_thinking column to endpoints table
+func (s *SQLiteStorage) migrateEndpointHideThinking() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='hide_thinking'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN hide_thinking INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointMaxConcurrency adds the max_concurrency column to endpoints table
+func (s *SQLiteStorage) migrateEndpointMaxConcurrency() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='max_concurrency'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN max_concurrency INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointTimeoutSeconds adds the timeout_seconds column to endpoints table
+func (s *SQLiteStorage) migrateEndpointTimeoutSeconds() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='timeout_seconds'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN timeout_seconds INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointModelRewrite adds the model_rewrite column to endpoints table
+func (s *SQLiteStorage) migrateEndpointModelRewrite() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='model_rewrite'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN model_rewrite TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointHealthCheckProbe adds the health_check_path and health_check_method columns to endpoints table
+func (s *SQLiteStorage) migrateEndpointHealthCheckProbe() error {
+	for _, column := range []string{"health_check_path", "health_check_method"} {
+		var count int
+		err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name=?`, column).Scan(&count)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN ` + column + ` TEXT DEFAULT ''`); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointActiveHours adds the active_hours column to endpoints table
+func (s *SQLiteStorage) migrateEndpointActiveHours() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='active_hours'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN active_hours TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointKeyExpiryDate adds the key_expiry_date column to endpoints table
+func (s *SQLiteStorage) migrateEndpointKeyExpiryDate() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='key_expiry_date'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN key_expiry_date TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointPinStatus adds the pin_status column to endpoints table
+func (s *SQLiteStorage) migrateEndpointPinStatus() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='pin_status'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN pin_status INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointDisableStreamUsage adds the disable_stream_usage column to endpoints table
+func (s *SQLiteStorage) migrateEndpointDisableStreamUsage() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='disable_stream_usage'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN disable_stream_usage INTEGER DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateEndpointQuotaMode adds the quota_mode column to endpoints table
+// and the cost_used column to endpoint_quotas table
+func (s *SQLiteStorage) migrateEndpointQuotaMode() error {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoints') WHERE name='quota_mode'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoints ADD COLUMN quota_mode TEXT DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+
+	err = s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('endpoint_quotas') WHERE name='cost_used'`).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		if _, err := s.db.Exec(`ALTER TABLE endpoint_quotas ADD COLUMN cost_used REAL DEFAULT 0`); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *SQLiteStorage) GetEndpoints() ([]Endpoint, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rows, err := s.db.Query(`SELECT id, name, COALESCE(client_type, 'claude') as client_type, api_url, api_key, enabled, COALESCE(status, '') as status, transformer, model, remark, COALESCE(tags, '') as tags, sort_order, created_at, updated_at, COALESCE(model_patterns, '') as model_patterns, COALESCE(cost_per_input_token, 0) as cost_per_input_token, COALESCE(cost_per_output_token, 0) as cost_per_output_token, COALESCE(quota_limit, 0) as quota_limit, COALESCE(quota_reset_cycle, '') as quota_reset_cycle, COALESCE(priority, 100) as priority FROM endpoints ORDER BY client_type, sort_order ASC`)
+	rows, err := s.db.Query(`SELECT id, name, COALESCE(client_type, 'claude') as client_type, api_url, api_key, enabled, COALESCE(status, '') as status, transformer, model, remark, COALESCE(tags, '') as tags, sort_order, created_at, updated_at, COALESCE(model_patterns, '') as model_patterns, COALESCE(cost_per_input_token, 0) as cost_per_input_token, COALESCE(cost_per_output_token, 0) as cost_per_output_token, COALESCE(cost_per_cache_read_token, 0) as cost_per_cache_read_token, COALESCE(quota_limit, 0) as quota_limit, COALESCE(quota_reset_cycle, '') as quota_reset_cycle, COALESCE(priority, 100) as priority, COALESCE(user_agent, '') as user_agent, COALESCE(sla_p95_ms, 0) as sla_p95_ms, COALESCE(weight, 1) as weight, COALESCE(models, '') as models, COALESCE(group_name, '') as group_name, COALESCE(header_mode, '') as header_mode, COALESCE(header_whitelist, '') as header_whitelist, COALESCE(health_fields, '') as health_fields, COALESCE(health_error_words, '') as health_error_words, COALESCE(refresh_token, '') as refresh_token, COALESCE(token_expiry, 0) as token_expiry, COALESCE(api_keys, '') as api_keys, COALESCE(hide_thinking, 0) as hide_thinking, COALESCE(max_concurrency, 0) as max_concurrency, COALESCE(timeout_seconds, 0) as timeout_seconds, COALESCE(model_rewrite, '') as model_rewrite, COALESCE(quota_mode, '') as quota_mode, COALESCE(disable_stream_usage, 0) as disable_stream_usage, COALESCE(pin_status, 0) as pin_status, COALESCE(health_check_path, '') as health_check_path, COALESCE(health_check_method, '') as health_check_method, COALESCE(active_hours, '') as active_hours, COALESCE(key_expiry_date, '') as key_expiry_date FROM endpoints ORDER BY client_type, sort_order ASC`)
 	if err != nil {
 		return nil, err
 	}
@@ -596,7 +1247,7 @@ func (s *SQLiteStorage) GetEndpoints() ([]Endpoint, error) {
 	for rows.Next() {
 		var ep Endpoint
 		var status string
-		if err := rows.Scan(&ep.ID, &ep.Name, &ep.ClientType, &ep.APIUrl, &ep.APIKey, &ep.Enabled, &status, &ep.Transformer, &ep.Model, &ep.Remark, &ep.Tags, &ep.SortOrder, &ep.CreatedAt, &ep.UpdatedAt, &ep.ModelPatterns, &ep.CostPerInputToken, &ep.CostPerOutputToken, &ep.QuotaLimit, &ep.QuotaResetCycle, &ep.Priority); err != nil {
+		if err := rows.Scan(&ep.ID, &ep.Name, &ep.ClientType, &ep.APIUrl, &ep.APIKey, &ep.Enabled, &status, &ep.Transformer, &ep.Model, &ep.Remark, &ep.Tags, &ep.SortOrder, &ep.CreatedAt, &ep.UpdatedAt, &ep.ModelPatterns, &ep.CostPerInputToken, &ep.CostPerOutputToken, &ep.CostPerCacheReadToken, &ep.QuotaLimit, &ep.QuotaResetCycle, &ep.Priority, &ep.UserAgent, &ep.SLAP95Ms, &ep.Weight, &ep.Models, &ep.Group, &ep.HeaderMode, &ep.HeaderWhitelist, &ep.HealthFields, &ep.HealthErrorWords, &ep.RefreshToken, &ep.TokenExpiry, &ep.APIKeys, &ep.HideThinking, &ep.MaxConcurrency, &ep.TimeoutSeconds, &ep.ModelRewrite, &ep.QuotaMode, &ep.DisableStreamUsage, &ep.PinStatus, &ep.HealthCheckPath, &ep.HealthCheckMethod, &ep.ActiveHours, &ep.KeyExpiryDate); err != nil {
 			return nil, err
 		}
 		// 设置状态字段，如果为空则从 enabled 推断
@@ -620,7 +1271,7 @@ func (s *SQLiteStorage) GetEndpointsByClient(clientType string) ([]Endpoint, err
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	rows, err := s.db.Query(`SELECT id, name, COALESCE(client_type, 'claude') as client_type, api_url, api_key, enabled, COALESCE(status, '') as status, transformer, model, remark, COALESCE(tags, '') as tags, sort_order, created_at, updated_at, COALESCE(model_patterns, '') as model_patterns, COALESCE(cost_per_input_token, 0) as cost_per_input_token, COALESCE(cost_per_output_token, 0) as cost_per_output_token, COALESCE(quota_limit, 0) as quota_limit, COALESCE(quota_reset_cycle, '') as quota_reset_cycle, COALESCE(priority, 100) as priority FROM endpoints WHERE COALESCE(client_type, 'claude') = ? ORDER BY sort_order ASC`, clientType)
+	rows, err := s.db.Query(`SELECT id, name, COALESCE(client_type, 'claude') as client_type, api_url, api_key, enabled, COALESCE(status, '') as status, transformer, model, remark, COALESCE(tags, '') as tags, sort_order, created_at, updated_at, COALESCE(model_patterns, '') as model_patterns, COALESCE(cost_per_input_token, 0) as cost_per_input_token, COALESCE(cost_per_output_token, 0) as cost_per_output_token, COALESCE(cost_per_cache_read_token, 0) as cost_per_cache_read_token, COALESCE(quota_limit, 0) as quota_limit, COALESCE(quota_reset_cycle, '') as quota_reset_cycle, COALESCE(priority, 100) as priority, COALESCE(user_agent, '') as user_agent, COALESCE(sla_p95_ms, 0) as sla_p95_ms, COALESCE(weight, 1) as weight, COALESCE(models, '') as models, COALESCE(group_name, '') as group_name, COALESCE(header_mode, '') as header_mode, COALESCE(header_whitelist, '') as header_whitelist, COALESCE(health_fields, '') as health_fields, COALESCE(health_error_words, '') as health_error_words, COALESCE(refresh_token, '') as refresh_token, COALESCE(token_expiry, 0) as token_expiry, COALESCE(api_keys, '') as api_keys, COALESCE(hide_thinking, 0) as hide_thinking, COALESCE(max_concurrency, 0) as max_concurrency, COALESCE(timeout_seconds, 0) as timeout_seconds, COALESCE(model_rewrite, '') as model_rewrite, COALESCE(quota_mode, '') as quota_mode, COALESCE(disable_stream_usage, 0) as disable_stream_usage, COALESCE(pin_status, 0) as pin_status, COALESCE(health_check_path, '') as health_check_path, COALESCE(health_check_method, '') as health_check_method, COALESCE(active_hours, '') as active_hours, COALESCE(key_expiry_date, '') as key_expiry_date FROM endpoints WHERE COALESCE(client_type, 'claude') = ? ORDER BY sort_order ASC`, clientType)
 	if err != nil {
 		return nil, err
 	}
@@ -630,7 +1281,7 @@ func (s *SQLiteStorage) GetEndpointsByClient(clientType string) ([]Endpoint, err
 	for rows.Next() {
 		var ep Endpoint
 		var status string
-		if err := rows.Scan(&ep.ID, &ep.Name, &ep.ClientType, &ep.APIUrl, &ep.APIKey, &ep.Enabled, &status, &ep.Transformer, &ep.Model, &ep.Remark, &ep.Tags, &ep.SortOrder, &ep.CreatedAt, &ep.UpdatedAt, &ep.ModelPatterns, &ep.CostPerInputToken, &ep.CostPerOutputToken, &ep.QuotaLimit, &ep.QuotaResetCycle, &ep.Priority); err != nil {
+		if err := rows.Scan(&ep.ID, &ep.Name, &ep.ClientType, &ep.APIUrl, &ep.APIKey, &ep.Enabled, &status, &ep.Transformer, &ep.Model, &ep.Remark, &ep.Tags, &ep.SortOrder, &ep.CreatedAt, &ep.UpdatedAt, &ep.ModelPatterns, &ep.CostPerInputToken, &ep.CostPerOutputToken, &ep.CostPerCacheReadToken, &ep.QuotaLimit, &ep.QuotaResetCycle, &ep.Priority, &ep.UserAgent, &ep.SLAP95Ms, &ep.Weight, &ep.Models, &ep.Group, &ep.HeaderMode, &ep.HeaderWhitelist, &ep.HealthFields, &ep.HealthErrorWords, &ep.RefreshToken, &ep.TokenExpiry, &ep.APIKeys, &ep.HideThinking, &ep.MaxConcurrency, &ep.TimeoutSeconds, &ep.ModelRewrite, &ep.QuotaMode, &ep.DisableStreamUsage, &ep.PinStatus, &ep.HealthCheckPath, &ep.HealthCheckMethod, &ep.ActiveHours, &ep.KeyExpiryDate); err != nil {
 			return nil, err
 		}
 		// 设置状态字段，如果为空则从 enabled 推断
@@ -665,8 +1316,8 @@ func (s *SQLiteStorage) SaveEndpoint(ep *Endpoint) error {
 		priority = 100
 	}
 
-	result, err := s.db.Exec(`INSERT INTO endpoints (name, client_type, api_url, api_key, enabled, status, transformer, model, remark, tags, sort_order, model_patterns, cost_per_input_token, cost_per_output_token, quota_limit, quota_reset_cycle, priority) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		ep.Name, clientType, ep.APIUrl, ep.APIKey, ep.Enabled, ep.Status, ep.Transformer, ep.Model, ep.Remark, ep.Tags, ep.SortOrder, ep.ModelPatterns, ep.CostPerInputToken, ep.CostPerOutputToken, ep.QuotaLimit, ep.QuotaResetCycle, priority)
+	result, err := s.db.Exec(`INSERT INTO endpoints (name, client_type, api_url, api_key, enabled, status, transformer, model, remark, tags, sort_order, model_patterns, cost_per_input_token, cost_per_output_token, cost_per_cache_read_token, quota_limit, quota_reset_cycle, priority, user_agent, sla_p95_ms, weight, models, group_name, header_mode, header_whitelist, health_fields, health_error_words, refresh_token, token_expiry, api_keys, hide_thinking, max_concurrency, timeout_seconds, model_rewrite, quota_mode, disable_stream_usage, pin_status, health_check_path, health_check_method, active_hours, key_expiry_date) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ep.Name, clientType, ep.APIUrl, ep.APIKey, ep.Enabled, ep.Status, ep.Transformer, ep.Model, ep.Remark, ep.Tags, ep.SortOrder, ep.ModelPatterns, ep.CostPerInputToken, ep.CostPerOutputToken, ep.CostPerCacheReadToken, ep.QuotaLimit, ep.QuotaResetCycle, priority, ep.UserAgent, ep.SLAP95Ms, ep.Weight, ep.Models, ep.Group, ep.HeaderMode, ep.HeaderWhitelist, ep.HealthFields, ep.HealthErrorWords, ep.RefreshToken, ep.TokenExpiry, ep.APIKeys, ep.HideThinking, ep.MaxConcurrency, ep.TimeoutSeconds, ep.ModelRewrite, ep.QuotaMode, ep.DisableStreamUsage, ep.PinStatus, ep.HealthCheckPath, ep.HealthCheckMethod, ep.ActiveHours, ep.KeyExpiryDate)
 	if err != nil {
 		return err
 	}
@@ -697,8 +1348,8 @@ func (s *SQLiteStorage) UpdateEndpoint(ep *Endpoint) error {
 		priority = 100
 	}
 
-	_, err := s.db.Exec(`UPDATE endpoints SET api_url=?, api_key=?, enabled=?, status=?, transformer=?, model=?, remark=?, tags=?, sort_order=?, model_patterns=?, cost_per_input_token=?, cost_per_output_token=?, quota_limit=?, quota_reset_cycle=?, priority=?, updated_at=CURRENT_TIMESTAMP WHERE name=? AND COALESCE(client_type, 'claude')=?`,
-		ep.APIUrl, ep.APIKey, ep.Enabled, ep.Status, ep.Transformer, ep.Model, ep.Remark, ep.Tags, ep.SortOrder, ep.ModelPatterns, ep.CostPerInputToken, ep.CostPerOutputToken, ep.QuotaLimit, ep.QuotaResetCycle, priority, ep.Name, clientType)
+	_, err := s.db.Exec(`UPDATE endpoints SET api_url=?, api_key=?, enabled=?, status=?, transformer=?, model=?, remark=?, tags=?, sort_order=?, model_patterns=?, cost_per_input_token=?, cost_per_output_token=?, cost_per_cache_read_token=?, quota_limit=?, quota_reset_cycle=?, priority=?, user_agent=?, sla_p95_ms=?, weight=?, models=?, group_name=?, header_mode=?, header_whitelist=?, health_fields=?, health_error_words=?, refresh_token=?, token_expiry=?, api_keys=?, hide_thinking=?, max_concurrency=?, timeout_seconds=?, model_rewrite=?, quota_mode=?, disable_stream_usage=?, pin_status=?, health_check_path=?, health_check_method=?, active_hours=?, key_expiry_date=?, updated_at=CURRENT_TIMESTAMP WHERE name=? AND COALESCE(client_type, 'claude')=?`,
+		ep.APIUrl, ep.APIKey, ep.Enabled, ep.Status, ep.Transformer, ep.Model, ep.Remark, ep.Tags, ep.SortOrder, ep.ModelPatterns, ep.CostPerInputToken, ep.CostPerOutputToken, ep.CostPerCacheReadToken, ep.QuotaLimit, ep.QuotaResetCycle, priority, ep.UserAgent, ep.SLAP95Ms, ep.Weight, ep.Models, ep.Group, ep.HeaderMode, ep.HeaderWhitelist, ep.HealthFields, ep.HealthErrorWords, ep.RefreshToken, ep.TokenExpiry, ep.APIKeys, ep.HideThinking, ep.MaxConcurrency, ep.TimeoutSeconds, ep.ModelRewrite, ep.QuotaMode, ep.DisableStreamUsage, ep.PinStatus, ep.HealthCheckPath, ep.HealthCheckMethod, ep.ActiveHours, ep.KeyExpiryDate, ep.Name, clientType)
 	return err
 }
 
@@ -715,6 +1366,20 @@ func (s *SQLiteStorage) DeleteEndpoint(name string, clientType string) error {
 	return err
 }
 
+// UpdateEndpointToken updates the access token (api_key), refresh token and expiry of an endpoint after an OAuth refresh
+func (s *SQLiteStorage) UpdateEndpointToken(name, clientType, apiKey, refreshToken string, tokenExpiry int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if clientType == "" {
+		clientType = "claude"
+	}
+
+	_, err := s.db.Exec(`UPDATE endpoints SET api_key=?, refresh_token=?, token_expiry=?, updated_at=CURRENT_TIMESTAMP WHERE name=? AND COALESCE(client_type, 'claude')=?`,
+		apiKey, refreshToken, tokenExpiry, name, clientType)
+	return err
+}
+
 func (s *SQLiteStorage) RecordDailyStat(stat *DailyStat) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -827,7 +1492,9 @@ func (s *SQLiteStorage) Close() error {
 	return s.db.Close()
 }
 
-func (s *SQLiteStorage) GetTotalStats() (int, map[string]*EndpointStats, error) {
+// GetTotalStats returns total stats per endpoint (key clientType:endpointName)
+// excludeTest 为 true 时扣除 request_stats 中标记为测试请求的用量
+func (s *SQLiteStorage) GetTotalStats(excludeTest bool) (int, map[string]*EndpointStats, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -864,10 +1531,123 @@ func (s *SQLiteStorage) GetTotalStats() (int, map[string]*EndpointStats, error)
 			CacheReadTokens:     cacheReadTokens,
 			OutputTokens:        outputTokens,
 		}
-		totalRequests += requests
+		totalRequests += requests
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	if excludeTest {
+		testStats, err := s.queryTestStats("", "")
+		if err != nil {
+			return 0, nil, err
+		}
+		for key, test := range testStats {
+			if st, ok := result[key]; ok {
+				totalRequests -= subtractEndpointStats(st, test)
+			}
+		}
+	}
+
+	return totalRequests, result, nil
+}
+
+// GetStatsByDevice aggregates daily stats by device_id within a date range (key device_id),
+// empty dates mean no limit. 多台设备同步统计后，用于查看每台设备各自的用量
+func (s *SQLiteStorage) GetStatsByDevice(startDate, endDate string) (map[string]*EndpointStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query := `SELECT COALESCE(NULLIF(device_id, ''), 'default') AS device, SUM(requests), SUM(errors),
+		SUM(input_tokens), SUM(COALESCE(cache_creation_tokens, 0)), SUM(COALESCE(cache_read_tokens, 0)), SUM(output_tokens)
+		FROM daily_stats WHERE 1=1`
+	var args []interface{}
+	if startDate != "" {
+		query += ` AND date >= ?`
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += ` AND date <= ?`
+		args = append(args, endDate)
+	}
+	query += ` GROUP BY device`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]*EndpointStats)
+	for rows.Next() {
+		var deviceID string
+		st := &EndpointStats{}
+		if err := rows.Scan(&deviceID, &st.Requests, &st.Errors,
+			&st.InputTokens, &st.CacheCreationTokens, &st.CacheReadTokens, &st.OutputTokens); err != nil {
+			return nil, err
+		}
+		result[deviceID] = st
+	}
+	return result, rows.Err()
+}
+
+// GetTestStats returns the usage of test requests per endpoint (key clientType:endpointName) within the date range,
+// empty dates mean no limit. 测试请求也会计入 daily_stats，查询排除测试请求的统计时用它扣除
+func (s *SQLiteStorage) GetTestStats(startDate, endDate string) (map[string]*EndpointStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.queryTestStats(startDate, endDate)
+}
+
+// queryTestStats 按端点汇总 request_stats 中的测试请求，调用方需持有读锁
+func (s *SQLiteStorage) queryTestStats(startDate, endDate string) (map[string]*EndpointStats, error) {
+	query := `SELECT COALESCE(client_type, 'claude') as client_type, endpoint_name, COUNT(*),
+		SUM(CASE WHEN success THEN 0 ELSE 1 END), SUM(input_tokens), SUM(COALESCE(cache_creation_tokens, 0)),
+		SUM(COALESCE(cache_read_tokens, 0)), SUM(output_tokens)
+		FROM request_stats WHERE COALESCE(is_test, 0) = 1`
+	var args []interface{}
+	if startDate != "" {
+		query += ` AND date >= ?`
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += ` AND date <= ?`
+		args = append(args, endDate)
+	}
+	query += ` GROUP BY client_type, endpoint_name`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]*EndpointStats)
+	for rows.Next() {
+		var clientType, endpointName string
+		st := &EndpointStats{}
+		if err := rows.Scan(&clientType, &endpointName, &st.Requests, &st.Errors,
+			&st.InputTokens, &st.CacheCreationTokens, &st.CacheReadTokens, &st.OutputTokens); err != nil {
+			return nil, err
+		}
+		result[clientType+":"+endpointName] = st
 	}
+	return result, rows.Err()
+}
 
-	return totalRequests, result, rows.Err()
+// subtractEndpointStats 从 st 中扣除 test 的用量（不低于 0），返回实际扣除的请求数
+func subtractEndpointStats(st, test *EndpointStats) int {
+	requests := test.Requests
+	if requests > st.Requests {
+		requests = st.Requests
+	}
+	st.Requests -= requests
+	st.Errors = max(st.Errors-test.Errors, 0)
+	st.InputTokens = max(st.InputTokens-test.InputTokens, 0)
+	st.CacheCreationTokens = max(st.CacheCreationTokens-test.CacheCreationTokens, 0)
+	st.CacheReadTokens = max(st.CacheReadTokens-test.CacheReadTokens, 0)
+	st.OutputTokens = max(st.OutputTokens-test.OutputTokens, 0)
+	return requests
 }
 
 // GetTotalStatsByClient returns total stats filtered by client type
@@ -942,6 +1722,16 @@ func (s *SQLiteStorage) GetEndpointTotalStats(endpointName string, clientType st
 		return nil, err
 	}
 
+	// 按端点配置的单价估算花费，端点不存在或未配置单价时花费为 0
+	var costInput, costOutput, costCacheRead float64
+	err = s.db.QueryRow(`SELECT COALESCE(cost_per_input_token, 0), COALESCE(cost_per_output_token, 0), COALESCE(cost_per_cache_read_token, 0)
+		FROM endpoints WHERE name=? AND COALESCE(client_type, 'claude')=?`, endpointName, clientType).Scan(&costInput, &costOutput, &costCacheRead)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	estimatedCost := pricing.CalculateCostBreakdown(int(inputTokens), int(outputTokens), int(cacheCreationTokens), int(cacheReadTokens),
+		config.EndpointPricing(costInput, costOutput, costCacheRead))
+
 	return &EndpointStats{
 		Requests:            requests,
 		Errors:              errors,
@@ -949,6 +1739,7 @@ func (s *SQLiteStorage) GetEndpointTotalStats(endpointName string, clientType st
 		CacheCreationTokens: cacheCreationTokens,
 		CacheReadTokens:     cacheReadTokens,
 		OutputTokens:        outputTokens,
+		EstimatedCost:       estimatedCost,
 	}, nil
 }
 
@@ -1393,6 +2184,202 @@ func (s *SQLiteStorage) mergeAppConfig(tx *sql.Tx, strategy MergeStrategy) error
 	}
 }
 
+// ExportPortableConfig 导出可迁移的 app_config 配置项（见 IsPortableConfigKey）
+// includeSecrets 为 false 时跳过 WebDAV 密码、S3 密钥等凭证
+func (s *SQLiteStorage) ExportPortableConfig(includeSecrets bool) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`SELECT key, value FROM app_config`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		if !IsPortableConfigKey(key) || (!includeSecrets && secretConfigKeys[key]) {
+			continue
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+// ImportPortableConfig 按策略写入可迁移的配置项，不可迁移的配置项被忽略，返回实际写入的数量
+// MergeStrategyKeepLocal 只写入本地没有的配置项，MergeStrategyOverwriteLocal 覆盖本地值
+func (s *SQLiteStorage) ImportPortableConfig(values map[string]string, strategy MergeStrategy) (int, error) {
+	var query string
+	switch strategy {
+	case MergeStrategyKeepLocal:
+		query = `INSERT OR IGNORE INTO app_config (key, value) VALUES (?, ?)`
+	case MergeStrategyOverwriteLocal:
+		query = `INSERT INTO app_config (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value=excluded.value, updated_at=CURRENT_TIMESTAMP`
+	default:
+		return 0, fmt.Errorf("unknown merge strategy: %s", strategy)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for key, value := range values {
+		if !IsPortableConfigKey(key) {
+			continue
+		}
+		result, err := tx.Exec(query, key, value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import config %s: %w", key, err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			written++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// ExportDailyStats 导出全部每日统计（不聚合设备），供其他实例通过 /stats/export 拉取
+func (s *SQLiteStorage) ExportDailyStats() (*StatsExport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deviceID string
+	err := s.db.QueryRow(`SELECT COALESCE((SELECT value FROM app_config WHERE key = 'device_id'), 'default')`).Scan(&deviceID)
+	if err != nil {
+		deviceID = "default"
+	}
+
+	rows, err := s.db.Query(`SELECT endpoint_name, COALESCE(client_type, 'claude'), date, requests, errors,
+		input_tokens, COALESCE(cache_creation_tokens, 0), COALESCE(cache_read_tokens, 0), output_tokens,
+		COALESCE(device_id, 'default')
+		FROM daily_stats ORDER BY date, endpoint_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	export := &StatsExport{
+		Version:    StatsExportVersion,
+		DeviceID:   deviceID,
+		ExportedAt: time.Now(),
+		DailyStats: []DailyStatExport{},
+	}
+	for rows.Next() {
+		var stat DailyStatExport
+		if err := rows.Scan(&stat.EndpointName, &stat.ClientType, &stat.Date, &stat.Requests, &stat.Errors,
+			&stat.InputTokens, &stat.CacheCreationTokens, &stat.CacheReadTokens, &stat.OutputTokens,
+			&stat.DeviceID); err != nil {
+			return nil, err
+		}
+		export.DailyStats = append(export.DailyStats, stat)
+	}
+
+	return export, rows.Err()
+}
+
+// ImportDailyStats 将其他实例导出的每日统计合并到本地
+// 与 mergeDailyStats 不同，这里保留来源的 device_id：不同设备的统计各自成行，
+// 同一来源重复导入时按 (endpoint_name, client_type, date, device_id) 命中同一行，不会重复累加；
+// device_id 与本机相同的行（本机数据经对方导入后又导出回来）会被跳过
+func (s *SQLiteStorage) ImportDailyStats(export *StatsExport, strategy MergeStrategy) (*StatsImportResult, error) {
+	if export == nil {
+		return nil, fmt.Errorf("stats export is nil")
+	}
+
+	var query string
+	switch strategy {
+	case MergeStrategyKeepLocal:
+		// 保留本地数据，只插入本地不存在的记录
+		query = `
+			INSERT OR IGNORE INTO daily_stats
+			(endpoint_name, client_type, date, requests, errors, input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens, device_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	case MergeStrategyOverwriteLocal:
+		// 用导入数据覆盖本地同一设备的记录（覆盖而非累加）
+		query = `
+			INSERT INTO daily_stats
+			(endpoint_name, client_type, date, requests, errors, input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens, device_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(endpoint_name, client_type, date, device_id) DO UPDATE SET
+				requests = excluded.requests,
+				errors = excluded.errors,
+				input_tokens = excluded.input_tokens,
+				cache_creation_tokens = excluded.cache_creation_tokens,
+				cache_read_tokens = excluded.cache_read_tokens,
+				output_tokens = excluded.output_tokens`
+	default:
+		return nil, fmt.Errorf("unknown merge strategy: %s", strategy)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var localDeviceID string
+	err := s.db.QueryRow(`SELECT COALESCE((SELECT value FROM app_config WHERE key = 'device_id'), 'default')`).Scan(&localDeviceID)
+	if err != nil {
+		localDeviceID = "default"
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	result := &StatsImportResult{}
+	for _, stat := range export.DailyStats {
+		// 旧数据可能没有 device_id，归属到导出实例
+		deviceID := stat.DeviceID
+		if deviceID == "" || deviceID == "default" {
+			deviceID = export.DeviceID
+		}
+		if deviceID == "" || deviceID == localDeviceID || stat.EndpointName == "" || stat.Date == "" {
+			result.Skipped++
+			continue
+		}
+		clientType := stat.ClientType
+		if clientType == "" {
+			clientType = "claude"
+		}
+
+		res, err := stmt.Exec(stat.EndpointName, clientType, stat.Date, stat.Requests, stat.Errors, stat.InputTokens,
+			stat.CacheCreationTokens, stat.CacheReadTokens, stat.OutputTokens, deviceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import daily stat: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
 // RecordRequestStat records a single request-level statistic
 func (s *SQLiteStorage) RecordRequestStat(stat *RequestStat) error {
 	s.mu.Lock()
@@ -1414,9 +2401,10 @@ func (s *SQLiteStorage) RecordRequestStat(stat *RequestStat) error {
 		INSERT INTO request_stats (
 			endpoint_name, client_type, client_ip, request_id, timestamp, date,
 			input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens,
-			model, is_streaming, success, device_id, duration_ms, error_message
+			model, is_streaming, success, device_id, duration_ms, error_message,
+			request_bytes, response_bytes, upstream_model, is_test, error_category
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		stat.EndpointName,        // endpoint_name
 		clientType,               // client_type
@@ -1434,6 +2422,11 @@ func (s *SQLiteStorage) RecordRequestStat(stat *RequestStat) error {
 		stat.DeviceID,            // device_id
 		stat.DurationMs,          // duration_ms
 		errorMessage,             // error_message
+		stat.RequestBytes,        // request_bytes
+		stat.ResponseBytes,       // response_bytes
+		stat.UpstreamModel,       // upstream_model
+		stat.IsTest,              // is_test
+		stat.ErrorCategory,       // error_category
 	)
 
 	return err
@@ -1459,7 +2452,10 @@ func (s *SQLiteStorage) GetRequestStats(endpointName string, clientType string,
 				request_id, timestamp, date,
 				input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens,
 				model, is_streaming, success, device_id, COALESCE(duration_ms, 0) as duration_ms,
-				COALESCE(error_message, '') as error_message
+				COALESCE(error_message, '') as error_message,
+				COALESCE(request_bytes, 0) as request_bytes, COALESCE(response_bytes, 0) as response_bytes,
+				COALESCE(upstream_model, '') as upstream_model, COALESCE(is_test, 0) as is_test,
+				COALESCE(error_category, '') as error_category
 			FROM request_stats
 			WHERE COALESCE(client_type, 'claude')=? AND date>=? AND date<=?
 			ORDER BY timestamp DESC
@@ -1473,7 +2469,10 @@ func (s *SQLiteStorage) GetRequestStats(endpointName string, clientType string,
 				request_id, timestamp, date,
 				input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens,
 				model, is_streaming, success, device_id, COALESCE(duration_ms, 0) as duration_ms,
-				COALESCE(error_message, '') as error_message
+				COALESCE(error_message, '') as error_message,
+				COALESCE(request_bytes, 0) as request_bytes, COALESCE(response_bytes, 0) as response_bytes,
+				COALESCE(upstream_model, '') as upstream_model, COALESCE(is_test, 0) as is_test,
+				COALESCE(error_category, '') as error_category
 			FROM request_stats
 			WHERE endpoint_name=? AND COALESCE(client_type, 'claude')=? AND date>=? AND date<=?
 			ORDER BY timestamp DESC
@@ -1497,6 +2496,9 @@ func (s *SQLiteStorage) GetRequestStats(endpointName string, clientType string,
 			&stat.InputTokens, &stat.CacheCreationTokens, &stat.CacheReadTokens, &stat.OutputTokens,
 			&stat.Model, &stat.IsStreaming, &stat.Success, &stat.DeviceID, &stat.DurationMs,
 			&stat.ErrorMessage,
+			&stat.RequestBytes, &stat.ResponseBytes,
+			&stat.UpstreamModel, &stat.IsTest,
+			&stat.ErrorCategory,
 		); err != nil {
 			return nil, err
 		}
@@ -1506,6 +2508,78 @@ func (s *SQLiteStorage) GetRequestStats(endpointName string, clientType string,
 	return stats, rows.Err()
 }
 
+// SearchRequestStats searches request-level stats whose error_message, model or endpoint_name
+// contains keyword (case-insensitive), newest first. clientType 为空时搜索所有客户端类型。
+// 返回当前页记录和匹配总数。
+//
+// 性能说明：前置通配的 LIKE '%kw%' 无法使用 B-tree 索引，只能依靠 date 范围（idx_request_stats_date）
+// 缩小扫描行数后逐行匹配；request_stats 会按保留天数定期清理，单日数据量下顺序扫描代价可接受，
+// 因此没有引入 FTS5 虚拟表（需要额外的同步触发器和存储空间）。调用方应尽量传入较小的日期范围。
+func (s *SQLiteStorage) SearchRequestStats(keyword, clientType, startDate, endDate string, limit, offset int) ([]RequestStat, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// 转义 LIKE 通配符，关键词按字面匹配
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.TrimSpace(keyword))
+	pattern := "%" + escaped + "%"
+
+	where := `date>=? AND date<=? AND (
+			COALESCE(error_message, '') LIKE ? ESCAPE '\'
+			OR COALESCE(model, '') LIKE ? ESCAPE '\'
+			OR endpoint_name LIKE ? ESCAPE '\'
+		)`
+	args := []interface{}{startDate, endDate, pattern, pattern, pattern}
+	if clientType != "" {
+		where += ` AND COALESCE(client_type, 'claude')=?`
+		args = append(args, clientType)
+	}
+
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM request_stats WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, endpoint_name, COALESCE(client_type, 'claude') as client_type, COALESCE(client_ip, '') as client_ip,
+			request_id, timestamp, date,
+			input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens,
+			model, is_streaming, success, device_id, COALESCE(duration_ms, 0) as duration_ms,
+			COALESCE(error_message, '') as error_message,
+			COALESCE(request_bytes, 0) as request_bytes, COALESCE(response_bytes, 0) as response_bytes,
+			COALESCE(upstream_model, '') as upstream_model, COALESCE(is_test, 0) as is_test,
+			COALESCE(error_category, '') as error_category
+		FROM request_stats
+		WHERE ` + where + `
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := s.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var stats []RequestStat
+	for rows.Next() {
+		var stat RequestStat
+		if err := rows.Scan(
+			&stat.ID, &stat.EndpointName, &stat.ClientType, &stat.ClientIP,
+			&stat.RequestID, &stat.Timestamp, &stat.Date,
+			&stat.InputTokens, &stat.CacheCreationTokens, &stat.CacheReadTokens, &stat.OutputTokens,
+			&stat.Model, &stat.IsStreaming, &stat.Success, &stat.DeviceID, &stat.DurationMs,
+			&stat.ErrorMessage,
+			&stat.RequestBytes, &stat.ResponseBytes,
+			&stat.UpstreamModel, &stat.IsTest,
+			&stat.ErrorCategory,
+		); err != nil {
+			return nil, 0, err
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, total, rows.Err()
+}
+
 // GetRequestStatsCount gets the total count of request stats for pagination
 func (s *SQLiteStorage) GetRequestStatsCount(endpointName string, clientType string, startDate, endDate string) (int, error) {
 	s.mu.RLock()
@@ -1536,6 +2610,79 @@ func (s *SQLiteStorage) GetRequestStatsCount(endpointName string, clientType str
 	return count, err
 }
 
+// GetStatsByModel aggregates request stats by model within a date range.
+// Records without a model are grouped under "unknown".
+func (s *SQLiteStorage) GetStatsByModel(startDate, endDate string) (map[string]*EndpointStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`
+		SELECT CASE WHEN COALESCE(model, '') = '' THEN 'unknown' ELSE model END AS model_name,
+			COUNT(*),
+			SUM(CASE WHEN success THEN 0 ELSE 1 END),
+			SUM(COALESCE(input_tokens, 0)),
+			SUM(COALESCE(cache_creation_tokens, 0)),
+			SUM(COALESCE(cache_read_tokens, 0)),
+			SUM(COALESCE(output_tokens, 0))
+		FROM request_stats
+		WHERE date>=? AND date<=?
+		GROUP BY model_name
+	`, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]*EndpointStats)
+	for rows.Next() {
+		var model string
+		stats := &EndpointStats{}
+		if err := rows.Scan(&model, &stats.Requests, &stats.Errors, &stats.InputTokens,
+			&stats.CacheCreationTokens, &stats.CacheReadTokens, &stats.OutputTokens); err != nil {
+			return nil, err
+		}
+		result[model] = stats
+	}
+
+	return result, rows.Err()
+}
+
+// GetErrorCategoryCounts counts failed requests by error_category within a date range, excluding test requests.
+// Also returns the total number of requests in the range. 没有错误分类的旧记录归入 "other"
+func (s *SQLiteStorage) GetErrorCategoryCounts(startDate, endDate string) (map[string]int, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows, err := s.db.Query(`
+		SELECT success, CASE WHEN COALESCE(error_category, '') = '' THEN 'other' ELSE error_category END AS category,
+			COUNT(*)
+		FROM request_stats
+		WHERE date>=? AND date<=? AND COALESCE(is_test, 0) = 0
+		GROUP BY success, category
+	`, startDate, endDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	total := 0
+	for rows.Next() {
+		var success bool
+		var category string
+		var count int
+		if err := rows.Scan(&success, &category, &count); err != nil {
+			return nil, 0, err
+		}
+		total += count
+		if !success {
+			counts[category] += count
+		}
+	}
+
+	return counts, total, rows.Err()
+}
+
 // CleanupOldRequestStats deletes request stats older than specified days
 func (s *SQLiteStorage) CleanupOldRequestStats(daysToKeep int) error {
 	s.mu.Lock()
@@ -1560,6 +2707,66 @@ func (s *SQLiteStorage) CleanupOldRequestStats(daysToKeep int) error {
 	return nil
 }
 
+// RebuildRequestStatsDates recomputes the date column of request_stats from the timestamp in loc,
+// used after the stats time zone changes. Returns the number of rows whose date changed.
+// daily_stats 是按天累加的汇总，无法按新时区重新划分，保留原有日期
+func (s *SQLiteStorage) RebuildRequestStatsDates(loc *time.Location) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query(`SELECT id, timestamp, date FROM request_stats`)
+	if err != nil {
+		return 0, err
+	}
+
+	type dateChange struct {
+		id   int64
+		date string
+	}
+	var changes []dateChange
+	for rows.Next() {
+		var id int64
+		var timestamp time.Time
+		var date string
+		if err := rows.Scan(&id, &timestamp, &date); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if newDate := timestamp.In(loc).Format("2006-01-02"); newDate != date {
+			changes = append(changes, dateChange{id: id, date: newDate})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE request_stats SET date = ? WHERE id = ?`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, change := range changes {
+		if _, err := stmt.Exec(change.date, change.id); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(changes), nil
+}
+
 // GetConnectedClients returns clients that have made requests in the past N hours
 func (s *SQLiteStorage) GetConnectedClients(hoursAgo int) ([]ClientStats, error) {
 	s.mu.RLock()
@@ -1784,6 +2991,80 @@ func (s *SQLiteStorage) CleanupOldHealthHistory(daysToKeep int) error {
 	return err
 }
 
+// migrateConfigAudit creates the config_audit table
+func (s *SQLiteStorage) migrateConfigAudit() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS config_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		action TEXT NOT NULL,
+		endpoint_name TEXT NOT NULL,
+		client_type TEXT DEFAULT 'claude',
+		before_json TEXT DEFAULT '',
+		after_json TEXT DEFAULT '',
+		source TEXT DEFAULT '',
+		device_id TEXT DEFAULT 'default',
+		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_config_audit_timestamp ON config_audit(timestamp DESC);
+	`
+	_, err := s.db.Exec(schema)
+	return err
+}
+
+// RecordConfigAudit records an endpoint config change to the audit log
+func (s *SQLiteStorage) RecordConfigAudit(record *ConfigAuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clientType := record.ClientType
+	if clientType == "" {
+		clientType = "claude"
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO config_audit (action, endpoint_name, client_type, before_json, after_json, source, device_id, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, record.Action, record.EndpointName, clientType, record.Before, record.After, record.Source, record.DeviceID, record.Timestamp)
+
+	return err
+}
+
+// GetConfigAuditLog returns config audit records, newest first, and the total record count
+func (s *SQLiteStorage) GetConfigAuditLog(limit, offset int) ([]ConfigAuditRecord, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM config_audit`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, action, endpoint_name, COALESCE(client_type, 'claude') as client_type,
+			COALESCE(before_json, '') as before_json, COALESCE(after_json, '') as after_json,
+			COALESCE(source, '') as source, COALESCE(device_id, '') as device_id, timestamp
+		FROM config_audit
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	records := []ConfigAuditRecord{}
+	for rows.Next() {
+		var r ConfigAuditRecord
+		if err := rows.Scan(&r.ID, &r.Action, &r.EndpointName, &r.ClientType, &r.Before, &r.After, &r.Source, &r.DeviceID, &r.Timestamp); err != nil {
+			return nil, 0, err
+		}
+		records = append(records, r)
+	}
+
+	return records, total, rows.Err()
+}
+
 // GetAllEndpointTags returns all unique tags used across all endpoints
 func (s *SQLiteStorage) GetAllEndpointTags() ([]string, error) {
 	s.mu.RLock()
@@ -1976,7 +3257,7 @@ func (s *SQLiteStorage) GetEndpointQuota(endpointName, clientType string) (*Endp
 
 	err := s.db.QueryRow(`
 		SELECT id, endpoint_name, COALESCE(client_type, 'claude') as client_type,
-			   period_start, period_end, tokens_used, quota_limit, last_updated
+			   period_start, period_end, tokens_used, quota_limit, COALESCE(cost_used, 0) as cost_used, last_updated
 		FROM endpoint_quotas
 		WHERE endpoint_name = ? AND COALESCE(client_type, 'claude') = ?
 		ORDER BY period_start DESC
@@ -1984,7 +3265,7 @@ func (s *SQLiteStorage) GetEndpointQuota(endpointName, clientType string) (*Endp
 	`, endpointName, clientType).Scan(
 		&quota.ID, &quota.EndpointName, &quota.ClientType,
 		&periodStartStr, &periodEndStr, &quota.TokensUsed,
-		&quota.QuotaLimit, &lastUpdatedStr,
+		&quota.QuotaLimit, &quota.CostUsed, &lastUpdatedStr,
 	)
 
 	if err == sql.ErrNoRows {
@@ -2035,13 +3316,14 @@ func (s *SQLiteStorage) UpdateEndpointQuota(quota *EndpointQuota) error {
 	}
 
 	_, err := s.db.Exec(`
-		INSERT INTO endpoint_quotas (endpoint_name, client_type, period_start, period_end, tokens_used, quota_limit, last_updated)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO endpoint_quotas (endpoint_name, client_type, period_start, period_end, tokens_used, quota_limit, cost_used, last_updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(endpoint_name, client_type, period_start) DO UPDATE SET
 			tokens_used = excluded.tokens_used,
 			quota_limit = excluded.quota_limit,
+			cost_used = excluded.cost_used,
 			last_updated = excluded.last_updated
-	`, quota.EndpointName, clientType, quota.PeriodStart, quota.PeriodEnd, quota.TokensUsed, quota.QuotaLimit, time.Now())
+	`, quota.EndpointName, clientType, quota.PeriodStart, quota.PeriodEnd, quota.TokensUsed, quota.QuotaLimit, quota.CostUsed, time.Now())
 
 	return err
 }
@@ -2079,7 +3361,10 @@ func (s *SQLiteStorage) GetRecentRequestsByEndpoint(endpointName string, clientT
 			request_id, timestamp, date,
 			input_tokens, cache_creation_tokens, cache_read_tokens, output_tokens,
 			model, is_streaming, success, device_id, COALESCE(duration_ms, 0) as duration_ms,
-			COALESCE(error_message, '') as error_message
+			COALESCE(error_message, '') as error_message,
+			COALESCE(request_bytes, 0) as request_bytes, COALESCE(response_bytes, 0) as response_bytes,
+			COALESCE(upstream_model, '') as upstream_model, COALESCE(is_test, 0) as is_test,
+			COALESCE(error_category, '') as error_category
 		FROM request_stats
 		WHERE endpoint_name=? AND COALESCE(client_type, 'claude')=?
 		ORDER BY timestamp DESC
@@ -2101,6 +3386,9 @@ func (s *SQLiteStorage) GetRecentRequestsByEndpoint(endpointName string, clientT
 			&stat.InputTokens, &stat.CacheCreationTokens, &stat.CacheReadTokens, &stat.OutputTokens,
 			&stat.Model, &stat.IsStreaming, &stat.Success, &stat.DeviceID, &stat.DurationMs,
 			&stat.ErrorMessage,
+			&stat.RequestBytes, &stat.ResponseBytes,
+			&stat.UpstreamModel, &stat.IsTest,
+			&stat.ErrorCategory,
 		); err != nil {
 			return nil, err
 		}
@@ -58,21 +58,41 @@ func (a *StatsStorageAdapter) RecordRequestStat(stat interface{}) error {
 		CacheReadTokens:     int(v.FieldByName("CacheReadTokens").Int()),
 		OutputTokens:        int(v.FieldByName("OutputTokens").Int()),
 		Model:               v.FieldByName("Model").String(),
+		UpstreamModel:       v.FieldByName("UpstreamModel").String(),
 		IsStreaming:         v.FieldByName("IsStreaming").Bool(),
 		Success:             v.FieldByName("Success").Bool(),
 		DeviceID:            v.FieldByName("DeviceID").String(),
 		DurationMs:          v.FieldByName("DurationMs").Int(),
+		ErrorMessage:        v.FieldByName("ErrorMessage").String(),
+		ErrorCategory:       v.FieldByName("ErrorCategory").String(),
+		RequestBytes:        v.FieldByName("RequestBytes").Int(),
+		ResponseBytes:       v.FieldByName("ResponseBytes").Int(),
+		IsTest:              v.FieldByName("IsTest").Bool(),
 	}
 	return a.storage.RecordRequestStat(requestStat)
 }
 
 // GetTotalStats gets total stats for all endpoints
-func (a *StatsStorageAdapter) GetTotalStats() (int, map[string]interface{}, error) {
-	totalRequests, endpointStats, err := a.storage.GetTotalStats()
+func (a *StatsStorageAdapter) GetTotalStats(excludeTest bool) (int, map[string]interface{}, error) {
+	totalRequests, endpointStats, err := a.storage.GetTotalStats(excludeTest)
 	if err != nil {
 		return 0, nil, err
 	}
 
+	return totalRequests, toStatsDataCompat(endpointStats), nil
+}
+
+// GetTestStats gets the usage of test requests for all endpoints within the date range
+func (a *StatsStorageAdapter) GetTestStats(startDate, endDate string) (map[string]interface{}, error) {
+	testStats, err := a.storage.GetTestStats(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStatsDataCompat(testStats), nil
+}
+
+func toStatsDataCompat(endpointStats map[string]*EndpointStats) map[string]interface{} {
 	result := make(map[string]interface{})
 	for name, stats := range endpointStats {
 		result[name] = &StatsDataCompat{
@@ -84,8 +104,7 @@ func (a *StatsStorageAdapter) GetTotalStats() (int, map[string]interface{}, erro
 			OutputTokens:        stats.OutputTokens,
 		}
 	}
-
-	return totalRequests, result, nil
+	return result
 }
 
 // StatsDataCompat is a compatible stats data structure
@@ -121,6 +140,11 @@ func (a *StatsStorageAdapter) GetDailyStats(endpointName, clientType, startDate,
 	return result, nil
 }
 
+// ExportDailyStats exports all daily stats for /stats/export
+func (a *StatsStorageAdapter) ExportDailyStats() (interface{}, error) {
+	return a.storage.ExportDailyStats()
+}
+
 // DailyRecordCompat is a compatible daily record structure
 type DailyRecordCompat struct {
 	Date                string
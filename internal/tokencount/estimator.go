@@ -2,6 +2,7 @@ package tokencount
 
 import (
 	"encoding/json"
+	"math"
 	"strings"
 )
 
@@ -67,7 +68,7 @@ func estimateAny(v any) int {
 		return tokens
 	default:
 		if data, err := json.Marshal(v); err == nil {
-			return len(data) / 4
+			return estimateText(string(data))
 		}
 		return 0
 	}
@@ -92,7 +93,7 @@ func estimateBlock(block any) int {
 	case "tool_use":
 		if input, ok := m["input"]; ok {
 			if data, err := json.Marshal(input); err == nil {
-				return len(data) / 4
+				return estimateText(string(data))
 			}
 		}
 	case "tool_result":
@@ -100,43 +101,89 @@ func estimateBlock(block any) int {
 	}
 
 	if data, err := json.Marshal(block); err == nil {
-		return len(data) / 4
+		return estimateText(string(data))
 	}
 	return 10
 }
 
+// estimateText 按字符类别加权估算 token 数，近似 BPE 分词的行为：
+//   - 英文单词：常见单词 1 个 token，长单词约 5 个字母 1 个 token
+//   - 数字：约 3 位 1 个 token
+//   - 标点 / 符号（代码、JSON 结构）：连续的 3 个以内约 1 个 token
+//   - 单个空格并入下一个单词，连续空格（缩进）约 4 个 1 个 token，换行单独计
+//   - CJK 汉字、假名、谚文：约 1 个字符 1 个 token，全角标点同样按 1 个 token
+//   - 其他非 ASCII 字符（带重音的拉丁字母、西里尔字母等）约 2 个 1 个 token，emoji 约 2 个 token
 func estimateText(text string) int {
 	if text == "" {
 		return 0
 	}
 
-	runes := []rune(text)
-	count := len(runes)
-	if count == 0 {
-		return 0
-	}
-
-	// Sample first 500 chars for Chinese detection
-	sample := count
-	if sample > 500 {
-		sample = 500
+	var tokens float64
+	var letters, digits, puncts, spaces int
+	flush := func() {
+		tokens += math.Ceil(float64(letters) / 5)
+		tokens += math.Ceil(float64(digits) / 3)
+		tokens += math.Ceil(float64(puncts) / 3)
+		if spaces > 1 {
+			tokens += math.Ceil(float64(spaces) / 4)
+		}
+		letters, digits, puncts, spaces = 0, 0, 0, 0
 	}
 
-	chinese := 0
-	for i := 0; i < sample; i++ {
-		if r := runes[i]; r >= 0x4E00 && r <= 0x9FFF {
-			chinese++
+	for _, r := range text {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			if digits > 0 || puncts > 0 || spaces > 0 {
+				flush()
+			}
+			letters++
+		case r >= '0' && r <= '9':
+			if letters > 0 || puncts > 0 || spaces > 0 {
+				flush()
+			}
+			digits++
+		case r == ' ' || r == '\t':
+			if letters > 0 || digits > 0 || puncts > 0 {
+				flush()
+			}
+			spaces++
+		case r == '\n' || r == '\r':
+			flush()
+			tokens++
+		case r < 0x80:
+			if letters > 0 || digits > 0 || spaces > 0 {
+				flush()
+			}
+			puncts++
+		default:
+			flush()
+			tokens += runeTokens(r)
 		}
 	}
+	flush()
 
-	ratio := float64(chinese) / float64(sample)
-	charsPerToken := 4.0 - 2.5*ratio // English: 4, Chinese: 1.5
-
-	tokens := int(float64(count) / charsPerToken)
 	if tokens < 1 {
 		return 1
 	}
-	return tokens
+	return int(math.Round(tokens))
+}
+
+// runeTokens 返回单个非 ASCII 字符的 token 权重
+func runeTokens(r rune) float64 {
+	switch {
+	case r >= 0x4E00 && r <= 0x9FFF, // CJK 统一汉字
+		r >= 0x3400 && r <= 0x4DBF, // CJK 扩展 A
+		r >= 0xF900 && r <= 0xFAFF, // CJK 兼容汉字
+		r >= 0x3040 && r <= 0x30FF, // 平假名、片假名
+		r >= 0xAC00 && r <= 0xD7AF, // 谚文音节
+		r >= 0x3000 && r <= 0x303F, // CJK 标点
+		r >= 0xFF00 && r <= 0xFFEF: // 全角字符
+		return 1
+	case r >= 0x1F000:
+		return 2 // emoji 等通常被拆成多个字节级 token
+	default:
+		return 0.5
+	}
 }
 
 func estimateTools(tools []Tool) int {
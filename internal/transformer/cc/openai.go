@@ -7,12 +7,24 @@ import (
 
 // OpenAITransformer transforms Claude Code requests to OpenAI Chat format
 type OpenAITransformer struct {
-	model string
+	model           string
+	forwardThinking bool // 是否把上游推理内容（reasoning_content）转换为 thinking block
+	streamUsage     bool // 流式请求是否注入 stream_options.include_usage
 }
 
 // NewOpenAITransformer creates a new transformer
 func NewOpenAITransformer(model string) *OpenAITransformer {
-	return &OpenAITransformer{model: model}
+	return &OpenAITransformer{model: model, forwardThinking: true, streamUsage: true}
+}
+
+// NewOpenAITransformerWithThinking creates a new transformer with explicit thinking forwarding
+func NewOpenAITransformerWithThinking(model string, forwardThinking bool) *OpenAITransformer {
+	return &OpenAITransformer{model: model, forwardThinking: forwardThinking, streamUsage: true}
+}
+
+// NewOpenAITransformerWithOptions creates a new transformer with explicit thinking forwarding and stream usage injection
+func NewOpenAITransformerWithOptions(model string, forwardThinking, streamUsage bool) *OpenAITransformer {
+	return &OpenAITransformer{model: model, forwardThinking: forwardThinking, streamUsage: streamUsage}
 }
 
 func (t *OpenAITransformer) Name() string {
@@ -20,19 +32,24 @@ func (t *OpenAITransformer) Name() string {
 }
 
 func (t *OpenAITransformer) TransformRequest(req []byte) ([]byte, error) {
-	return convert.ClaudeReqToOpenAI(req, t.model)
+	openaiReq, err := convert.ClaudeReqToOpenAI(req, t.model)
+	if err != nil || t.streamUsage {
+		return openaiReq, err
+	}
+	return convert.ApplyStreamUsage(openaiReq, false)
 }
 
 func (t *OpenAITransformer) TransformResponse(resp []byte, isStreaming bool) ([]byte, error) {
 	if isStreaming {
 		return nil, nil
 	}
-	return convert.OpenAIRespToClaude(resp)
+	return convert.OpenAIRespToClaude(resp, t.forwardThinking)
 }
 
 func (t *OpenAITransformer) TransformResponseWithContext(resp []byte, isStreaming bool, ctx *transformer.StreamContext) ([]byte, error) {
 	if isStreaming {
+		ctx.EnableThinking = t.forwardThinking
 		return convert.OpenAIStreamToClaude(resp, ctx)
 	}
-	return convert.OpenAIRespToClaude(resp)
+	return convert.OpenAIRespToClaude(resp, t.forwardThinking)
 }
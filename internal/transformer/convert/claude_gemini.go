@@ -19,11 +19,8 @@ func ClaudeReqToGemini(claudeReq []byte, model string) ([]byte, error) {
 
 	// Convert system prompt
 	if req.System != nil {
-		systemText := extractSystemText(req.System)
-		if systemText != "" {
-			geminiReq["systemInstruction"] = map[string]interface{}{
-				"parts": []map[string]interface{}{{"text": systemText}},
-			}
+		if instruction := geminiSystemInstruction(extractSystemText(req.System)); instruction != nil {
+			geminiReq["systemInstruction"] = instruction
 		}
 	}
 
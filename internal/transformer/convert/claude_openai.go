@@ -307,8 +307,9 @@ func ClaudeRespToOpenAI(claudeResp []byte, model string) ([]byte, error) {
 	return json.Marshal(openaiResp)
 }
 
-// OpenAIRespToClaude converts OpenAI Chat response to Claude response
-func OpenAIRespToClaude(openaiResp []byte) ([]byte, error) {
+// OpenAIRespToClaude converts OpenAI Chat response to Claude response.
+// includeThinking 为 true 时把 reasoning_content / reasoning 转换为 thinking block
+func OpenAIRespToClaude(openaiResp []byte, includeThinking bool) ([]byte, error) {
 	var resp transformer.OpenAIResponse
 	if err := json.Unmarshal(openaiResp, &resp); err != nil {
 		return nil, err
@@ -319,6 +320,9 @@ func OpenAIRespToClaude(openaiResp []byte) ([]byte, error) {
 
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		if reasoning := openAIReasoning(choice.Message.ReasoningContent, choice.Message.Reasoning); includeThinking && reasoning != "" {
+			content = append(content, map[string]interface{}{"type": "thinking", "thinking": reasoning})
+		}
 		if choice.Message.Content != "" {
 			content = append(content, map[string]interface{}{"type": "text", "text": choice.Message.Content})
 		}
@@ -432,6 +436,7 @@ func OpenAIStreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte,
 		if jsonData == "[DONE]" {
 			var result []byte
 			// Close any open content blocks before message_stop
+			result = append(result, closeThinkingBlock(ctx)...)
 			if ctx.ContentBlockStarted {
 				result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ContentIndex})...)
 				ctx.ContentBlockStarted = false
@@ -488,6 +493,30 @@ func OpenAIStreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte,
 	choice := chunk.Choices[0]
 	delta := choice.Delta
 
+	// Reasoning content (DeepSeek reasoning_content / reasoning) -> thinking block
+	if reasoning := openAIReasoning(delta.ReasoningContent, delta.Reasoning); ctx.EnableThinking && reasoning != "" {
+		if !ctx.ThinkingBlockStarted {
+			if ctx.ContentBlockStarted {
+				result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ContentIndex})...)
+				ctx.ContentBlockStarted = false
+				ctx.ContentIndex++
+			}
+			ctx.ThinkingBlockStarted = true
+			ctx.ThinkingIndex = ctx.ContentIndex
+			result = append(result, buildClaudeEvent("content_block_start", map[string]interface{}{
+				"index": ctx.ThinkingIndex, "content_block": map[string]interface{}{"type": "thinking", "thinking": ""},
+			})...)
+		}
+		result = append(result, buildClaudeEvent("content_block_delta", map[string]interface{}{
+			"index": ctx.ThinkingIndex, "delta": map[string]interface{}{"type": "thinking_delta", "thinking": reasoning},
+		})...)
+	}
+
+	// 推理结束（开始输出正文或工具调用）时关闭 thinking block
+	if delta.Content != "" || len(delta.ToolCalls) > 0 {
+		result = append(result, closeThinkingBlock(ctx)...)
+	}
+
 	// Text content
 	if delta.Content != "" {
 		if !ctx.ContentBlockStarted {
@@ -531,6 +560,7 @@ func OpenAIStreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte,
 
 	// Finish
 	if choice.FinishReason != nil {
+		result = append(result, closeThinkingBlock(ctx)...)
 		if ctx.ContentBlockStarted {
 			result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ContentIndex})...)
 			ctx.ContentBlockStarted = false
@@ -570,6 +600,24 @@ func OpenAIStreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte,
 
 // Helper functions
 
+// openAIReasoning returns the reasoning text of an OpenAI-compatible message or delta
+func openAIReasoning(reasoningContent, reasoning string) string {
+	if reasoningContent != "" {
+		return reasoningContent
+	}
+	return reasoning
+}
+
+// closeThinkingBlock closes the open thinking block (if any) and advances the content index
+func closeThinkingBlock(ctx *transformer.StreamContext) []byte {
+	if !ctx.ThinkingBlockStarted {
+		return nil
+	}
+	ctx.ThinkingBlockStarted = false
+	ctx.ContentIndex++
+	return buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ThinkingIndex})
+}
+
 func convertClaudeContentToOpenAI(content []interface{}) (interface{}, []transformer.OpenAIToolCall) {
 	var textParts []string
 	var toolCalls []transformer.OpenAIToolCall
@@ -26,25 +26,22 @@ func ClaudeReqToOpenAI2(claudeReq []byte, model string) ([]byte, error) {
 	}
 
 	// Convert messages to input
+	// tool_use / tool_result 块转换为独立的 function_call / function_call_output 输入项
 	var input []map[string]interface{}
 	for _, msg := range req.Messages {
-		item := map[string]interface{}{
-			"type": "message",
-			"role": msg.Role,
-		}
-
-		var contentParts []map[string]interface{}
 		switch content := msg.Content.(type) {
 		case string:
-			contentParts = append(contentParts, map[string]interface{}{
-				"type": "input_text",
-				"text": content,
+			input = append(input, map[string]interface{}{
+				"type": "message",
+				"role": msg.Role,
+				"content": []map[string]interface{}{{
+					"type": "input_text",
+					"text": content,
+				}},
 			})
 		case []interface{}:
-			contentParts = convertClaudeContentToOpenAI2(content, msg.Role)
+			input = append(input, convertClaudeContentToOpenAI2(content, msg.Role)...)
 		}
-		item["content"] = contentParts
-		input = append(input, item)
 	}
 	openai2Req["input"] = input
 
@@ -65,6 +62,25 @@ func ClaudeReqToOpenAI2(claudeReq []byte, model string) ([]byte, error) {
 			})
 		}
 		openai2Req["tools"] = tools
+
+		// Convert tool_choice
+		switch tc := req.ToolChoice.(type) {
+		case map[string]interface{}:
+			switch tc["type"] {
+			case "tool":
+				if name, ok := tc["name"].(string); ok {
+					openai2Req["tool_choice"] = map[string]interface{}{"type": "function", "name": name}
+				}
+			case "any":
+				openai2Req["tool_choice"] = "required"
+			case "auto":
+				openai2Req["tool_choice"] = "auto"
+			case "none":
+				openai2Req["tool_choice"] = "none"
+			}
+		case string:
+			openai2Req["tool_choice"] = tc
+		}
 	}
 
 	return json.Marshal(openai2Req)
@@ -203,6 +219,9 @@ func OpenAI2RespToClaude(openai2Resp []byte) ([]byte, error) {
 
 	var content []map[string]interface{}
 	stopReason := "end_turn"
+	if resp.Status == "incomplete" {
+		stopReason = "max_tokens"
+	}
 
 	for _, item := range resp.Output {
 		switch item.Type {
@@ -213,11 +232,15 @@ func OpenAI2RespToClaude(openai2Resp []byte) ([]byte, error) {
 				}
 			}
 		case "function_call":
-			var args map[string]interface{}
+			args := map[string]interface{}{}
 			json.Unmarshal([]byte(item.Arguments), &args)
+			callID := item.CallID
+			if callID == "" {
+				callID = item.ID
+			}
 			content = append(content, map[string]interface{}{
 				"type":  "tool_use",
-				"id":    item.CallID,
+				"id":    callID,
 				"name":  item.Name,
 				"input": args,
 			})
@@ -416,7 +439,9 @@ func ClaudeStreamToOpenAI2(event []byte, ctx *transformer.StreamContext) ([]byte
 func OpenAI2StreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte, error) {
 	_, jsonData := parseSSE(event)
 	if jsonData == "" || jsonData == "[DONE]" {
-		if jsonData == "[DONE]" {
+		// Responses API 通常不发送 [DONE]，message_stop 已在 response.completed 时发出
+		if jsonData == "[DONE]" && !ctx.FinishReasonSent {
+			ctx.FinishReasonSent = true
 			return buildClaudeEvent("message_stop", map[string]interface{}{}), nil
 		}
 		return nil, nil
@@ -469,6 +494,9 @@ func OpenAI2StreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte
 			ctx.ToolBlockStarted = true
 			ctx.ToolIndex = ctx.ContentIndex
 			ctx.CurrentToolID = evt.Item.CallID
+			if ctx.CurrentToolID == "" {
+				ctx.CurrentToolID = evt.Item.ID
+			}
 			ctx.CurrentToolName = evt.Item.Name
 			ctx.ToolArguments = ""
 			result = append(result, buildClaudeEvent("content_block_start", map[string]interface{}{
@@ -488,17 +516,31 @@ func OpenAI2StreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte
 
 	case "response.output_item.done":
 		if evt.Item != nil && evt.Item.Type == "function_call" && ctx.ToolBlockStarted {
+			// 部分上游不推送参数增量，只在 done 事件里给出完整参数
+			if ctx.ToolArguments == "" && evt.Item.Arguments != "" {
+				ctx.ToolArguments = evt.Item.Arguments
+				result = append(result, buildClaudeEvent("content_block_delta", map[string]interface{}{
+					"index": ctx.ToolIndex, "delta": map[string]interface{}{"type": "input_json_delta", "partial_json": evt.Item.Arguments},
+				})...)
+			}
 			result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ToolIndex})...)
 			ctx.ToolBlockStarted = false
 			ctx.ContentIndex++
 		}
 
-	case "response.completed":
+	case "response.completed", "response.incomplete":
 		if ctx.ContentBlockStarted {
 			result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ContentIndex})...)
+			ctx.ContentBlockStarted = false
+		}
+		if ctx.ToolBlockStarted {
+			result = append(result, buildClaudeEvent("content_block_stop", map[string]interface{}{"index": ctx.ToolIndex})...)
+			ctx.ToolBlockStarted = false
 		}
 		stopReason := "end_turn"
-		if ctx.ToolIndex > 0 || ctx.CurrentToolID != "" {
+		if evt.Type == "response.incomplete" {
+			stopReason = "max_tokens"
+		} else if ctx.ToolIndex > 0 || ctx.CurrentToolID != "" {
 			stopReason = "tool_use"
 		}
 		// 从 OpenAI2 response 中提取 input_tokens 和 output_tokens（如果有）
@@ -520,6 +562,8 @@ func OpenAI2StreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte
 				"output_tokens": outputTokens,
 			},
 		})...)
+		result = append(result, buildClaudeEvent("message_stop", map[string]interface{}{})...)
+		ctx.FinishReasonSent = true
 	}
 
 	return result, nil
@@ -527,13 +571,24 @@ func OpenAI2StreamToClaude(event []byte, ctx *transformer.StreamContext) ([]byte
 
 // Helper functions
 
+// convertClaudeContentToOpenAI2 将 Claude 内容块转换为 Responses API 的输入项
+// 文本块合并为 message 项，tool_use / tool_result 拆成 function_call / function_call_output 项，保持原有顺序
 func convertClaudeContentToOpenAI2(content []interface{}, role string) []map[string]interface{} {
+	var items []map[string]interface{}
 	var parts []map[string]interface{}
 	contentType := "input_text"
 	if role == "assistant" {
 		contentType = "output_text"
 	}
 
+	flushParts := func() {
+		if len(parts) == 0 {
+			return
+		}
+		items = append(items, map[string]interface{}{"type": "message", "role": role, "content": parts})
+		parts = nil
+	}
+
 	for _, block := range content {
 		m, ok := block.(map[string]interface{})
 		if !ok {
@@ -546,19 +601,25 @@ func convertClaudeContentToOpenAI2(content []interface{}, role string) []map[str
 			// Skip thinking blocks - they are Claude's internal reasoning
 			continue
 		case "tool_use":
+			flushParts()
 			args, _ := json.Marshal(m["input"])
-			parts = append(parts, map[string]interface{}{
-				"type": "output_text",
-				"text": fmt.Sprintf("[Tool Call: %s(%s)]", m["name"], string(args)),
+			items = append(items, map[string]interface{}{
+				"type":      "function_call",
+				"call_id":   m["id"],
+				"name":      m["name"],
+				"arguments": string(args),
 			})
 		case "tool_result":
-			parts = append(parts, map[string]interface{}{
-				"type": "input_text",
-				"text": fmt.Sprintf("[Tool Result: %v]", m["content"]),
+			flushParts()
+			items = append(items, map[string]interface{}{
+				"type":    "function_call_output",
+				"call_id": m["tool_use_id"],
+				"output":  extractToolResultContent(m["content"]),
 			})
 		}
 	}
-	return parts
+	flushParts()
+	return items
 }
 
 func convertOpenAI2InputToClaude(input interface{}) []map[string]interface{} {
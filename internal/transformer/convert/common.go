@@ -78,7 +78,7 @@ func extractSystemText(system interface{}) string {
 		var parts []string
 		for _, block := range s {
 			if m, ok := block.(map[string]interface{}); ok {
-				if text, ok := m["text"].(string); ok {
+				if text, ok := m["text"].(string); ok && strings.TrimSpace(text) != "" {
 					parts = append(parts, text)
 				}
 			}
@@ -87,3 +87,52 @@ func extractSystemText(system interface{}) string {
 	}
 	return ""
 }
+
+// geminiSystemInstruction builds a Gemini systemInstruction from system prompt segments
+// 多段内容合并为一段，全部为空时返回 nil（请求中不应携带该字段）
+func geminiSystemInstruction(segments ...string) map[string]interface{} {
+	var texts []string
+	for _, segment := range segments {
+		if text := strings.TrimSpace(segment); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"parts": []map[string]interface{}{{"text": strings.Join(texts, "\n\n")}},
+	}
+}
+
+// ApplyStreamUsage sets or removes stream_options.include_usage on an OpenAI Chat request.
+// 部分 OpenAI 兼容端点流式响应默认不返回 usage，include 为 true 时对 stream=true 的请求注入
+// include_usage，让上游在最后一个 chunk 返回 usage；为 false 时移除 stream_options（端点不支持该字段）
+func ApplyStreamUsage(openaiReq []byte, include bool) ([]byte, error) {
+	var req map[string]interface{}
+	if err := json.Unmarshal(openaiReq, &req); err != nil {
+		return nil, err
+	}
+
+	if !include {
+		if _, ok := req["stream_options"]; !ok {
+			return openaiReq, nil
+		}
+		delete(req, "stream_options")
+		return json.Marshal(req)
+	}
+
+	if stream, _ := req["stream"].(bool); !stream {
+		return openaiReq, nil
+	}
+	options, _ := req["stream_options"].(map[string]interface{})
+	if enabled, _ := options["include_usage"].(bool); enabled {
+		return openaiReq, nil
+	}
+	if options == nil {
+		options = make(map[string]interface{})
+	}
+	options["include_usage"] = true
+	req["stream_options"] = options
+	return json.Marshal(req)
+}
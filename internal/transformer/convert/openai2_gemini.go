@@ -17,11 +17,10 @@ func OpenAI2ReqToGemini(openai2Req []byte, model string) ([]byte, error) {
 
 	geminiReq := map[string]interface{}{}
 
-	// Convert instructions to system instruction
-	if req.Instructions != "" {
-		geminiReq["systemInstruction"] = map[string]interface{}{
-			"parts": []map[string]interface{}{{"text": req.Instructions}},
-		}
+	// Convert instructions and system/developer messages to system instruction
+	systemTexts := append([]string{req.Instructions}, extractOpenAI2SystemTexts(req.Input)...)
+	if instruction := geminiSystemInstruction(systemTexts...); instruction != nil {
+		geminiReq["systemInstruction"] = instruction
 	}
 
 	// Convert input to contents
@@ -147,7 +146,8 @@ func GeminiRespToOpenAI2(geminiResp []byte) ([]byte, error) {
 func GeminiStreamToOpenAI2(event []byte, ctx *transformer.StreamContext) ([]byte, error) {
 	_, jsonData := parseSSE(event)
 	if jsonData == "" || jsonData == "[DONE]" {
-		if jsonData == "[DONE]" {
+		if jsonData == "[DONE]" && !ctx.FinishReasonSent {
+			ctx.FinishReasonSent = true
 			var result strings.Builder
 			writeEvent := func(evt map[string]interface{}) {
 				d, _ := json.Marshal(evt)
@@ -187,7 +187,13 @@ func GeminiStreamToOpenAI2(event []byte, ctx *transformer.StreamContext) ([]byte
 		return nil, nil
 	}
 
-	if len(resp.Candidates) == 0 {
+	// usageMetadata 为截至当前 chunk 的累计值，保留最新的一份写入 response.completed
+	if resp.UsageMetadata != nil {
+		ctx.InputTokens = resp.UsageMetadata.PromptTokenCount
+		ctx.OutputTokens = resp.UsageMetadata.CandidatesTokenCount
+	}
+
+	if len(resp.Candidates) == 0 || ctx.FinishReasonSent {
 		return nil, nil
 	}
 
@@ -241,6 +247,7 @@ func GeminiStreamToOpenAI2(event []byte, ctx *transformer.StreamContext) ([]byte
 
 	// Check for finish
 	if candidate.FinishReason != "" {
+		ctx.FinishReasonSent = true
 		if ctx.ContentBlockStarted {
 			writeEvent(map[string]interface{}{"type": "response.output_text.done", "output_index": 0, "content_index": 0})
 			writeEvent(map[string]interface{}{"type": "response.content_part.done", "output_index": 0, "content_index": 0, "part": map[string]interface{}{"type": "output_text"}})
@@ -357,6 +364,10 @@ func convertOpenAI2InputToGeminiContents(input interface{}) []map[string]interfa
 				}
 
 				role, _ := itemMap["role"].(string)
+				if role == "system" || role == "developer" {
+					// 已合并到 systemInstruction，Gemini contents 只接受 user/model
+					continue
+				}
 				if role == "assistant" {
 					role = "model"
 				}
@@ -405,6 +416,32 @@ func convertOpenAI2InputToGeminiContents(input interface{}) []map[string]interfa
 	return contents
 }
 
+// extractOpenAI2SystemTexts extracts the text of system/developer messages in Responses API input
+func extractOpenAI2SystemTexts(input interface{}) []string {
+	items, ok := input.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var texts []string
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		role, _ := itemMap["role"].(string)
+		if itemMap["type"] != "message" || (role != "system" && role != "developer") {
+			continue
+		}
+		for _, part := range convertOpenAI2ContentToGeminiParts(itemMap["content"]) {
+			if text, ok := part["text"].(string); ok {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
 func convertOpenAI2ContentToGeminiParts(content interface{}) []map[string]interface{} {
 	var parts []map[string]interface{}
 
@@ -19,14 +19,12 @@ func OpenAIReqToGemini(openaiReq []byte, model string) ([]byte, error) {
 
 	// Convert messages
 	var contents []map[string]interface{}
-	var systemInstruction string
+	var systemTexts []string
 	toolCallIDToName := make(map[string]string) // Map tool_call_id to function name
 
 	for _, msg := range req.Messages {
-		if msg.Role == "system" {
-			if content, ok := msg.Content.(string); ok {
-				systemInstruction += content + "\n"
-			}
+		if msg.Role == "system" || msg.Role == "developer" {
+			systemTexts = append(systemTexts, extractSystemText(msg.Content))
 			continue
 		}
 
@@ -38,7 +36,10 @@ func OpenAIReqToGemini(openaiReq []byte, model string) ([]byte, error) {
 		var parts []map[string]interface{}
 		switch content := msg.Content.(type) {
 		case string:
-			parts = append(parts, map[string]interface{}{"text": content})
+			// assistant 只有 tool_calls 时 content 为空字符串，Gemini 不接受空的 text part
+			if content != "" {
+				parts = append(parts, map[string]interface{}{"text": content})
+			}
 		case []interface{}:
 			parts = convertOpenAIContentToGeminiParts(content)
 		}
@@ -67,17 +68,23 @@ func OpenAIReqToGemini(openaiReq []byte, model string) ([]byte, error) {
 				},
 			}
 			role = "user"
+			// 并行工具调用的多个结果合并到同一条 user 消息，与 model 消息中的多个 functionCall 对应
+			if n := len(contents); n > 0 && isGeminiFunctionResponseContent(contents[n-1]) {
+				contents[n-1]["parts"] = append(contents[n-1]["parts"].([]map[string]interface{}), parts...)
+				continue
+			}
 		}
 
+		if len(parts) == 0 {
+			continue
+		}
 		contents = append(contents, map[string]interface{}{"role": role, "parts": parts})
 	}
 
 	geminiReq["contents"] = contents
 
-	if systemInstruction != "" {
-		geminiReq["systemInstruction"] = map[string]interface{}{
-			"parts": []map[string]interface{}{{"text": strings.TrimSpace(systemInstruction)}},
-		}
+	if instruction := geminiSystemInstruction(systemTexts...); instruction != nil {
+		geminiReq["systemInstruction"] = instruction
 	}
 
 	// Generation config
@@ -133,6 +140,7 @@ func GeminiRespToOpenAI(geminiResp []byte, model string) ([]byte, error) {
 
 	if len(resp.Candidates) > 0 {
 		candidate := resp.Candidates[0]
+		finishReason = geminiFinishReasonToOpenAI(candidate.FinishReason)
 		for _, part := range candidate.Content.Parts {
 			if part.Text != "" {
 				textContent += part.Text
@@ -180,27 +188,49 @@ func GeminiRespToOpenAI(geminiResp []byte, model string) ([]byte, error) {
 }
 
 // GeminiStreamToOpenAI converts Gemini stream chunk to OpenAI Chat stream chunk
+// Gemini 流没有 [DONE]，在带 finishReason 的 chunk 后依次输出结束 chunk、usage chunk 和 [DONE]
 func GeminiStreamToOpenAI(event []byte, ctx *transformer.StreamContext, model string) ([]byte, error) {
 	_, jsonData := parseSSE(event)
 	if jsonData == "" || jsonData == "[DONE]" {
-		if jsonData == "[DONE]" {
-			return []byte("data: [DONE]\n\n"), nil
+		if jsonData == "[DONE]" && !ctx.FinishReasonSent {
+			ctx.FinishReasonSent = true
+			var result strings.Builder
+			chunk, _ := buildOpenAIChunk("gemini-chunk", model, "", nil, "stop")
+			result.Write(chunk)
+			result.Write(buildOpenAIUsageChunk(model, ctx))
+			result.WriteString("data: [DONE]\n\n")
+			return []byte(result.String()), nil
 		}
 		return nil, nil
 	}
 
+	// Check for error response
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &errResp); err == nil && errResp.Error.Message != "" {
+		return nil, fmt.Errorf("upstream error: %s", errResp.Error.Message)
+	}
+
 	var resp transformer.GeminiResponse
 	if err := json.Unmarshal([]byte(jsonData), &resp); err != nil {
 		return nil, nil
 	}
 
-	if len(resp.Candidates) == 0 {
+	// usageMetadata 为截至当前 chunk 的累计值，保留最新的一份
+	if resp.UsageMetadata != nil {
+		ctx.InputTokens = resp.UsageMetadata.PromptTokenCount
+		ctx.OutputTokens = resp.UsageMetadata.CandidatesTokenCount
+	}
+
+	if len(resp.Candidates) == 0 || ctx.FinishReasonSent {
 		return nil, nil
 	}
 
 	var result strings.Builder
 	candidate := resp.Candidates[0]
-	hasToolCall := false
 
 	for _, part := range candidate.Content.Parts {
 		if part.Text != "" {
@@ -208,7 +238,6 @@ func GeminiStreamToOpenAI(event []byte, ctx *transformer.StreamContext, model st
 			result.Write(chunk)
 		}
 		if part.FunctionCall != nil {
-			hasToolCall = true
 			args, _ := json.Marshal(part.FunctionCall.Args)
 			toolCall := []map[string]interface{}{
 				{
@@ -229,18 +258,67 @@ func GeminiStreamToOpenAI(event []byte, ctx *transformer.StreamContext, model st
 
 	// Check for finish
 	if candidate.FinishReason != "" {
-		finishReason := "stop"
-		if hasToolCall || candidate.FinishReason == "TOOL_CODE" {
+		ctx.FinishReasonSent = true
+		finishReason := geminiFinishReasonToOpenAI(candidate.FinishReason)
+		// 工具调用可能在之前的 chunk 中输出，ContentIndex 即已输出的工具调用数
+		if ctx.ContentIndex > 0 {
 			finishReason = "tool_calls"
 		}
 		chunk, _ := buildOpenAIChunk("gemini-chunk", model, "", nil, finishReason)
 		result.Write(chunk)
+		result.Write(buildOpenAIUsageChunk(model, ctx))
 		result.WriteString("data: [DONE]\n\n")
 	}
 
 	return []byte(result.String()), nil
 }
 
+// buildOpenAIUsageChunk builds the trailing usage chunk (choices is empty, as with stream_options.include_usage)
+func buildOpenAIUsageChunk(model string, ctx *transformer.StreamContext) []byte {
+	chunk := map[string]interface{}{
+		"id": "gemini-chunk", "object": "chat.completion.chunk", "model": model,
+		"choices": []interface{}{},
+		"usage": map[string]interface{}{
+			"prompt_tokens":     ctx.InputTokens,
+			"completion_tokens": ctx.OutputTokens,
+			"total_tokens":      ctx.InputTokens + ctx.OutputTokens,
+		},
+	}
+	data, _ := json.Marshal(chunk)
+	return []byte(fmt.Sprintf("data: %s\n\n", data))
+}
+
+// geminiFinishReasonToOpenAI maps a Gemini finishReason to an OpenAI finish_reason
+func geminiFinishReasonToOpenAI(reason string) string {
+	switch reason {
+	case "MAX_TOKENS":
+		return "length"
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII":
+		return "content_filter"
+	case "TOOL_CODE":
+		return "tool_calls"
+	default:
+		return "stop"
+	}
+}
+
+// isGeminiFunctionResponseContent reports whether a Gemini content only carries functionResponse parts
+func isGeminiFunctionResponseContent(content map[string]interface{}) bool {
+	if content["role"] != "user" {
+		return false
+	}
+	parts, _ := content["parts"].([]map[string]interface{})
+	if len(parts) == 0 {
+		return false
+	}
+	for _, part := range parts {
+		if _, ok := part["functionResponse"]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // OpenAIStreamToGemini converts OpenAI Chat stream chunk to Gemini stream format
 func OpenAIStreamToGemini(event []byte, ctx *transformer.StreamContext) ([]byte, error) {
 	_, jsonData := parseSSE(event)
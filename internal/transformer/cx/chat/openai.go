@@ -2,16 +2,23 @@ package chat
 
 import (
 	"github.com/lich0821/ccNexus/internal/transformer"
+	"github.com/lich0821/ccNexus/internal/transformer/convert"
 )
 
 // OpenAITransformer is a passthrough transformer for Codex Chat → OpenAI Chat
 type OpenAITransformer struct {
-	model string
+	model       string
+	streamUsage bool // 流式请求是否注入 stream_options.include_usage
 }
 
 // NewOpenAITransformer creates a new passthrough transformer
 func NewOpenAITransformer(model string) *OpenAITransformer {
-	return &OpenAITransformer{model: model}
+	return &OpenAITransformer{model: model, streamUsage: true}
+}
+
+// NewOpenAITransformerWithStreamUsage creates a new passthrough transformer with explicit stream usage injection
+func NewOpenAITransformerWithStreamUsage(model string, streamUsage bool) *OpenAITransformer {
+	return &OpenAITransformer{model: model, streamUsage: streamUsage}
 }
 
 func (t *OpenAITransformer) Name() string {
@@ -19,7 +26,7 @@ func (t *OpenAITransformer) Name() string {
 }
 
 func (t *OpenAITransformer) TransformRequest(req []byte) ([]byte, error) {
-	return req, nil
+	return convert.ApplyStreamUsage(req, t.streamUsage)
 }
 
 func (t *OpenAITransformer) TransformResponse(resp []byte, isStreaming bool) ([]byte, error) {
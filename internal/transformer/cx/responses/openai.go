@@ -7,12 +7,18 @@ import (
 
 // OpenAITransformer transforms Codex Responses requests to OpenAI Chat format
 type OpenAITransformer struct {
-	model string
+	model       string
+	streamUsage bool // 流式请求是否注入 stream_options.include_usage
 }
 
 // NewOpenAITransformer creates a new transformer
 func NewOpenAITransformer(model string) *OpenAITransformer {
-	return &OpenAITransformer{model: model}
+	return &OpenAITransformer{model: model, streamUsage: true}
+}
+
+// NewOpenAITransformerWithStreamUsage creates a new transformer with explicit stream usage injection
+func NewOpenAITransformerWithStreamUsage(model string, streamUsage bool) *OpenAITransformer {
+	return &OpenAITransformer{model: model, streamUsage: streamUsage}
 }
 
 func (t *OpenAITransformer) Name() string {
@@ -20,7 +26,11 @@ func (t *OpenAITransformer) Name() string {
 }
 
 func (t *OpenAITransformer) TransformRequest(req []byte) ([]byte, error) {
-	return convert.OpenAI2ReqToOpenAI(req, t.model)
+	openaiReq, err := convert.OpenAI2ReqToOpenAI(req, t.model)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ApplyStreamUsage(openaiReq, t.streamUsage)
 }
 
 func (t *OpenAITransformer) TransformResponse(resp []byte, isStreaming bool) ([]byte, error) {
@@ -59,9 +59,11 @@ type OpenAIResponse struct {
 	Choices []struct {
 		Index   int `json:"index"`
 		Message struct {
-			Role      string           `json:"role"`
-			Content   string           `json:"content"`
-			ToolCalls []OpenAIToolCall `json:"tool_calls,omitempty"`
+			Role             string           `json:"role"`
+			Content          string           `json:"content"`
+			ReasoningContent string           `json:"reasoning_content,omitempty"` // DeepSeek 等模型的推理内容
+			Reasoning        string           `json:"reasoning,omitempty"`         // 部分 OpenAI 兼容服务使用的推理字段
+			ToolCalls        []OpenAIToolCall `json:"tool_calls,omitempty"`
 		} `json:"message"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
@@ -84,6 +86,7 @@ type OpenAIStreamChunk struct {
 			Role             string           `json:"role,omitempty"`
 			Content          string           `json:"content,omitempty"`
 			ReasoningContent string           `json:"reasoning_content,omitempty"` // For models with reasoning/thinking
+			Reasoning        string           `json:"reasoning,omitempty"`         // 部分 OpenAI 兼容服务使用的推理字段
 			ToolCalls        []OpenAIToolCall `json:"tool_calls,omitempty"`
 		} `json:"delta"`
 		FinishReason *string `json:"finish_reason"`
@@ -155,6 +158,16 @@ func ExtractTokenUsageDetail(usage map[string]interface{}) TokenUsageDetail {
 	if val, ok := usage["cache_read_input_tokens"].(float64); ok {
 		detail.CacheReadInputTokens = int(val)
 	}
+	// 较新的响应在 cache_creation 中按缓存时长细分写入量，缺少汇总字段时按细分求和
+	if detail.CacheCreationInputTokens == 0 {
+		if breakdown, ok := usage["cache_creation"].(map[string]interface{}); ok {
+			for _, val := range breakdown {
+				if tokens, ok := val.(float64); ok {
+					detail.CacheCreationInputTokens += int(tokens)
+				}
+			}
+		}
+	}
 	if val, ok := usage["output_tokens"].(float64); ok {
 		detail.OutputTokens = int(val)
 	}